@@ -0,0 +1,29 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ExpressionFilter represents a rule to skip rows of DML events by SQL boolean expressions over column values,
+// like `status = 'deleted' OR tenant_id != 42`, rows matched by the expression are skipped.
+// schema and table are the upstream ones, and support wildcards like binlog event filter rules.
+// for UPDATE, if both update-old-value-expr and update-new-value-expr are set,
+// the row is skipped only when both the old value and the new value matched.
+// strings are compared case-insensitively like the default collations of MySQL, and values of ENUM and SET are compared as strings
+type ExpressionFilter struct {
+	Schema             string `yaml:"schema" toml:"schema" json:"schema"`
+	Table              string `yaml:"table" toml:"table" json:"table"`
+	InsertValueExpr    string `yaml:"insert-value-expr" toml:"insert-value-expr" json:"insert-value-expr"`
+	UpdateOldValueExpr string `yaml:"update-old-value-expr" toml:"update-old-value-expr" json:"update-old-value-expr"`
+	UpdateNewValueExpr string `yaml:"update-new-value-expr" toml:"update-new-value-expr" json:"update-new-value-expr"`
+	DeleteValueExpr    string `yaml:"delete-value-expr" toml:"delete-value-expr" json:"delete-value-expr"`
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// policies when a sharding DDL lock is not synced by all dm-workers in `shard-lock-timeout`
+const (
+	// ShardLockTimeoutFail fails sub tasks of dm-workers waiting for the lock with an error, and removes the lock
+	ShardLockTimeoutFail = "fail"
+	// ShardLockTimeoutSkip resolves the lock without dm-workers not synced,
+	// and they are removed from DDL locks of the target table until they send DDL info again
+	ShardLockTimeoutSkip = "skip"
+	// ShardLockTimeoutExec resolves the lock without dm-workers not synced,
+	// and they skip the DDL when they reach it
+	ShardLockTimeoutExec = "exec"
+)
+
+// LockTimeout returns the max time waiting for all dm-workers to sync a sharding DDL lock, 0 if never timeout
+func (c *TaskConfig) LockTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.ShardLockTimeout)
+	return d
+}
+
+// IdleTimeout returns the max time a source table not synced a sharding DDL lock can stay idle,
+// before it's removed from the lock, 0 if never removed.
+// a source table is idle when no binlog event of it is received by the syncer
+func (c *TaskConfig) IdleTimeout() time.Duration {
+	d, _ := time.ParseDuration(c.ShardIdleTimeout)
+	return d
+}
+
+// adjustShardLock checks and adjusts timeouts and policies of sharding DDL locks
+func (c *TaskConfig) adjustShardLock() error {
+	if !c.IsSharding {
+		if len(c.ShardLockTimeout) > 0 || len(c.ShardLockTimeoutPolicy) > 0 || len(c.ShardIdleTimeout) > 0 {
+			return errors.NotValidf("shard-lock-timeout, shard-lock-timeout-policy or shard-idle-timeout for non-sharding task")
+		}
+		return nil
+	}
+
+	if err := verifyTimeout("shard-lock-timeout", c.ShardLockTimeout); err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyTimeout("shard-idle-timeout", c.ShardIdleTimeout); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch c.ShardLockTimeoutPolicy {
+	case "":
+		c.ShardLockTimeoutPolicy = ShardLockTimeoutFail
+	case ShardLockTimeoutFail, ShardLockTimeoutSkip, ShardLockTimeoutExec:
+	default:
+		return errors.NotSupportedf("shard-lock-timeout-policy %s", c.ShardLockTimeoutPolicy)
+	}
+	return nil
+}
+
+// verifyTimeout verifies the timeout is a positive duration if not empty
+func verifyTimeout(name, timeout string) error {
+	if len(timeout) == 0 {
+		return nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s %s", name, timeout)
+	}
+	if d <= 0 {
+		return errors.NotValidf("%s %s", name, timeout)
+	}
+	return nil
+}
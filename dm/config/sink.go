@@ -0,0 +1,83 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// sinks where syncer executes jobs
+const (
+	// SinkMySQL executes jobs as SQLs in MySQL / TiDB of `target-database`
+	SinkMySQL = "mysql"
+	// SinkFile writes row changes as JSON change events into local files,
+	// checkpoints are also saved into local files
+	SinkFile = "file"
+	// SinkSQL writes jobs as SQLs into local files instead of executing them (dry-run),
+	// checkpoints are also saved into local files
+	SinkSQL = "sql"
+)
+
+// default file sink config values
+var (
+	defaultFileSinkMaxSize int64 = 64 // MB
+)
+
+// FileSinkConfig is the config of the file sink and the SQL sink, which write jobs into files in `dir`.
+// files are rotated when the size exceeds `max-size` (MB), or opened longer than `rotate-interval` (like "1h")
+type FileSinkConfig struct {
+	Dir            string `yaml:"dir" toml:"dir" json:"dir"`
+	MaxSize        int64  `yaml:"max-size" toml:"max-size" json:"max-size"`
+	RotateInterval string `yaml:"rotate-interval" toml:"rotate-interval" json:"rotate-interval"`
+}
+
+// Interval returns the rotate interval, 0 if not rotated by time
+func (c *FileSinkConfig) Interval() time.Duration {
+	d, _ := time.ParseDuration(c.RotateInterval)
+	return d
+}
+
+// adjustSink checks and adjusts configs of the sink
+func (c *SyncerConfig) adjustSink() error {
+	switch c.Sink {
+	case "":
+		c.Sink = SinkMySQL
+	case SinkMySQL:
+	case SinkFile, SinkSQL:
+		if len(c.FileSink.Dir) == 0 {
+			return errors.Errorf("dir of file-sink should not be empty for %s sink", c.Sink)
+		}
+		if c.FileSink.MaxSize <= 0 {
+			c.FileSink.MaxSize = defaultFileSinkMaxSize
+		}
+		if len(c.FileSink.RotateInterval) > 0 {
+			if _, err := time.ParseDuration(c.FileSink.RotateInterval); err != nil {
+				return errors.Annotatef(err, "invalid rotate-interval %s of file-sink", c.FileSink.RotateInterval)
+			}
+		}
+		// checkpoints can not be saved in files atomically with jobs
+		if c.ExactlyOnce {
+			return errors.NotSupportedf("exactly-once with %s sink", c.Sink)
+		}
+		// rows are written as changes by the file sink
+		if c.CompactDML && c.Sink == SinkFile {
+			return errors.NotSupportedf("compact-dml with file sink")
+		}
+	default:
+		return errors.NotSupportedf("sink %s", c.Sink)
+	}
+	return nil
+}
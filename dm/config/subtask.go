@@ -22,6 +22,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/transform"
 	"github.com/pingcap/dm/pkg/utils"
 	"github.com/pingcap/errors"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
@@ -38,6 +39,24 @@ const (
 	ModeIncrement = "incremental"
 )
 
+// shard modes, how sharding DDLs are coordinated
+const (
+	// ShardPessimistic blocks DMLs of all shard tables until they all execute the same DDLs
+	ShardPessimistic = "pessimistic"
+	// ShardOptimistic applies DDLs of each shard table at once if they are compatible with the joined schema of all shard tables
+	ShardOptimistic = "optimistic"
+)
+
+// policies of TRUNCATE TABLE, DROP TABLE and DROP DATABASE in sharding groups
+const (
+	// ShardPolicyIgnore ignores the DDL, and tables dropped leave their sharding groups
+	ShardPolicyIgnore = "ignore"
+	// ShardPolicyCoordinate syncs the DDL like other sharding DDLs, and executes it in downstream after all shard tables executed it
+	ShardPolicyCoordinate = "coordinate"
+	// ShardPolicyError stops the sync with an error, so the DDL can be handled manually
+	ShardPolicyError = "error"
+)
+
 // CmdName represents name for binary
 type CmdName string
 
@@ -82,8 +101,12 @@ type SubTaskConfig struct {
 
 	// when in sharding, multi dm-workers do one task
 	IsSharding      bool   `toml:"is-sharding" json:"is-sharding"`
+	ShardMode       string `toml:"shard-mode" json:"shard-mode"`
 	OnlineDDLScheme string `toml:"online-ddl-scheme" json:"online-ddl-scheme"`
 
+	// policy of TRUNCATE TABLE, DROP TABLE and DROP DATABASE in sharding groups
+	ShardTruncateDropPolicy string `toml:"shard-truncate-drop-policy" json:"shard-truncate-drop-policy"`
+
 	// handle schema/table name mode, and only for schema/table name/pattern
 	// if case insensitive, we would convert schema/table name/pattern to lower case
 	CaseSensitive bool `toml:"case-sensitive" json:"case-sensitive"`
@@ -102,6 +125,8 @@ type SubTaskConfig struct {
 	EnableHeartbeat  bool   `toml:"enable-heartbeat" json:"enable-heartbeat"`
 	Meta             *Meta  `toml:"meta" json:"meta"`
 	Timezone         string `toml:"timezone" josn:"timezone"`
+	// stop syncing and finish the sub task at the point, used for planned cutovers
+	StopAt *StopAt `toml:"stop-at" json:"stop-at"`
 
 	BinlogType string `toml:"binlog-type" json:"binlog-type"`
 	// RelayDir get value from dm-worker config
@@ -109,10 +134,12 @@ type SubTaskConfig struct {
 	From     DBConfig `toml:"from" json:"from"`
 	To       DBConfig `toml:"to" json:"to"`
 
-	RouteRules         []*router.TableRule   `toml:"route-rules" json:"route-rules"`
-	FilterRules        []*bf.BinlogEventRule `toml:"filter-rules" json:"filter-rules"`
-	ColumnMappingRules []*column.Rule        `toml:"mapping-rule" json:"mapping-rule"`
-	BWList             *filter.Rules         `toml:"black-white-list" json:"black-white-list"`
+	RouteRules           []*router.TableRule   `toml:"route-rules" json:"route-rules"`
+	FilterRules          []*bf.BinlogEventRule `toml:"filter-rules" json:"filter-rules"`
+	ColumnMappingRules   []*column.Rule        `toml:"mapping-rule" json:"mapping-rule"`
+	BWList               *filter.Rules         `toml:"black-white-list" json:"black-white-list"`
+	ExprFilter           []*ExpressionFilter   `toml:"expression-filter" json:"expression-filter"`
+	ColumnTransformRules []*transform.Rule     `toml:"column-transform-rule" json:"column-transform-rule"`
 
 	MydumperConfig // Mydumper configuration
 	LoaderConfig   // Loader configuration
@@ -253,6 +280,66 @@ func (c *SubTaskConfig) adjust() error {
 		}
 	}
 
+	if err := c.StopAt.Verify(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := verifyShardMode(c.IsSharding, &c.ShardMode); err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyShardTruncateDropPolicy(c.IsSharding, &c.ShardTruncateDropPolicy); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.ExactlyOnce {
+		// checkpoints of tables in sharding groups can't be saved before sharding DDLs synced,
+		// and the order of jobs for a table is guaranteed by causality
+		if c.IsSharding {
+			return errors.NotSupportedf("exactly-once for sharding task")
+		}
+		if c.DisableCausality {
+			return errors.NotSupportedf("exactly-once with disable-detect")
+		}
+	}
+
+	if err := c.SyncerConfig.adjustSink(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// verifyShardMode verifies shard-mode, and sets it to pessimistic by default for sharding task
+func verifyShardMode(isSharding bool, mode *string) error {
+	switch *mode {
+	case "":
+		if isSharding {
+			*mode = ShardPessimistic
+		}
+	case ShardPessimistic, ShardOptimistic:
+		if !isSharding {
+			return errors.NotValidf("shard-mode %s for non-sharding task", *mode)
+		}
+	default:
+		return errors.NotSupportedf("shard-mode %s", *mode)
+	}
+	return nil
+}
+
+// verifyShardTruncateDropPolicy verifies shard-truncate-drop-policy, and sets it to ignore by default for sharding task
+func verifyShardTruncateDropPolicy(isSharding bool, policy *string) error {
+	switch *policy {
+	case "":
+		if isSharding {
+			*policy = ShardPolicyIgnore
+		}
+	case ShardPolicyIgnore, ShardPolicyCoordinate, ShardPolicyError:
+		if !isSharding {
+			return errors.NotValidf("shard-truncate-drop-policy %s for non-sharding task", *policy)
+		}
+	default:
+		return errors.NotSupportedf("shard-truncate-drop-policy %s", *policy)
+	}
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/transform"
 	"github.com/pingcap/errors"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
 	"github.com/pingcap/tidb-tools/pkg/column-mapping"
@@ -40,7 +41,6 @@ var (
 	defaultEnableHeartbeat = false
 	defaultIsSharding      = false
 	// MydumperConfig
-	defaultMydumperPath        = "./bin/mydumper"
 	defaultThreads             = 4
 	defaultChunkFilesize int64 = 64
 	defaultSkipTzUTC           = true
@@ -53,32 +53,100 @@ var (
 	defaultMaxRetry    = 100
 )
 
+// binlogTimeLayout is the layout of binlog-time in Meta
+const binlogTimeLayout = "2006-01-02 15:04:05"
+
 // Meta represents binlog's meta pos
 // NOTE: refine to put these config structs into pkgs
 // NOTE: now, syncer does not support GTID mode and which is supported by relay
 type Meta struct {
 	BinLogName string `yaml:"binlog-name"`
 	BinLogPos  uint32 `yaml:"binlog-pos"`
+	BinLogGTID string `yaml:"binlog-gtid"` // GTID set executed until binlog-pos, only used if enable-gtid is true
+	// start from the first transaction committed at or after the time instead of binlog-name and binlog-pos,
+	// in format `2006-01-02 15:04:05` of the syncer's timezone
+	BinLogTime string `yaml:"binlog-time"`
 }
 
 // Verify does verification on configs
 func (m *Meta) Verify() error {
-	if m != nil && len(m.BinLogName) == 0 {
-		return errors.New("binlog-name must specify")
+	if m == nil {
+		return nil
+	}
+	if len(m.BinLogTime) > 0 {
+		if len(m.BinLogName) > 0 {
+			return errors.New("binlog-name and binlog-time can not be specified at the same time")
+		}
+		_, err := m.ParseBinLogTime(time.UTC)
+		return errors.Trace(err)
+	}
+	if len(m.BinLogName) == 0 {
+		return errors.New("binlog-name or binlog-time must specify")
 	}
 
 	return nil
 }
 
+// ParseBinLogTime parses binlog-time in the location
+func (m *Meta) ParseBinLogTime(loc *time.Location) (time.Time, error) {
+	return parseBinLogTime(m.BinLogTime, loc)
+}
+
+// StopAt represents the point where the syncer stops and the sub task finishes, used for planned cutovers.
+// only one of binlog-name (with binlog-pos), binlog-gtid and binlog-time can be specified
+type StopAt struct {
+	// stop at the first transaction boundary at or after the position
+	BinLogName string `yaml:"binlog-name"`
+	BinLogPos  uint32 `yaml:"binlog-pos"`
+	// stop after the GTID set executed, only used if enable-gtid is true
+	BinLogGTID string `yaml:"binlog-gtid"`
+	// stop before the first transaction committed at or after the time, in format `2006-01-02 15:04:05` of the syncer's timezone
+	BinLogTime string `yaml:"binlog-time"`
+}
+
+// Verify does verification on configs
+func (s *StopAt) Verify() error {
+	if s == nil {
+		return nil
+	}
+	specified := 0
+	for _, item := range []string{s.BinLogName, s.BinLogGTID, s.BinLogTime} {
+		if len(item) > 0 {
+			specified++
+		}
+	}
+	if specified != 1 {
+		return errors.New("one and only one of binlog-name, binlog-gtid and binlog-time must specify in stop-at")
+	}
+	if len(s.BinLogTime) > 0 {
+		_, err := s.ParseBinLogTime(time.UTC)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ParseBinLogTime parses binlog-time in the location
+func (s *StopAt) ParseBinLogTime(loc *time.Location) (time.Time, error) {
+	return parseBinLogTime(s.BinLogTime, loc)
+}
+
+func parseBinLogTime(str string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(binlogTimeLayout, str, loc)
+	return t, errors.Annotatef(err, "binlog-time %s should be in format %s", str, binlogTimeLayout)
+}
+
 // MySQLInstance represents a sync config of a MySQL instance
 type MySQLInstance struct {
 	// it represents a MySQL/MariaDB instance or a replica group
-	SourceID           string   `yaml:"source-id"`
-	Meta               *Meta    `yaml:"meta"`
-	FilterRules        []string `yaml:"filter-rules"`
-	ColumnMappingRules []string `yaml:"column-mapping-rules"`
-	RouteRules         []string `yaml:"route-rules"`
-	BWListName         string   `yaml:"black-white-list"`
+	SourceID             string   `yaml:"source-id"`
+	Meta                 *Meta    `yaml:"meta"`
+	StopAt               *StopAt  `yaml:"stop-at"`
+	FilterRules          []string `yaml:"filter-rules"`
+	ColumnMappingRules   []string `yaml:"column-mapping-rules"`
+	RouteRules           []string `yaml:"route-rules"`
+	BWListName           string   `yaml:"black-white-list"`
+	ExpressionFilters    []string `yaml:"expression-filters"`
+	ColumnTransformRules []string `yaml:"column-transform-rules"`
 
 	MydumperConfigName string          `yaml:"mydumper-config-name"`
 	Mydumper           *MydumperConfig `yaml:"mydumper"`
@@ -116,19 +184,18 @@ func (m *MySQLInstance) Verify() error {
 }
 
 // MydumperConfig represents mydumper process unit's specific config
+// NOTE: data is dumped by the built-in dumper, schemas and tables are selected by black-white list and filter rules
 type MydumperConfig struct {
-	MydumperPath  string `yaml:"mydumper-path" toml:"mydumper-path" json:"mydumper-path"`    // mydumper binary path
-	Threads       int    `yaml:"threads" toml:"threads" json:"threads"`                      // -t, --threads
-	ChunkFilesize int64  `yaml:"chunk-filesize" toml:"chunk-filesize" json:"chunk-filesize"` // -F, --chunk-filesize
-	SkipTzUTC     bool   `yaml:"skip-tz-utc" toml:"skip-tz-utc" json:"skip-tz-utc"`          // --skip-tz-utc
-	ExtraArgs     string `yaml:"extra-args" toml:"extra-args" json:"extra-args"`             // other extra args
-	// NOTE: use LoaderConfig.Dir as --outputdir
-	// TODO zxc: combine -B -T --regex with filter rules?
+	MydumperPath  string `yaml:"mydumper-path" toml:"mydumper-path" json:"mydumper-path"`    // deprecated, mydumper binary is not used any more
+	Threads       int    `yaml:"threads" toml:"threads" json:"threads"`                      // count of concurrent connections to dump data
+	ChunkFilesize int64  `yaml:"chunk-filesize" toml:"chunk-filesize" json:"chunk-filesize"` // split data of a table into chunks of this size (MB)
+	SkipTzUTC     bool   `yaml:"skip-tz-utc" toml:"skip-tz-utc" json:"skip-tz-utc"`          // not set session time_zone to '+00:00' when dumping
+	ExtraArgs     string `yaml:"extra-args" toml:"extra-args" json:"extra-args"`             // deprecated, use black-white list and filter rules instead
+	// NOTE: use LoaderConfig.Dir as output dir
 }
 
 func defaultMydumperConfig() MydumperConfig {
 	return MydumperConfig{
-		MydumperPath:  defaultMydumperPath,
 		Threads:       defaultThreads,
 		ChunkFilesize: defaultChunkFilesize,
 		SkipTzUTC:     defaultSkipTzUTC,
@@ -187,6 +254,17 @@ type SyncerConfig struct {
 	DisableCausality bool `yaml:"disable-detect" toml:"disable-detect" json:"disable-detect"`
 	SafeMode         bool `yaml:"safe-mode" toml:"safe-mode" json:"safe-mode"`
 	EnableANSIQuotes bool `yaml:"enable-ansi-quotes" toml:"enable-ansi-quotes" json:"enable-ansi-quotes"`
+	// execute each upstream transaction atomically in one downstream transaction
+	PreserveTxn bool `yaml:"preserve-txn" toml:"preserve-txn" json:"preserve-txn"`
+	// compact changes of the same rows in a batch, and merge them into multi-value statements
+	CompactDML bool `yaml:"compact-dml" toml:"compact-dml" json:"compact-dml"`
+	// save checkpoints in the same downstream transaction with DMLs, then no safe-mode needed when restarting.
+	// it implies `preserve-txn`, and can't be used with `disable-detect` or sharding
+	ExactlyOnce bool `yaml:"exactly-once" toml:"exactly-once" json:"exactly-once"`
+	// sink where jobs are executed, `mysql` (default), `file` or `sql`,
+	// checkpoints are saved in `target-database` except for `sql` which saves them in `file-sink.dir`
+	Sink     string         `yaml:"sink" toml:"sink" json:"sink"`
+	FileSink FileSinkConfig `yaml:"file-sink" toml:"file-sink" json:"file-sink"`
 }
 
 func defaultSyncerConfig() SyncerConfig {
@@ -217,6 +295,15 @@ type TaskConfig struct {
 	Name       string `yaml:"name"`
 	TaskMode   string `yaml:"task-mode"`
 	IsSharding bool   `yaml:"is-sharding"`
+	ShardMode  string `yaml:"shard-mode"` // pessimistic (default) or optimistic
+	// ignore (default), coordinate or error for TRUNCATE TABLE, DROP TABLE and DROP DATABASE in sharding groups
+	ShardTruncateDropPolicy string `yaml:"shard-truncate-drop-policy"`
+
+	// timeouts and policies of sharding DDL locks, see shard_lock.go
+	ShardLockTimeout       string `yaml:"shard-lock-timeout"`        // like "30m", never timeout if empty
+	ShardLockTimeoutPolicy string `yaml:"shard-lock-timeout-policy"` // fail (default), skip or exec
+	ShardIdleTimeout       string `yaml:"shard-idle-timeout"`        // like "10m", idle source tables never removed if empty
+
 	//  treat it as hidden configuration
 	IgnoreCheckingItems []string `yaml:"ignore-checking-items"`
 	// we store detail status in meta
@@ -239,10 +326,12 @@ type TaskConfig struct {
 
 	OnlineDDLScheme string `yaml:"online-ddl-scheme"`
 
-	Routes         map[string]*router.TableRule   `yaml:"routes"`
-	Filters        map[string]*bf.BinlogEventRule `yaml:"filters"`
-	ColumnMappings map[string]*column.Rule        `yaml:"column-mappings"`
-	BWList         map[string]*filter.Rules       `yaml:"black-white-list"`
+	Routes           map[string]*router.TableRule   `yaml:"routes"`
+	Filters          map[string]*bf.BinlogEventRule `yaml:"filters"`
+	ColumnMappings   map[string]*column.Rule        `yaml:"column-mappings"`
+	BWList           map[string]*filter.Rules       `yaml:"black-white-list"`
+	ExprFilter       map[string]*ExpressionFilter   `yaml:"expression-filter"`
+	ColumnTransforms map[string]*transform.Rule     `yaml:"column-transforms"`
 
 	Mydumpers map[string]*MydumperConfig `yaml:"mydumpers"`
 	Loaders   map[string]*LoaderConfig   `yaml:"loaders"`
@@ -262,6 +351,8 @@ func NewTaskConfig() *TaskConfig {
 		Filters:          make(map[string]*bf.BinlogEventRule),
 		ColumnMappings:   make(map[string]*column.Rule),
 		BWList:           make(map[string]*filter.Rules),
+		ExprFilter:       make(map[string]*ExpressionFilter),
+		ColumnTransforms: make(map[string]*transform.Rule),
 		Mydumpers:        make(map[string]*MydumperConfig),
 		Loaders:          make(map[string]*LoaderConfig),
 		Syncers:          make(map[string]*SyncerConfig),
@@ -319,6 +410,16 @@ func (c *TaskConfig) adjust() error {
 		}
 	}
 
+	if err := verifyShardMode(c.IsSharding, &c.ShardMode); err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyShardTruncateDropPolicy(c.IsSharding, &c.ShardTruncateDropPolicy); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.adjustShardLock(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if c.OnlineDDLScheme != "" && c.OnlineDDLScheme != PT && c.OnlineDDLScheme != GHOST {
 		return errors.NotSupportedf("online scheme %s", c.OnlineDDLScheme)
 	}
@@ -346,6 +447,9 @@ func (c *TaskConfig) adjust() error {
 			if inst.Meta != nil {
 				log.Warnf("[config] mysql-instance(%d) set meta, but it will not be used for task-mode %s.\n for Full mode, incremental sync will never occur; for All mode, the meta dumped by MyDumper will be used", i, c.TaskMode)
 			}
+			if inst.StopAt != nil && c.TaskMode == ModeFull {
+				log.Warnf("[config] mysql-instance(%d) set stop-at, but it will not be used for task-mode %s", i, c.TaskMode)
+			}
 		case ModeIncrement:
 			if inst.Meta == nil {
 				return errors.Errorf("mysql-instance(%d) must set meta for task-mode %s", i, c.TaskMode)
@@ -355,6 +459,9 @@ func (c *TaskConfig) adjust() error {
 				return errors.Annotatef(err, "mysql-instance: %d", i)
 			}
 		}
+		if err := inst.StopAt.Verify(); err != nil {
+			return errors.Annotatef(err, "mysql-instance: %d", i)
+		}
 
 		for _, name := range inst.RouteRules {
 			if _, ok := c.Routes[name]; !ok {
@@ -371,6 +478,20 @@ func (c *TaskConfig) adjust() error {
 				return errors.Errorf("mysql-instance(%d)'s column-mapping-rules %s not exist in column-mapping", i, name)
 			}
 		}
+		for _, name := range inst.ExpressionFilters {
+			if _, ok := c.ExprFilter[name]; !ok {
+				return errors.Errorf("mysql-instance(%d)'s expression-filters %s not exist in expression-filter", i, name)
+			}
+		}
+		for _, name := range inst.ColumnTransformRules {
+			rule, ok := c.ColumnTransforms[name]
+			if !ok {
+				return errors.Errorf("mysql-instance(%d)'s column-transform-rules %s not exist in column-transforms", i, name)
+			}
+			if err := rule.Valid(); err != nil {
+				return errors.Annotatef(err, "mysql-instance(%d)'s column-transform-rules %s", i, name)
+			}
+		}
 		if _, ok := c.BWList[inst.BWListName]; len(inst.BWListName) > 0 && !ok {
 			return errors.Errorf("mysql-instance(%d)'s list %s not exist in black white list", i, inst.BWListName)
 		}
@@ -387,9 +508,8 @@ func (c *TaskConfig) adjust() error {
 			inst.Mydumper = &defaultCfg
 		}
 
-		if (c.TaskMode == ModeFull || c.TaskMode == ModeAll) && len(inst.Mydumper.MydumperPath) == 0 {
-			// only verify if set, whether is valid can only be verify when we run it
-			return errors.Errorf("mysql-instance(%d)'s mydumper-path must specify a valid path to mydumper binary when task-mode is all or full", i)
+		if len(inst.Mydumper.MydumperPath) > 0 || len(inst.Mydumper.ExtraArgs) > 0 {
+			log.Warnf("[config] mysql-instance(%d)'s mydumper-path and extra-args are deprecated and ignored, schemas and tables to dump are selected by black-white list and filter rules", i)
 		}
 
 		if len(inst.LoaderConfigName) > 0 {
@@ -415,6 +535,9 @@ func (c *TaskConfig) adjust() error {
 			defaultCfg := defaultSyncerConfig()
 			inst.Syncer = &defaultCfg
 		}
+		if err := inst.Syncer.adjustSink(); err != nil {
+			return errors.Annotatef(err, "mysql-instance(%d)'s syncer config", i)
+		}
 	}
 
 	if c.Timezone != "" {
@@ -438,6 +561,8 @@ func (c *TaskConfig) SubTaskConfigs(sources map[string]DBConfig) ([]*SubTaskConf
 
 		cfg := NewSubTaskConfig()
 		cfg.IsSharding = c.IsSharding
+		cfg.ShardMode = c.ShardMode
+		cfg.ShardTruncateDropPolicy = c.ShardTruncateDropPolicy
 		cfg.OnlineDDLScheme = c.OnlineDDLScheme
 		cfg.IgnoreCheckingItems = c.IgnoreCheckingItems
 		cfg.Name = c.Name
@@ -450,6 +575,7 @@ func (c *TaskConfig) SubTaskConfigs(sources map[string]DBConfig) ([]*SubTaskConf
 		cfg.EnableHeartbeat = c.EnableHeartbeat || !c.DisableHeartbeat
 		cfg.Timezone = c.Timezone
 		cfg.Meta = inst.Meta
+		cfg.StopAt = inst.StopAt
 
 		cfg.From = dbCfg
 		cfg.To = *c.TargetDB
@@ -473,6 +599,16 @@ func (c *TaskConfig) SubTaskConfigs(sources map[string]DBConfig) ([]*SubTaskConf
 
 		cfg.BWList = c.BWList[inst.BWListName]
 
+		cfg.ExprFilter = make([]*ExpressionFilter, len(inst.ExpressionFilters))
+		for j, name := range inst.ExpressionFilters {
+			cfg.ExprFilter[j] = c.ExprFilter[name]
+		}
+
+		cfg.ColumnTransformRules = make([]*transform.Rule, len(inst.ColumnTransformRules))
+		for j, name := range inst.ColumnTransformRules {
+			cfg.ColumnTransformRules[j] = c.ColumnTransforms[name]
+		}
+
 		cfg.MydumperConfig = *inst.Mydumper
 		cfg.LoaderConfig = *inst.Loader
 		cfg.SyncerConfig = *inst.Syncer
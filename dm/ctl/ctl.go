@@ -91,6 +91,8 @@ func Start(args []string) {
 			master.NewUpdateMasterConfigCmd(),
 			master.NewUpdateRelayCmd(),
 			master.NewPurgeRelayCmd(),
+			master.NewListMemberCmd(),
+			master.NewShowRescheduleCmd(),
 		)
 	case common.OfflineMode:
 	}
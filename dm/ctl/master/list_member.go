@@ -0,0 +1,53 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/dm/dm/ctl/common"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewListMemberCmd creates a ListMember command
+func NewListMemberCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-member",
+		Short: "list members of dm-master group and registered dm-workers",
+		Run:   listMemberFunc,
+	}
+	return cmd
+}
+
+// listMemberFunc does list member request
+func listMemberFunc(cmd *cobra.Command, _ []string) {
+	if len(cmd.Flags().Args()) > 0 {
+		fmt.Println(cmd.Usage())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := common.MasterClient()
+	resp, err := cli.ListMember(ctx, &pb.ListMemberRequest{})
+	if err != nil {
+		common.PrintLines("can not list member:\n%v", errors.ErrorStack(err))
+		return
+	}
+
+	common.PrettyPrintResponse(resp)
+}
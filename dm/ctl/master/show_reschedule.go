@@ -0,0 +1,57 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/dm/dm/ctl/common"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewShowRescheduleCmd creates a ShowReschedule command
+func NewShowRescheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-reschedule [source]",
+		Short: "show records of rescheduling sub tasks from offline dm-workers",
+		Run:   showRescheduleFunc,
+	}
+	return cmd
+}
+
+// showRescheduleFunc does show reschedule request
+func showRescheduleFunc(cmd *cobra.Command, _ []string) {
+	if len(cmd.Flags().Args()) > 1 {
+		fmt.Println(cmd.Usage())
+		return
+	}
+	source := ""
+	if len(cmd.Flags().Args()) == 1 {
+		source = cmd.Flags().Arg(0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := common.MasterClient()
+	resp, err := cli.ShowReschedule(ctx, &pb.ShowRescheduleRequest{Source: source})
+	if err != nil {
+		common.PrintLines("can not show reschedule records:\n%v", errors.ErrorStack(err))
+		return
+	}
+
+	common.PrettyPrintResponse(resp)
+}
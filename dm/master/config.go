@@ -22,11 +22,18 @@ import (
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/coreos/etcd/embed"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/dm/pkg/utils"
 	"github.com/pingcap/errors"
 )
 
+const (
+	defaultName                = "dm-master"
+	defaultPeerURLs            = "http://127.0.0.1:8291"
+	defaultInitialClusterState = embed.ClusterStateFlagNew
+)
+
 // SampleConfigFile is sample config file of dm-master
 // later we can read it from dm/master/dm-master.toml
 // and assign it to SampleConfigFile while we build dm-master
@@ -42,6 +49,16 @@ func NewConfig() *Config {
 	fs.BoolVar(&cfg.printSampleConfig, "print-sample-config", false, "print sample config file of dm-worker")
 	fs.StringVar(&cfg.ConfigFile, "config", "", "path to config file")
 	fs.StringVar(&cfg.MasterAddr, "master-addr", "", "master API server and status addr")
+	fs.StringVar(&cfg.AdvertiseAddr, "advertise-addr", "", `advertise address for other dm-masters to forward requests (default "${master-addr}")`)
+	fs.StringVar(&cfg.MetaDir, "meta-dir", "./dm_master_meta", "data directory of the embedded etcd, used to store meta of tasks, DDL locks and deploy map")
+	fs.StringVar(&cfg.Name, "name", "", "human-readable name of this dm-master in the dm-master group")
+	fs.StringVar(&cfg.PeerURLs, "peer-urls", defaultPeerURLs, "URLs for peer traffic of the embedded etcd")
+	fs.StringVar(&cfg.AdvertisePeerURLs, "advertise-peer-urls", "", `advertise URLs for peer traffic of the embedded etcd (default "${peer-urls}")`)
+	fs.StringVar(&cfg.InitialCluster, "initial-cluster", "", `initial dm-master group configuration for bootstrapping, e.g. dm-master-1=http://127.0.0.1:8291 (default "${name}=${advertise-peer-urls}")`)
+	fs.StringVar(&cfg.InitialClusterState, "initial-cluster-state", defaultInitialClusterState, "initial dm-master group state, new or existing")
+	fs.Int64Var(&cfg.WorkerHeartbeatInterval, "worker-heartbeat-interval", 1, "interval (seconds) for registered dm-workers to send heartbeat")
+	fs.Int64Var(&cfg.WorkerHeartbeatTimeout, "worker-heartbeat-timeout", 10, "registered dm-worker is marked as offline if no heartbeat received in this (seconds)")
+	fs.Int64Var(&cfg.WorkerOfflineGracePeriod, "worker-offline-grace-period", 60, "sub tasks of dm-worker offline longer than this (seconds) are rescheduled to standby dm-worker, 0 to disable")
 	fs.StringVar(&cfg.LogLevel, "L", "info", "log level: debug, info, warn, error, fatal")
 	fs.StringVar(&cfg.LogFile, "log-file", "", "log file path")
 	//fs.StringVar(&cfg.LogRotate, "log-rotate", "day", "log file rotate type, hour/day")
@@ -73,7 +90,21 @@ type Config struct {
 	LogFile   string `toml:"log-file" json:"log-file"`
 	LogRotate string `toml:"log-rotate" json:"log-rotate"`
 
-	MasterAddr string `toml:"master-addr" json:"master-addr"`
+	MasterAddr    string `toml:"master-addr" json:"master-addr"`
+	AdvertiseAddr string `toml:"advertise-addr" json:"advertise-addr"`
+	MetaDir       string `toml:"meta-dir" json:"meta-dir"`
+
+	// embedded etcd, used for leader election and storing meta
+	Name                string `toml:"name" json:"name"`
+	PeerURLs            string `toml:"peer-urls" json:"peer-urls"`
+	AdvertisePeerURLs   string `toml:"advertise-peer-urls" json:"advertise-peer-urls"`
+	InitialCluster      string `toml:"initial-cluster" json:"initial-cluster"`
+	InitialClusterState string `toml:"initial-cluster-state" json:"initial-cluster-state"`
+
+	// registered dm-workers
+	WorkerHeartbeatInterval  int64 `toml:"worker-heartbeat-interval" json:"worker-heartbeat-interval"`
+	WorkerHeartbeatTimeout   int64 `toml:"worker-heartbeat-timeout" json:"worker-heartbeat-timeout"`
+	WorkerOfflineGracePeriod int64 `toml:"worker-offline-grace-period" json:"worker-offline-grace-period"`
 
 	Deploy    []*DeployMapper   `toml:"deploy" json:"-"`
 	DeployMap map[string]string `json:"deploy"`
@@ -161,6 +192,41 @@ func (c *Config) adjust() error {
 
 		c.DeployMap[item.Source] = item.Worker
 	}
+
+	if c.AdvertiseAddr == "" {
+		c.AdvertiseAddr = c.MasterAddr
+	}
+	if c.Name == "" {
+		c.Name = defaultName
+	}
+	if c.PeerURLs == "" {
+		c.PeerURLs = defaultPeerURLs
+	}
+	if c.AdvertisePeerURLs == "" {
+		c.AdvertisePeerURLs = c.PeerURLs
+	}
+	if c.InitialCluster == "" {
+		items := strings.Split(c.AdvertisePeerURLs, ",")
+		for i, item := range items {
+			items[i] = fmt.Sprintf("%s=%s", c.Name, item)
+		}
+		c.InitialCluster = strings.Join(items, ",")
+	}
+	if c.InitialClusterState == "" {
+		c.InitialClusterState = defaultInitialClusterState
+	}
+	if c.WorkerHeartbeatInterval <= 0 {
+		return errors.NotValidf("worker-heartbeat-interval %d", c.WorkerHeartbeatInterval)
+	}
+	if c.WorkerHeartbeatTimeout <= c.WorkerHeartbeatInterval {
+		return errors.NotValidf("worker-heartbeat-timeout %d, should be greater than worker-heartbeat-interval %d", c.WorkerHeartbeatTimeout, c.WorkerHeartbeatInterval)
+	}
+	if c.WorkerOfflineGracePeriod != 0 && c.WorkerOfflineGracePeriod < c.WorkerHeartbeatTimeout {
+		return errors.NotValidf("worker-offline-grace-period %d, should be 0 or not less than worker-heartbeat-timeout %d", c.WorkerOfflineGracePeriod, c.WorkerHeartbeatTimeout)
+	}
+	if c.InitialClusterState != embed.ClusterStateFlagNew && c.InitialClusterState != embed.ClusterStateFlagExisting {
+		return errors.NotValidf("initial-cluster-state %s, should be %s or %s", c.InitialClusterState, embed.ClusterStateFlagNew, embed.ClusterStateFlagExisting)
+	}
 	return nil
 }
 
@@ -31,6 +31,16 @@ package master
  *
  * one DDL lock can do re-try-sync by same dm-worker multi times, reentrant
  *
+ * DDL locks are persisted in etcd, and restored after dm-master restarted (or a new leader elected).
+ * dm-workers re-send DDL info not resolved at intervals, with the DDL lock ID recorded if any,
+ * so the restored DDL locks can be synced and resolved again.
+ * the DDL info re-sent is matched with the DDL lock by its ID, and the DDL lock is not created again if not exists,
+ * because it has been resolved or removed.
+ * the progress of resolving is also persisted, so dm-workers which have executed / skipped the DDL
+ * are not requested again after dm-master restarted. NOTE: the DDL may still be executed twice by the owner
+ * if dm-master restarted after the owner executed it but before the progress persisted.
+ * re-sent DDL info with a DDL lock ID not exists is failed with an error, then user can use dmctl to handle it.
+ *
  * normal work flow
  * 1. sub task process unit encounters DDL when syncing
  * 2. process unit saves DDL info and hangs self up
@@ -65,7 +75,7 @@ package master
  *    use dmctl to force the dm-worker to execute / skip the DDL which current is blocking
  * 4. some dm-workers occurred error when executing DDL
  *    use dmctl to force the dm-worker to execute / skip the DDL which current is blocking
- * 5. DDL lock info lost after dm-master restarted
+ * 5. DDL lock removed by dmctl (with `--force-remove`), but the dm-worker is still blocking
  *    use dmctl to force the dm-worker to execute / skip the DDL which current is blocking
  *
  * dmctl operations to handle abnormal cases
@@ -73,6 +83,8 @@ package master
  *    dm-workers will try execute / skip the DDL
  *    supporting use a different dm-worker to replace the owner to execute the DDL
  * 2. force dm-worker to execute / skip the DDL which current is blocking
+ *
+ * DDL locks not synced in time can also be handled by timeout policies of the task automatically, see lock_timeout.go
  */
 
 import (
@@ -82,22 +94,29 @@ import (
 	"github.com/pingcap/errors"
 )
 
-// genDDLLockID generates a DDL lock ID
+// genDDLLockID generates a DDL lock ID, table is empty for schema-level DDL
 // NOTE: refine to include DDL type or other info?
 func genDDLLockID(task, schema, table string) string {
+	if len(table) == 0 {
+		return fmt.Sprintf("%s-`%s`", task, schema)
+	}
 	return fmt.Sprintf("%s-`%s`.`%s`", task, schema, table)
 }
 
 // LockKeeper used to keep and handle DDL lock
 type LockKeeper struct {
 	sync.RWMutex
-	locks map[string]*Lock // lockID -> lock
+	locks  map[string]*Lock            // lockID -> lock
+	groups map[string]*OptimisticGroup // groupID -> optimistic group, see optimistic_lock.go
+	skips  map[string]*SkippedShards   // lockID -> dm-workers skipped, see lock_timeout.go
 }
 
 // NewLockKeeper creates a new LockKeeper
 func NewLockKeeper() *LockKeeper {
 	l := &LockKeeper{
-		locks: make(map[string]*Lock),
+		locks:  make(map[string]*Lock),
+		groups: make(map[string]*OptimisticGroup),
+		skips:  make(map[string]*SkippedShards),
 	}
 	return l
 }
@@ -113,6 +132,7 @@ func (lk *LockKeeper) TrySync(task, schema, table, worker string, stmts []string
 	lk.Lock()
 	defer lk.Unlock()
 
+	workers = lk.excludeWorkers(lockID, workers)
 	if l, ok = lk.locks[lockID]; !ok {
 		lk.locks[lockID] = NewLock(lockID, task, worker, stmts, workers)
 		l = lk.locks[lockID]
@@ -150,3 +170,14 @@ func (lk *LockKeeper) Locks() map[string]*Lock {
 	}
 	return locks
 }
+
+// Restore replaces all locks with locks restored from persistent information
+func (lk *LockKeeper) Restore(metas []*LockMeta) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	lk.locks = make(map[string]*Lock, len(metas))
+	for _, meta := range metas {
+		lk.locks[meta.ID] = NewLockFromMeta(meta)
+	}
+}
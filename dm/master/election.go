@@ -0,0 +1,137 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go/sync2"
+)
+
+var campaignRetryInterval = time.Second
+
+// Election campaigns for the leader of dm-master group through etcd
+type Election struct {
+	cli   *clientv3.Client
+	key   string
+	value string // advertise address of this dm-master
+	ttl   int
+
+	isLeader sync2.AtomicBool
+}
+
+// NewElection creates a new Election
+func NewElection(cli *clientv3.Client, key, value string, ttl int) *Election {
+	return &Election{
+		cli:   cli,
+		key:   key,
+		value: value,
+		ttl:   ttl,
+	}
+}
+
+// Run campaigns for the leader until ctx canceled, blocks.
+// onLeader is called after elected and before IsLeader returns true,
+// onRetire is called after IsLeader returns false and before resigning
+func (e *Election) Run(ctx context.Context, onLeader func(ctx context.Context) error, onRetire func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		err := e.campaignOnce(ctx, onLeader, onRetire)
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("[election] campaign for %s error %v", e.key, errors.ErrorStack(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(campaignRetryInterval):
+		}
+	}
+}
+
+// campaignOnce campaigns for the leader in a new session, and returns after it is no longer the leader
+func (e *Election) campaignOnce(ctx context.Context, onLeader func(ctx context.Context) error, onRetire func()) error {
+	session, err := concurrency.NewSession(e.cli, concurrency.WithTTL(e.ttl))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer session.Close()
+
+	elec := concurrency.NewElection(session, e.key)
+	err = elec.Campaign(ctx, e.value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	select {
+	case <-session.Done():
+		return errors.New("session expired after campaign")
+	default:
+	}
+	log.Infof("[election] %s become leader", e.value)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = onLeader(leaderCtx)
+	if err == nil {
+		e.isLeader.Set(true)
+		select {
+		case <-ctx.Done():
+		case <-session.Done():
+			log.Warnf("[election] session of %s expired", e.value)
+		}
+		e.isLeader.Set(false)
+	}
+	cancel()
+	onRetire()
+	log.Infof("[election] %s retire from leader", e.value)
+
+	// resign with a new context, because ctx may be canceled
+	rctx, rcancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer rcancel()
+	if err2 := elec.Resign(rctx); err2 != nil {
+		log.Warnf("[election] %s resign error %v", e.value, err2)
+	}
+	return errors.Trace(err)
+}
+
+// IsLeader returns whether this dm-master is the leader
+func (e *Election) IsLeader() bool {
+	return e.isLeader.Get()
+}
+
+// Leader returns the advertise address of the current leader, empty if no leader elected
+func (e *Election) Leader(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := e.cli.Get(ctx, e.key+"/", clientv3.WithFirstCreate()...)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/embed"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/pingcap/errors"
+)
+
+var (
+	etcdStartTimeout   = time.Minute
+	etcdRequestTimeout = 5 * time.Second
+)
+
+// keys of meta stored in the embedded etcd
+const (
+	electionKey         = "/dm-master/leader"
+	taskKeyPrefix       = "/dm-master/task/"
+	ddlLockKeyPrefix    = "/dm-master/ddl-lock/"
+	optimisticKeyPrefix = "/dm-master/optimistic-group/" // task/group ID -> optimistic group
+	skippedKeyPrefix    = "/dm-master/skipped-shards/"   // task/lock ID -> skipped dm-workers
+	deployMapKey        = "/dm-master/deploy"
+	memberKeyPrefix     = "/dm-master/member/"     // member name -> advertise address
+	workerKeyPrefix     = "/dm-master/worker/"     // dm-worker's address -> registered information
+	rescheduleKeyPrefix = "/dm-master/reschedule/" // source -> reschedule records
+	electionSessionTTL  = 10                       // seconds
+)
+
+// startEtcd starts an embedded etcd with dm-master's config, and waits until it is ready to serve.
+// no client URLs are listened, clients should be created by v3client in the same process
+func startEtcd(cfg *Config) (*embed.Etcd, error) {
+	ecfg := embed.NewConfig()
+	ecfg.Name = cfg.Name
+	ecfg.Dir = cfg.MetaDir
+	ecfg.InitialCluster = cfg.InitialCluster
+	ecfg.ClusterState = cfg.InitialClusterState
+	ecfg.LCUrls = []url.URL{}
+	ecfg.ACUrls = []url.URL{}
+
+	var err error
+	ecfg.LPUrls, err = parseURLs(cfg.PeerURLs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "peer-urls %s", cfg.PeerURLs)
+	}
+	ecfg.APUrls, err = parseURLs(cfg.AdvertisePeerURLs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "advertise-peer-urls %s", cfg.AdvertisePeerURLs)
+	}
+
+	e, err := embed.StartEtcd(ecfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	select {
+	case <-e.Server.ReadyNotify():
+		return e, nil
+	case err = <-e.Err():
+		e.Close()
+		return nil, errors.Trace(err)
+	case <-time.After(etcdStartTimeout):
+		e.Close()
+		return nil, errors.Errorf("start embedded etcd timeout %v", etcdStartTimeout)
+	}
+}
+
+// parseURLs parses comma separated URLs
+func parseURLs(s string) ([]url.URL, error) {
+	urls, err := types.NewURLs(strings.Split(s, ","))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []url.URL(urls), nil
+}
@@ -0,0 +1,589 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+// becomeLeader restores meta from etcd and starts background jobs of the leader,
+// background jobs exit when ctx canceled
+func (s *Server) becomeLeader(ctx context.Context) error {
+	err := s.loadDeployMap(ctx)
+	if err != nil {
+		return errors.Annotate(err, "load deploy map")
+	}
+
+	err = s.taskMeta.Load()
+	if err != nil {
+		return errors.Annotate(err, "load task meta")
+	}
+	taskWorkers := make(map[string][]string)
+	for _, task := range s.taskMeta.All() {
+		taskWorkers[task.Name] = task.Workers()
+	}
+	s.replaceTaskWorkers(taskWorkers)
+
+	locks, err := s.loadLocks(ctx)
+	if err != nil {
+		return errors.Annotate(err, "load DDL locks")
+	}
+	s.lockKeeper.Restore(locks)
+	groups, err := s.loadOptimisticGroups(ctx)
+	if err != nil {
+		return errors.Annotate(err, "load optimistic groups")
+	}
+	s.lockKeeper.RestoreOptimisticGroups(groups)
+	skips, err := s.loadSkippedShards(ctx)
+	if err != nil {
+		return errors.Annotate(err, "load skipped dm-workers of DDL locks")
+	}
+	s.lockKeeper.RestoreSkippedShards(skips)
+	log.Infof("[server] restored %d tasks, %d DDL locks, %d optimistic groups and %d records of skipped dm-workers from etcd", len(taskWorkers), len(locks), len(groups), len(skips))
+
+	// registered dm-workers are treated as online until heartbeat timeout,
+	// so dm-workers crashed during failover can also be rescheduled
+	workers, err := s.loadWorkers(ctx)
+	if err != nil {
+		return errors.Annotate(err, "load registered dm-workers")
+	}
+	s.registry.Restore(workers, time.Now())
+	s.leaderWg.Add(1)
+	go func() {
+		defer s.leaderWg.Done()
+		s.checkWorkers(ctx)
+	}()
+
+	s.leaderWg.Add(1)
+	go func() {
+		defer s.leaderWg.Done()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(3 * time.Second):
+			// recover tasks and update task -> workers after became leader
+			s.recoverTasks(ctx)
+		}
+	}()
+
+	s.leaderWg.Add(1)
+	go func() {
+		defer s.leaderWg.Done()
+		// fetch DDL info from dm-workers to sync sharding DDL
+		s.fetchWorkerDDLInfo(ctx)
+	}()
+
+	s.leaderWg.Add(1)
+	go func() {
+		defer s.leaderWg.Done()
+		// handle DDL locks not synced in time by timeout policies of tasks
+		s.checkDDLLocks(ctx)
+	}()
+
+	return nil
+}
+
+// retire waits for background jobs of the leader to exit
+func (s *Server) retire() {
+	s.leaderWg.Wait()
+}
+
+// leaderClient returns a client to forward requests to the leader,
+// returns nil if this dm-master is the leader
+func (s *Server) leaderClient(ctx context.Context) (pb.MasterClient, error) {
+	if s.election.IsLeader() {
+		return nil, nil
+	}
+
+	leader, err := s.election.Leader(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "get leader of dm-master group")
+	}
+	if leader == "" {
+		return nil, errors.New("no leader of dm-master group elected, please try again later")
+	}
+	if leader == s.cfg.AdvertiseAddr {
+		return nil, errors.Errorf("dm-master %s is becoming leader, please try again later", leader)
+	}
+
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+	if s.leaderAddr != leader {
+		if s.leaderConn != nil {
+			s.leaderConn.Close()
+		}
+		conn, err2 := grpc.Dial(leader, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(3*time.Second))
+		if err2 != nil {
+			s.leaderAddr, s.leaderConn, s.leaderCli = "", nil, nil
+			return nil, errors.Trace(err2)
+		}
+		log.Infof("[server] forward requests to leader %s", leader)
+		s.leaderAddr, s.leaderConn, s.leaderCli = leader, conn, pb.NewMasterClient(conn)
+	}
+	return s.leaderCli, nil
+}
+
+// forwardToLeader is a gRPC unary interceptor which forwards requests to the leader if this dm-master is not the leader,
+// the request is forwarded by calling the method with the same name of the client to the leader
+func (s *Server) forwardToLeader(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	cli, err := s.leaderClient(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if cli == nil {
+		return handler(ctx, req)
+	}
+
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	fn := reflect.ValueOf(cli).MethodByName(method)
+	if !fn.IsValid() {
+		return nil, errors.NotSupportedf("forward request of %s to the leader", info.FullMethod)
+	}
+	log.Debugf("[server] forward %s request %+v to the leader", method, req)
+	out := fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+	if err, ok := out[1].Interface().(error); ok && err != nil {
+		return nil, err
+	}
+	return out[0].Interface(), nil
+}
+
+// closeLeaderClient closes the client used to forward requests to the leader
+func (s *Server) closeLeaderClient() {
+	s.leaderMu.Lock()
+	defer s.leaderMu.Unlock()
+	if s.leaderConn != nil {
+		s.leaderConn.Close()
+	}
+	s.leaderAddr, s.leaderConn, s.leaderCli = "", nil, nil
+}
+
+// loadDeployMap loads deploy map from etcd, and updates dm-worker clients.
+// deploy map in config is saved if no deploy map in etcd
+func (s *Server) loadDeployMap(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := s.etcdCli.Get(ctx, deployMapKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if len(resp.Kvs) == 0 {
+		return errors.Trace(s.saveDeployMap(ctx, s.cfg.DeployMap))
+	}
+
+	deployMap := make(map[string]string)
+	err = json.Unmarshal(resp.Kvs[0].Value, &deployMap)
+	if err != nil {
+		return errors.Annotatef(err, "decode deploy map %s", resp.Kvs[0].Value)
+	}
+	err = s.updateWorkerClients(deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.cfg.DeployMap = deployMap
+	return nil
+}
+
+// saveDeployMap saves deploy map into etcd
+func (s *Server) saveDeployMap(ctx context.Context, deployMap map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	value, err := json.Marshal(deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.etcdCli.Put(ctx, deployMapKey, string(value))
+	return errors.Trace(err)
+}
+
+// updateWorkerClients creates clients for new dm-workers and removes clients for dm-workers not in deploy map,
+// should be called with lock held except when starting
+func (s *Server) updateWorkerClients(deployMap map[string]string) error {
+	exist := make(map[string]struct{}, len(deployMap))
+	for _, workerAddr := range deployMap {
+		exist[workerAddr] = struct{}{}
+		if _, ok := s.workerClients[workerAddr]; ok {
+			continue
+		}
+		conn, err := grpc.Dial(workerAddr, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(3*time.Second))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.workerClients[workerAddr] = pb.NewWorkerClient(conn)
+	}
+	for workerAddr := range s.workerClients {
+		if _, ok := exist[workerAddr]; !ok {
+			delete(s.workerClients, workerAddr)
+		}
+	}
+	return nil
+}
+
+// bindWorker binds the dm-worker to the source in deploy map if no dm-worker bound to the source yet,
+// otherwise the dm-worker is a standby for the source
+func (s *Server) bindWorker(ctx context.Context, sourceID, worker string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if bound, ok := s.cfg.DeployMap[sourceID]; ok {
+		if bound != worker {
+			log.Infof("[server] source %s is bound to dm-worker %s, dm-worker %s registered as standby", sourceID, bound, worker)
+		}
+		return nil
+	}
+
+	deployMap := make(map[string]string, len(s.cfg.DeployMap)+1)
+	for source, w := range s.cfg.DeployMap {
+		deployMap[source] = w
+	}
+	deployMap[sourceID] = worker
+
+	err := s.saveDeployMap(ctx, deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.updateWorkerClients(deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.cfg.DeployMap = deployMap
+	log.Infof("[server] bind dm-worker %s to source %s", worker, sourceID)
+	return nil
+}
+
+// checkWorkers marks registered dm-workers without heartbeat as offline at intervals,
+// reschedules sub tasks of dm-workers offline longer than grace period,
+// and stops stale sub tasks on newly registered standby dm-workers
+func (s *Server) checkWorkers(ctx context.Context) {
+	interval := time.Duration(s.cfg.WorkerHeartbeatInterval) * time.Second
+	gracePeriod := time.Duration(s.cfg.WorkerOfflineGracePeriod) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	rescheduling := make(map[string]struct{}) // offline dm-workers with sub tasks not rescheduled yet, retry at intervals
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, worker := range s.registry.CheckOffline(now) {
+				log.Warnf("[server] dm-worker %s is offline, no heartbeat received in %d seconds", worker, s.cfg.WorkerHeartbeatTimeout)
+			}
+			if gracePeriod > 0 {
+				offline := s.registry.OfflineWorkers(now, gracePeriod)
+				retrying := make(map[string]struct{}, len(offline))
+				for _, worker := range offline {
+					if _, ok := rescheduling[worker]; !ok {
+						log.Warnf("[server] dm-worker %s is offline longer than %d seconds, reschedule its sub tasks", worker, s.cfg.WorkerOfflineGracePeriod)
+					}
+					if s.rescheduleWorker(ctx, worker) {
+						s.registry.MarkOfflineHandled(worker)
+					} else {
+						retrying[worker] = struct{}{}
+					}
+				}
+				rescheduling = retrying
+			}
+			// wait for restored sub tasks started on the dm-worker
+			for _, worker := range s.registry.RegisteredWorkers(now, 2*interval) {
+				s.stopStaleSubTasks(ctx, worker)
+			}
+		}
+	}
+}
+
+// saveWorker saves information of the registered dm-worker into etcd
+func (s *Server) saveWorker(ctx context.Context, info *WorkerInfo) error {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	value, err := json.Marshal(info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.etcdCli.Put(ctx, workerKeyPrefix+info.Address, string(value))
+	return errors.Trace(err)
+}
+
+// loadWorkers loads information of registered dm-workers from etcd
+func (s *Server) loadWorkers(ctx context.Context) ([]*WorkerInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := s.etcdCli.Get(ctx, workerKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	workers := make([]*WorkerInfo, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		info := &WorkerInfo{}
+		err = json.Unmarshal(kv.Value, info)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode dm-worker %s", kv.Key)
+		}
+		workers = append(workers, info)
+	}
+	return workers, nil
+}
+
+// saveMember saves advertise address of this dm-master into etcd
+func (s *Server) saveMember() error {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err := s.etcdCli.Put(ctx, memberKeyPrefix+s.cfg.Name, s.cfg.AdvertiseAddr)
+	return errors.Trace(err)
+}
+
+// listMasters lists members of dm-master group
+func (s *Server) listMasters(ctx context.Context) ([]*pb.MasterMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	memberResp, err := s.etcdCli.MemberList(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addrResp, err := s.etcdCli.Get(ctx, memberKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addrs := make(map[string]string, len(addrResp.Kvs))
+	for _, kv := range addrResp.Kvs {
+		addrs[strings.TrimPrefix(string(kv.Key), memberKeyPrefix)] = string(kv.Value)
+	}
+	leader, err := s.election.Leader(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	masters := make([]*pb.MasterMember, 0, len(memberResp.Members))
+	for _, m := range memberResp.Members {
+		addr := addrs[m.Name]
+		masters = append(masters, &pb.MasterMember{
+			Name:     m.Name,
+			Address:  addr,
+			PeerURLs: m.PeerURLs,
+			Leader:   addr != "" && addr == leader,
+		})
+	}
+	sort.Slice(masters, func(i, j int) bool {
+		return masters[i].Name < masters[j].Name
+	})
+	return masters, nil
+}
+
+// listWorkers lists registered dm-workers and dm-workers in deploy map
+func (s *Server) listWorkers() []*pb.WorkerMember {
+	s.Lock()
+	deployMap := s.cfg.DeployMap
+	s.Unlock()
+
+	registered := make(map[string]struct{})
+	workers := make([]*pb.WorkerMember, 0, len(deployMap))
+	for _, w := range s.registry.Workers() {
+		registered[w.Address] = struct{}{}
+		workers = append(workers, &pb.WorkerMember{
+			Address:       w.Address,
+			SourceID:      w.SourceID,
+			Capabilities:  w.Capabilities,
+			Version:       w.Version,
+			Status:        w.Status,
+			Bound:         deployMap[w.SourceID] == w.Address,
+			LastHeartbeat: w.LastHeartbeat.Format("2006-01-02 15:04:05"),
+		})
+	}
+	for source, worker := range deployMap {
+		if _, ok := registered[worker]; !ok {
+			workers = append(workers, &pb.WorkerMember{
+				Address:  worker,
+				SourceID: source,
+				Status:   workerUnregistered,
+				Bound:    true,
+			})
+		}
+	}
+	sort.Slice(workers, func(i, j int) bool {
+		if workers[i].Address != workers[j].Address {
+			return workers[i].Address < workers[j].Address
+		}
+		return workers[i].SourceID < workers[j].SourceID
+	})
+	return workers
+}
+
+// loadLocks loads DDL locks from etcd
+func (s *Server) loadLocks(ctx context.Context) ([]*LockMeta, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := s.etcdCli.Get(ctx, ddlLockKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	locks := make([]*LockMeta, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		lock := &LockMeta{}
+		err = json.Unmarshal(kv.Value, lock)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode DDL lock %s", kv.Key)
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+// saveLock saves the DDL lock into etcd, deletes it from etcd if it not exists anymore
+func (s *Server) saveLock(lockID string) error {
+	lock := s.lockKeeper.FindLock(lockID)
+	if lock == nil {
+		return errors.Trace(s.deleteLock(lockID))
+	}
+
+	value, err := json.Marshal(lock.Meta())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err = s.etcdCli.Put(ctx, ddlLockKeyPrefix+lockID, string(value))
+	return errors.Trace(err)
+}
+
+// deleteLock deletes the DDL lock from etcd
+func (s *Server) deleteLock(lockID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err := s.etcdCli.Delete(ctx, ddlLockKeyPrefix+lockID)
+	return errors.Trace(err)
+}
+
+// loadOptimisticGroups loads all optimistic groups from etcd
+func (s *Server) loadOptimisticGroups(ctx context.Context) ([]*OptimisticGroup, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := s.etcdCli.Get(ctx, optimisticKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	groups := make([]*OptimisticGroup, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		g := &OptimisticGroup{}
+		err = json.Unmarshal(kv.Value, g)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode optimistic group %s", kv.Key)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// saveOptimisticGroup saves the optimistic group into etcd
+func (s *Server) saveOptimisticGroup(groupID string) error {
+	g := s.lockKeeper.OptimisticGroup(groupID)
+	if g == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(g)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err = s.etcdCli.Put(ctx, optimisticKeyPrefix+g.Task+"/"+groupID, string(value))
+	return errors.Trace(err)
+}
+
+// removeOptimisticGroups removes all optimistic groups of the task, and deletes them from etcd
+func (s *Server) removeOptimisticGroups(task string) error {
+	s.lockKeeper.RemoveOptimisticGroups(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err := s.etcdCli.Delete(ctx, optimisticKeyPrefix+task+"/", clientv3.WithPrefix())
+	return errors.Trace(err)
+}
+
+// loadSkippedShards loads all records of skipped dm-workers of DDL locks from etcd
+func (s *Server) loadSkippedShards(ctx context.Context) ([]*SkippedShards, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	resp, err := s.etcdCli.Get(ctx, skippedKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	records := make([]*SkippedShards, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		ss := &SkippedShards{}
+		err = json.Unmarshal(kv.Value, ss)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode skipped dm-workers %s", kv.Key)
+		}
+		records = append(records, ss)
+	}
+	return records, nil
+}
+
+// saveSkippedShards saves the record of skipped dm-workers of the DDL lock into etcd,
+// deletes it from etcd if it not exists anymore
+func (s *Server) saveSkippedShards(task, lockID string) error {
+	key := skippedKeyPrefix + task + "/" + lockID
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+
+	ss := s.lockKeeper.SkippedShards(lockID)
+	if ss == nil {
+		_, err := s.etcdCli.Delete(ctx, key)
+		return errors.Trace(err)
+	}
+
+	value, err := json.Marshal(ss)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.etcdCli.Put(ctx, key, string(value))
+	return errors.Trace(err)
+}
+
+// removeSkippedShards removes all records of skipped dm-workers of the task, and deletes them from etcd
+func (s *Server) removeSkippedShards(task string) error {
+	s.lockKeeper.RemoveSkippedShards(task)
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	_, err := s.etcdCli.Delete(ctx, skippedKeyPrefix+task+"/", clientv3.WithPrefix())
+	return errors.Trace(err)
+}
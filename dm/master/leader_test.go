@@ -0,0 +1,144 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/pingcap/check"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+// freeAddr returns a free address on localhost
+func freeAddr(c *C) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// newTestConfigs creates configs for a dm-master group with n members on localhost
+func newTestConfigs(c *C, n int) []*Config {
+	cfgs := make([]*Config, 0, n)
+	members := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		cfg := NewConfig()
+		cfg.Name = fmt.Sprintf("dm-master-%d", i+1)
+		cfg.MasterAddr = freeAddr(c)
+		cfg.MetaDir = c.MkDir()
+		cfg.PeerURLs = "http://" + freeAddr(c)
+		members = append(members, fmt.Sprintf("%s=%s", cfg.Name, cfg.PeerURLs))
+		cfgs = append(cfgs, cfg)
+	}
+	for _, cfg := range cfgs {
+		cfg.InitialCluster = strings.Join(members, ",")
+		c.Assert(cfg.adjust(), IsNil)
+	}
+	return cfgs
+}
+
+// waitLeader waits until one of the servers becomes the leader, returns its index
+func waitLeader(c *C, servers []*Server) int {
+	for i := 0; i < 100; i++ {
+		for j, s := range servers {
+			if s != nil && s.election != nil && s.election.IsLeader() {
+				return j
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Fatal("no leader elected")
+	return -1
+}
+
+func (t *testMaster) TestLeaderFailover(c *C) {
+	cfgs := newTestConfigs(c, 3)
+	servers := make([]*Server, 0, len(cfgs))
+	errCh := make(chan error, len(cfgs))
+	for _, cfg := range cfgs {
+		s := NewServer(cfg)
+		servers = append(servers, s)
+		go func() {
+			errCh <- s.Start()
+		}()
+	}
+	defer func() {
+		for _, s := range servers {
+			if s != nil {
+				s.Close()
+			}
+		}
+	}()
+
+	// the first leader holds a DDL lock
+	leader := waitLeader(c, servers)
+	lockID, _, _, err := servers[leader].lockKeeper.TrySync("test", "db", "tbl", "127.0.0.1:8262", []string{"stmt"}, []string{"127.0.0.1:8262", "127.0.0.1:8263"})
+	c.Assert(err, IsNil)
+	c.Assert(servers[leader].saveLock(lockID), IsNil)
+
+	showLocks := func(addr string) *pb.ShowDDLLocksResponse {
+		conn, err2 := grpc.Dial(addr, grpc.WithInsecure())
+		c.Assert(err2, IsNil)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err2 := pb.NewMasterClient(conn).ShowDDLLocks(ctx, &pb.ShowDDLLocksRequest{})
+		c.Assert(err2, IsNil)
+		return resp
+	}
+
+	// requests to followers are forwarded to the leader
+	for i, cfg := range cfgs {
+		if i == leader {
+			continue
+		}
+		c.Assert(servers[i].election.IsLeader(), IsFalse)
+		resp := showLocks(cfg.MasterAddr)
+		c.Assert(resp.Locks, HasLen, 1)
+		c.Assert(resp.Locks[0].ID, Equals, lockID)
+		c.Assert(resp.Locks[0].Synced, DeepEquals, []string{"127.0.0.1:8262"})
+
+		// the leader is got from the election even if listed by a follower
+		masters, err := servers[i].listMasters(context.Background())
+		c.Assert(err, IsNil)
+		c.Assert(masters, HasLen, len(cfgs))
+		for _, m := range masters {
+			c.Assert(m.Leader, Equals, m.Address == cfgs[leader].AdvertiseAddr)
+		}
+	}
+
+	// close the leader, a new leader elected and restores the DDL lock
+	servers[leader].Close()
+	c.Assert(<-errCh, IsNil)
+	servers[leader] = nil
+	newLeader := waitLeader(c, servers)
+	c.Assert(newLeader, Not(Equals), leader)
+	c.Assert(servers[newLeader].lockKeeper.FindLock(lockID), NotNil)
+
+	for i, cfg := range cfgs {
+		if servers[i] == nil {
+			continue
+		}
+		resp := showLocks(cfg.MasterAddr)
+		c.Assert(resp.Locks, HasLen, 1)
+		c.Assert(resp.Locks[0].ID, Equals, lockID)
+		c.Assert(resp.Locks[0].Unsynced, DeepEquals, []string{"127.0.0.1:8263"})
+	}
+}
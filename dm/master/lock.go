@@ -14,7 +14,9 @@
 package master
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/pingcap/dm/pkg/utils"
 	"github.com/pingcap/errors"
@@ -28,11 +30,18 @@ type Lock struct {
 	Task      string           // lock's corresponding task name
 	Owner     string           // lock's Owner, a dm-worker
 	Stmts     []string         // SQL statement
+	Skip      bool             // whether the owner should skip the DDL too, in optimistic shard mode
+	ExecDDLs  []string         // DDLs executed by the owner instead of Stmts, generated from the changes of the joined schema in optimistic shard mode
+	Conflict  string           // why the DDL conflicts with the joined schema, in optimistic shard mode
+	Created   time.Time        // when the lock created, used to check whether it's timeout
 	remain    int              // remain count needed to sync
 	ready     map[string]bool  // whether dm-worker is synced
 	ddls      []string         // ddls of each dm-worker
+	done      map[string]bool  // dm-workers which have executed / skipped the DDL when resolving
 	AutoRetry sync2.AtomicBool // whether re-try resolve at intervals
 	Resolving sync2.AtomicBool // whether the lock is resolving
+	// dm-worker -> source table -> binlog sync progress of source tables not synced, see lock_timeout.go
+	progress map[string]map[string]*shardProgress
 }
 
 // NewLock creates a new Lock
@@ -44,6 +53,10 @@ func NewLock(id, task, owner string, stmts []string, workers []string) *Lock {
 		Stmts:  stmts,
 		remain: len(workers),
 		ready:  make(map[string]bool),
+		done:   make(map[string]bool),
+
+		Created:  time.Now(),
+		progress: make(map[string]map[string]*shardProgress),
 	}
 	for _, w := range workers {
 		l.ready[w] = false
@@ -105,3 +118,109 @@ func (l *Lock) DDLs() []string {
 	defer l.RUnlock()
 	return l.ddls // never modify elem in slice, no copy
 }
+
+// MarkDone marks the dm-worker has executed / skipped the DDL when resolving the lock
+func (l *Lock) MarkDone(worker string) {
+	l.Lock()
+	defer l.Unlock()
+	l.done[worker] = true
+}
+
+// IsDone returns whether the dm-worker has executed / skipped the DDL,
+// the lock may be resolved partially before dm-master restarted
+func (l *Lock) IsDone(worker string) bool {
+	l.RLock()
+	defer l.RUnlock()
+	return l.done[worker]
+}
+
+// RemoveWorker removes a dm-worker not synced from the lock, so the lock can be synced without it
+func (l *Lock) RemoveWorker(worker string) {
+	l.Lock()
+	defer l.Unlock()
+	if synced, ok := l.ready[worker]; ok && !synced {
+		l.remain--
+		delete(l.ready, worker)
+		delete(l.progress, worker)
+	}
+}
+
+// LockMeta represents persistent information of a DDL lock
+type LockMeta struct {
+	ID    string          `json:"id"`
+	Task  string          `json:"task"`
+	Owner string          `json:"owner"`
+	Stmts []string        `json:"stmts"`
+	Ready map[string]bool `json:"ready"`
+	DDLs  []string        `json:"ddls"`
+
+	Skip     bool     `json:"skip,omitempty"`
+	ExecDDLs []string `json:"exec-ddls,omitempty"`
+	Conflict string   `json:"conflict,omitempty"`
+
+	Done []string `json:"done,omitempty"` // dm-workers which have executed / skipped the DDL
+
+	Created time.Time `json:"created"`
+}
+
+// Meta returns persistent information of the lock
+func (l *Lock) Meta() *LockMeta {
+	l.RLock()
+	defer l.RUnlock()
+	meta := &LockMeta{
+		ID:    l.ID,
+		Task:  l.Task,
+		Owner: l.Owner,
+		Stmts: l.Stmts,
+		Ready: make(map[string]bool, len(l.ready)),
+		DDLs:  l.ddls,
+
+		Skip:     l.Skip,
+		ExecDDLs: l.ExecDDLs,
+		Conflict: l.Conflict,
+
+		Created: l.Created,
+	}
+	for k, v := range l.ready {
+		meta.Ready[k] = v
+	}
+	for worker := range l.done {
+		meta.Done = append(meta.Done, worker)
+	}
+	sort.Strings(meta.Done)
+	return meta
+}
+
+// NewLockFromMeta restores a Lock from its persistent information
+func NewLockFromMeta(meta *LockMeta) *Lock {
+	l := &Lock{
+		ID:    meta.ID,
+		Task:  meta.Task,
+		Owner: meta.Owner,
+		Stmts: meta.Stmts,
+		ready: make(map[string]bool, len(meta.Ready)),
+		ddls:  meta.DDLs,
+		done:  make(map[string]bool, len(meta.Done)),
+
+		Skip:     meta.Skip,
+		ExecDDLs: meta.ExecDDLs,
+		Conflict: meta.Conflict,
+
+		Created:  meta.Created,
+		progress: make(map[string]map[string]*shardProgress),
+	}
+	for k, v := range meta.Ready {
+		l.ready[k] = v
+		if !v {
+			l.remain++
+		}
+	}
+	for _, worker := range meta.Done {
+		l.done[worker] = true
+	}
+	if l.Created.IsZero() {
+		// persisted before the created time recorded
+		l.Created = time.Now()
+	}
+	return l
+}
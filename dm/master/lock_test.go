@@ -89,3 +89,44 @@ func (t *testMaster) TestSyncLock(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(synced, IsTrue)
 }
+
+func (t *testMaster) TestLockMeta(c *C) {
+	workers := []string{"worker-1", "worker-2", "worker-3"}
+	l := NewLock("test_id", "test_task", "worker-1", []string{"stmt"}, workers)
+	_, _, err := l.TrySync("worker-1", workers, []string{"stmt"})
+	c.Assert(err, IsNil)
+
+	meta := l.Meta()
+	c.Assert(meta.DDLs, DeepEquals, []string{"stmt"})
+	c.Assert(meta.Ready, DeepEquals, map[string]bool{"worker-1": true, "worker-2": false, "worker-3": false})
+
+	l2 := NewLockFromMeta(meta)
+	c.Assert(l2.Meta(), DeepEquals, meta)
+	synced, remain := l2.IsSync()
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 2)
+
+	// re-sync by the same worker after restored
+	synced, remain, err = l2.TrySync("worker-1", workers, []string{"stmt"})
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 2)
+
+	_, _, err = l2.TrySync("worker-2", workers, []string{"stmt"})
+	c.Assert(err, IsNil)
+	synced, remain, err = l2.TrySync("worker-3", workers, []string{"stmt"})
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	c.Assert(remain, Equals, 0)
+
+	// the progress of resolving is restored
+	c.Assert(l2.IsDone("worker-1"), IsFalse)
+	l2.MarkDone("worker-1")
+	meta = l2.Meta()
+	c.Assert(meta.Done, DeepEquals, []string{"worker-1"})
+	l3 := NewLockFromMeta(meta)
+	c.Assert(l3.IsDone("worker-1"), IsTrue)
+	c.Assert(l3.IsDone("worker-2"), IsFalse)
+	synced, _ = l3.IsSync()
+	c.Assert(synced, IsTrue)
+}
@@ -0,0 +1,508 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+/*
+ * sharding DDL lock timeout description
+ *
+ * a DDL lock is never synced if some dm-workers never reach the DDL,
+ * like their shard tables have been decommissioned, or the DDL is filtered by them.
+ * for the task with `shard-lock-timeout`, the lock not synced in the timeout is handled by `shard-lock-timeout-policy`
+ * 1. fail: dm-workers waiting for the lock are requested to fail with an error, and the lock is removed,
+ *    so their sub tasks are paused, and the error can be queried by `query-status`.
+ *    DDL locks conflicting with the joined schema in optimistic shard mode are always handled in this way
+ * 2. exec: dm-workers not synced are removed from the lock, and the lock is resolved by dm-workers synced.
+ *    the DDL is recorded as skipped for the removed dm-workers, and they skip it when they reach it
+ * 3. skip: same as exec, and the removed dm-workers are also excluded from DDL locks of the target table,
+ *    until they send DDL info again
+ *
+ * for the task with `shard-idle-timeout`, source tables not synced in dm-workers not synced the lock, whose binlog position
+ * of the last event not changed in the timeout, are idle. the syncer reports the position of each source table in the sharding group.
+ * 1. idle source tables are removed from the sharding group of the dm-worker, and the dm-worker syncs the lock
+ *    when other source tables in the group have executed the DDL.
+ * 2. a dm-worker whose source tables are all idle never syncs the lock, it's removed from the lock like `skip` policy.
+ * source tables of dm-workers with sub tasks not running are not idle.
+ *
+ * skipped dm-workers are persisted in etcd, and restored after dm-master restarted.
+ * an alert is raised by metrics of dm-master when a lock is timeout, or an idle source table is removed.
+ */
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var checkDDLLockInterval = 10 * time.Second
+
+// shardProgress is the binlog sync progress of a source table not synced the DDL lock
+type shardProgress struct {
+	pos   string    // binlog position of the last event of the source table, empty if no event received
+	since time.Time // when the position changed last time
+}
+
+// IdleSources updates binlog positions of source tables not synced in the dm-worker not synced the lock,
+// returns source tables whose position not changed longer than timeout, sorted.
+// source tables not in progress are removed, and nil progress (unknown) resets the dm-worker
+func (l *Lock) IdleSources(worker string, progress map[string]string, timeout time.Duration, now time.Time) []string {
+	l.Lock()
+	defer l.Unlock()
+	if synced, ok := l.ready[worker]; !ok || synced || progress == nil {
+		delete(l.progress, worker)
+		return nil
+	}
+
+	prev := l.progress[worker]
+	curr := make(map[string]*shardProgress, len(progress))
+	idle := make([]string, 0)
+	for source, pos := range progress {
+		p, ok := prev[source]
+		if !ok || p.pos != pos {
+			p = &shardProgress{pos: pos, since: now}
+		} else if now.Sub(p.since) > timeout {
+			idle = append(idle, source)
+		}
+		curr[source] = p
+	}
+	l.progress[worker] = curr
+	sort.Strings(idle)
+	return idle
+}
+
+// SkippedShards records dm-workers removed from DDL locks of a target table without syncing them
+type SkippedShards struct {
+	ID   string `json:"id"` // same as the DDL lock ID of the target table
+	Task string `json:"task"`
+	// dm-worker -> DDLs of locks resolved without it, in order, the dm-worker skips them when reaches them
+	DDLs map[string][][]string `json:"ddls"`
+	// dm-workers excluded from DDL locks of the target table, until they send DDL info again
+	Excluded map[string]bool `json:"excluded,omitempty"`
+}
+
+// removeWorker removes the dm-worker from the record, returns whether it's recorded
+func (ss *SkippedShards) removeWorker(worker string) bool {
+	_, skipped := ss.DDLs[worker]
+	excluded := ss.Excluded[worker]
+	delete(ss.DDLs, worker)
+	delete(ss.Excluded, worker)
+	return skipped || excluded
+}
+
+// SkipWorkers removes dm-workers not synced from the lock, and records the DDLs of the lock to be skipped by them.
+// they are also excluded from DDL locks of the target table if exclude is true
+func (lk *LockKeeper) SkipWorkers(l *Lock, workers []string, exclude bool) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	ss, ok := lk.skips[l.ID]
+	if !ok {
+		ss = &SkippedShards{
+			ID:       l.ID,
+			Task:     l.Task,
+			DDLs:     make(map[string][][]string),
+			Excluded: make(map[string]bool),
+		}
+		lk.skips[l.ID] = ss
+	}
+	ddls := l.DDLs()
+	for _, worker := range workers {
+		l.RemoveWorker(worker)
+		ss.DDLs[worker] = append(ss.DDLs[worker], ddls)
+		if exclude {
+			ss.Excluded[worker] = true
+		}
+	}
+}
+
+// TrySkip checks whether the DDLs from the dm-worker should be skipped, because the DDL lock has been resolved without it,
+// returns the DDL lock ID, whether to skip the DDLs, and whether the record of skipped dm-workers changed.
+// the dm-worker sending DDLs not skipped is removed from the record, it's not excluded from DDL locks anymore
+func (lk *LockKeeper) TrySkip(task, schema, table, worker string, ddls []string) (string, bool, bool) {
+	lockID := genDDLLockID(task, schema, table)
+
+	lk.Lock()
+	defer lk.Unlock()
+
+	ss, ok := lk.skips[lockID]
+	if !ok {
+		return lockID, false, false
+	}
+	if skipped := ss.DDLs[worker]; len(skipped) > 0 && utils.CompareShardingDDLs(skipped[0], ddls) {
+		return lockID, true, false
+	}
+	changed := ss.removeWorker(worker)
+	if len(ss.DDLs) == 0 && len(ss.Excluded) == 0 {
+		delete(lk.skips, lockID)
+	}
+	return lockID, false, changed
+}
+
+// DoneSkip marks the DDLs have been skipped by the dm-worker, and it's not excluded from DDL locks anymore
+func (lk *LockKeeper) DoneSkip(lockID, worker string, ddls []string) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	ss, ok := lk.skips[lockID]
+	if !ok {
+		return
+	}
+	if skipped := ss.DDLs[worker]; len(skipped) > 0 && utils.CompareShardingDDLs(skipped[0], ddls) {
+		if len(skipped) > 1 {
+			ss.DDLs[worker] = skipped[1:]
+		} else {
+			delete(ss.DDLs, worker)
+		}
+		delete(ss.Excluded, worker)
+	}
+	if len(ss.DDLs) == 0 && len(ss.Excluded) == 0 {
+		delete(lk.skips, lockID)
+	}
+}
+
+// excludeWorkers returns dm-workers not excluded from the DDL lock, should be called with lock held
+func (lk *LockKeeper) excludeWorkers(lockID string, workers []string) []string {
+	ss, ok := lk.skips[lockID]
+	if !ok || len(ss.Excluded) == 0 {
+		return workers
+	}
+	included := make([]string, 0, len(workers))
+	for _, worker := range workers {
+		if !ss.Excluded[worker] {
+			included = append(included, worker)
+		}
+	}
+	return included
+}
+
+// SkippedShards returns a copy of the record of skipped dm-workers, nil if not exists
+func (lk *LockKeeper) SkippedShards(lockID string) *SkippedShards {
+	lk.RLock()
+	defer lk.RUnlock()
+
+	ss, ok := lk.skips[lockID]
+	if !ok {
+		return nil
+	}
+	clone := &SkippedShards{
+		ID:       ss.ID,
+		Task:     ss.Task,
+		DDLs:     make(map[string][][]string, len(ss.DDLs)),
+		Excluded: make(map[string]bool, len(ss.Excluded)),
+	}
+	for worker, ddls := range ss.DDLs {
+		clone.DDLs[worker] = ddls // never modified in place, no copy
+	}
+	for worker, excluded := range ss.Excluded {
+		clone.Excluded[worker] = excluded
+	}
+	return clone
+}
+
+// RestoreSkippedShards replaces all records of skipped dm-workers with records restored from etcd
+func (lk *LockKeeper) RestoreSkippedShards(records []*SkippedShards) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	lk.skips = make(map[string]*SkippedShards, len(records))
+	for _, ss := range records {
+		if ss.DDLs == nil {
+			ss.DDLs = make(map[string][][]string)
+		}
+		if ss.Excluded == nil {
+			ss.Excluded = make(map[string]bool)
+		}
+		lk.skips[ss.ID] = ss
+	}
+}
+
+// RemoveSkippedShards removes all records of skipped dm-workers of the task
+func (lk *LockKeeper) RemoveSkippedShards(task string) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	for id, ss := range lk.skips {
+		if ss.Task == task {
+			delete(lk.skips, id)
+		}
+	}
+}
+
+// checkDDLLocks handles DDL locks not synced in `shard-lock-timeout`,
+// and removes dm-workers idle longer than `shard-idle-timeout` from DDL locks at intervals
+func (s *Server) checkDDLLocks(ctx context.Context) {
+	ticker := time.NewTicker(checkDDLLockInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, lock := range s.lockKeeper.Locks() {
+				if synced, _ := lock.IsSync(); synced || lock.Resolving.Get() {
+					continue
+				}
+				cfg, err := s.taskConfig(lock.Task)
+				if err != nil {
+					log.Warnf("[server] check DDL lock %s error %v", lock.ID, errors.ErrorStack(err))
+					continue
+				}
+				s.checkDDLLock(ctx, lock, cfg, now)
+			}
+		}
+	}
+}
+
+// checkDDLLock handles the DDL lock not synced by timeout policies of the task
+func (s *Server) checkDDLLock(ctx context.Context, lock *Lock, cfg *config.TaskConfig, now time.Time) {
+	if timeout := cfg.IdleTimeout(); timeout > 0 && len(lock.Conflict) == 0 {
+		idle := s.idleShardsOfWorkers(ctx, lock, timeout, now)
+		skipped := make([]string, 0, len(idle))
+		for _, worker := range unsyncedWorkers(lock) {
+			shards, ok := idle[worker]
+			if !ok {
+				continue
+			}
+			log.Errorf("[server] sources %v of worker %s have no binlog synced in %s, remove them from DDL lock %s", shards.sources, worker, cfg.ShardIdleTimeout, lock.ID)
+			for _, source := range shards.sources {
+				idleShardRemovedCounter.WithLabelValues(lock.Task, worker, source).Inc()
+			}
+			s.leaveShardingGroup(ctx, worker, lock.Task, shards.group.Target, shards.sources)
+			if shards.allIdle() {
+				// the dm-worker never reaches the DDL
+				skipped = append(skipped, worker)
+			}
+		}
+		if len(skipped) > 0 {
+			s.skipWorkers(lock, skipped, true)
+		}
+	}
+
+	synced, _ := lock.IsSync()
+	if timeout := cfg.LockTimeout(); !synced && timeout > 0 && now.Sub(lock.Created) > timeout {
+		policy := cfg.ShardLockTimeoutPolicy
+		if len(lock.Conflict) > 0 {
+			policy = config.ShardLockTimeoutFail // the conflicting DDL can't be executed or skipped safely
+		}
+		unsynced := unsyncedWorkers(lock)
+		log.Errorf("[server] DDL lock %s not synced in %s, waiting for workers %v, handle it by policy %s", lock.ID, cfg.ShardLockTimeout, unsynced, policy)
+		ddlLockTimeoutCounter.WithLabelValues(lock.Task, policy).Inc()
+
+		if policy == config.ShardLockTimeoutFail {
+			s.failDDLLock(ctx, lock, fmt.Sprintf("not synced in shard-lock-timeout %s, waiting for workers %v", cfg.ShardLockTimeout, unsynced))
+			return
+		}
+		s.skipWorkers(lock, unsynced, policy == config.ShardLockTimeoutSkip)
+		synced = true
+	}
+
+	if synced {
+		resps, err := s.resolveDDLLock(ctx, lock.ID, "", nil)
+		if err == nil {
+			log.Infof("[server] resolve DDL lock %s without skipped workers successfully, remove it", lock.ID)
+		} else {
+			log.Errorf("[server] resolve DDL lock %s without skipped workers fail %v, responses is:\n%+v", lock.ID, errors.ErrorStack(err), resps)
+			lock.AutoRetry.Set(true)
+		}
+	}
+}
+
+// unsyncedWorkers returns dm-workers not synced the DDL lock, sorted
+func unsyncedWorkers(lock *Lock) []string {
+	workers := make([]string, 0)
+	for worker, synced := range lock.Ready() {
+		if !synced {
+			workers = append(workers, worker)
+		}
+	}
+	sort.Strings(workers)
+	return workers
+}
+
+// idleShards is the idle source tables of a dm-worker not synced the DDL lock
+type idleShards struct {
+	group   *pb.ShardingGroup // sharding group of the target table of the lock in the dm-worker
+	sources []string          // idle source tables not synced in the group
+}
+
+// allIdle returns whether all source tables in the group are idle, so the dm-worker never syncs the lock
+func (is *idleShards) allIdle() bool {
+	return len(is.group.Synced) == 0 && len(is.sources) == len(is.group.Unsynced)
+}
+
+// idleShardsOfWorkers returns dm-worker -> idle source tables not synced the DDL lock,
+// whose binlog position of the last event not changed longer than timeout
+func (s *Server) idleShardsOfWorkers(ctx context.Context, lock *Lock, timeout time.Duration, now time.Time) map[string]*idleShards {
+	workers := make([]string, 0)
+	for _, worker := range unsyncedWorkers(lock) {
+		if _, ok := s.workerClient(worker); ok {
+			workers = append(workers, worker)
+		}
+	}
+	if len(workers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkDDLLockInterval)
+	defer cancel()
+	workerRespCh := s.queryStatusFromWorkers(ctx, workers, &pb.QueryStatusRequest{Name: lock.Task, ShardingProgress: true})
+
+	idle := make(map[string]*idleShards)
+	for len(workerRespCh) > 0 {
+		workerResp := <-workerRespCh
+		var group *pb.ShardingGroup
+		for _, st := range workerResp.SubTaskStatus {
+			if st.Name != lock.Task || st.Stage != pb.Stage_Running || st.GetSync() == nil {
+				continue
+			}
+			for _, g := range st.GetSync().ShardingGroups {
+				if shardingGroupLockID(lock.Task, g.Target) == lock.ID {
+					group = g
+				}
+			}
+		}
+
+		var progress map[string]string // nil if unknown
+		if group != nil {
+			progress = make(map[string]string, len(group.Progress))
+			for _, p := range group.Progress {
+				progress[p.Source] = p.LastBinlog
+			}
+		}
+		if sources := lock.IdleSources(workerResp.Worker, progress, timeout, now); len(sources) > 0 {
+			idle[workerResp.Worker] = &idleShards{group: group, sources: sources}
+		}
+	}
+	return idle
+}
+
+// shardingGroupLockID returns the DDL lock ID of the sharding group,
+// the target of the group is the table ID like `schema`.`table` generated by the syncer
+func shardingGroupLockID(task, target string) string {
+	return fmt.Sprintf("%s-%s", task, target)
+}
+
+// leaveShardingGroup requests the dm-worker to remove idle source tables from the sharding group
+func (s *Server) leaveShardingGroup(ctx context.Context, worker, task, target string, sources []string) {
+	cli, ok := s.workerClient(worker)
+	if !ok {
+		log.Errorf("[server] worker %s relevant worker-client not found", worker)
+		return
+	}
+	log.Infof("[server] requesting %s to remove sources %v from sharding group %s", worker, sources, target)
+	resp, err := cli.LeaveShardingGroup(ctx, &pb.LeaveShardingGroupRequest{
+		Task:    task,
+		Target:  target,
+		Sources: sources,
+	})
+	if err != nil {
+		log.Errorf("[server] request %s to remove sources %v from sharding group %s error %v", worker, sources, target, errors.ErrorStack(err))
+	} else if !resp.Result {
+		log.Errorf("[server] request %s to remove sources %v from sharding group %s fail %s", worker, sources, target, resp.Msg)
+	}
+}
+
+// skipWorkers removes dm-workers not synced from the DDL lock, and persists them as skipped dm-workers
+func (s *Server) skipWorkers(lock *Lock, workers []string, exclude bool) {
+	s.lockKeeper.SkipWorkers(lock, workers, exclude)
+	if err := s.saveLock(lock.ID); err != nil {
+		log.Errorf("[server] save DDL lock %s into etcd error %v", lock.ID, errors.ErrorStack(err))
+	}
+	if err := s.saveSkippedShards(lock.Task, lock.ID); err != nil {
+		log.Errorf("[server] save skipped workers of DDL lock %s into etcd error %v", lock.ID, errors.ErrorStack(err))
+	}
+}
+
+// failDDLLock requests dm-workers waiting for the DDL lock to fail with the error, and removes the lock
+func (s *Server) failDDLLock(ctx context.Context, lock *Lock, msg string) {
+	workers := []string{lock.Owner}
+	for worker, synced := range lock.Ready() {
+		if synced && worker != lock.Owner {
+			workers = append(workers, worker)
+		}
+	}
+
+	for _, worker := range workers {
+		cli, ok := s.workerClient(worker)
+		if !ok {
+			log.Errorf("[server] worker %s relevant worker-client not found", worker)
+			continue
+		}
+		s.failDDL(ctx, cli, worker, lock.Task, lock.ID, msg)
+	}
+
+	s.lockKeeper.RemoveLock(lock.ID)
+	if err := s.deleteLock(lock.ID); err != nil {
+		log.Errorf("[server] delete DDL lock %s from etcd error %v", lock.ID, errors.ErrorStack(err))
+	}
+}
+
+// failDDL requests the dm-worker waiting for the DDL lock to fail with the error,
+// so its sub task is paused and the error can be queried by `query-status`
+func (s *Server) failDDL(ctx context.Context, cli pb.WorkerClient, worker, task, lockID, msg string) {
+	log.Infof("[server] requesting %s to fail DDL (with ID %s)", worker, lockID)
+	resp, err := cli.ExecuteDDL(ctx, &pb.ExecDDLRequest{
+		Task:   task,
+		LockID: lockID,
+		Error:  msg,
+	})
+	if err != nil {
+		log.Errorf("[server] request %s to fail DDL (with ID %s) error %v", worker, lockID, errors.ErrorStack(err))
+	} else if !resp.Result {
+		log.Errorf("[server] request %s to fail DDL (with ID %s) fail %s", worker, lockID, resp.Msg)
+	}
+}
+
+// skipDDL requests the dm-worker to skip the DDLs of the DDL lock resolved without it
+func (s *Server) skipDDL(ctx context.Context, cli pb.WorkerClient, worker string, in *pb.DDLInfo, lockID string) {
+	log.Infof("[server] requesting %s to skip DDL (with ID %s) resolved without it", worker, lockID)
+	resp, err := cli.ExecuteDDL(ctx, &pb.ExecDDLRequest{
+		Task:     in.Task,
+		LockID:   lockID,
+		Exec:     false,
+		TraceGID: s.idGen.NextID("skipDDL", 0),
+	})
+	if err != nil {
+		log.Errorf("[server] request %s to skip DDL (with ID %s) error %v", worker, lockID, errors.ErrorStack(err))
+		return
+	} else if !resp.Result {
+		log.Errorf("[server] request %s to skip DDL (with ID %s) fail %s", worker, lockID, resp.Msg)
+		return
+	}
+
+	s.lockKeeper.DoneSkip(lockID, worker, in.DDLs)
+	if err = s.saveSkippedShards(in.Task, lockID); err != nil {
+		log.Errorf("[server] save skipped workers of DDL lock %s into etcd error %v", lockID, errors.ErrorStack(err))
+	}
+}
+
+// taskConfig returns the config of the task started by dm-master
+func (s *Server) taskConfig(task string) (*config.TaskConfig, error) {
+	meta := s.taskMeta.Get(task)
+	if meta == nil {
+		return nil, errors.NotFoundf("task %s", task)
+	}
+	cfg := config.NewTaskConfig()
+	if err := cfg.Decode(meta.Task); err != nil {
+		return nil, errors.Annotatef(err, "decode config of task %s", task)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+)
+
+func (t *testMaster) TestSkipWorkers(c *C) {
+	var (
+		workers = []string{"worker-1", "worker-2", "worker-3"}
+		ddls1   = []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c1` INT"}
+		ddls2   = []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c2` INT"}
+		lk      = NewLockKeeper()
+	)
+	lockID, synced, remain, err := lk.TrySync("task", "db", "tb", workers[0], ddls1, workers)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 2)
+
+	// worker-2 skips the DDL later, worker-3 is also excluded from DDL locks
+	l := lk.FindLock(lockID)
+	lk.SkipWorkers(l, workers[1:2], false)
+	lk.SkipWorkers(l, workers[2:], true)
+	synced, _ = l.IsSync()
+	c.Assert(synced, IsTrue)
+	c.Assert(l.Ready(), DeepEquals, map[string]bool{"worker-1": true})
+	ss := lk.SkippedShards(lockID)
+	c.Assert(ss.DDLs, DeepEquals, map[string][][]string{"worker-2": {ddls1}, "worker-3": {ddls1}})
+	c.Assert(ss.Excluded, DeepEquals, map[string]bool{"worker-3": true})
+	c.Assert(lk.RemoveLock(lockID), IsTrue)
+
+	// worker-3 excluded from the next DDL lock
+	_, _, remain, err = lk.TrySync("task", "db", "tb", workers[0], ddls2, workers)
+	c.Assert(err, IsNil)
+	c.Assert(remain, Equals, 1)
+	c.Assert(lk.FindLock(lockID).Ready(), DeepEquals, map[string]bool{"worker-1": true, "worker-2": false})
+
+	// worker-2 reaches the DDL resolved without it
+	_, skipped, changed := lk.TrySkip("task", "db", "tb", workers[1], ddls1)
+	c.Assert(skipped, IsTrue)
+	c.Assert(changed, IsFalse)
+	lk.DoneSkip(lockID, workers[1], ddls1)
+	_, skipped, changed = lk.TrySkip("task", "db", "tb", workers[1], ddls2)
+	c.Assert(skipped, IsFalse)
+	c.Assert(changed, IsFalse)
+	c.Assert(lk.SkippedShards(lockID).DDLs, HasLen, 1)
+
+	// worker-3 sends DDL info again, not excluded anymore
+	_, skipped, changed = lk.TrySkip("task", "db", "tb", workers[2], ddls2)
+	c.Assert(skipped, IsFalse)
+	c.Assert(changed, IsTrue)
+	c.Assert(lk.SkippedShards(lockID), IsNil)
+	_, _, remain, err = lk.TrySync("task", "db", "tb", workers[2], ddls2, workers)
+	c.Assert(err, IsNil)
+	c.Assert(remain, Equals, 1)
+
+	// restored from etcd, and removed after the task stopped
+	lk.RestoreSkippedShards([]*SkippedShards{ss})
+	c.Assert(lk.SkippedShards(lockID), DeepEquals, ss)
+	lk.RemoveSkippedShards("task")
+	c.Assert(lk.SkippedShards(lockID), IsNil)
+}
+
+func (t *testMaster) TestLockIdleSources(c *C) {
+	var (
+		workers = []string{"worker-1", "worker-2"}
+		now     = time.Now()
+		timeout = time.Minute
+		pos1    = "(mysql-bin.000001, 100)"
+		pos2    = "(mysql-bin.000001, 200)"
+		tb1     = "`db`.`tb1`"
+		tb2     = "`db`.`tb2`"
+	)
+	l := NewLock("test_id", "test_task", "worker-1", []string{"stmt"}, workers)
+	_, _, err := l.TrySync("worker-1", workers, []string{"stmt"})
+	c.Assert(err, IsNil)
+
+	// synced worker is never idle
+	c.Assert(l.IdleSources("worker-1", map[string]string{tb1: pos1}, timeout, now), HasLen, 0)
+	c.Assert(l.IdleSources("worker-1", map[string]string{tb1: pos1}, timeout, now.Add(time.Hour)), HasLen, 0)
+
+	// tb2 without any event received is idle too
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos1, tb2: ""}, timeout, now), HasLen, 0)
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos1, tb2: ""}, timeout, now.Add(2*time.Minute)), DeepEquals, []string{tb1, tb2})
+	// binlog position of tb1 changed
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos2, tb2: ""}, timeout, now.Add(3*time.Minute)), DeepEquals, []string{tb2})
+	// tb2 left the sharding group
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos2}, timeout, now.Add(5*time.Minute)), DeepEquals, []string{tb1})
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos2, tb2: ""}, timeout, now.Add(5*time.Minute)), DeepEquals, []string{tb1})
+	// progress unknown
+	c.Assert(l.IdleSources("worker-2", nil, timeout, now.Add(6*time.Minute)), HasLen, 0)
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos2}, timeout, now.Add(8*time.Minute)), HasLen, 0)
+
+	l.RemoveWorker("worker-2")
+	synced, _ := l.IsSync()
+	c.Assert(synced, IsTrue)
+	c.Assert(l.IdleSources("worker-2", map[string]string{tb1: pos2}, timeout, now.Add(time.Hour)), HasLen, 0)
+}
+
+func (t *testMaster) TestCheckDDLLockIdleSources(c *C) {
+	s := NewServer(newTestConfigs(c, 1)[0])
+	go s.Start()
+	defer s.Close()
+	waitLeader(c, []*Server{s})
+
+	var (
+		workers = []string{"worker-1", "worker-2", "worker-3"}
+		ddls    = []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c1` INT"}
+		clients = make(map[string]*mockWorkerClient, len(workers))
+		now     = time.Now()
+		pos1    = "(mysql-bin.000001, 100)"
+		pos2    = "(mysql-bin.000001, 200)"
+	)
+	syncStatus := func(group *pb.ShardingGroup) *pb.QueryStatusResponse {
+		return &pb.QueryStatusResponse{
+			Result: true,
+			SubTaskStatus: []*pb.SubTaskStatus{{
+				Name:   "task",
+				Stage:  pb.Stage_Running,
+				Status: &pb.SubTaskStatus_Sync{Sync: &pb.SyncStatus{ShardingGroups: []*pb.ShardingGroup{group}}},
+			}},
+		}
+	}
+	s.Lock()
+	for _, worker := range workers {
+		clients[worker] = &mockWorkerClient{}
+		s.workerClients[worker] = clients[worker]
+	}
+	s.Unlock()
+	// worker-2 synced `tb1` and waits for `tb2` and `tb3`, worker-3 has no binlog of `tb4` received
+	clients["worker-2"].status = syncStatus(&pb.ShardingGroup{
+		Target:   "`db`.`tb`",
+		DDLs:     ddls,
+		Synced:   []string{"`db`.`tb1`"},
+		Unsynced: []string{"`db`.`tb2`", "`db`.`tb3`"},
+		Progress: []*pb.ShardingSource{{Source: "`db`.`tb2`", LastBinlog: pos1}, {Source: "`db`.`tb3`", LastBinlog: pos1}},
+	})
+	clients["worker-3"].status = syncStatus(&pb.ShardingGroup{
+		Target:   "`db`.`tb`",
+		Unsynced: []string{"`db`.`tb4`"},
+		Progress: []*pb.ShardingSource{{Source: "`db`.`tb4`"}},
+	})
+	lockID, _, _, err := s.lockKeeper.TrySync("task", "db", "tb", "worker-1", ddls, workers)
+	c.Assert(err, IsNil)
+	lock := s.lockKeeper.FindLock(lockID)
+
+	cfg := config.NewTaskConfig()
+	cfg.ShardIdleTimeout = "1m"
+	s.checkDDLLock(context.Background(), lock, cfg, now)
+	for _, worker := range workers {
+		c.Assert(clients[worker].leaveReqs, HasLen, 0)
+	}
+
+	// binlog of `tb2` received in worker-2
+	clients["worker-2"].status.SubTaskStatus[0].GetSync().ShardingGroups[0].Progress[0].LastBinlog = pos2
+	s.checkDDLLock(context.Background(), lock, cfg, now.Add(2*time.Minute))
+	c.Assert(clients["worker-2"].leaveReqs, DeepEquals, []*pb.LeaveShardingGroupRequest{{Task: "task", Target: "`db`.`tb`", Sources: []string{"`db`.`tb3`"}}})
+	c.Assert(clients["worker-3"].leaveReqs, DeepEquals, []*pb.LeaveShardingGroupRequest{{Task: "task", Target: "`db`.`tb`", Sources: []string{"`db`.`tb4`"}}})
+
+	// worker-3 with all source tables idle is removed from the lock, worker-2 syncs the lock after `tb3` left
+	c.Assert(lock.Ready(), DeepEquals, map[string]bool{"worker-1": true, "worker-2": false})
+	c.Assert(s.lockKeeper.SkippedShards(lockID).Excluded, DeepEquals, map[string]bool{"worker-3": true})
+}
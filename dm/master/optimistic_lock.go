@@ -0,0 +1,199 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+/*
+ * optimistic shard mode description
+ *
+ * in optimistic shard mode, dm-master keeps structures of all shard tables of a target table,
+ * and their joined schema, which all DMLs of the shard tables can be written into.
+ * DMLs of shard tables are never blocked, and each sharding DDL is handled once received:
+ * 1. dm-worker sends DDL info with the structures of the upstream table before and after the DDL
+ * 2. dm-master updates the structure of the table, and computes the joined schema again
+ * 3. if the joined schema changed, dm-master requests the dm-worker to execute DDLs generated from the changes of the joined schema
+ *    in downstream, like the first shard table adding a column, or widening a column type,
+ *    rather than the DDL itself, which may change the joined schema partly or in another way
+ * 4. if the joined schema not changed, dm-master requests the dm-worker to skip the DDL,
+ *    like shard tables adding a column which already added by other shard tables
+ * 5. if the DDL conflicts with other shard tables, like modifying a column to an incompatible type,
+ *    the DDL lock keeps un-synced, user need to use dmctl to handle it
+ *
+ * a lock owned by the dm-worker is created for each DDL, so they can be resolved and handled in the same way.
+ */
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/shardddl"
+)
+
+// OptimisticGroup keeps structures of shard tables of a target table in optimistic shard mode
+type OptimisticGroup struct {
+	ID      string          `json:"id"` // same as the pessimistic DDL lock ID of the target table
+	Task    string          `json:"task"`
+	Initial *shardddl.Table `json:"initial"` // structure of shard tables before any sharding DDLs
+	// dm-worker -> upstream table -> structure, nil if not changed from the initial structure
+	Tables map[string]map[string]*shardddl.Table `json:"tables"`
+}
+
+// join computes the joined schema of shard tables of the dm-workers,
+// shard tables of dm-workers not reported yet are treated as the initial structure
+func (g *OptimisticGroup) join(workers []string) (*shardddl.Table, error) {
+	var (
+		tables  = make([]*shardddl.Table, 0, len(workers)+1)
+		initial bool
+	)
+	for _, worker := range workers {
+		sources, ok := g.Tables[worker]
+		if !ok {
+			initial = true
+			continue
+		}
+		names := make([]string, 0, len(sources))
+		for name := range sources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if sources[name] == nil {
+				initial = true
+			} else {
+				tables = append(tables, sources[name])
+			}
+		}
+	}
+	if initial {
+		tables = append([]*shardddl.Table{g.Initial}, tables...)
+	}
+	return shardddl.Join(tables...)
+}
+
+// genOptimisticLockID generates ID for the DDL lock owned by the dm-worker in optimistic shard mode
+func genOptimisticLockID(task, schema, table, worker string) string {
+	return fmt.Sprintf("%s-%s", genDDLLockID(task, schema, table), worker)
+}
+
+// TrySyncOptimistic tries to apply the DDL of a shard table to the joined schema in optimistic shard mode.
+// the DDL lock created is owned by the dm-worker and synced at once, and the DDL is skipped if the joined schema not changed,
+// otherwise DDLs generated from the changes of the joined schema are executed instead.
+// if the DDL conflicts with other shard tables, the DDL lock is un-synced with the conflict, and the joined schema not changed
+func (lk *LockKeeper) TrySyncOptimistic(info *pb.DDLInfo, worker string, workers []string) (string, bool, error) {
+	lockID := genOptimisticLockID(info.Task, info.Schema, info.Table, worker)
+
+	lk.Lock()
+	defer lk.Unlock()
+
+	if l, ok := lk.locks[lockID]; ok {
+		// DDL info re-sent by the dm-worker
+		synced, _ := l.IsSync()
+		return lockID, synced, nil
+	}
+
+	before, err := shardddl.ParseTable(info.TableBefore)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	after, err := shardddl.ParseTable(info.TableAfter)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+
+	groupID := genDDLLockID(info.Task, info.Schema, info.Table)
+	g, ok := lk.groups[groupID]
+	if !ok {
+		g = &OptimisticGroup{
+			ID:      groupID,
+			Task:    info.Task,
+			Initial: before,
+			Tables:  make(map[string]map[string]*shardddl.Table),
+		}
+		lk.groups[groupID] = g
+	}
+
+	joinedBefore, errBefore := g.join(workers)
+	prev, hasPrev := g.Tables[worker]
+	tables := make(map[string]*shardddl.Table, len(info.Sources))
+	for _, source := range info.Sources {
+		tables[source] = prev[source] // tables dropped from the sharding group are removed
+	}
+	tables[info.Source] = after
+	g.Tables[worker] = tables
+
+	joinedAfter, err := g.join(workers)
+
+	l := NewLock(lockID, info.Task, worker, info.DDLs, []string{worker})
+	lk.locks[lockID] = l
+	if err != nil {
+		// keep the joined schema not changed, and wait for the DDL lock to be handled manually
+		if hasPrev {
+			g.Tables[worker] = prev
+		} else {
+			delete(g.Tables, worker)
+		}
+		l.Conflict = err.Error()
+		return lockID, false, nil
+	}
+
+	l.Skip = errBefore == nil && joinedBefore.Equal(joinedAfter)
+	if !l.Skip && errBefore == nil {
+		// the DDL may change the joined schema partly, like adding two columns while one of them is already added
+		l.ExecDDLs = shardddl.DiffDDLs(info.Schema, info.Table, joinedBefore, joinedAfter)
+	}
+	synced, _, err := l.TrySync(worker, []string{worker}, info.DDLs)
+	return lockID, synced, errors.Trace(err)
+}
+
+// OptimisticGroup returns a copy of the optimistic group, nil if not exists
+func (lk *LockKeeper) OptimisticGroup(groupID string) *OptimisticGroup {
+	lk.RLock()
+	defer lk.RUnlock()
+
+	g, ok := lk.groups[groupID]
+	if !ok {
+		return nil
+	}
+	clone := *g
+	clone.Tables = make(map[string]map[string]*shardddl.Table, len(g.Tables))
+	for worker, tables := range g.Tables {
+		clone.Tables[worker] = tables // never modified in place, no copy
+	}
+	return &clone
+}
+
+// RemoveOptimisticGroups removes all optimistic groups of the task
+func (lk *LockKeeper) RemoveOptimisticGroups(task string) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	for id, g := range lk.groups {
+		if g.Task == task {
+			delete(lk.groups, id)
+		}
+	}
+}
+
+// RestoreOptimisticGroups replaces all optimistic groups with groups restored from persistent information
+func (lk *LockKeeper) RestoreOptimisticGroups(groups []*OptimisticGroup) {
+	lk.Lock()
+	defer lk.Unlock()
+
+	lk.groups = make(map[string]*OptimisticGroup, len(groups))
+	for _, g := range groups {
+		lk.groups[g.ID] = g
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+func (t *testMaster) TestTrySyncOptimistic(c *C) {
+	var (
+		workers = []string{"worker-1", "worker-2"}
+		sources = []string{"`db`.`tb1`", "`db`.`tb2`"}
+		initial = `{"columns":[{"name":"id","type":"int(11)","not-null":true}],"indexes":{"primary":["id"]}}`
+		added   = `{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"c","type":"int(11)"}],"indexes":{"primary":["id"]}}`
+		widened = `{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"c","type":"bigint(20)"}],"indexes":{"primary":["id"]}}`
+		text    = `{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"c","type":"text"}],"indexes":{"primary":["id"]}}`
+	)
+	newInfo := func(source, before, after, ddl string) *pb.DDLInfo {
+		return &pb.DDLInfo{
+			Task:        "task",
+			Schema:      "db",
+			Table:       "tb",
+			DDLs:        []string{ddl},
+			Optimistic:  true,
+			Source:      source,
+			Sources:     sources,
+			TableBefore: before,
+			TableAfter:  after,
+		}
+	}
+	lk := NewLockKeeper()
+	trySync := func(worker string, info *pb.DDLInfo, synced, skip bool, conflict bool, execDDLs ...string) {
+		id, ok, err := lk.TrySyncOptimistic(info, worker, workers)
+		c.Assert(err, IsNil)
+		c.Assert(ok, Equals, synced)
+		lock := lk.FindLock(id)
+		c.Assert(lock, NotNil)
+		c.Assert(lock.Owner, Equals, worker)
+		c.Assert(lock.Skip, Equals, skip)
+		c.Assert(len(lock.Conflict) > 0, Equals, conflict)
+		c.Assert(lock.ExecDDLs, DeepEquals, execDDLs)
+		c.Assert(lk.RemoveLock(id), IsTrue)
+	}
+
+	// the first shard table adds the column, executed
+	trySync(workers[0], newInfo(sources[0], initial, added, "ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"), true, false, false,
+		"ALTER TABLE `db`.`tb` ADD COLUMN `c` int(11)")
+	// other shard tables add the same column, skipped
+	trySync(workers[0], newInfo(sources[1], initial, added, "ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"), true, true, false)
+	trySync(workers[1], newInfo(sources[0], initial, added, "ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"), true, true, false)
+	// widen the column, executed
+	trySync(workers[1], newInfo(sources[0], added, widened, "ALTER TABLE `db`.`tb` MODIFY COLUMN `c` BIGINT"), true, false, false,
+		"ALTER TABLE `db`.`tb` MODIFY COLUMN `c` bigint(20)")
+	// adding the column with the type already widened by other shard tables, skipped
+	trySync(workers[1], newInfo(sources[1], initial, widened, "ALTER TABLE `db`.`tb` ADD COLUMN `c` BIGINT"), true, true, false)
+	// incompatible column type, conflict
+	trySync(workers[0], newInfo(sources[0], added, text, "ALTER TABLE `db`.`tb` MODIFY COLUMN `c` TEXT"), false, false, true)
+
+	// conflicting DDL not applied to the group
+	g := lk.OptimisticGroup(genDDLLockID("task", "db", "tb"))
+	c.Assert(g, NotNil)
+	c.Assert(g.Tables[workers[0]][sources[0]].Columns[1].Type, Equals, "int(11)")
+	joined, err := g.join(workers)
+	c.Assert(err, IsNil)
+	c.Assert(joined.Columns, HasLen, 2)
+	c.Assert(joined.Columns[1].Type, Equals, "bigint(20)")
+
+	// DDL info re-sent returns the same lock
+	info := newInfo(sources[1], added, widened, "ALTER TABLE `db`.`tb` MODIFY COLUMN `c` BIGINT")
+	id, synced, err := lk.TrySyncOptimistic(info, workers[0], workers)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	id2, synced, err := lk.TrySyncOptimistic(info, workers[0], workers)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	c.Assert(id2, Equals, id)
+
+	lk.RemoveOptimisticGroups("task")
+	c.Assert(lk.OptimisticGroup(g.ID), IsNil)
+}
+
+func (t *testMaster) TestTrySyncOptimisticPartlyChanged(c *C) {
+	var (
+		workers = []string{"worker-1"}
+		sources = []string{"`db`.`tb1`", "`db`.`tb2`"}
+		initial = `{"columns":[{"name":"id","type":"int(11)","not-null":true}],"indexes":{"PRIMARY":["id"]}}`
+		addedB  = `{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"b","type":"int(11)"}],"indexes":{"PRIMARY":["id"]}}`
+		addedAB = `{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"a","type":"varchar(10)"},{"name":"b","type":"bigint(20)"},` +
+			`{"name":"c","type":"int(11)","not-null":true,"default":"0"}],"indexes":{"PRIMARY":["id"]}}`
+	)
+	newInfo := func(source, before, after, ddl string) *pb.DDLInfo {
+		return &pb.DDLInfo{
+			Task:        "task",
+			Schema:      "db",
+			Table:       "tb",
+			DDLs:        []string{ddl},
+			Optimistic:  true,
+			Source:      source,
+			Sources:     sources,
+			TableBefore: before,
+			TableAfter:  after,
+		}
+	}
+	lk := NewLockKeeper()
+
+	// the first shard table adds column b
+	id, synced, err := lk.TrySyncOptimistic(newInfo(sources[0], initial, addedB, "ALTER TABLE `db`.`tb` ADD COLUMN `b` INT"), workers[0], workers)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	c.Assert(lk.FindLock(id).ExecDDLs, DeepEquals, []string{"ALTER TABLE `db`.`tb` ADD COLUMN `b` int(11)"})
+	c.Assert(lk.RemoveLock(id), IsTrue)
+
+	// the other shard table adds column b with a wider type and other columns in a DDL,
+	// only the new columns are added and column b is widened, rather than adding column b again
+	ddl := "ALTER TABLE `db`.`tb` ADD COLUMN `a` VARCHAR(10), ADD COLUMN `b` BIGINT, ADD COLUMN `c` INT NOT NULL DEFAULT 0"
+	id, synced, err = lk.TrySyncOptimistic(newInfo(sources[1], initial, addedAB, ddl), workers[0], workers)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	lock := lk.FindLock(id)
+	c.Assert(lock.Skip, IsFalse)
+	c.Assert(lock.Conflict, Equals, "")
+	c.Assert(lock.Stmts, DeepEquals, []string{ddl})
+	c.Assert(lock.ExecDDLs, DeepEquals, []string{
+		"ALTER TABLE `db`.`tb` MODIFY COLUMN `b` bigint(20)",
+		"ALTER TABLE `db`.`tb` ADD COLUMN `a` varchar(10)",
+		"ALTER TABLE `db`.`tb` ADD COLUMN `c` int(11) NOT NULL DEFAULT '0'",
+	})
+	c.Assert(NewLockFromMeta(lock.Meta()).ExecDDLs, DeepEquals, lock.ExecDDLs)
+}
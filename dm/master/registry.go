@@ -0,0 +1,226 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+// status of dm-worker in WorkerRegistry
+const (
+	workerOnline       = "online"
+	workerOffline      = "offline"
+	workerUnregistered = "unregistered"
+)
+
+// WorkerInfo represents information of a registered dm-worker
+type WorkerInfo struct {
+	Address       string    `json:"address"`
+	SourceID      string    `json:"source-id"`
+	Capabilities  []string  `json:"capabilities"`
+	Version       string    `json:"version"`
+	Status        string    `json:"-"`
+	LastHeartbeat time.Time `json:"-"`
+	RegisteredAt  time.Time `json:"-"`
+
+	offlineHandled  bool // whether offline has been handled, like rescheduling its sub tasks
+	registerHandled bool // whether registering has been handled, like stopping its stale sub tasks
+}
+
+// WorkerRegistry keeps live information of registered dm-workers,
+// dm-worker is marked as offline if no heartbeat received in timeout
+type WorkerRegistry struct {
+	sync.RWMutex
+	timeout time.Duration
+	workers map[string]*WorkerInfo // dm-worker's address -> info
+}
+
+// NewWorkerRegistry creates a new WorkerRegistry
+func NewWorkerRegistry(timeout time.Duration) *WorkerRegistry {
+	return &WorkerRegistry{
+		timeout: timeout,
+		workers: make(map[string]*WorkerInfo),
+	}
+}
+
+// Register registers a dm-worker, or updates its information if registered before
+func (r *WorkerRegistry) Register(req *pb.RegisterWorkerRequest) error {
+	if req.Address == "" {
+		return errors.NotValidf("empty dm-worker address")
+	}
+	if req.SourceID == "" {
+		return errors.NotValidf("empty source-id for dm-worker %s", req.Address)
+	}
+
+	now := time.Now()
+	r.Lock()
+	defer r.Unlock()
+	r.workers[req.Address] = &WorkerInfo{
+		Address:       req.Address,
+		SourceID:      req.SourceID,
+		Capabilities:  req.Capabilities,
+		Version:       req.Version,
+		Status:        workerOnline,
+		LastHeartbeat: now,
+		RegisteredAt:  now,
+	}
+	return nil
+}
+
+// Heartbeat refreshes last heartbeat time of a dm-worker,
+// returns false if the dm-worker not registered or registered with another source
+func (r *WorkerRegistry) Heartbeat(address, sourceID string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	w, ok := r.workers[address]
+	if !ok || w.SourceID != sourceID {
+		return false
+	}
+	w.Status = workerOnline
+	w.LastHeartbeat = time.Now()
+	w.offlineHandled = false
+	return true
+}
+
+// CheckOffline marks dm-workers without heartbeat in timeout as offline,
+// returns addresses of dm-workers newly marked as offline, sorted
+func (r *WorkerRegistry) CheckOffline(now time.Time) []string {
+	r.Lock()
+	defer r.Unlock()
+
+	offline := make([]string, 0)
+	for addr, w := range r.workers {
+		if w.Status == workerOnline && now.Sub(w.LastHeartbeat) > r.timeout {
+			w.Status = workerOffline
+			offline = append(offline, addr)
+		}
+	}
+	sort.Strings(offline)
+	return offline
+}
+
+// OfflineWorkers returns addresses of dm-workers offline longer than gracePeriod and not handled yet, sorted,
+// they are returned again in the next checking until marked as handled by MarkOfflineHandled
+func (r *WorkerRegistry) OfflineWorkers(now time.Time, gracePeriod time.Duration) []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	offline := make([]string, 0)
+	for addr, w := range r.workers {
+		if w.Status == workerOffline && !w.offlineHandled && now.Sub(w.LastHeartbeat) > gracePeriod {
+			offline = append(offline, addr)
+		}
+	}
+	sort.Strings(offline)
+	return offline
+}
+
+// MarkOfflineHandled marks the offline dm-worker as handled until online again,
+// like after all of its sub tasks rescheduled
+func (r *WorkerRegistry) MarkOfflineHandled(address string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if w, ok := r.workers[address]; ok && w.Status == workerOffline {
+		w.offlineHandled = true
+	}
+}
+
+// RegisteredWorkers returns addresses of online dm-workers registered longer than delay and not handled yet, sorted,
+// they are marked as handled until registering again
+func (r *WorkerRegistry) RegisteredWorkers(now time.Time, delay time.Duration) []string {
+	r.Lock()
+	defer r.Unlock()
+
+	registered := make([]string, 0)
+	for addr, w := range r.workers {
+		if w.Status == workerOnline && !w.registerHandled && now.Sub(w.RegisteredAt) >= delay {
+			w.registerHandled = true
+			registered = append(registered, addr)
+		}
+	}
+	sort.Strings(registered)
+	return registered
+}
+
+// Standby returns the first online dm-worker for the source except excluded ones, empty if not found
+func (r *WorkerRegistry) Standby(sourceID string, exclude map[string]struct{}) string {
+	r.RLock()
+	defer r.RUnlock()
+
+	standby := ""
+	for addr, w := range r.workers {
+		if _, ok := exclude[addr]; ok || w.SourceID != sourceID || w.Status != workerOnline {
+			continue
+		}
+		if standby == "" || addr < standby {
+			standby = addr
+		}
+	}
+	return standby
+}
+
+// Worker returns a copy of information of a dm-worker, nil if not registered
+func (r *WorkerRegistry) Worker(address string) *WorkerInfo {
+	r.RLock()
+	defer r.RUnlock()
+
+	w, ok := r.workers[address]
+	if !ok {
+		return nil
+	}
+	clone := *w
+	return &clone
+}
+
+// Workers returns copies of information of all registered dm-workers, sorted by address
+func (r *WorkerRegistry) Workers() []*WorkerInfo {
+	r.RLock()
+	defer r.RUnlock()
+
+	workers := make([]*WorkerInfo, 0, len(r.workers))
+	for _, w := range r.workers {
+		clone := *w
+		workers = append(workers, &clone)
+	}
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].Address < workers[j].Address
+	})
+	return workers
+}
+
+// Restore replaces all registered dm-workers with workers registered before, like after dm-master failover.
+// they are treated as online at now, and will be marked as offline if no heartbeat received in timeout
+func (r *WorkerRegistry) Restore(workers []*WorkerInfo, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.workers = make(map[string]*WorkerInfo, len(workers))
+	for _, w := range workers {
+		clone := *w
+		clone.Status = workerOnline
+		clone.LastHeartbeat = now
+		clone.RegisteredAt = now
+		clone.offlineHandled = false
+		clone.registerHandled = false
+		r.workers[w.Address] = &clone
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+func (t *testMaster) TestWorkerRegistry(c *C) {
+	r := NewWorkerRegistry(time.Minute)
+	c.Assert(r.Register(&pb.RegisterWorkerRequest{SourceID: "source-1"}), NotNil)
+	c.Assert(r.Register(&pb.RegisterWorkerRequest{Address: "127.0.0.1:8262"}), NotNil)
+	c.Assert(r.Heartbeat("127.0.0.1:8262", "source-1"), IsFalse)
+
+	c.Assert(r.Register(&pb.RegisterWorkerRequest{Address: "127.0.0.1:8262", SourceID: "source-1", Capabilities: []string{"Sync"}}), IsNil)
+	c.Assert(r.Register(&pb.RegisterWorkerRequest{Address: "127.0.0.1:8263", SourceID: "source-1"}), IsNil)
+	c.Assert(r.Heartbeat("127.0.0.1:8262", "source-1"), IsTrue)
+	c.Assert(r.Heartbeat("127.0.0.1:8262", "source-2"), IsFalse)
+
+	workers := r.Workers()
+	c.Assert(workers, HasLen, 2)
+	c.Assert(workers[0].Address, Equals, "127.0.0.1:8262")
+	c.Assert(workers[0].Capabilities, DeepEquals, []string{"Sync"})
+	c.Assert(workers[0].Status, Equals, workerOnline)
+
+	// no heartbeat in timeout
+	c.Assert(r.CheckOffline(time.Now()), HasLen, 0)
+	c.Assert(r.CheckOffline(time.Now().Add(2*time.Minute)), DeepEquals, []string{"127.0.0.1:8262", "127.0.0.1:8263"})
+	c.Assert(r.CheckOffline(time.Now().Add(2*time.Minute)), HasLen, 0)
+	c.Assert(r.Worker("127.0.0.1:8263").Status, Equals, workerOffline)
+
+	// online again after heartbeat
+	c.Assert(r.Heartbeat("127.0.0.1:8263", "source-1"), IsTrue)
+	c.Assert(r.Worker("127.0.0.1:8263").Status, Equals, workerOnline)
+
+	// 8262 offline longer than grace period, 8263 is the standby
+	now := time.Now().Add(30 * time.Second)
+	c.Assert(r.CheckOffline(now), HasLen, 0)
+	c.Assert(r.OfflineWorkers(now, 5*time.Minute), HasLen, 0)
+	c.Assert(r.OfflineWorkers(now, 10*time.Second), DeepEquals, []string{"127.0.0.1:8262"})
+	// returned again until marked as handled, like retrying rescheduling
+	c.Assert(r.OfflineWorkers(now, 10*time.Second), DeepEquals, []string{"127.0.0.1:8262"})
+	r.MarkOfflineHandled("127.0.0.1:8262")
+	c.Assert(r.OfflineWorkers(now, 10*time.Second), HasLen, 0)
+	c.Assert(r.Standby("source-1", map[string]struct{}{"127.0.0.1:8262": {}}), Equals, "127.0.0.1:8263")
+	c.Assert(r.Standby("source-1", map[string]struct{}{"127.0.0.1:8263": {}}), Equals, "")
+	c.Assert(r.Standby("source-2", nil), Equals, "")
+
+	// registered workers are handled once
+	c.Assert(r.RegisteredWorkers(now, 5*time.Minute), HasLen, 0)
+	c.Assert(r.RegisteredWorkers(now, 10*time.Second), DeepEquals, []string{"127.0.0.1:8263"})
+	c.Assert(r.RegisteredWorkers(now, 10*time.Second), HasLen, 0)
+
+	// restored workers are online
+	r.Restore([]*WorkerInfo{{Address: "127.0.0.1:8264", SourceID: "source-2"}}, now)
+	c.Assert(r.Workers(), HasLen, 1)
+	c.Assert(r.Worker("127.0.0.1:8263"), IsNil)
+	c.Assert(r.Worker("127.0.0.1:8264").Status, Equals, workerOnline)
+	c.Assert(r.Worker("127.0.0.1:8264").LastHeartbeat, Equals, now)
+}
+
+func (t *testMaster) TestRegisterWorker(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	cfg.DeployMap = map[string]string{"source-1": "127.0.0.1:18262"}
+	s := NewServer(cfg)
+	go s.Start()
+	defer s.Close()
+	waitLeader(c, []*Server{s})
+
+	ctx := context.Background()
+	for _, worker := range []string{"127.0.0.1:18263", "127.0.0.1:18264"} {
+		resp, err := s.RegisterWorker(ctx, &pb.RegisterWorkerRequest{Address: worker, SourceID: "source-2"})
+		c.Assert(err, IsNil)
+		c.Assert(resp.Result, IsTrue)
+		c.Assert(resp.HeartbeatInterval, Equals, cfg.WorkerHeartbeatInterval)
+	}
+	resp, err := s.RegisterWorker(ctx, &pb.RegisterWorkerRequest{Address: "127.0.0.1:18265"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Result, IsFalse)
+
+	hbResp, err := s.Heartbeat(ctx, &pb.HeartbeatRequest{Address: "127.0.0.1:18263", SourceID: "source-2"})
+	c.Assert(err, IsNil)
+	c.Assert(hbResp.Registered, IsTrue)
+	hbResp, err = s.Heartbeat(ctx, &pb.HeartbeatRequest{Address: "127.0.0.1:18265", SourceID: "source-2"})
+	c.Assert(err, IsNil)
+	c.Assert(hbResp.Registered, IsFalse)
+
+	// the first registered dm-worker is bound to the source
+	c.Assert(s.cfg.DeployMap, DeepEquals, map[string]string{"source-1": "127.0.0.1:18262", "source-2": "127.0.0.1:18263"})
+	deployMap := s.cfg.DeployMap
+	c.Assert(s.loadDeployMap(ctx), IsNil)
+	c.Assert(s.cfg.DeployMap, DeepEquals, deployMap)
+
+	listResp, err := s.ListMember(ctx, &pb.ListMemberRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(listResp.Result, IsTrue)
+	c.Assert(listResp.Masters, HasLen, 1)
+	c.Assert(listResp.Masters[0].Name, Equals, cfg.Name)
+	c.Assert(listResp.Masters[0].Address, Equals, cfg.AdvertiseAddr)
+	c.Assert(listResp.Masters[0].Leader, IsTrue)
+	c.Assert(listResp.Workers, HasLen, 3)
+	expected := []struct {
+		status string
+		bound  bool
+	}{
+		{workerUnregistered, true},
+		{workerOnline, true},
+		{workerOnline, false},
+	}
+	for i, w := range listResp.Workers {
+		c.Assert(w.Status, Equals, expected[i].status)
+		c.Assert(w.Bound, Equals, expected[i].bound)
+	}
+}
@@ -0,0 +1,421 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	_ "github.com/go-sql-driver/mysql" // for mysql
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var checkpointQueryTimeout = "30s"
+
+// rescheduleWorker reschedules sub tasks on an offline dm-worker to standby dm-workers, source by source.
+// relay unit of the standby dm-worker starts pulling binlog from the minimum checkpoint of these sub tasks,
+// then sub tasks are re-started on it and continue from their checkpoints in the downstream.
+// returns whether all sub tasks have been rescheduled, otherwise it should be retried later,
+// like when a standby dm-worker online, and sub tasks left are rescheduled to the same standby dm-worker
+func (s *Server) rescheduleWorker(ctx context.Context, worker string) bool {
+	s.Lock()
+	sourceSet := make(map[string]struct{}, 1)
+	bound := make(map[string]struct{}, len(s.cfg.DeployMap))
+	for source, w := range s.cfg.DeployMap {
+		if w == worker {
+			sourceSet[source] = struct{}{}
+		}
+		bound[w] = struct{}{}
+	}
+	s.Unlock()
+	// sources rebound in previous rescheduling, but with sub tasks left
+	for _, task := range s.taskMeta.All() {
+		if st := task.SubTask(worker); st != nil {
+			sourceSet[st.SourceID] = struct{}{}
+		}
+	}
+	sources := make([]string, 0, len(sourceSet))
+	for source := range sourceSet {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	done := true
+	for _, source := range sources {
+		record := &pb.RescheduleRecord{
+			Time:       time.Now().Format("2006-01-02 15:04:05"),
+			Source:     source,
+			FromWorker: worker,
+		}
+		err := s.rescheduleSource(ctx, record, bound)
+		if err != nil {
+			record.Result = false
+			record.Msg = err.Error()
+		}
+		if record.ToWorker != "" {
+			bound[record.ToWorker] = struct{}{}
+		}
+
+		if record.Result {
+			log.Infof("[server] rescheduled sub tasks of source %s from offline dm-worker %s to %s", source, worker, record.ToWorker)
+		} else {
+			done = false
+			if !s.rescheduleFailChanged(record) {
+				// the same as the last failure, not record it again when retrying
+				log.Debugf("[server] reschedule sub tasks of source %s from offline dm-worker %s still fail %s", source, worker, record.Msg)
+				continue
+			}
+			log.Errorf("[server] reschedule sub tasks of source %s from offline dm-worker %s fail %s, record %+v", source, worker, record.Msg, record)
+		}
+		err = s.saveRescheduleRecord(record)
+		if err != nil {
+			log.Errorf("[server] save reschedule record %+v error %v", record, errors.ErrorStack(err))
+		}
+	}
+	return done
+}
+
+// rescheduleFailChanged returns whether the failed rescheduling record differs from the last failure of the source,
+// and updates the last failure. a successful rescheduling resets the last failure
+func (s *Server) rescheduleFailChanged(record *pb.RescheduleRecord) bool {
+	fail := ""
+	if !record.Result {
+		fail = fmt.Sprintf("%s %s %s", record.FromWorker, record.ToWorker, record.Msg)
+		for _, r := range record.SubTasks {
+			fail += fmt.Sprintf(" %s:%s", r.Task, r.Msg)
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	changed := s.rescheduleFails[record.Source] != fail
+	if len(fail) > 0 {
+		s.rescheduleFails[record.Source] = fail
+	} else {
+		delete(s.rescheduleFails, record.Source)
+	}
+	return changed
+}
+
+// rescheduleSource reschedules sub tasks of the source to a standby dm-worker, and fills the result into record.
+// if the source has been bound to a standby dm-worker in previous rescheduling, sub tasks left are rescheduled to it.
+// the standby dm-worker is bound to the source only when at least one sub task can be rescheduled,
+// otherwise an error is returned and the rescheduling should be retried later
+func (s *Server) rescheduleSource(ctx context.Context, record *pb.RescheduleRecord, bound map[string]struct{}) error {
+	s.Lock()
+	standby := s.cfg.DeployMap[record.Source]
+	s.Unlock()
+	rebound := len(standby) > 0 && standby != record.FromWorker
+	if !rebound {
+		standby = s.registry.Standby(record.Source, bound)
+		if standby == "" {
+			return errors.New("no online standby dm-worker for the source")
+		}
+	}
+	record.ToWorker = standby
+
+	// find sub tasks on the offline dm-worker, and their checkpoints
+	tasks := make([]*TaskMeta, 0)
+	var (
+		relayPos   *mysql.Position
+		relayInUse bool // sub tasks of the source rescheduled to the standby dm-worker before
+	)
+	for _, task := range s.taskMeta.All() {
+		if st := task.SubTask(standby); st != nil && st.SourceID == record.Source {
+			relayInUse = true
+		}
+		st := task.SubTask(record.FromWorker)
+		if st == nil || st.SourceID != record.Source {
+			continue
+		}
+		stResult := &pb.RescheduleSubTask{Task: task.Name}
+		record.SubTasks = append(record.SubTasks, stResult)
+
+		pos, err := subTaskCheckpointFunc(ctx, st)
+		if err != nil {
+			stResult.Msg = fmt.Sprintf("query checkpoint fail %s", errors.ErrorStack(err))
+			continue
+		} else if pos == nil {
+			stResult.Msg = "no checkpoint of sync unit flushed, only sub tasks in sync unit can be rescheduled"
+			continue
+		}
+		if relayPos == nil || pos.Compare(*relayPos) < 0 {
+			relayPos = pos
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(record.SubTasks) > 0 && len(tasks) == 0 {
+		// no sub tasks can be moved, keep the source on the offline dm-worker
+		return errors.New("no sub tasks can be rescheduled, retry later")
+	}
+
+	// bind the standby dm-worker to the source
+	if !rebound {
+		err := s.rebindWorker(ctx, record.Source, record.FromWorker, standby)
+		if err != nil {
+			return errors.Annotate(err, "bind standby dm-worker")
+		}
+	}
+
+	// relay unit already pulling binlog for sub tasks rescheduled before is not migrated again
+	if relayPos != nil && !relayInUse {
+		record.RelayPos = relayPos.String()
+		err := s.migrateRelay(ctx, standby, relayPos)
+		if err != nil {
+			return errors.Annotate(err, "migrate relay of standby dm-worker")
+		}
+	}
+
+	record.Result = true
+	for _, task := range tasks {
+		var stResult *pb.RescheduleSubTask
+		for _, r := range record.SubTasks {
+			if r.Task == task.Name {
+				stResult = r
+			}
+		}
+
+		st := task.SubTask(record.FromWorker)
+		st.Worker = standby
+		err := s.recoverSubTask(ctx, task.Name, st)
+		if err != nil {
+			stResult.Msg = errors.ErrorStack(err)
+			record.Result = false
+			continue
+		}
+		stResult.Result = true
+
+		err = s.taskMeta.Set(task.Name, task.Task, []*SubTaskMeta{st}, false)
+		if err == nil {
+			err = s.taskMeta.Remove(task.Name, []string{record.FromWorker})
+		}
+		if err != nil {
+			log.Errorf("[server] update meta of task %s error %v", task.Name, errors.ErrorStack(err))
+		}
+		s.removeTaskWorkers(task.Name, []string{record.FromWorker})
+		s.addTaskWorkers(task.Name, []string{standby}, false)
+	}
+	for _, r := range record.SubTasks {
+		if !r.Result {
+			record.Result = false
+			record.Msg = "some sub tasks not rescheduled, retry later"
+		}
+	}
+	if record.Result {
+		s.rescheduleFailChanged(record) // reset the last failure
+	}
+	return nil
+}
+
+// rebindWorker binds the source to another dm-worker in deploy map
+func (s *Server) rebindWorker(ctx context.Context, source, from, to string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if bound := s.cfg.DeployMap[source]; bound != from {
+		return errors.Errorf("source %s is bound to dm-worker %s now", source, bound)
+	}
+
+	deployMap := make(map[string]string, len(s.cfg.DeployMap))
+	for source, w := range s.cfg.DeployMap {
+		deployMap[source] = w
+	}
+	deployMap[source] = to
+
+	err := s.saveDeployMap(ctx, deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.updateWorkerClients(deployMap)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.cfg.DeployMap = deployMap
+	log.Infof("[server] bind dm-worker %s to source %s instead of %s", to, source, from)
+	return nil
+}
+
+// migrateRelay makes relay unit of the dm-worker pulling binlog from the position
+func (s *Server) migrateRelay(ctx context.Context, worker string, pos *mysql.Position) error {
+	cli, ok := s.workerClient(worker)
+	if !ok {
+		return errors.NotFoundf("%s relevant worker-client", worker)
+	}
+
+	resp, err := cli.MigrateRelay(ctx, &pb.MigrateRelayRequest{BinlogName: pos.Name, BinlogPos: pos.Pos})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !resp.Result {
+		return errors.Errorf("migrate relay fail %s", resp.Msg)
+	}
+
+	// relay unit is paused when migrating
+	relayResp, err := cli.OperateRelay(ctx, &pb.OperateRelayRequest{Op: pb.RelayOp_ResumeRelay})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !relayResp.Result {
+		return errors.Errorf("resume relay fail %s", relayResp.Msg)
+	}
+	return nil
+}
+
+// stopStaleSubTasks stops sub tasks on the dm-worker which have been rescheduled to other dm-workers,
+// like sub tasks restored by the offline dm-worker after it restarted
+func (s *Server) stopStaleSubTasks(ctx context.Context, worker string) {
+	_, ok := s.workerClient(worker)
+	if ok {
+		return // bound dm-worker, it's sub tasks are handled by recoverTasks
+	}
+
+	info := s.registry.Worker(worker)
+	if info == nil {
+		return
+	}
+	stale := make([]string, 0)
+	for _, task := range s.taskMeta.All() {
+		if task.SubTask(worker) != nil {
+			continue
+		}
+		for _, st := range task.SubTasks {
+			if st.SourceID == info.SourceID {
+				stale = append(stale, task.Name)
+				break
+			}
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	conn, err := grpc.Dial(worker, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(3*time.Second))
+	if err != nil {
+		log.Errorf("[server] dial dm-worker %s error %v", worker, err)
+		return
+	}
+	defer conn.Close()
+	cli := pb.NewWorkerClient(conn)
+
+	resp, err := cli.QueryStatus(ctx, &pb.QueryStatusRequest{})
+	if err != nil {
+		log.Errorf("[server] query status from dm-worker %s error %v", worker, errors.ErrorStack(err))
+		return
+	}
+	for _, status := range resp.SubTaskStatus {
+		for _, task := range stale {
+			if status.Name != task {
+				continue
+			}
+			opResp, err := cli.OperateSubTask(ctx, &pb.OperateSubTaskRequest{Op: pb.TaskOp_Stop, Name: task})
+			if err != nil {
+				log.Errorf("[server] stop stale sub task %s on dm-worker %s error %v", task, worker, errors.ErrorStack(err))
+			} else if !opResp.Result {
+				log.Errorf("[server] stop stale sub task %s on dm-worker %s fail %s", task, worker, opResp.Msg)
+			} else {
+				log.Infof("[server] stale sub task %s on dm-worker %s stopped, it has been rescheduled", task, worker)
+			}
+		}
+	}
+}
+
+// subTaskCheckpointFunc queries the checkpoint of the sub task, can be replaced in tests
+var subTaskCheckpointFunc = subTaskCheckpoint
+
+// subTaskCheckpoint queries the global checkpoint of sync unit from the downstream meta schema,
+// returns nil if no checkpoint flushed yet
+func subTaskCheckpoint(ctx context.Context, st *SubTaskMeta) (*mysql.Position, error) {
+	cfg := config.NewSubTaskConfig()
+	err := cfg.Decode(st.Config)
+	if err != nil {
+		return nil, errors.Annotate(err, "decode sub task config")
+	}
+
+	var pswd string
+	if len(cfg.To.Password) > 0 {
+		pswd, err = utils.Decrypt(cfg.To.Password)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8&readTimeout=%s", cfg.To.User, pswd, cfg.To.Host, cfg.To.Port, checkpointQueryTimeout)
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer db.Close()
+
+	// the same as checkpoint of syncer, see syncer/checkpoint.go
+	query := fmt.Sprintf("SELECT `binlog_name`, `binlog_pos` FROM `%s`.`%s_syncer_checkpoint` WHERE `id`=? AND `is_global`=1", cfg.MetaSchema, cfg.Name)
+	pos := &mysql.Position{}
+	err = db.QueryRowContext(ctx, query, cfg.SourceID).Scan(&pos.Name, &pos.Pos)
+	if err == sql.ErrNoRows || utils.IsErrTableNotExists(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return pos, nil
+}
+
+// saveRescheduleRecord saves a reschedule record into etcd
+func (s *Server) saveRescheduleRecord(record *pb.RescheduleRecord) error {
+	value, err := record.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	key := fmt.Sprintf("%s%s/%020d", rescheduleKeyPrefix, record.Source, time.Now().UnixNano())
+	_, err = s.etcdCli.Put(ctx, key, string(value))
+	return errors.Trace(err)
+}
+
+// loadRescheduleRecords loads reschedule records of the source from etcd, all sources if source is empty
+func (s *Server) loadRescheduleRecords(ctx context.Context, source string) ([]*pb.RescheduleRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, etcdRequestTimeout)
+	defer cancel()
+
+	prefix := rescheduleKeyPrefix
+	if source != "" {
+		prefix += source + "/"
+	}
+	resp, err := s.etcdCli.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	records := make([]*pb.RescheduleRecord, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		record := &pb.RescheduleRecord{}
+		err = record.Unmarshal(kv.Value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decode reschedule record %s", kv.Key)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+func (t *testMaster) TestRescheduleWorker(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	cfg.DeployMap = map[string]string{"source-1": "127.0.0.1:18262"}
+	s := NewServer(cfg)
+	go s.Start()
+	defer s.Close()
+	waitLeader(c, []*Server{s})
+
+	ctx := context.Background()
+	c.Assert(s.registry.Register(&pb.RegisterWorkerRequest{Address: "127.0.0.1:18262", SourceID: "source-1"}), IsNil)
+
+	// no standby dm-worker
+	c.Assert(s.rescheduleWorker(ctx, "127.0.0.1:18262"), IsFalse)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, "127.0.0.1:18262")
+
+	// no sub tasks to reschedule, only bind the standby dm-worker
+	c.Assert(s.registry.Register(&pb.RegisterWorkerRequest{Address: "127.0.0.1:18263", SourceID: "source-1"}), IsNil)
+	c.Assert(s.rescheduleWorker(ctx, "127.0.0.1:18262"), IsTrue)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, "127.0.0.1:18263")
+	_, ok := s.workerClients["127.0.0.1:18263"]
+	c.Assert(ok, IsTrue)
+
+	resp, err := s.ShowReschedule(ctx, &pb.ShowRescheduleRequest{Source: "source-1"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Result, IsTrue)
+	c.Assert(resp.Records, HasLen, 2)
+	c.Assert(resp.Records[0].Result, IsFalse)
+	c.Assert(resp.Records[0].ToWorker, Equals, "")
+	c.Assert(resp.Records[1].Result, IsTrue)
+	c.Assert(resp.Records[1].FromWorker, Equals, "127.0.0.1:18262")
+	c.Assert(resp.Records[1].ToWorker, Equals, "127.0.0.1:18263")
+
+	resp, err = s.ShowReschedule(ctx, &pb.ShowRescheduleRequest{Source: "source-2"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Records, HasLen, 0)
+}
+
+func (t *testMaster) TestRescheduleSubTasks(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	cfg.DeployMap = map[string]string{"source-1": "127.0.0.1:18262"}
+	s := NewServer(cfg)
+	go s.Start()
+	defer s.Close()
+	waitLeader(c, []*Server{s})
+
+	oldCheckpointFunc := subTaskCheckpointFunc
+	defer func() {
+		subTaskCheckpointFunc = oldCheckpointFunc
+	}()
+	var checkpointErr error
+	subTaskCheckpointFunc = func(ctx context.Context, st *SubTaskMeta) (*mysql.Position, error) {
+		if checkpointErr != nil {
+			return nil, checkpointErr
+		}
+		return &mysql.Position{Name: "mysql-bin.000002", Pos: 1234}, nil
+	}
+
+	var (
+		ctx     = context.Background()
+		offline = "127.0.0.1:18262"
+		standby = "127.0.0.1:18263"
+		records = func() []*pb.RescheduleRecord {
+			resp, err := s.ShowReschedule(ctx, &pb.ShowRescheduleRequest{Source: "source-1"})
+			c.Assert(err, IsNil)
+			return resp.Records
+		}
+	)
+	c.Assert(s.registry.Register(&pb.RegisterWorkerRequest{Address: offline, SourceID: "source-1"}), IsNil)
+	c.Assert(s.taskMeta.Set("task-1", "", []*SubTaskMeta{
+		{Worker: offline, SourceID: "source-1", Config: "name = \"task-1\"", Stage: pb.Stage_Running.String()},
+	}, false), IsNil)
+
+	// no standby dm-worker, retried later, the same failure is recorded once
+	c.Assert(s.rescheduleWorker(ctx, offline), IsFalse)
+	c.Assert(s.rescheduleWorker(ctx, offline), IsFalse)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, offline)
+	c.Assert(records(), HasLen, 1)
+
+	// standby dm-worker appears, but no sub tasks can be moved without checkpoints, the source is not bound to it
+	c.Assert(s.registry.Register(&pb.RegisterWorkerRequest{Address: standby, SourceID: "source-1"}), IsNil)
+	cli := &mockWorkerClient{startSubTaskMsg: "mock start fail"}
+	s.Lock()
+	s.workerClients[standby] = cli
+	s.Unlock()
+	checkpointErr = errors.New("mock query checkpoint fail")
+	c.Assert(s.rescheduleWorker(ctx, offline), IsFalse)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, offline)
+	c.Assert(cli.migrateRelayReqs, HasLen, 0)
+	c.Assert(cli.startSubTaskReqs, HasLen, 0)
+	c.Assert(records(), HasLen, 2)
+	c.Assert(records()[1].Result, IsFalse)
+	c.Assert(records()[1].SubTasks, HasLen, 1)
+	c.Assert(records()[1].SubTasks[0].Result, IsFalse)
+
+	// fail to start the sub task on the standby dm-worker
+	checkpointErr = nil
+	c.Assert(s.rescheduleWorker(ctx, offline), IsFalse)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, standby)
+	c.Assert(cli.migrateRelayReqs, HasLen, 1)
+	c.Assert(cli.migrateRelayReqs[0].BinlogName, Equals, "mysql-bin.000002")
+	c.Assert(cli.migrateRelayReqs[0].BinlogPos, Equals, uint32(1234))
+	c.Assert(s.taskMeta.Get("task-1").SubTask(offline), NotNil)
+	c.Assert(records(), HasLen, 3)
+	c.Assert(records()[2].ToWorker, Equals, standby)
+	c.Assert(records()[2].Result, IsFalse)
+
+	// retry on the source bound to the standby dm-worker
+	cli.startSubTaskMsg = ""
+	c.Assert(s.rescheduleWorker(ctx, offline), IsTrue)
+	c.Assert(s.cfg.DeployMap["source-1"], Equals, standby)
+	c.Assert(cli.startSubTaskReqs, HasLen, 2)
+	task := s.taskMeta.Get("task-1")
+	c.Assert(task.SubTask(offline), IsNil)
+	c.Assert(task.SubTask(standby), NotNil)
+	c.Assert(task.SubTask(standby).Stage, Equals, pb.Stage_Running.String())
+	c.Assert(s.taskWorkers["task-1"], DeepEquals, []string{standby})
+	c.Assert(records(), HasLen, 4)
+	c.Assert(records()[3].Result, IsTrue)
+	c.Assert(records()[3].SubTasks, HasLen, 1)
+	c.Assert(records()[3].SubTasks[0].Result, IsTrue)
+
+	// nothing left on the offline dm-worker
+	c.Assert(s.rescheduleWorker(ctx, offline), IsTrue)
+	c.Assert(records(), HasLen, 4)
+}
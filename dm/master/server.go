@@ -23,6 +23,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/embed"
+	"github.com/coreos/etcd/etcdserver/api/v3client"
 	"github.com/pingcap/dm/pkg/log"
 	"github.com/pingcap/errors"
 	"github.com/siddontang/go/sync2"
@@ -52,12 +55,33 @@ type Server struct {
 	rootLis net.Listener
 	svr     *grpc.Server
 
+	// embedded etcd, used for leader election and storing meta
+	etcd     *embed.Etcd
+	etcdCli  *clientv3.Client
+	election *Election
+	leaderWg sync.WaitGroup // wait for background jobs of the leader
+
+	// client used to forward requests to the leader
+	leaderMu   sync.Mutex
+	leaderAddr string
+	leaderConn *grpc.ClientConn
+	leaderCli  pb.MasterClient
+
 	// dm-worker-ID(host:ip) -> dm-worker-client
 	workerClients map[string]pb.WorkerClient
 
 	// task-name -> worker-list
 	taskWorkers map[string][]string
 
+	// registered dm-workers
+	registry *WorkerRegistry
+
+	// source-id -> last failure of rescheduling, to not record the same failure again when retrying
+	rescheduleFails map[string]string
+
+	// persistent meta of started tasks, stored in etcd
+	taskMeta *TaskMetaStore
+
 	// DDL lock keeper
 	lockKeeper *LockKeeper
 
@@ -76,6 +100,8 @@ func NewServer(cfg *Config) *Server {
 		cfg:               cfg,
 		workerClients:     make(map[string]pb.WorkerClient),
 		taskWorkers:       make(map[string][]string),
+		registry:          NewWorkerRegistry(time.Duration(cfg.WorkerHeartbeatTimeout) * time.Second),
+		rescheduleFails:   make(map[string]string),
 		lockKeeper:        NewLockKeeper(),
 		sqlOperatorHolder: operator.NewHolder(),
 		idGen:             tracing.NewIDGen(),
@@ -91,13 +117,31 @@ func (s *Server) Start() error {
 		return errors.Trace(err)
 	}
 
-	for _, workerAddr := range s.cfg.DeployMap {
-		conn, err2 := grpc.Dial(workerAddr, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(3*time.Second))
-		if err2 != nil {
-			return errors.Trace(err2)
-		}
-		s.workerClients[workerAddr] = pb.NewWorkerClient(conn)
+	s.etcd, err = startEtcd(s.cfg)
+	if err != nil {
+		s.rootLis.Close()
+		return errors.Annotate(err, "start embedded etcd")
 	}
+	s.etcdCli = v3client.New(s.etcd.Server)
+	defer func() {
+		s.etcdCli.Close()
+		s.etcd.Close()
+	}()
+	s.election = NewElection(s.etcdCli, electionKey, s.cfg.AdvertiseAddr, electionSessionTTL)
+	s.taskMeta = NewTaskMetaStore(s.etcdCli)
+
+	err = s.saveMember()
+	if err != nil {
+		s.rootLis.Close()
+		return errors.Trace(err)
+	}
+
+	err = s.updateWorkerClients(s.cfg.DeployMap)
+	if err != nil {
+		s.rootLis.Close()
+		return errors.Trace(err)
+	}
+
 	s.closed.Set(false)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -105,20 +149,8 @@ func (s *Server) Start() error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(3 * time.Second):
-			// update task -> workers after started
-			s.updateTaskWorkers(ctx)
-		}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// fetch DDL info from dm-workers to sync sharding DDL
-		s.fetchWorkerDDLInfo(ctx)
+		// campaign for the leader, tasks and DDL locks are handled by the leader only
+		s.election.Run(ctx, s.becomeLeader, s.retire)
 	}()
 
 	// auto resolve DDL lock is not very useful, comment it
@@ -145,7 +177,8 @@ func (s *Server) Start() error {
 	grpcL := m.Match(cmux.HTTP2HeaderField("content-type", "application/grpc"))
 	httpL := m.Match(cmux.HTTP1Fast())
 
-	s.svr = grpc.NewServer()
+	// requests to followers are forwarded to the leader
+	s.svr = grpc.NewServer(grpc.UnaryInterceptor(s.forwardToLeader))
 	pb.RegisterMasterServer(s.svr, s)
 	go func() {
 		err2 := s.svr.Serve(grpcL)
@@ -163,6 +196,7 @@ func (s *Server) Start() error {
 
 	cancel()
 	wg.Wait()
+	s.closeLeaderClient()
 	return err
 }
 
@@ -221,13 +255,14 @@ func (s *Server) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.S
 	}
 
 	validWorkerCh := make(chan string, len(stCfgs))
+	subTaskMetaCh := make(chan *SubTaskMeta, len(stCfgs))
 	var wg sync.WaitGroup
 	for _, stCfg := range stCfgs {
 		wg.Add(1)
 		go func(stCfg *config.SubTaskConfig) {
 			defer wg.Done()
 			worker, ok1 := s.cfg.DeployMap[stCfg.SourceID]
-			cli, ok2 := s.workerClients[worker]
+			cli, ok2 := s.workerClient(worker)
 			if !ok1 || !ok2 {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -254,6 +289,14 @@ func (s *Server) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.S
 			}
 			workerResp.Worker = worker
 			workerRespCh <- workerResp
+			if workerResp.Result {
+				subTaskMetaCh <- &SubTaskMeta{
+					Worker:   worker,
+					SourceID: stCfg.SourceID,
+					Config:   stCfgToml,
+					Stage:    pb.Stage_Running.String(),
+				}
+			}
 		}(stCfg)
 	}
 	wg.Wait()
@@ -281,6 +324,21 @@ func (s *Server) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.S
 	replace := len(req.Workers) == 0 // a fresh start
 	s.addTaskWorkers(cfg.Name, validWorkers, replace)
 
+	// record task meta, so we can recover it after dm-master restarted
+	subTaskMetas := make([]*SubTaskMeta, 0, len(subTaskMetaCh))
+	for len(subTaskMetaCh) > 0 {
+		subTaskMetas = append(subTaskMetas, <-subTaskMetaCh)
+	}
+	err = s.taskMeta.Set(cfg.Name, req.Task, subTaskMetas, replace)
+	if err != nil {
+		log.Errorf("[server] save meta for task %s error %v", cfg.Name, errors.ErrorStack(err))
+		return &pb.StartTaskResponse{
+			Result:  false,
+			Msg:     fmt.Sprintf("save meta for task %s error, it can't be recovered after dm-master restarted: %s", cfg.Name, errors.ErrorStack(err)),
+			Workers: workerResps,
+		}, nil
+	}
+
 	return &pb.StartTaskResponse{
 		Result:  true,
 		Workers: workerResps,
@@ -315,7 +373,7 @@ func (s *Server) OperateTask(ctx context.Context, req *pb.OperateTaskRequest) (*
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerResp := &pb.OperateSubTaskResponse{
 					Op:     req.Op,
@@ -355,13 +413,40 @@ func (s *Server) OperateTask(ctx context.Context, req *pb.OperateTaskRequest) (*
 		workerResps = append(workerResps, workerRespMap[worker])
 	}
 
-	if req.Op == pb.TaskOp_Stop {
+	var err error
+	switch req.Op {
+	case pb.TaskOp_Stop:
 		// remove (partial / all) workers for a task
 		s.removeTaskWorkers(req.Name, validWorkers)
+		err = s.taskMeta.Remove(req.Name, validWorkers)
+		if len(s.getTaskWorkers(req.Name)) == 0 {
+			// structures of shard tables and skipped dm-workers are not needed after the task stopped
+			if err2 := s.removeOptimisticGroups(req.Name); err2 != nil {
+				log.Warnf("[server] remove optimistic groups of task %s error %v", req.Name, errors.ErrorStack(err2))
+			}
+			if err2 := s.removeSkippedShards(req.Name); err2 != nil {
+				log.Warnf("[server] remove skipped workers of DDL locks of task %s error %v", req.Name, errors.ErrorStack(err2))
+			}
+		}
+	case pb.TaskOp_Pause, pb.TaskOp_Resume:
+		stage := pb.Stage_Paused
+		if req.Op == pb.TaskOp_Resume {
+			stage = pb.Stage_Running
+		}
+		if s.taskMeta.Get(req.Name) != nil {
+			err = s.taskMeta.UpdateStage(req.Name, validWorkers, stage)
+		} else {
+			log.Warnf("[server] task %s not found in task meta, skip to update its stage", req.Name)
+		}
+	}
+	resp.Workers = workerResps
+	if err != nil {
+		log.Errorf("[server] update meta for task %s error %v", req.Name, errors.ErrorStack(err))
+		resp.Msg = fmt.Sprintf("update meta for task %s error, it can't be recovered after dm-master restarted: %s", req.Name, errors.ErrorStack(err))
+		return resp, nil
 	}
 
 	resp.Result = true
-	resp.Workers = workerResps
 
 	return resp, nil
 }
@@ -405,13 +490,14 @@ func (s *Server) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb
 		}
 	}
 
+	subTaskMetaCh := make(chan *SubTaskMeta, len(stCfgs))
 	var wg sync.WaitGroup
 	for _, stCfg := range stCfgs {
 		wg.Add(1)
 		go func(stCfg *config.SubTaskConfig) {
 			defer wg.Done()
 			worker, ok1 := s.cfg.DeployMap[stCfg.SourceID]
-			cli, ok2 := s.workerClients[worker]
+			cli, ok2 := s.workerClient(worker)
 			if !ok1 || !ok2 {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -437,6 +523,13 @@ func (s *Server) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb
 			}
 			workerResp.Worker = worker
 			workerRespCh <- workerResp
+			if workerResp.Result {
+				subTaskMetaCh <- &SubTaskMeta{
+					Worker:   worker,
+					SourceID: stCfg.SourceID,
+					Config:   stCfgToml,
+				}
+			}
 		}(stCfg)
 	}
 	wg.Wait()
@@ -455,6 +548,29 @@ func (s *Server) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb
 		workerResps = append(workerResps, workerRespMap[worker])
 	}
 
+	// update task meta, keep the requested stage of sub tasks
+	if meta := s.taskMeta.Get(cfg.Name); meta != nil {
+		subTaskMetas := make([]*SubTaskMeta, 0, len(subTaskMetaCh))
+		for len(subTaskMetaCh) > 0 {
+			stMeta := <-subTaskMetaCh
+			if old := meta.SubTask(stMeta.Worker); old != nil {
+				stMeta.Stage = old.Stage
+				subTaskMetas = append(subTaskMetas, stMeta)
+			}
+		}
+		err = s.taskMeta.Set(cfg.Name, req.Task, subTaskMetas, false)
+		if err != nil {
+			log.Errorf("[server] save meta for task %s error %v", cfg.Name, errors.ErrorStack(err))
+			return &pb.UpdateTaskResponse{
+				Result:  false,
+				Msg:     fmt.Sprintf("save meta for task %s error, it can't be recovered after dm-master restarted: %s", cfg.Name, errors.ErrorStack(err)),
+				Workers: workerResps,
+			}, nil
+		}
+	} else {
+		log.Warnf("[server] task %s not found in task meta, skip to update it", cfg.Name)
+	}
+
 	return &pb.UpdateTaskResponse{
 		Result:  true,
 		Workers: workerResps,
@@ -465,12 +581,13 @@ func (s *Server) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb
 func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusListRequest) (*pb.QueryStatusListResponse, error) {
 	log.Infof("[server] receive QueryStatus request %+v", req)
 
-	workers := make([]string, 0, len(s.workerClients))
+	clients := s.workerClientsSnapshot()
+	workers := make([]string, 0, len(clients))
 	if len(req.Workers) > 0 {
 		// query specified dm-workers
 		invalidWorkers := make([]string, 0, len(req.Workers))
 		for _, worker := range req.Workers {
-			if _, ok := s.workerClients[worker]; !ok {
+			if _, ok := clients[worker]; !ok {
 				invalidWorkers = append(invalidWorkers, worker)
 			}
 		}
@@ -492,7 +609,7 @@ func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusListRequest
 		}
 	} else {
 		// query all workers
-		for worker := range s.workerClients {
+		for worker := range clients {
 			workers = append(workers, worker)
 		}
 	}
@@ -521,12 +638,13 @@ func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusListRequest
 func (s *Server) QueryError(ctx context.Context, req *pb.QueryErrorListRequest) (*pb.QueryErrorListResponse, error) {
 	log.Infof("[server] receive QueryError request %+v", req)
 
-	workers := make([]string, 0, len(s.workerClients))
+	clients := s.workerClientsSnapshot()
+	workers := make([]string, 0, len(clients))
 	if len(req.Workers) > 0 {
 		// query specified dm-workers
 		invalidWorkers := make([]string, 0, len(req.Workers))
 		for _, worker := range req.Workers {
-			if _, ok := s.workerClients[worker]; !ok {
+			if _, ok := clients[worker]; !ok {
 				invalidWorkers = append(invalidWorkers, worker)
 			}
 		}
@@ -548,7 +666,7 @@ func (s *Server) QueryError(ctx context.Context, req *pb.QueryErrorListRequest)
 		}
 	} else {
 		// query all workers
-		for worker := range s.workerClients {
+		for worker := range clients {
 			workers = append(workers, worker)
 		}
 	}
@@ -604,6 +722,7 @@ func (s *Server) ShowDDLLocks(ctx context.Context, req *pb.ShowDDLLocksRequest)
 			DDLs:     lock.Stmts,
 			Synced:   make([]string, 0, len(ready)),
 			Unsynced: make([]string, 0, len(ready)),
+			Conflict: lock.Conflict,
 		}
 		for worker, synced := range ready {
 			if synced {
@@ -637,6 +756,9 @@ func (s *Server) UnlockDDLLock(ctx context.Context, req *pb.UnlockDDLLockRequest
 
 		if req.ForceRemove {
 			s.lockKeeper.RemoveLock(req.ID)
+			if err2 := s.deleteLock(req.ID); err2 != nil {
+				log.Errorf("[server] delete DDL lock %s from etcd error %v", req.ID, errors.ErrorStack(err2))
+			}
 			log.Warnf("[server] force to remove DDL lock %s because of `ForceRemove` set", req.ID)
 		}
 	} else {
@@ -663,7 +785,7 @@ func (s *Server) BreakWorkerDDLLock(ctx context.Context, req *pb.BreakWorkerDDLL
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -741,9 +863,9 @@ func (s *Server) HandleSQLs(ctx context.Context, req *pb.HandleSQLsRequest) (*pb
 		BinlogPos:  req.BinlogPos,
 		SqlPattern: req.SqlPattern,
 	}
-	cli, ok := s.workerClients[req.Worker]
+	cli, ok := s.workerClient(req.Worker)
 	if !ok {
-		resp.Msg = fmt.Sprintf("worker %s client not found in %v", req.Worker, s.workerClients)
+		resp.Msg = fmt.Sprintf("worker %s client not found in %v", req.Worker, s.workerClientsSnapshot())
 		return resp, nil
 	}
 	workerResp, err := cli.HandleSQLs(ctx, subReq)
@@ -776,7 +898,7 @@ func (s *Server) PurgeWorkerRelay(ctx context.Context, req *pb.PurgeWorkerRelayR
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -828,7 +950,7 @@ func (s *Server) SwitchWorkerRelayMaster(ctx context.Context, req *pb.SwitchWork
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -880,7 +1002,7 @@ func (s *Server) OperateWorkerRelayTask(ctx context.Context, req *pb.OperateWork
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerResp := &pb.OperateRelayResponse{
 					Op:     req.Op,
@@ -959,15 +1081,15 @@ func (s *Server) addTaskWorkers(task string, workers []string, replace bool) {
 		return
 	}
 
+	s.Lock()
+	defer s.Unlock()
+
 	valid := make([]string, 0, len(workers))
 	for _, worker := range workers {
 		if _, ok := s.workerClients[worker]; ok {
 			valid = append(valid, worker)
 		}
 	}
-
-	s.Lock()
-	defer s.Unlock()
 	if !replace {
 		// merge with old workers
 		old, ok := s.taskWorkers[task]
@@ -1041,6 +1163,25 @@ func (s *Server) getTaskWorkers(task string) []string {
 	return ret
 }
 
+// workerClient returns the client of the dm-worker, clients are updated when dm-workers are bound or rebound
+func (s *Server) workerClient(worker string) (pb.WorkerClient, bool) {
+	s.Lock()
+	defer s.Unlock()
+	cli, ok := s.workerClients[worker]
+	return cli, ok
+}
+
+// workerClientsSnapshot returns a copy of all dm-worker clients, which can be iterated without lock held
+func (s *Server) workerClientsSnapshot() map[string]pb.WorkerClient {
+	s.Lock()
+	defer s.Unlock()
+	clients := make(map[string]pb.WorkerClient, len(s.workerClients))
+	for worker, cli := range s.workerClients {
+		clients[worker] = cli
+	}
+	return clients
+}
+
 // containWorker checks whether worker in workers
 func (s *Server) containWorker(workers []string, worker string) bool {
 	for _, w := range workers {
@@ -1056,15 +1197,26 @@ func (s *Server) getStatusFromWorkers(ctx context.Context, workers []string, tas
 	workerReq := &pb.QueryStatusRequest{
 		Name: taskName,
 	}
+	return s.queryStatusFromWorkers(ctx, workers, workerReq)
+}
 
+// queryStatusFromWorkers does RPC request to get status from dm-workers with the request
+func (s *Server) queryStatusFromWorkers(ctx context.Context, workers []string, workerReq *pb.QueryStatusRequest) chan *pb.QueryStatusResponse {
 	workerRespCh := make(chan *pb.QueryStatusResponse, len(workers))
 	var wg sync.WaitGroup
 	for _, worker := range workers {
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli := s.workerClients[worker]
-			workerStatus, err := cli.QueryStatus(ctx, workerReq)
+			var (
+				workerStatus *pb.QueryStatusResponse
+				err          error
+			)
+			if cli, ok := s.workerClient(worker); ok {
+				workerStatus, err = cli.QueryStatus(ctx, workerReq)
+			} else {
+				err = errors.NotFoundf("%s relevant worker-client", worker)
+			}
 			if err != nil {
 				workerStatus = &pb.QueryStatusResponse{
 					Result: false,
@@ -1091,8 +1243,15 @@ func (s *Server) getErrorFromWorkers(ctx context.Context, workers []string, task
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli := s.workerClients[worker]
-			workerError, err := cli.QueryError(ctx, workerReq)
+			var (
+				workerError *pb.QueryErrorResponse
+				err         error
+			)
+			if cli, ok := s.workerClient(worker); ok {
+				workerError, err = cli.QueryError(ctx, workerReq)
+			} else {
+				err = errors.NotFoundf("%s relevant worker-client", worker)
+			}
 			if err != nil {
 				workerError = &pb.QueryErrorResponse{
 					Result: false,
@@ -1107,21 +1266,115 @@ func (s *Server) getErrorFromWorkers(ctx context.Context, workers []string, task
 	return workerRespCh
 }
 
-// updateTaskWorkers fetches task-workers mapper from dm-workers and update s.taskWorkers
-func (s *Server) updateTaskWorkers(ctx context.Context) {
-	taskWorkers, _ := s.fetchTaskWorkers(ctx)
+// recoverTasks reconciles task meta with sub tasks reported by dm-workers, and update s.taskWorkers
+// sub tasks recorded in task meta but lost by dm-workers (like dm-worker restarted) will be re-started
+func (s *Server) recoverTasks(ctx context.Context) {
+	clients := s.workerClientsSnapshot()
+	workers := make([]string, 0, len(clients))
+	for worker := range clients {
+		workers = append(workers, worker)
+	}
+
+	// dm-worker -> task-name -> stage
+	workerStages := make(map[string]map[string]pb.Stage, len(workers))
+	taskWorkers := make(map[string][]string)
+	workerRespCh := s.getStatusFromWorkers(ctx, workers, "")
+	for len(workerRespCh) > 0 {
+		workerResp := <-workerRespCh
+		if len(workerResp.Msg) > 0 || !workerResp.Result {
+			log.Warnf("[server] query status from %s fail %v, skip to recover tasks on it", workerResp.Worker, workerResp.Msg)
+			continue
+		}
+		stages := make(map[string]pb.Stage, len(workerResp.SubTaskStatus))
+		for _, status := range workerResp.SubTaskStatus {
+			if status.Stage == pb.Stage_InvalidStage {
+				continue // invalid status
+			}
+			stages[status.Name] = status.Stage
+			taskWorkers[status.Name] = append(taskWorkers[status.Name], workerResp.Worker)
+		}
+		workerStages[workerResp.Worker] = stages
+	}
+
+	for _, task := range s.taskMeta.All() {
+		for _, st := range task.SubTasks {
+			stages, ok := workerStages[st.Worker]
+			if !ok {
+				// dm-worker not available now, keep it in task-workers mapper
+				if !s.containWorker(taskWorkers[task.Name], st.Worker) {
+					taskWorkers[task.Name] = append(taskWorkers[task.Name], st.Worker)
+				}
+				continue
+			}
+
+			stage, ok := stages[task.Name]
+			if !ok {
+				err := s.recoverSubTask(ctx, task.Name, st)
+				if err != nil {
+					log.Errorf("[server] recover sub task %s on %s error %v", task.Name, st.Worker, errors.ErrorStack(err))
+					continue
+				}
+				log.Infof("[server] sub task %s on %s recovered with stage %s", task.Name, st.Worker, st.Stage)
+				taskWorkers[task.Name] = append(taskWorkers[task.Name], st.Worker)
+			} else if stage == pb.Stage_Running && st.Stage == pb.Stage_Paused.String() {
+				err := s.operateSubTask(ctx, task.Name, st.Worker, pb.TaskOp_Pause)
+				if err != nil {
+					log.Errorf("[server] pause sub task %s on %s error %v", task.Name, st.Worker, errors.ErrorStack(err))
+				}
+			}
+		}
+	}
+
 	if len(taskWorkers) == 0 {
 		return // keep the old
 	}
-	// simple replace, maybe we can do more accurate update later
 	s.replaceTaskWorkers(taskWorkers)
 	log.Infof("[server] update task workers to %v", taskWorkers)
 }
 
+// recoverSubTask re-starts a sub task on dm-worker with its meta
+func (s *Server) recoverSubTask(ctx context.Context, task string, st *SubTaskMeta) error {
+	cli, ok := s.workerClient(st.Worker)
+	if !ok {
+		return errors.NotFoundf("%s relevant worker-client", st.Worker)
+	}
+
+	workerResp, err := cli.StartSubTask(ctx, &pb.StartSubTaskRequest{Task: st.Config})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !workerResp.Result {
+		return errors.Errorf("start sub task fail %s", workerResp.Msg)
+	}
+
+	if st.Stage == pb.Stage_Paused.String() {
+		return errors.Trace(s.operateSubTask(ctx, task, st.Worker, pb.TaskOp_Pause))
+	}
+	return nil
+}
+
+// operateSubTask does an operation on a sub task of dm-worker
+func (s *Server) operateSubTask(ctx context.Context, task string, worker string, op pb.TaskOp) error {
+	cli, ok := s.workerClient(worker)
+	if !ok {
+		return errors.NotFoundf("%s relevant worker-client", worker)
+	}
+
+	workerResp, err := cli.OperateSubTask(ctx, &pb.OperateSubTaskRequest{Op: op, Name: task})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !workerResp.Result {
+		return errors.Errorf("%s sub task fail %s", op.String(), workerResp.Msg)
+	}
+	return nil
+}
+
 // fetchTaskWorkers fetches task-workers mapper from workers based on deployment
 func (s *Server) fetchTaskWorkers(ctx context.Context) (map[string][]string, map[string]string) {
-	workers := make([]string, 0, len(s.workerClients))
-	for worker := range s.workerClients {
+	clients := s.workerClientsSnapshot()
+	workers := make([]string, 0, len(clients))
+	for worker := range clients {
 		workers = append(workers, worker)
 	}
 
@@ -1174,116 +1427,255 @@ func (s *Server) checkTaskAndWorkerMatch(taskname string, targetWorker string) b
 // fetchWorkerDDLInfo fetches DDL info from all dm-workers
 // and sends DDL lock info back to dm-workers
 func (s *Server) fetchWorkerDDLInfo(ctx context.Context) {
-	var wg sync.WaitGroup
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fetching = make(map[string]*fetchingWorker) // dm-worker -> its fetching goroutine
+	)
 
-	for worker, cli := range s.workerClients {
-		wg.Add(1)
-		go func(worker string, cli pb.WorkerClient) {
-			defer wg.Done()
-			var doRetry bool
+	for {
+		// dm-workers may be added by registering or updating config, check them at intervals
+		s.Lock()
+		clients := make(map[string]pb.WorkerClient, len(s.workerClients))
+		for worker, cli := range s.workerClients {
+			clients[worker] = cli
+		}
+		s.Unlock()
 
-			for {
-				if doRetry {
-					select {
-					case <-ctx.Done():
-						return
-					case <-time.After(retryTimeout):
+		mu.Lock()
+		// stop fetching from dm-workers removed (like unregistered or rescheduled) or offline
+		for worker, fw := range fetching {
+			if cli, ok := clients[worker]; !ok || cli != fw.cli || s.isWorkerOffline(worker) {
+				log.Infof("[server] stop fetching DDL info from worker %s", worker)
+				fw.cancel()
+				delete(fetching, worker)
+			}
+		}
+		for worker, cli := range clients {
+			if _, ok := fetching[worker]; ok || s.isWorkerOffline(worker) {
+				continue
+			}
+			workerCtx, cancel := context.WithCancel(ctx)
+			fw := &fetchingWorker{cli: cli, cancel: cancel}
+			fetching[worker] = fw
+			wg.Add(1)
+			go func(worker string, fw *fetchingWorker) {
+				defer func() {
+					mu.Lock()
+					if fetching[worker] == fw {
+						delete(fetching, worker)
 					}
-				}
-				doRetry = false // reset
+					mu.Unlock()
+					fw.cancel()
+					wg.Done()
+				}()
+				s.fetchDDLInfoFromWorker(ctx, workerCtx, &wg, worker, fw.cli)
+			}(worker, fw)
+		}
+		mu.Unlock()
 
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-time.After(retryTimeout):
+		}
+	}
+}
+
+// fetchingWorker is a dm-worker which DDL info is fetching from
+type fetchingWorker struct {
+	cli    pb.WorkerClient
+	cancel context.CancelFunc
+}
+
+// isWorkerOffline returns whether the dm-worker is registered and offline now
+func (s *Server) isWorkerOffline(worker string) bool {
+	w := s.registry.Worker(worker)
+	return w != nil && w.Status == workerOffline
+}
+
+// fetchDDLInfoFromWorker fetches DDL info from a dm-worker to sync sharding DDL, returns when workerCtx canceled.
+// resolving synced DDL locks are added to wg, and canceled only when ctx canceled
+func (s *Server) fetchDDLInfoFromWorker(ctx, workerCtx context.Context, wg *sync.WaitGroup, worker string, cli pb.WorkerClient) {
+	var doRetry bool
+
+	for {
+		if doRetry {
+			select {
+			case <-workerCtx.Done():
+				return
+			case <-time.After(retryTimeout):
+			}
+		}
+		doRetry = false // reset
+
+		select {
+		case <-workerCtx.Done():
+			return
+		default:
+			stream, err := cli.FetchDDLInfo(workerCtx)
+			if err != nil {
+				log.Errorf("[server] create FetchDDLInfo stream for worker %s fail %v", worker, err)
+				doRetry = true
+				continue
+			}
+			for {
+				in, err := stream.Recv()
+				if err == io.EOF {
+					doRetry = true
+					break
+				}
 				select {
-				case <-ctx.Done():
+				case <-workerCtx.Done(): // check whether canceled again
 					return
 				default:
-					stream, err := cli.FetchDDLInfo(ctx)
-					if err != nil {
-						log.Errorf("[server] create FetchDDLInfo stream for worker %s fail %v", worker, err)
-						doRetry = true
-						continue
-					}
-					for {
-						in, err := stream.Recv()
-						if err == io.EOF {
-							doRetry = true
-							break
-						}
-						select {
-						case <-ctx.Done(): // check whether canceled again
-							return
-						default:
-						}
-						if err != nil {
-							log.Errorf("[server] receive DDLInfo from worker %s fail %v", worker, err)
-							doRetry = true
-							break
-						}
-						log.Infof("[server] receive DDLInfo %v from worker %s", in, worker)
+				}
+				if err != nil {
+					log.Errorf("[server] receive DDLInfo from worker %s fail %v", worker, err)
+					doRetry = true
+					break
+				}
+				log.Infof("[server] receive DDLInfo %v from worker %s", in, worker)
 
-						workers := s.getTaskWorkers(in.Task)
-						if len(workers) == 0 {
-							// should happen only when starting and before updateTaskWorkers return
-							log.Errorf("[server] try to sync sharding DDL for task %s, but with no workers", in.Task)
-							doRetry = true
-							break
+				if !in.Optimistic {
+					lockID, skipped, changed := s.lockKeeper.TrySkip(in.Task, in.Schema, in.Table, worker, in.DDLs)
+					if changed {
+						if err = s.saveSkippedShards(in.Task, lockID); err != nil {
+							log.Errorf("[server] save skipped workers of DDL lock %s into etcd error %v", lockID, errors.ErrorStack(err))
 						}
-						if !s.containWorker(workers, worker) {
-							// should not happen
-							log.Errorf("[server] try to sync sharding DDL for task %s, but worker %s not in workers %v", in.Task, worker, workers)
-							doRetry = true
-							break
-						}
-
-						lockID, synced, remain, err := s.lockKeeper.TrySync(in.Task, in.Schema, in.Table, worker, in.DDLs, workers)
-						if err != nil {
-							log.Errorf("[server] try to sync lock for worker %s fail %v", worker, err)
-							doRetry = true
-							break
-						}
-
+					}
+					if skipped {
+						// the DDL lock has been resolved without the dm-worker by timeout policies, see lock_timeout.go
+						log.Warnf("[server] DDL lock %s of DDLInfo %v has been resolved without worker %s, request it to skip the DDL", lockID, in, worker)
 						out := &pb.DDLLockInfo{
 							Task: in.Task,
 							ID:   lockID,
 						}
-						err = stream.Send(out)
-						if err != nil {
+						if err = stream.Send(out); err != nil {
 							log.Errorf("[server] send DDLLockInfo %v to worker %s fail %v", out, worker, err)
 							doRetry = true
 							break
 						}
+						wg.Add(1)
+						go func(in *pb.DDLInfo, lockID string) {
+							defer wg.Done()
+							s.skipDDL(ctx, cli, worker, in, lockID)
+						}(in, lockID)
+						continue
+					}
+				}
 
-						if !synced {
-							// still need wait other workers to sync
-							log.Infof("[server] sharding DDL %s in syncing, waiting %v workers to sync", lockID, remain)
-							continue
-						}
+				if len(in.LockID) > 0 && s.lockKeeper.FindLock(in.LockID) == nil {
+					// DDL locks are persisted, so the DDL lock has been resolved or removed,
+					// don't create it again, but fail the dm-worker with an error, then user can use dmctl to handle it
+					log.Warnf("[server] DDL lock %s of DDLInfo %v re-sent by worker %s not exists, maybe it has been resolved or removed", in.LockID, in, worker)
+					out := &pb.DDLLockInfo{
+						Task: in.Task,
+						ID:   in.LockID,
+					}
+					if err = stream.Send(out); err != nil {
+						log.Errorf("[server] send DDLLockInfo %v to worker %s fail %v", out, worker, err)
+						doRetry = true
+						break
+					}
+					wg.Add(1)
+					go func(task, lockID string) {
+						defer wg.Done()
+						s.failDDL(ctx, cli, worker, task, lockID, "DDL lock not exists in dm-master, maybe it has been resolved or removed, please use `break-ddl-lock` to handle it")
+					}(in.Task, in.LockID)
+					continue
+				}
 
-						log.Infof("[server] sharding DDL %s synced", lockID)
+				workers := s.getTaskWorkers(in.Task)
+				if len(workers) == 0 {
+					// should happen only when starting and before recoverTasks return
+					log.Errorf("[server] try to sync sharding DDL for task %s, but with no workers", in.Task)
+					doRetry = true
+					break
+				}
+				if !s.containWorker(workers, worker) {
+					// should not happen
+					log.Errorf("[server] try to sync sharding DDL for task %s, but worker %s not in workers %v", in.Task, worker, workers)
+					doRetry = true
+					break
+				}
 
-						// resolve DDL lock
-						wg.Add(1)
-						go func(lockID string) {
-							defer wg.Done()
-							resps, err := s.resolveDDLLock(ctx, lockID, "", nil)
-							if err == nil {
-								log.Infof("[server] resolve DDL lock %s successfully, remove it", lockID)
-							} else {
-								log.Errorf("[server] resolve DDL lock %s fail %v, responses is:\n%+v", lockID, errors.ErrorStack(err), resps)
-								lock := s.lockKeeper.FindLock(lockID)
-								if lock != nil {
-									lock.AutoRetry.Set(true) // need auto-retry resolve at intervals
-								}
-							}
-						}(lockID)
+				var (
+					lockID string
+					synced bool
+					remain int
+				)
+				if in.Optimistic {
+					lockID, synced, err = s.lockKeeper.TrySyncOptimistic(in, worker, workers)
+					if !synced {
+						remain = 1
 					}
-					stream.CloseSend()
+				} else {
+					lockID, synced, remain, err = s.lockKeeper.TrySync(in.Task, in.Schema, in.Table, worker, in.DDLs, workers)
+				}
+				if err != nil {
+					log.Errorf("[server] try to sync lock for worker %s fail %v", worker, err)
+					doRetry = true
+					break
+				}
+				if in.Optimistic {
+					groupID := genDDLLockID(in.Task, in.Schema, in.Table)
+					if err = s.saveOptimisticGroup(groupID); err != nil {
+						log.Errorf("[server] save optimistic group %s into etcd error %v", groupID, errors.ErrorStack(err))
+					}
+				}
+				if err = s.saveLock(lockID); err != nil {
+					log.Errorf("[server] save DDL lock %s into etcd error %v", lockID, errors.ErrorStack(err))
 				}
-			}
 
-		}(worker, cli)
-	}
+				out := &pb.DDLLockInfo{
+					Task: in.Task,
+					ID:   lockID,
+				}
+				err = stream.Send(out)
+				if err != nil {
+					log.Errorf("[server] send DDLLockInfo %v to worker %s fail %v", out, worker, err)
+					doRetry = true
+					break
+				}
 
-	wg.Wait()
+				if !synced {
+					if lock := s.lockKeeper.FindLock(lockID); lock != nil && len(lock.Conflict) > 0 {
+						log.Errorf("[server] sharding DDL %s conflicts with the joined schema: %s, please handle it manually", lockID, lock.Conflict)
+						continue
+					}
+					// still need wait other workers to sync
+					log.Infof("[server] sharding DDL %s in syncing, waiting %v workers to sync", lockID, remain)
+					continue
+				}
+
+				log.Infof("[server] sharding DDL %s synced", lockID)
+				if lock := s.lockKeeper.FindLock(lockID); lock != nil && lock.Resolving.Get() {
+					// DDL info re-sent when resolving
+					log.Infof("[server] sharding DDL %s is resolving", lockID)
+					continue
+				}
+
+				// resolve DDL lock
+				wg.Add(1)
+				go func(lockID string) {
+					defer wg.Done()
+					resps, err := s.resolveDDLLock(ctx, lockID, "", nil)
+					if err == nil {
+						log.Infof("[server] resolve DDL lock %s successfully, remove it", lockID)
+					} else {
+						log.Errorf("[server] resolve DDL lock %s fail %v, responses is:\n%+v", lockID, errors.ErrorStack(err), resps)
+						lock := s.lockKeeper.FindLock(lockID)
+						if lock != nil {
+							lock.AutoRetry.Set(true) // need auto-retry resolve at intervals
+						}
+					}
+				}(lockID)
+			}
+			stream.CloseSend()
+		}
+	}
 }
 
 // resolveDDLLock resolves DDL lock
@@ -1309,7 +1701,7 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 	if len(replaceOwner) > 0 {
 		owner = replaceOwner
 	}
-	cli, ok := s.workerClients[owner]
+	cli, ok := s.workerClient(owner)
 	if !ok {
 		return nil, errors.NotFoundf("worker %s relevant worker-client", owner)
 	}
@@ -1342,17 +1734,28 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 	// TODO: we need a better way to combine brain split tracing events into one
 	// single group.
 	traceGID := s.idGen.NextID("resolveDDLLock", 0)
-	log.Infof("[server] requesting %s to execute DDL (with ID %s)", owner, lockID)
-	ownerResp, err := cli.ExecuteDDL(ctx, &pb.ExecDDLRequest{
-		Task:     lock.Task,
-		LockID:   lockID,
-		Exec:     true,
-		TraceGID: traceGID,
-	})
-	if err != nil {
-		ownerResp = &pb.CommonWorkerResponse{
-			Result: false,
-			Msg:    errors.ErrorStack(err),
+	var (
+		ownerResp *pb.CommonWorkerResponse
+		err       error
+	)
+	if lock.IsDone(owner) {
+		// the owner executed the DDL before dm-master restarted
+		log.Infof("[server] %s has executed DDL (with ID %s), skip requesting it", owner, lockID)
+		ownerResp = &pb.CommonWorkerResponse{Result: true}
+	} else {
+		log.Infof("[server] requesting %s to execute DDL (with ID %s, skip %v)", owner, lockID, lock.Skip)
+		ownerResp, err = cli.ExecuteDDL(ctx, &pb.ExecDDLRequest{
+			Task:     lock.Task,
+			LockID:   lockID,
+			Exec:     !lock.Skip, // the DDL not changing the joined schema is skipped in optimistic shard mode
+			TraceGID: traceGID,
+			DDLs:     lock.ExecDDLs,
+		})
+		if err != nil {
+			ownerResp = &pb.CommonWorkerResponse{
+				Result: false,
+				Msg:    errors.ErrorStack(err),
+			}
 		}
 	}
 	ownerResp.Worker = owner
@@ -1362,6 +1765,13 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 			ownerResp,
 		}, errors.Errorf("owner %s ExecuteDDL fail", owner)
 	}
+	if !lock.IsDone(owner) {
+		// persist the progress, so the owner is not requested to execute the DDL again after dm-master restarted
+		lock.MarkDone(owner)
+		if err2 := s.saveLock(lockID); err2 != nil {
+			log.Errorf("[server] save DDL lock %s into etcd error %v", lockID, errors.ErrorStack(err2))
+		}
+	}
 
 	// request other dm-workers to ignore DDL
 	workers := make([]string, 0, len(ready))
@@ -1381,7 +1791,10 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 	}
 
 	workerRespCh := make(chan *pb.CommonWorkerResponse, len(workers))
-	var wg sync.WaitGroup
+	var (
+		wg     sync.WaitGroup
+		doneMu sync.Mutex // serialize persisting the progress, so the latest progress is persisted at last
+	)
 	for _, worker := range workers {
 		if worker == owner {
 			continue // owner has executed DDL
@@ -1390,7 +1803,7 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 		wg.Add(1)
 		go func(worker string) {
 			defer wg.Done()
-			cli, ok := s.workerClients[worker]
+			cli, ok := s.workerClient(worker)
 			if !ok {
 				workerRespCh <- &pb.CommonWorkerResponse{
 					Result: false,
@@ -1408,6 +1821,13 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 				return
 			}
 
+			if lock.IsDone(worker) {
+				// the dm-worker skipped the DDL before dm-master restarted
+				log.Infof("[server] %s has skipped DDL (with ID %s), skip requesting it", worker, lockID)
+				workerRespCh <- &pb.CommonWorkerResponse{Result: true, Worker: worker}
+				return
+			}
+
 			log.Infof("[server] requesting %s to skip DDL (with ID %s)", worker, lockID)
 			workerResp, err2 := cli.ExecuteDDL(ctx, req)
 			if err2 != nil {
@@ -1415,6 +1835,14 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 					Result: false,
 					Msg:    errors.ErrorStack(err2),
 				}
+			} else if workerResp.Result {
+				// persist the progress, so the dm-worker is not requested to skip the DDL again after dm-master restarted
+				doneMu.Lock()
+				lock.MarkDone(worker)
+				if err3 := s.saveLock(lockID); err3 != nil {
+					log.Errorf("[server] save DDL lock %s into etcd error %v", lockID, errors.ErrorStack(err3))
+				}
+				doneMu.Unlock()
 			}
 			workerResp.Worker = worker
 			workerRespCh <- workerResp
@@ -1445,6 +1873,9 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 	// owner has ExecuteDDL successfully, we remove the Lock
 	// if some dm-workers ExecuteDDL occurred error, we should use dmctl to handle dm-worker directly
 	s.lockKeeper.RemoveLock(lockID)
+	if err2 := s.deleteLock(lockID); err2 != nil {
+		log.Errorf("[server] delete DDL lock %s from etcd error %v", lockID, errors.ErrorStack(err2))
+	}
 
 	if !success {
 		err = errors.Errorf("DDL lock %s owner ExecuteDDL successfully, so DDL lock removed. but some dm-workers ExecuteDDL fail, you should to handle dm-worker directly", lockID)
@@ -1455,6 +1886,7 @@ func (s *Server) resolveDDLLock(ctx context.Context, lockID string, replaceOwner
 // UpdateMasterConfig implements MasterServer.UpdateConfig
 func (s *Server) UpdateMasterConfig(ctx context.Context, req *pb.UpdateMasterConfigRequest) (*pb.UpdateMasterConfigResponse, error) {
 	log.Infof("[server] receive UpdateMasterConfig request %+v", req)
+
 	s.Lock()
 
 	err := s.cfg.UpdateConfigFile(req.Config)
@@ -1479,6 +1911,13 @@ func (s *Server) UpdateMasterConfig(ctx context.Context, req *pb.UpdateMasterCon
 	}
 	log.Infof("[server] updating dm-master config file with config:\n%+v", cfg)
 
+	// keep registered dm-workers which are not in config file
+	for source, workerAddr := range s.cfg.DeployMap {
+		if _, ok := cfg.DeployMap[source]; !ok && s.registry.Worker(workerAddr) != nil {
+			cfg.DeployMap[source] = workerAddr
+		}
+	}
+
 	// delete worker
 	wokerList := make([]string, 0, len(s.cfg.DeployMap))
 	for k, workerAddr := range s.cfg.DeployMap {
@@ -1525,6 +1964,16 @@ func (s *Server) UpdateMasterConfig(ctx context.Context, req *pb.UpdateMasterCon
 		}
 	}
 
+	// save deploy map for other dm-masters in the group
+	err = s.saveDeployMap(ctx, cfg.DeployMap)
+	if err != nil {
+		s.Unlock()
+		return &pb.UpdateMasterConfigResponse{
+			Result: false,
+			Msg:    "Failed to save deploy map. detail: " + errors.ErrorStack(err),
+		}, nil
+	}
+
 	// update log configure
 	log.SetLevelByString(strings.ToLower(cfg.LogLevel))
 	if len(cfg.LogFile) > 0 {
@@ -1535,8 +1984,9 @@ func (s *Server) UpdateMasterConfig(ctx context.Context, req *pb.UpdateMasterCon
 	log.Infof("[server] update dm-master config file success")
 	s.Unlock()
 
-	workers := make([]string, 0, len(s.workerClients))
-	for worker := range s.workerClients {
+	clients := s.workerClientsSnapshot()
+	workers := make([]string, 0, len(clients))
+	for worker := range clients {
 		workers = append(workers, worker)
 	}
 
@@ -1587,7 +2037,7 @@ func (s *Server) tryResolveDDLLocks(ctx context.Context) {
 func (s *Server) UpdateWorkerRelayConfig(ctx context.Context, req *pb.UpdateWorkerRelayConfigRequest) (*pb.CommonWorkerResponse, error) {
 	worker := req.Worker
 	content := req.Config
-	cli, ok := s.workerClients[worker]
+	cli, ok := s.workerClient(worker)
 	if !ok {
 		return &pb.CommonWorkerResponse{
 			Result: false,
@@ -1611,7 +2061,8 @@ func (s *Server) allWorkerConfigs(ctx context.Context) (map[string]config.DBConf
 		wg          sync.WaitGroup
 		workerMutex sync.Mutex
 		workerCfgs  = make(map[string]config.DBConfig)
-		errCh       = make(chan error, len(s.workerClients))
+		clients     = s.workerClientsSnapshot()
+		errCh       = make(chan error, len(clients))
 		err         error
 	)
 	handErr := func(err2 error) {
@@ -1621,7 +2072,7 @@ func (s *Server) allWorkerConfigs(ctx context.Context) (map[string]config.DBConf
 		errCh <- errors.Trace(err2)
 	}
 
-	for id, worker := range s.workerClients {
+	for id, worker := range clients {
 		wg.Add(1)
 		go Emit(func(args ...interface{}) {
 			defer wg.Done()
@@ -1691,7 +2142,7 @@ func (s *Server) MigrateWorkerRelay(ctx context.Context, req *pb.MigrateWorkerRe
 	worker := req.Worker
 	binlogPos := req.BinlogPos
 	binlogName := req.BinlogName
-	cli, ok := s.workerClients[worker]
+	cli, ok := s.workerClient(worker)
 	if !ok {
 		return &pb.CommonWorkerResponse{
 			Result: false,
@@ -1752,3 +2203,84 @@ func (s *Server) generateSubTask(ctx context.Context, task string) (*config.Task
 
 	return cfg, stCfgs, nil
 }
+
+// RegisterWorker implements MasterServer.RegisterWorker
+func (s *Server) RegisterWorker(ctx context.Context, req *pb.RegisterWorkerRequest) (*pb.RegisterWorkerResponse, error) {
+	log.Infof("[server] receive RegisterWorker request %+v", req)
+
+	err := s.registry.Register(req)
+	if err != nil {
+		return &pb.RegisterWorkerResponse{
+			Result: false,
+			Msg:    errors.ErrorStack(err),
+		}, nil
+	}
+
+	err = s.saveWorker(ctx, s.registry.Worker(req.Address))
+	if err != nil {
+		log.Errorf("[server] save dm-worker %s error %v", req.Address, errors.ErrorStack(err))
+		return &pb.RegisterWorkerResponse{
+			Result: false,
+			Msg:    errors.ErrorStack(err),
+		}, nil
+	}
+
+	err = s.bindWorker(ctx, req.SourceID, req.Address)
+	if err != nil {
+		log.Errorf("[server] bind dm-worker %s to source %s error %v", req.Address, req.SourceID, errors.ErrorStack(err))
+		return &pb.RegisterWorkerResponse{
+			Result: false,
+			Msg:    errors.ErrorStack(err),
+		}, nil
+	}
+
+	return &pb.RegisterWorkerResponse{
+		Result:            true,
+		HeartbeatInterval: s.cfg.WorkerHeartbeatInterval,
+	}, nil
+}
+
+// Heartbeat implements MasterServer.Heartbeat
+func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	return &pb.HeartbeatResponse{
+		Result:     true,
+		Registered: s.registry.Heartbeat(req.Address, req.SourceID),
+	}, nil
+}
+
+// ListMember implements MasterServer.ListMember
+func (s *Server) ListMember(ctx context.Context, req *pb.ListMemberRequest) (*pb.ListMemberResponse, error) {
+	log.Infof("[server] receive ListMember request %+v", req)
+
+	masters, err := s.listMasters(ctx)
+	if err != nil {
+		return &pb.ListMemberResponse{
+			Result: false,
+			Msg:    errors.ErrorStack(err),
+		}, nil
+	}
+
+	return &pb.ListMemberResponse{
+		Result:  true,
+		Masters: masters,
+		Workers: s.listWorkers(),
+	}, nil
+}
+
+// ShowReschedule implements MasterServer.ShowReschedule
+func (s *Server) ShowReschedule(ctx context.Context, req *pb.ShowRescheduleRequest) (*pb.ShowRescheduleResponse, error) {
+	log.Infof("[server] receive ShowReschedule request %+v", req)
+
+	records, err := s.loadRescheduleRecords(ctx, req.Source)
+	if err != nil {
+		return &pb.ShowRescheduleResponse{
+			Result: false,
+			Msg:    errors.ErrorStack(err),
+		}, nil
+	}
+
+	return &pb.ShowRescheduleResponse{
+		Result:  true,
+		Records: records,
+	}, nil
+}
@@ -14,9 +14,16 @@
 package master
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/dm/dm/pb"
 )
 
 func TestMaster(t *testing.T) {
@@ -27,3 +34,182 @@ type testMaster struct {
 }
 
 var _ = Suite(&testMaster{})
+
+// mockWorkerClient is a dm-worker client used in tests, only RPCs used by tests are implemented
+type mockWorkerClient struct {
+	pb.WorkerClient
+
+	sync.Mutex
+	execDDLReqs []*pb.ExecDDLRequest
+	onExecDDL   func(req *pb.ExecDDLRequest)
+
+	onFetchDDLInfo func(ctx context.Context)
+
+	migrateRelayReqs []*pb.MigrateRelayRequest
+	startSubTaskReqs []*pb.StartSubTaskRequest
+	startSubTaskMsg  string // start sub task fail with the msg if not empty
+
+	status    *pb.QueryStatusResponse // returned by QueryStatus if not nil
+	leaveReqs []*pb.LeaveShardingGroupRequest
+}
+
+func (m *mockWorkerClient) LeaveShardingGroup(ctx context.Context, in *pb.LeaveShardingGroupRequest, opts ...grpc.CallOption) (*pb.CommonWorkerResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.leaveReqs = append(m.leaveReqs, in)
+	return &pb.CommonWorkerResponse{Result: true}, nil
+}
+
+func (m *mockWorkerClient) ExecuteDDL(ctx context.Context, in *pb.ExecDDLRequest, opts ...grpc.CallOption) (*pb.CommonWorkerResponse, error) {
+	if m.onExecDDL != nil {
+		m.onExecDDL(in)
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.execDDLReqs = append(m.execDDLReqs, in)
+	return &pb.CommonWorkerResponse{Result: true}, nil
+}
+
+func (m *mockWorkerClient) FetchDDLInfo(ctx context.Context, opts ...grpc.CallOption) (pb.Worker_FetchDDLInfoClient, error) {
+	if m.onFetchDDLInfo != nil {
+		m.onFetchDDLInfo(ctx)
+	}
+	return nil, errors.NotSupportedf("FetchDDLInfo")
+}
+
+func (m *mockWorkerClient) QueryStatus(ctx context.Context, in *pb.QueryStatusRequest, opts ...grpc.CallOption) (*pb.QueryStatusResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.status == nil {
+		return &pb.QueryStatusResponse{Result: true}, nil
+	}
+	resp := *m.status
+	return &resp, nil
+}
+
+func (m *mockWorkerClient) execDDLs() []*pb.ExecDDLRequest {
+	m.Lock()
+	defer m.Unlock()
+	return m.execDDLReqs
+}
+
+func (m *mockWorkerClient) MigrateRelay(ctx context.Context, in *pb.MigrateRelayRequest, opts ...grpc.CallOption) (*pb.CommonWorkerResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.migrateRelayReqs = append(m.migrateRelayReqs, in)
+	return &pb.CommonWorkerResponse{Result: true}, nil
+}
+
+func (m *mockWorkerClient) OperateRelay(ctx context.Context, in *pb.OperateRelayRequest, opts ...grpc.CallOption) (*pb.OperateRelayResponse, error) {
+	return &pb.OperateRelayResponse{Result: true}, nil
+}
+
+func (m *mockWorkerClient) StartSubTask(ctx context.Context, in *pb.StartSubTaskRequest, opts ...grpc.CallOption) (*pb.CommonWorkerResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.startSubTaskReqs = append(m.startSubTaskReqs, in)
+	return &pb.CommonWorkerResponse{Result: len(m.startSubTaskMsg) == 0, Msg: m.startSubTaskMsg}, nil
+}
+
+func (m *mockWorkerClient) OperateSubTask(ctx context.Context, in *pb.OperateSubTaskRequest, opts ...grpc.CallOption) (*pb.OperateSubTaskResponse, error) {
+	return &pb.OperateSubTaskResponse{Result: true, Op: in.Op}, nil
+}
+
+func (t *testMaster) TestFetchWorkerDDLInfo(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	s := NewServer(cfg)
+
+	fetched := make(chan context.Context, 10)
+	s.Lock()
+	for _, worker := range []string{"worker-1", "worker-2"} {
+		s.workerClients[worker] = &mockWorkerClient{onFetchDDLInfo: func(ctx context.Context) {
+			fetched <- ctx
+		}}
+	}
+	s.Unlock()
+	c.Assert(s.registry.Register(&pb.RegisterWorkerRequest{Address: "worker-2", SourceID: "source-2"}), IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.fetchWorkerDDLInfo(ctx)
+		close(done)
+	}()
+	fetchCtxs := []context.Context{<-fetched, <-fetched}
+
+	// fetching stops after the dm-worker removed or offline
+	s.Lock()
+	delete(s.workerClients, "worker-1")
+	s.Unlock()
+	c.Assert(s.registry.CheckOffline(time.Now().Add(time.Hour)), DeepEquals, []string{"worker-2"})
+	for _, fetchCtx := range fetchCtxs {
+		select {
+		case <-fetchCtx.Done():
+		case <-time.After(2 * retryTimeout):
+			c.Fatal("fetching DDL info from removed or offline dm-worker not stopped")
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+func (t *testMaster) TestResolveDDLLockProgress(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	s := NewServer(cfg)
+	go s.Start()
+	defer s.Close()
+	waitLeader(c, []*Server{s})
+
+	var (
+		workers = []string{"worker-1", "worker-2", "worker-3", "worker-4"}
+		ddls    = []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c1` INT"}
+		clients = make(map[string]*mockWorkerClient, len(workers))
+		lockID  string
+	)
+	s.Lock()
+	for _, worker := range workers {
+		clients[worker] = &mockWorkerClient{}
+		s.workerClients[worker] = clients[worker]
+	}
+	s.Unlock()
+	for _, worker := range workers {
+		var err error
+		lockID, _, _, err = s.lockKeeper.TrySync("task", "db", "tb", worker, ddls, workers)
+		c.Assert(err, IsNil)
+	}
+
+	// worker-2 skipped the DDL before dm-master restarted
+	s.lockKeeper.FindLock(lockID).MarkDone(workers[1])
+	// worker-3 and worker-4 are requested concurrently, worker-4 waits for the progress of worker-3 persisted
+	persisted := false
+	clients[workers[3]].onExecDDL = func(req *pb.ExecDDLRequest) {
+		for i := 0; i < 50 && !persisted; i++ {
+			locks, err := s.loadLocks(context.Background())
+			if err == nil && len(locks) == 1 {
+				for _, worker := range locks[0].Done {
+					persisted = persisted || worker == workers[2]
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	resps, err := s.resolveDDLLock(context.Background(), lockID, "", nil)
+	c.Assert(err, IsNil)
+	c.Assert(resps, HasLen, len(workers))
+	for _, resp := range resps {
+		c.Assert(resp.Result, IsTrue)
+	}
+	c.Assert(persisted, IsTrue)
+
+	// the owner executes the DDL, worker-2 is not requested again
+	c.Assert(clients[workers[0]].execDDLs(), HasLen, 1)
+	c.Assert(clients[workers[0]].execDDLs()[0].Exec, IsTrue)
+	c.Assert(clients[workers[1]].execDDLs(), HasLen, 0)
+	for _, worker := range workers[2:] {
+		c.Assert(clients[worker].execDDLs(), HasLen, 1)
+		c.Assert(clients[worker].execDDLs()[0].Exec, IsFalse)
+	}
+	c.Assert(s.lockKeeper.FindLock(lockID), IsNil)
+}
@@ -18,11 +18,30 @@ import (
 	"net/http"
 
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/pingcap/dm/dm/common"
 	"github.com/pingcap/dm/pkg/utils"
 )
 
+var (
+	ddlLockTimeoutCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "master",
+			Name:      "ddl_lock_timeout_total",
+			Help:      "total number of sharding DDL locks not synced in shard-lock-timeout, by the timeout policy",
+		}, []string{"task", "policy"})
+
+	idleShardRemovedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "master",
+			Name:      "idle_shard_removed_total",
+			Help:      "total number of idle source tables removed from sharding DDL locks after shard-idle-timeout",
+		}, []string{"task", "worker", "source"})
+)
+
 type statusHandler struct {
 }
 
@@ -37,8 +56,18 @@ func (h *statusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 // InitStatus initializes the HTTP status server
 func InitStatus(lis net.Listener) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	registry.MustRegister(prometheus.NewGoCollector())
+
+	registry.MustRegister(ddlLockTimeoutCounter)
+	registry.MustRegister(idleShardRemovedCounter)
+	prometheus.DefaultGatherer = registry
+
 	mux := http.NewServeMux()
 	mux.Handle("/status", &statusHandler{})
+	mux.Handle("/metrics", prometheus.Handler())
+
 	httpS := &http.Server{
 		Handler: mux,
 	}
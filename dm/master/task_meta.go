@@ -0,0 +1,257 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+// SubTaskMeta represents persistent information of a sub task dispatched to a dm-worker
+type SubTaskMeta struct {
+	Worker   string `json:"dm-worker"`
+	SourceID string `json:"source-id"`
+	Config   string `json:"config"` // sub task config in TOML format
+	Stage    string `json:"stage"`  // requested stage, Running or Paused
+}
+
+// TaskMeta represents persistent information of a task started by dm-master
+type TaskMeta struct {
+	Name     string         `json:"name"`
+	Task     string         `json:"task"` // task config in YAML format
+	SubTasks []*SubTaskMeta `json:"sub-task"`
+}
+
+// Workers returns dm-workers of all sub tasks, sorted
+func (t *TaskMeta) Workers() []string {
+	workers := make([]string, 0, len(t.SubTasks))
+	for _, st := range t.SubTasks {
+		workers = append(workers, st.Worker)
+	}
+	sort.Strings(workers)
+	return workers
+}
+
+// SubTask returns sub task meta dispatched to the dm-worker, nil if not found
+func (t *TaskMeta) SubTask(worker string) *SubTaskMeta {
+	for _, st := range t.SubTasks {
+		if st.Worker == worker {
+			return st
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of the task meta
+func (t *TaskMeta) clone() *TaskMeta {
+	clone := &TaskMeta{
+		Name:     t.Name,
+		Task:     t.Task,
+		SubTasks: make([]*SubTaskMeta, 0, len(t.SubTasks)),
+	}
+	for _, st := range t.SubTasks {
+		stClone := *st
+		clone.SubTasks = append(clone.SubTasks, &stClone)
+	}
+	return clone
+}
+
+// TaskMetaStore keeps meta of started tasks in etcd,
+// so the leader of dm-master group can recover tasks' information after failover
+type TaskMetaStore struct {
+	sync.RWMutex
+	cli   *clientv3.Client
+	tasks map[string]*TaskMeta
+}
+
+// NewTaskMetaStore creates a new TaskMetaStore
+func NewTaskMetaStore(cli *clientv3.Client) *TaskMetaStore {
+	return &TaskMetaStore{
+		cli:   cli,
+		tasks: make(map[string]*TaskMeta),
+	}
+}
+
+// Load loads task meta from etcd
+func (s *TaskMetaStore) Load() error {
+	s.Lock()
+	defer s.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+	resp, err := s.cli.Get(ctx, taskKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tasks := make(map[string]*TaskMeta, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		task := &TaskMeta{}
+		err = json.Unmarshal(kv.Value, task)
+		if err != nil {
+			return errors.Annotatef(err, "decode task meta %s", kv.Key)
+		}
+		tasks[task.Name] = task
+	}
+	s.tasks = tasks
+	return nil
+}
+
+// Get returns a copy of the task meta, nil if not found
+func (s *TaskMetaStore) Get(name string) *TaskMeta {
+	s.RLock()
+	defer s.RUnlock()
+
+	task, ok := s.tasks[name]
+	if !ok {
+		return nil
+	}
+	return task.clone()
+}
+
+// All returns copies of all task metas, sorted by task name
+func (s *TaskMetaStore) All() []*TaskMeta {
+	s.RLock()
+	defer s.RUnlock()
+
+	names := make([]string, 0, len(s.tasks))
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tasks := make([]*TaskMeta, 0, len(names))
+	for _, name := range names {
+		tasks = append(tasks, s.tasks[name].clone())
+	}
+	return tasks
+}
+
+// Set saves task config and sub tasks for a task.
+// if replace is false, sub tasks are merged with old sub tasks, keyed by dm-worker
+func (s *TaskMetaStore) Set(name, task string, subTasks []*SubTaskMeta, replace bool) error {
+	s.Lock()
+	defer s.Unlock()
+
+	meta := &TaskMeta{
+		Name:     name,
+		Task:     task,
+		SubTasks: make([]*SubTaskMeta, 0, len(subTasks)),
+	}
+	exist := make(map[string]struct{}, len(subTasks))
+	for _, st := range subTasks {
+		stClone := *st
+		meta.SubTasks = append(meta.SubTasks, &stClone)
+		exist[st.Worker] = struct{}{}
+	}
+	if old, ok := s.tasks[name]; ok && !replace {
+		for _, st := range old.SubTasks {
+			if _, ok2 := exist[st.Worker]; !ok2 {
+				meta.SubTasks = append(meta.SubTasks, st)
+			}
+		}
+	}
+	if len(meta.SubTasks) == 0 {
+		return nil
+	}
+	sort.Slice(meta.SubTasks, func(i, j int) bool {
+		return meta.SubTasks[i].Worker < meta.SubTasks[j].Worker
+	})
+
+	return errors.Trace(s.update(name, meta))
+}
+
+// UpdateStage updates requested stage of sub tasks for a task on specified dm-workers
+func (s *TaskMetaStore) UpdateStage(name string, workers []string, stage pb.Stage) error {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.tasks[name]
+	if !ok {
+		return errors.NotFoundf("task %s", name)
+	}
+
+	meta := old.clone()
+	for _, worker := range workers {
+		if st := meta.SubTask(worker); st != nil {
+			st.Stage = stage.String()
+		}
+	}
+	return errors.Trace(s.update(name, meta))
+}
+
+// Remove removes sub tasks for a task on specified dm-workers,
+// the task will be removed if no sub tasks remain
+func (s *TaskMetaStore) Remove(name string, workers []string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.tasks[name]
+	if !ok {
+		return nil
+	}
+
+	toRemove := make(map[string]struct{}, len(workers))
+	for _, worker := range workers {
+		toRemove[worker] = struct{}{}
+	}
+	meta := old.clone()
+	remain := make([]*SubTaskMeta, 0, len(meta.SubTasks))
+	for _, st := range meta.SubTasks {
+		if _, ok2 := toRemove[st.Worker]; !ok2 {
+			remain = append(remain, st)
+		}
+	}
+	meta.SubTasks = remain
+
+	if len(remain) == 0 {
+		meta = nil
+	}
+	return errors.Trace(s.update(name, meta))
+}
+
+// update writes (or deletes if meta is nil) meta of a task into etcd,
+// in-memory meta is updated only after written successfully
+func (s *TaskMetaStore) update(name string, meta *TaskMeta) error {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdRequestTimeout)
+	defer cancel()
+
+	key := taskKeyPrefix + name
+	if meta == nil {
+		_, err := s.cli.Delete(ctx, key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		delete(s.tasks, name)
+		return nil
+	}
+
+	value, err := json.Marshal(meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.cli.Put(ctx, key, string(value))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.tasks[name] = meta
+	return nil
+}
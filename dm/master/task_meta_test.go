@@ -0,0 +1,122 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+
+	"github.com/coreos/etcd/etcdserver/api/v3client"
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+func (t *testMaster) TestTaskMetaStore(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	etcd, err := startEtcd(cfg)
+	c.Assert(err, IsNil)
+	defer etcd.Close()
+	cli := v3client.New(etcd.Server)
+	defer cli.Close()
+
+	store := NewTaskMetaStore(cli)
+	c.Assert(store.Load(), IsNil)
+	c.Assert(store.All(), HasLen, 0)
+
+	task := "name: test\ntask-mode: all\n"
+	subTasks := []*SubTaskMeta{
+		{Worker: "127.0.0.1:8263", SourceID: "source-2", Config: "name = \"test\"\nsource-id = \"source-2\"\n", Stage: pb.Stage_Running.String()},
+		{Worker: "127.0.0.1:8262", SourceID: "source-1", Config: "name = \"test\"\nsource-id = \"source-1\"\n", Stage: pb.Stage_Running.String()},
+	}
+	c.Assert(store.Set("test", task, subTasks, true), IsNil)
+
+	meta := store.Get("test")
+	c.Assert(meta, NotNil)
+	c.Assert(meta.Task, Equals, task)
+	c.Assert(meta.Workers(), DeepEquals, []string{"127.0.0.1:8262", "127.0.0.1:8263"})
+	c.Assert(store.Get("not-exist"), IsNil)
+
+	// modify the returned copy should not affect the store
+	meta.SubTasks[0].Stage = pb.Stage_Paused.String()
+	c.Assert(store.Get("test").SubTask("127.0.0.1:8262").Stage, Equals, pb.Stage_Running.String())
+
+	// pause a sub task
+	c.Assert(store.UpdateStage("test", []string{"127.0.0.1:8263"}, pb.Stage_Paused), IsNil)
+	c.Assert(store.UpdateStage("not-exist", []string{"127.0.0.1:8263"}, pb.Stage_Paused), NotNil)
+
+	// merge with old sub tasks
+	c.Assert(store.Set("test", task, []*SubTaskMeta{
+		{Worker: "127.0.0.1:8264", SourceID: "source-3", Config: "name = \"test\"\n", Stage: pb.Stage_Running.String()},
+	}, false), IsNil)
+	c.Assert(store.Get("test").Workers(), DeepEquals, []string{"127.0.0.1:8262", "127.0.0.1:8263", "127.0.0.1:8264"})
+
+	// reload from etcd
+	store2 := NewTaskMetaStore(cli)
+	c.Assert(store2.Load(), IsNil)
+	c.Assert(store2.All(), DeepEquals, store.All())
+	meta = store2.Get("test")
+	c.Assert(meta.SubTask("127.0.0.1:8262").Config, Equals, subTasks[1].Config)
+	c.Assert(meta.SubTask("127.0.0.1:8263").Stage, Equals, pb.Stage_Paused.String())
+
+	// remove partial sub tasks
+	c.Assert(store2.Remove("test", []string{"127.0.0.1:8262", "127.0.0.1:8264"}), IsNil)
+	c.Assert(store2.Get("test").Workers(), DeepEquals, []string{"127.0.0.1:8263"})
+
+	// remove all sub tasks
+	c.Assert(store2.Remove("test", []string{"127.0.0.1:8263"}), IsNil)
+	c.Assert(store2.Get("test"), IsNil)
+
+	store3 := NewTaskMetaStore(cli)
+	c.Assert(store3.Load(), IsNil)
+	c.Assert(store3.All(), HasLen, 0)
+}
+
+func (t *testMaster) TestOperateTaskMetaError(c *C) {
+	cfg := newTestConfigs(c, 1)[0]
+	etcd, err := startEtcd(cfg)
+	c.Assert(err, IsNil)
+	cli := v3client.New(etcd.Server)
+	defer cli.Close()
+
+	s := NewServer(cfg)
+	s.taskMeta = NewTaskMetaStore(cli)
+	worker := "127.0.0.1:8262"
+	s.workerClients[worker] = &mockWorkerClient{}
+	s.addTaskWorkers("test", []string{worker}, true)
+	s.addTaskWorkers("not-persisted", []string{worker}, true)
+	c.Assert(s.taskMeta.Set("test", "name: test\n", []*SubTaskMeta{
+		{Worker: worker, SourceID: "source-1", Config: "name = \"test\"\n", Stage: pb.Stage_Running.String()},
+	}, true), IsNil)
+
+	ctx := context.Background()
+	resp, err := s.OperateTask(ctx, &pb.OperateTaskRequest{Op: pb.TaskOp_Pause, Name: "test"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Result, IsTrue)
+	c.Assert(s.taskMeta.Get("test").SubTask(worker).Stage, Equals, pb.Stage_Paused.String())
+
+	// tasks not in task meta are operated as before
+	resp, err = s.OperateTask(ctx, &pb.OperateTaskRequest{Op: pb.TaskOp_Pause, Name: "not-persisted"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Result, IsTrue)
+
+	// fail to persist the stage
+	etcd.Close()
+	resp, err = s.OperateTask(ctx, &pb.OperateTaskRequest{Op: pb.TaskOp_Resume, Name: "test"})
+	c.Assert(err, IsNil)
+	c.Assert(resp.Result, IsFalse)
+	c.Assert(resp.Msg, Matches, "(?s)update meta for task test error.*")
+	c.Assert(resp.Workers, HasLen, 1)
+	c.Assert(resp.Workers[0].Result, IsTrue)
+	c.Assert(s.taskMeta.Get("test").SubTask(worker).Stage, Equals, pb.Stage_Paused.String())
+}
@@ -547,7 +547,8 @@ func (m *UpdateSubTaskRequest) GetTask() string {
 }
 
 type QueryStatusRequest struct {
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name             string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ShardingProgress bool   `protobuf:"varint,2,opt,name=shardingProgress,proto3" json:"shardingProgress,omitempty"`
 }
 
 func (m *QueryStatusRequest) Reset()         { *m = QueryStatusRequest{} }
@@ -590,6 +591,13 @@ func (m *QueryStatusRequest) GetName() string {
 	return ""
 }
 
+func (m *QueryStatusRequest) GetShardingProgress() bool {
+	if m != nil {
+		return m.ShardingProgress
+	}
+	return false
+}
+
 type QueryErrorRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
@@ -1013,8 +1021,17 @@ func (m *CheckStatus) GetDetail() []byte {
 }
 
 // DumpStatus represents status for dump unit
-// add fields later
+// estimatedRows: estimated rows of all tables to dump, from information_schema
+// currentFiles: data files in writing
+// elapsed: time elapsed since dumping started
 type DumpStatus struct {
+	TotalTables    int32    `protobuf:"varint,1,opt,name=totalTables,proto3" json:"totalTables,omitempty"`
+	FinishedTables int32    `protobuf:"varint,2,opt,name=finishedTables,proto3" json:"finishedTables,omitempty"`
+	EstimatedRows  int64    `protobuf:"varint,3,opt,name=estimatedRows,proto3" json:"estimatedRows,omitempty"`
+	DumpedRows     int64    `protobuf:"varint,4,opt,name=dumpedRows,proto3" json:"dumpedRows,omitempty"`
+	WrittenBytes   int64    `protobuf:"varint,5,opt,name=writtenBytes,proto3" json:"writtenBytes,omitempty"`
+	CurrentFiles   []string `protobuf:"bytes,6,rep,name=currentFiles,proto3" json:"currentFiles,omitempty"`
+	Elapsed        string   `protobuf:"bytes,7,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
 }
 
 func (m *DumpStatus) Reset()         { *m = DumpStatus{} }
@@ -1050,6 +1067,55 @@ func (m *DumpStatus) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DumpStatus proto.InternalMessageInfo
 
+func (m *DumpStatus) GetTotalTables() int32 {
+	if m != nil {
+		return m.TotalTables
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetFinishedTables() int32 {
+	if m != nil {
+		return m.FinishedTables
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetEstimatedRows() int64 {
+	if m != nil {
+		return m.EstimatedRows
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetDumpedRows() int64 {
+	if m != nil {
+		return m.DumpedRows
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetWrittenBytes() int64 {
+	if m != nil {
+		return m.WrittenBytes
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetCurrentFiles() []string {
+	if m != nil {
+		return m.CurrentFiles
+	}
+	return nil
+}
+
+func (m *DumpStatus) GetElapsed() string {
+	if m != nil {
+		return m.Elapsed
+	}
+	return ""
+}
+
 // LoadStatus represents status for load unit
 type LoadStatus struct {
 	FinishedBytes int64  `protobuf:"varint,1,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
@@ -1126,11 +1192,12 @@ func (m *LoadStatus) GetMetaBinlog() string {
 // synced: synced source tables
 // unsynced: unsynced source tables
 type ShardingGroup struct {
-	Target   string   `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	DDLs     []string `protobuf:"bytes,2,rep,name=DDLs,proto3" json:"DDLs,omitempty"`
-	FirstPos string   `protobuf:"bytes,3,opt,name=firstPos,proto3" json:"firstPos,omitempty"`
-	Synced   []string `protobuf:"bytes,4,rep,name=synced,proto3" json:"synced,omitempty"`
-	Unsynced []string `protobuf:"bytes,5,rep,name=unsynced,proto3" json:"unsynced,omitempty"`
+	Target   string            `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	DDLs     []string          `protobuf:"bytes,2,rep,name=DDLs,proto3" json:"DDLs,omitempty"`
+	FirstPos string            `protobuf:"bytes,3,opt,name=firstPos,proto3" json:"firstPos,omitempty"`
+	Synced   []string          `protobuf:"bytes,4,rep,name=synced,proto3" json:"synced,omitempty"`
+	Unsynced []string          `protobuf:"bytes,5,rep,name=unsynced,proto3" json:"unsynced,omitempty"`
+	Progress []*ShardingSource `protobuf:"bytes,6,rep,name=progress,proto3" json:"progress,omitempty"`
 }
 
 func (m *ShardingGroup) Reset()         { *m = ShardingGroup{} }
@@ -1201,6 +1268,66 @@ func (m *ShardingGroup) GetUnsynced() []string {
 	return nil
 }
 
+func (m *ShardingGroup) GetProgress() []*ShardingSource {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+// ShardingSource represents binlog sync progress of an upstream table in a sharding group
+type ShardingSource struct {
+	Source     string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	LastBinlog string `protobuf:"bytes,2,opt,name=lastBinlog,proto3" json:"lastBinlog,omitempty"`
+}
+
+func (m *ShardingSource) Reset()         { *m = ShardingSource{} }
+func (m *ShardingSource) String() string { return proto.CompactTextString(m) }
+func (*ShardingSource) ProtoMessage()    {}
+func (*ShardingSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+}
+func (m *ShardingSource) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardingSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardingSource.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardingSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardingSource.Merge(m, src)
+}
+func (m *ShardingSource) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardingSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardingSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardingSource proto.InternalMessageInfo
+
+func (m *ShardingSource) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ShardingSource) GetLastBinlog() string {
+	if m != nil {
+		return m.LastBinlog
+	}
+	return ""
+}
+
 // SyncStatus represents status for sync unit
 type SyncStatus struct {
 	TotalEvents      int64            `protobuf:"varint,1,opt,name=totalEvents,proto3" json:"totalEvents,omitempty"`
@@ -1213,13 +1340,14 @@ type SyncStatus struct {
 	BlockingDDLs     []string         `protobuf:"bytes,8,rep,name=blockingDDLs,proto3" json:"blockingDDLs,omitempty"`
 	UnresolvedGroups []*ShardingGroup `protobuf:"bytes,9,rep,name=unresolvedGroups,proto3" json:"unresolvedGroups,omitempty"`
 	Synced           bool             `protobuf:"varint,10,opt,name=synced,proto3" json:"synced,omitempty"`
+	ShardingGroups   []*ShardingGroup `protobuf:"bytes,11,rep,name=shardingGroups,proto3" json:"shardingGroups,omitempty"`
 }
 
 func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
 func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
 func (*SyncStatus) ProtoMessage()    {}
 func (*SyncStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+	return fileDescriptor_51a1b9e17fd67b10, []int{17}
 }
 func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1318,6 +1446,13 @@ func (m *SyncStatus) GetSynced() bool {
 	return false
 }
 
+func (m *SyncStatus) GetShardingGroups() []*ShardingGroup {
+	if m != nil {
+		return m.ShardingGroups
+	}
+	return nil
+}
+
 // RelayStatus represents status for relay unit.
 type RelayStatus struct {
 	MasterBinlog       string         `protobuf:"bytes,1,opt,name=masterBinlog,proto3" json:"masterBinlog,omitempty"`
@@ -1334,7 +1469,7 @@ func (m *RelayStatus) Reset()         { *m = RelayStatus{} }
 func (m *RelayStatus) String() string { return proto.CompactTextString(m) }
 func (*RelayStatus) ProtoMessage()    {}
 func (*RelayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{17}
+	return fileDescriptor_51a1b9e17fd67b10, []int{18}
 }
 func (m *RelayStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1448,7 +1583,7 @@ func (m *SubTaskStatus) Reset()         { *m = SubTaskStatus{} }
 func (m *SubTaskStatus) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatus) ProtoMessage()    {}
 func (*SubTaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{18}
+	return fileDescriptor_51a1b9e17fd67b10, []int{19}
 }
 func (m *SubTaskStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1718,7 +1853,7 @@ func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
 func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatusList) ProtoMessage()    {}
 func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{19}
+	return fileDescriptor_51a1b9e17fd67b10, []int{20}
 }
 func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1764,7 +1899,7 @@ func (m *CheckError) Reset()         { *m = CheckError{} }
 func (m *CheckError) String() string { return proto.CompactTextString(m) }
 func (*CheckError) ProtoMessage()    {}
 func (*CheckError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{20}
+	return fileDescriptor_51a1b9e17fd67b10, []int{21}
 }
 func (m *CheckError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1810,7 +1945,7 @@ func (m *DumpError) Reset()         { *m = DumpError{} }
 func (m *DumpError) String() string { return proto.CompactTextString(m) }
 func (*DumpError) ProtoMessage()    {}
 func (*DumpError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{21}
+	return fileDescriptor_51a1b9e17fd67b10, []int{22}
 }
 func (m *DumpError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1855,7 +1990,7 @@ func (m *LoadError) Reset()         { *m = LoadError{} }
 func (m *LoadError) String() string { return proto.CompactTextString(m) }
 func (*LoadError) ProtoMessage()    {}
 func (*LoadError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{22}
+	return fileDescriptor_51a1b9e17fd67b10, []int{23}
 }
 func (m *LoadError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1902,7 +2037,7 @@ func (m *SyncSQLError) Reset()         { *m = SyncSQLError{} }
 func (m *SyncSQLError) String() string { return proto.CompactTextString(m) }
 func (*SyncSQLError) ProtoMessage()    {}
 func (*SyncSQLError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{23}
+	return fileDescriptor_51a1b9e17fd67b10, []int{24}
 }
 func (m *SyncSQLError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1961,7 +2096,7 @@ func (m *SyncError) Reset()         { *m = SyncError{} }
 func (m *SyncError) String() string { return proto.CompactTextString(m) }
 func (*SyncError) ProtoMessage()    {}
 func (*SyncError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{24}
+	return fileDescriptor_51a1b9e17fd67b10, []int{25}
 }
 func (m *SyncError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2006,7 +2141,7 @@ func (m *RelayError) Reset()         { *m = RelayError{} }
 func (m *RelayError) String() string { return proto.CompactTextString(m) }
 func (*RelayError) ProtoMessage()    {}
 func (*RelayError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{25}
+	return fileDescriptor_51a1b9e17fd67b10, []int{26}
 }
 func (m *RelayError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2065,7 +2200,7 @@ func (m *SubTaskError) Reset()         { *m = SubTaskError{} }
 func (m *SubTaskError) String() string { return proto.CompactTextString(m) }
 func (*SubTaskError) ProtoMessage()    {}
 func (*SubTaskError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{26}
+	return fileDescriptor_51a1b9e17fd67b10, []int{27}
 }
 func (m *SubTaskError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2321,7 +2456,7 @@ func (m *SubTaskErrorList) Reset()         { *m = SubTaskErrorList{} }
 func (m *SubTaskErrorList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskErrorList) ProtoMessage()    {}
 func (*SubTaskErrorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{27}
+	return fileDescriptor_51a1b9e17fd67b10, []int{28}
 }
 func (m *SubTaskErrorList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2371,7 +2506,7 @@ func (m *ProcessResult) Reset()         { *m = ProcessResult{} }
 func (m *ProcessResult) String() string { return proto.CompactTextString(m) }
 func (*ProcessResult) ProtoMessage()    {}
 func (*ProcessResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{28}
+	return fileDescriptor_51a1b9e17fd67b10, []int{29}
 }
 func (m *ProcessResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2432,7 +2567,7 @@ func (m *ProcessError) Reset()         { *m = ProcessError{} }
 func (m *ProcessError) String() string { return proto.CompactTextString(m) }
 func (*ProcessError) ProtoMessage()    {}
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{29}
+	return fileDescriptor_51a1b9e17fd67b10, []int{30}
 }
 func (m *ProcessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2484,13 +2619,20 @@ type DDLInfo struct {
 	Schema string   `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
 	Table  string   `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
 	DDLs   []string `protobuf:"bytes,4,rep,name=DDLs,proto3" json:"DDLs,omitempty"`
+	// for optimistic shard mode
+	Optimistic  bool     `protobuf:"varint,5,opt,name=optimistic,proto3" json:"optimistic,omitempty"`
+	Source      string   `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Sources     []string `protobuf:"bytes,7,rep,name=sources,proto3" json:"sources,omitempty"`
+	TableBefore string   `protobuf:"bytes,8,opt,name=tableBefore,proto3" json:"tableBefore,omitempty"`
+	TableAfter  string   `protobuf:"bytes,9,opt,name=tableAfter,proto3" json:"tableAfter,omitempty"`
+	LockID      string   `protobuf:"bytes,10,opt,name=lockID,proto3" json:"lockID,omitempty"`
 }
 
 func (m *DDLInfo) Reset()         { *m = DDLInfo{} }
 func (m *DDLInfo) String() string { return proto.CompactTextString(m) }
 func (*DDLInfo) ProtoMessage()    {}
 func (*DDLInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{30}
+	return fileDescriptor_51a1b9e17fd67b10, []int{31}
 }
 func (m *DDLInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2547,6 +2689,48 @@ func (m *DDLInfo) GetDDLs() []string {
 	return nil
 }
 
+func (m *DDLInfo) GetOptimistic() bool {
+	if m != nil {
+		return m.Optimistic
+	}
+	return false
+}
+
+func (m *DDLInfo) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *DDLInfo) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *DDLInfo) GetTableBefore() string {
+	if m != nil {
+		return m.TableBefore
+	}
+	return ""
+}
+
+func (m *DDLInfo) GetTableAfter() string {
+	if m != nil {
+		return m.TableAfter
+	}
+	return ""
+}
+
+func (m *DDLInfo) GetLockID() string {
+	if m != nil {
+		return m.LockID
+	}
+	return ""
+}
+
 // DDLLockInfo represents a DDL lock
 // it been sent from dm-master to dm-worker
 // add more fields if needed
@@ -2559,7 +2743,7 @@ func (m *DDLLockInfo) Reset()         { *m = DDLLockInfo{} }
 func (m *DDLLockInfo) String() string { return proto.CompactTextString(m) }
 func (*DDLLockInfo) ProtoMessage()    {}
 func (*DDLLockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{31}
+	return fileDescriptor_51a1b9e17fd67b10, []int{32}
 }
 func (m *DDLLockInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2604,17 +2788,19 @@ func (m *DDLLockInfo) GetID() string {
 
 // ExecDDLRequest represents a request for a dm-worker to execute (or ignore) a DDL
 type ExecDDLRequest struct {
-	Task     string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
-	LockID   string `protobuf:"bytes,2,opt,name=lockID,proto3" json:"lockID,omitempty"`
-	Exec     bool   `protobuf:"varint,3,opt,name=exec,proto3" json:"exec,omitempty"`
-	TraceGID string `protobuf:"bytes,4,opt,name=traceGID,proto3" json:"traceGID,omitempty"`
+	Task     string   `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	LockID   string   `protobuf:"bytes,2,opt,name=lockID,proto3" json:"lockID,omitempty"`
+	Exec     bool     `protobuf:"varint,3,opt,name=exec,proto3" json:"exec,omitempty"`
+	TraceGID string   `protobuf:"bytes,4,opt,name=traceGID,proto3" json:"traceGID,omitempty"`
+	Error    string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	DDLs     []string `protobuf:"bytes,6,rep,name=DDLs,proto3" json:"DDLs,omitempty"`
 }
 
 func (m *ExecDDLRequest) Reset()         { *m = ExecDDLRequest{} }
 func (m *ExecDDLRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecDDLRequest) ProtoMessage()    {}
 func (*ExecDDLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{32}
+	return fileDescriptor_51a1b9e17fd67b10, []int{33}
 }
 func (m *ExecDDLRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2671,6 +2857,20 @@ func (m *ExecDDLRequest) GetTraceGID() string {
 	return ""
 }
 
+func (m *ExecDDLRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ExecDDLRequest) GetDDLs() []string {
+	if m != nil {
+		return m.DDLs
+	}
+	return nil
+}
+
 // BreakDDLLockRequest represents a request for a dm-worker to force to break the DDL lock
 // task: sub task's name
 // removeLockID: DDLLockInfo's ID which need to remove
@@ -2688,7 +2888,7 @@ func (m *BreakDDLLockRequest) Reset()         { *m = BreakDDLLockRequest{} }
 func (m *BreakDDLLockRequest) String() string { return proto.CompactTextString(m) }
 func (*BreakDDLLockRequest) ProtoMessage()    {}
 func (*BreakDDLLockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{33}
+	return fileDescriptor_51a1b9e17fd67b10, []int{34}
 }
 func (m *BreakDDLLockRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2745,6 +2945,67 @@ func (m *BreakDDLLockRequest) GetSkipDDL() bool {
 	return false
 }
 
+// LeaveShardingGroupRequest represents a request for a dm-worker to remove upstream tables from a sharding group
+type LeaveShardingGroupRequest struct {
+	Task    string   `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Target  string   `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Sources []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (m *LeaveShardingGroupRequest) Reset()         { *m = LeaveShardingGroupRequest{} }
+func (m *LeaveShardingGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaveShardingGroupRequest) ProtoMessage()    {}
+func (*LeaveShardingGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{35}
+}
+func (m *LeaveShardingGroupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaveShardingGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaveShardingGroupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeaveShardingGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveShardingGroupRequest.Merge(m, src)
+}
+func (m *LeaveShardingGroupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaveShardingGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveShardingGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveShardingGroupRequest proto.InternalMessageInfo
+
+func (m *LeaveShardingGroupRequest) GetTask() string {
+	if m != nil {
+		return m.Task
+	}
+	return ""
+}
+
+func (m *LeaveShardingGroupRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *LeaveShardingGroupRequest) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
 // SwitchRelayMasterRequest represents a request for switching a dm-worker's relay unit to another master server
 type SwitchRelayMasterRequest struct {
 }
@@ -2753,7 +3014,7 @@ func (m *SwitchRelayMasterRequest) Reset()         { *m = SwitchRelayMasterReque
 func (m *SwitchRelayMasterRequest) String() string { return proto.CompactTextString(m) }
 func (*SwitchRelayMasterRequest) ProtoMessage()    {}
 func (*SwitchRelayMasterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{34}
+	return fileDescriptor_51a1b9e17fd67b10, []int{36}
 }
 func (m *SwitchRelayMasterRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2791,7 +3052,7 @@ func (m *OperateRelayRequest) Reset()         { *m = OperateRelayRequest{} }
 func (m *OperateRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateRelayRequest) ProtoMessage()    {}
 func (*OperateRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{35}
+	return fileDescriptor_51a1b9e17fd67b10, []int{37}
 }
 func (m *OperateRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2838,7 +3099,7 @@ func (m *OperateRelayResponse) Reset()         { *m = OperateRelayResponse{} }
 func (m *OperateRelayResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateRelayResponse) ProtoMessage()    {}
 func (*OperateRelayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{36}
+	return fileDescriptor_51a1b9e17fd67b10, []int{38}
 }
 func (m *OperateRelayResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2911,7 +3172,7 @@ func (m *PurgeRelayRequest) Reset()         { *m = PurgeRelayRequest{} }
 func (m *PurgeRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*PurgeRelayRequest) ProtoMessage()    {}
 func (*PurgeRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{37}
+	return fileDescriptor_51a1b9e17fd67b10, []int{39}
 }
 func (m *PurgeRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2975,7 +3236,7 @@ func (m *QueryWorkerConfigRequest) Reset()         { *m = QueryWorkerConfigReque
 func (m *QueryWorkerConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryWorkerConfigRequest) ProtoMessage()    {}
 func (*QueryWorkerConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{38}
+	return fileDescriptor_51a1b9e17fd67b10, []int{40}
 }
 func (m *QueryWorkerConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3016,7 +3277,7 @@ func (m *QueryWorkerConfigResponse) Reset()         { *m = QueryWorkerConfigResp
 func (m *QueryWorkerConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryWorkerConfigResponse) ProtoMessage()    {}
 func (*QueryWorkerConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{39}
+	return fileDescriptor_51a1b9e17fd67b10, []int{41}
 }
 func (m *QueryWorkerConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3103,6 +3364,7 @@ func init() {
 	proto.RegisterType((*DumpStatus)(nil), "pb.DumpStatus")
 	proto.RegisterType((*LoadStatus)(nil), "pb.LoadStatus")
 	proto.RegisterType((*ShardingGroup)(nil), "pb.ShardingGroup")
+	proto.RegisterType((*ShardingSource)(nil), "pb.ShardingSource")
 	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
 	proto.RegisterType((*RelayStatus)(nil), "pb.RelayStatus")
 	proto.RegisterType((*SubTaskStatus)(nil), "pb.SubTaskStatus")
@@ -3121,6 +3383,7 @@ func init() {
 	proto.RegisterType((*DDLLockInfo)(nil), "pb.DDLLockInfo")
 	proto.RegisterType((*ExecDDLRequest)(nil), "pb.ExecDDLRequest")
 	proto.RegisterType((*BreakDDLLockRequest)(nil), "pb.BreakDDLLockRequest")
+	proto.RegisterType((*LeaveShardingGroupRequest)(nil), "pb.LeaveShardingGroupRequest")
 	proto.RegisterType((*SwitchRelayMasterRequest)(nil), "pb.SwitchRelayMasterRequest")
 	proto.RegisterType((*OperateRelayRequest)(nil), "pb.OperateRelayRequest")
 	proto.RegisterType((*OperateRelayResponse)(nil), "pb.OperateRelayResponse")
@@ -3132,138 +3395,155 @@ func init() {
 func init() { proto.RegisterFile("dmworker.proto", fileDescriptor_51a1b9e17fd67b10) }
 
 var fileDescriptor_51a1b9e17fd67b10 = []byte{
-	// 2093 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x6f, 0x23, 0x49,
-	0x15, 0x77, 0xb7, 0x3f, 0x62, 0x3f, 0x3b, 0x99, 0x4e, 0x25, 0x9b, 0xf5, 0x98, 0xdd, 0x10, 0x7a,
-	0x56, 0xbb, 0x59, 0x1f, 0xa2, 0xdd, 0x00, 0x02, 0x01, 0xcb, 0x47, 0xec, 0x4c, 0xc6, 0xe0, 0x99,
-	0x49, 0xda, 0x33, 0xc0, 0x0d, 0x75, 0xda, 0x15, 0xa7, 0x95, 0x76, 0x77, 0x4f, 0x7f, 0x24, 0x9b,
-	0x23, 0xe2, 0x88, 0x84, 0x90, 0x90, 0x90, 0x10, 0x67, 0xfe, 0x0b, 0x6e, 0x1c, 0xe0, 0xb8, 0x47,
-	0x8e, 0x68, 0xe6, 0xdf, 0xe0, 0x80, 0xde, 0xab, 0xea, 0xee, 0xea, 0xc4, 0xf6, 0xce, 0x61, 0xb8,
-	0x58, 0x7e, 0x1f, 0xf5, 0xea, 0xd5, 0xaf, 0x5e, 0xff, 0x5e, 0x55, 0xc1, 0xc6, 0x74, 0x7e, 0x13,
-	0x44, 0x57, 0x3c, 0x3a, 0x08, 0xa3, 0x20, 0x09, 0x98, 0x1e, 0x9e, 0x9b, 0x9f, 0xc2, 0xd6, 0x24,
-	0xb1, 0xa3, 0x64, 0x92, 0x9e, 0xbf, 0xb0, 0xe3, 0x2b, 0x8b, 0xbf, 0x4a, 0x79, 0x9c, 0x30, 0x06,
-	0xb5, 0xc4, 0x8e, 0xaf, 0xba, 0xda, 0x9e, 0xb6, 0xdf, 0xb2, 0xe8, 0xbf, 0x79, 0x00, 0xec, 0x65,
-	0x38, 0xb5, 0x13, 0x6e, 0x71, 0xcf, 0xbe, 0xcd, 0x3c, 0xbb, 0xb0, 0xe6, 0x04, 0x7e, 0xc2, 0xfd,
-	0x44, 0x3a, 0x67, 0xa2, 0x39, 0x81, 0xad, 0xa7, 0xee, 0x2c, 0xba, 0x3b, 0x60, 0x17, 0xe0, 0xc8,
-	0xf5, 0xbd, 0x60, 0xf6, 0xcc, 0x9e, 0x73, 0x39, 0x46, 0xd1, 0xb0, 0x0f, 0xa0, 0x25, 0xa4, 0xd3,
-	0x20, 0xee, 0xea, 0x7b, 0xda, 0xfe, 0xba, 0x55, 0x28, 0xcc, 0x13, 0x78, 0xef, 0x79, 0xc8, 0x31,
-	0xe8, 0x9d, 0x8c, 0x7b, 0xa0, 0x07, 0x21, 0x85, 0xdb, 0x38, 0x84, 0x83, 0xf0, 0xfc, 0x00, 0x8d,
-	0xcf, 0x43, 0x4b, 0x0f, 0x42, 0x5c, 0x8d, 0x8f, 0x93, 0xe9, 0x62, 0x35, 0xf8, 0xdf, 0xbc, 0x86,
-	0x9d, 0xbb, 0x81, 0xe2, 0x30, 0xf0, 0x63, 0xbe, 0x32, 0xd2, 0x0e, 0x34, 0x22, 0x1e, 0xa7, 0x5e,
-	0x42, 0xb1, 0x9a, 0x96, 0x94, 0x50, 0x2f, 0xa0, 0xed, 0x56, 0x69, 0x0e, 0x29, 0x31, 0x03, 0xaa,
-	0xf3, 0x78, 0xd6, 0xad, 0x91, 0x12, 0xff, 0x9a, 0x7d, 0xd8, 0x16, 0x28, 0xbe, 0x05, 0xe2, 0xfb,
-	0xc0, 0xce, 0x52, 0x1e, 0xdd, 0x4e, 0x12, 0x3b, 0x49, 0x63, 0xc5, 0xd3, 0x2f, 0xa0, 0x13, 0xab,
-	0xf9, 0x04, 0x36, 0xc9, 0xf3, 0x38, 0x8a, 0x82, 0x68, 0x95, 0xe3, 0x5f, 0x35, 0xe8, 0x3e, 0xb1,
-	0xfd, 0xa9, 0x97, 0xcd, 0x3f, 0x39, 0x1b, 0xaf, 0x8a, 0xcc, 0x1e, 0x12, 0x1a, 0x3a, 0xa1, 0xd1,
-	0x42, 0x34, 0x26, 0x67, 0xe3, 0x02, 0x56, 0x3b, 0x9a, 0xc5, 0xdd, 0xea, 0x5e, 0x15, 0xdd, 0xf1,
-	0x3f, 0xee, 0xde, 0x79, 0xbe, 0x7b, 0x62, 0xd9, 0x85, 0x02, 0xf7, 0x3e, 0x7e, 0xe5, 0x9d, 0xda,
-	0x49, 0xc2, 0x23, 0xbf, 0x5b, 0x17, 0x7b, 0x5f, 0x68, 0xcc, 0x5f, 0xc3, 0xf6, 0x20, 0x98, 0xcf,
-	0x03, 0xff, 0x57, 0x04, 0x5f, 0xbe, 0x25, 0x05, 0xec, 0xda, 0x12, 0xd8, 0xf5, 0x45, 0xb0, 0x57,
-	0x0b, 0xd8, 0xff, 0xa1, 0xc1, 0x56, 0x09, 0xcb, 0x77, 0x15, 0x99, 0x7d, 0x0f, 0xd6, 0x63, 0x09,
-	0x25, 0x85, 0xee, 0xd6, 0xf6, 0xaa, 0xfb, 0xed, 0xc3, 0x4d, 0xc2, 0x4a, 0x35, 0x58, 0x65, 0x3f,
-	0xf6, 0x39, 0xb4, 0x23, 0xfc, 0x30, 0xe4, 0x30, 0x44, 0xa3, 0x7d, 0xf8, 0x00, 0x87, 0x59, 0x85,
-	0xda, 0x52, 0x7d, 0xcc, 0xbf, 0x6b, 0xb2, 0x22, 0xe4, 0x3e, 0xbf, 0xb3, 0x45, 0x7c, 0x07, 0x3a,
-	0x32, 0x39, 0x8a, 0x2c, 0xd7, 0x60, 0x28, 0x6b, 0x10, 0x33, 0x96, 0xbc, 0xd8, 0x01, 0x00, 0xa5,
-	0x2a, 0xc6, 0x88, 0x05, 0x6c, 0xe4, 0x0b, 0x10, 0x23, 0x14, 0x0f, 0xf3, 0x6f, 0x1a, 0xb4, 0x07,
-	0x97, 0xdc, 0xc9, 0x10, 0xd8, 0x81, 0x46, 0x68, 0xc7, 0x31, 0x9f, 0x66, 0x79, 0x0b, 0x89, 0x6d,
-	0x43, 0x3d, 0x09, 0x12, 0xdb, 0xa3, 0xb4, 0xeb, 0x96, 0x10, 0xa8, 0x78, 0x52, 0xc7, 0xe1, 0x71,
-	0x7c, 0x91, 0x7a, 0x94, 0x7c, 0xdd, 0x52, 0x34, 0x18, 0xed, 0xc2, 0x76, 0x3d, 0x3e, 0xa5, 0xba,
-	0xab, 0x5b, 0x52, 0x42, 0x86, 0xba, 0xb1, 0x23, 0xdf, 0xf5, 0x67, 0x94, 0x62, 0xdd, 0xca, 0x44,
-	0x1c, 0x31, 0xe5, 0x89, 0xed, 0x7a, 0xdd, 0xc6, 0x9e, 0xb6, 0xdf, 0xb1, 0xa4, 0x64, 0x76, 0x00,
-	0x86, 0xe9, 0x3c, 0x94, 0xa0, 0xff, 0x41, 0x03, 0x18, 0x07, 0xf6, 0x54, 0x26, 0xfd, 0x11, 0xac,
-	0x5f, 0xb8, 0xbe, 0x1b, 0x5f, 0xf2, 0xe9, 0xd1, 0x6d, 0xc2, 0x63, 0xca, 0xbd, 0x6a, 0x95, 0x95,
-	0x98, 0x2c, 0x65, 0x2d, 0x5c, 0x74, 0x72, 0x51, 0x34, 0xac, 0x07, 0xcd, 0x30, 0x0a, 0x66, 0x11,
-	0x8f, 0x63, 0xb9, 0x0f, 0xb9, 0x8c, 0x63, 0xe7, 0x3c, 0xb1, 0x05, 0xe9, 0xc9, 0x8f, 0x48, 0xd1,
-	0x98, 0xbf, 0xd7, 0x60, 0x7d, 0x72, 0x69, 0x47, 0x53, 0xd7, 0x9f, 0x9d, 0x44, 0x41, 0x4a, 0xb4,
-	0x94, 0xd8, 0xd1, 0x8c, 0x67, 0x1c, 0x2c, 0x25, 0xfc, 0x42, 0x87, 0xc3, 0x31, 0xce, 0x4f, 0x5f,
-	0x28, 0xfe, 0xc7, 0x99, 0x2f, 0xdc, 0x28, 0x4e, 0xf0, 0x03, 0x95, 0x33, 0x67, 0x32, 0xc6, 0x89,
-	0x6f, 0x7d, 0x87, 0x20, 0xc4, 0x11, 0x52, 0xc2, 0x31, 0xa9, 0x2f, 0x2d, 0x75, 0xb2, 0xe4, 0xb2,
-	0xf9, 0xbb, 0x2a, 0xc0, 0xe4, 0xd6, 0x77, 0x24, 0x3c, 0x7b, 0xd0, 0xa6, 0x65, 0x1e, 0x5f, 0x73,
-	0x3f, 0xc9, 0xc0, 0x51, 0x55, 0x18, 0x8c, 0xc4, 0x17, 0x61, 0x06, 0x4c, 0x2e, 0x23, 0x7d, 0x44,
-	0xdc, 0xe1, 0x7e, 0x82, 0xc6, 0x2a, 0x19, 0x0b, 0x05, 0x33, 0xa1, 0x33, 0xb7, 0xe3, 0x84, 0x47,
-	0x25, 0x68, 0x4a, 0x3a, 0xd6, 0x07, 0x43, 0x95, 0x4f, 0x12, 0x77, 0x2a, 0x89, 0xe6, 0x9e, 0x1e,
-	0xe3, 0xd1, 0x22, 0xb2, 0x78, 0x0d, 0x11, 0x4f, 0xd5, 0x61, 0x3c, 0x55, 0xa6, 0x78, 0x6b, 0x22,
-	0xde, 0x5d, 0x3d, 0xc6, 0x3b, 0xf7, 0x02, 0xe7, 0xca, 0xf5, 0x67, 0x04, 0x7b, 0x93, 0xa0, 0x2a,
-	0xe9, 0xd8, 0x17, 0x60, 0xa4, 0x7e, 0xc4, 0xe3, 0xc0, 0xbb, 0xe6, 0x53, 0xda, 0xbd, 0xb8, 0xdb,
-	0x52, 0x18, 0x43, 0xdd, 0x57, 0xeb, 0x9e, 0xab, 0xb2, 0x43, 0x20, 0x3e, 0x19, 0xb9, 0x0b, 0xff,
-	0xd4, 0xa1, 0xad, 0xd0, 0xc6, 0x3d, 0xa8, 0xb4, 0xb7, 0x84, 0x4a, 0x5f, 0x02, 0xd5, 0x5e, 0x46,
-	0x56, 0xe9, 0xf9, 0xd0, 0xcd, 0xba, 0x9c, 0xaa, 0xca, 0x3d, 0x4a, 0x7b, 0xa3, 0xaa, 0xd8, 0x3e,
-	0x3c, 0x50, 0x44, 0x65, 0x67, 0xee, 0xaa, 0xd9, 0x01, 0x30, 0x52, 0x0d, 0xec, 0xc4, 0xb9, 0x7c,
-	0x19, 0x3e, 0xa5, 0x6c, 0x68, 0x7b, 0x9a, 0xd6, 0x02, 0x0b, 0xfb, 0x26, 0xd4, 0xe3, 0xc4, 0x9e,
-	0x71, 0xda, 0x99, 0xac, 0x4f, 0xa1, 0xc2, 0x12, 0x7a, 0xf6, 0x69, 0xce, 0x90, 0x4d, 0x62, 0x29,
-	0xc2, 0xfa, 0x34, 0x0a, 0x90, 0x3b, 0x2c, 0x32, 0x64, 0xa4, 0x69, 0xfe, 0x57, 0x87, 0xf5, 0x12,
-	0x6f, 0x2f, 0x6c, 0x8b, 0xf9, 0x8c, 0xfa, 0x92, 0x19, 0xf7, 0xa0, 0x96, 0xfa, 0x6e, 0x42, 0x48,
-	0x6d, 0x1c, 0x76, 0xd0, 0xfe, 0xd2, 0x77, 0x93, 0x17, 0xb7, 0x21, 0xb7, 0xc8, 0xa2, 0xe4, 0x54,
-	0xfb, 0x9a, 0x9c, 0xd8, 0x67, 0xb0, 0x55, 0x54, 0xc2, 0x70, 0x38, 0x1e, 0x07, 0xce, 0xd5, 0x68,
-	0x28, 0xd1, 0x5b, 0x64, 0x62, 0x4c, 0x50, 0x3c, 0x55, 0xf4, 0x93, 0x8a, 0x20, 0xf9, 0x4f, 0xa0,
-	0xee, 0x20, 0xfb, 0x12, 0x4a, 0xb2, 0xd5, 0x28, 0x74, 0xfc, 0xa4, 0x62, 0x09, 0x3b, 0xfb, 0x08,
-	0x6a, 0xd3, 0x74, 0x1e, 0x4a, 0xac, 0x88, 0xd1, 0x0b, 0x3e, 0x7c, 0x52, 0xb1, 0xc8, 0x8a, 0x5e,
-	0x5e, 0x60, 0x4f, 0xbb, 0xad, 0xc2, 0xab, 0xa0, 0x49, 0xf4, 0x42, 0x2b, 0x7a, 0x61, 0x89, 0x52,
-	0xb9, 0x4a, 0xaf, 0x82, 0x2d, 0xd0, 0x0b, 0xad, 0x47, 0x4d, 0x68, 0xc4, 0x82, 0x6d, 0x7f, 0x0c,
-	0x9b, 0x25, 0xf4, 0xc7, 0x6e, 0x4c, 0x50, 0x09, 0x73, 0x57, 0x5b, 0xd6, 0x5c, 0xb3, 0xf1, 0xbb,
-	0x00, 0xb4, 0x26, 0xd1, 0xa1, 0x64, 0xa7, 0xd3, 0x8a, 0x83, 0xc0, 0x87, 0xd0, 0xc2, 0xb5, 0xac,
-	0x30, 0xe3, 0x22, 0x96, 0x99, 0x43, 0xe8, 0x50, 0xf6, 0x67, 0xe3, 0x25, 0x1e, 0xec, 0x10, 0xb6,
-	0x45, 0xdf, 0xc9, 0xcf, 0xac, 0x6e, 0xe2, 0x06, 0xbe, 0xfc, 0xb0, 0x16, 0xda, 0x90, 0x11, 0x39,
-	0x86, 0x9b, 0x9c, 0x8d, 0x33, 0x4a, 0xce, 0x64, 0xf3, 0xbb, 0xd0, 0xc2, 0x19, 0xc5, 0x74, 0xfb,
-	0xd0, 0x20, 0x43, 0x86, 0x83, 0x91, 0xc3, 0x29, 0x13, 0xb2, 0xa4, 0x1d, 0x61, 0x28, 0x1a, 0xef,
-	0x82, 0x85, 0xfc, 0x45, 0x87, 0x8e, 0xda, 0xd9, 0xff, 0x5f, 0x45, 0xce, 0x94, 0x03, 0x70, 0x56,
-	0x87, 0x1f, 0x67, 0x75, 0xa8, 0x9c, 0x18, 0x8a, 0x3d, 0x2b, 0xca, 0xf0, 0x91, 0x2c, 0xc3, 0x06,
-	0xb9, 0xad, 0x67, 0x65, 0x98, 0x79, 0x89, 0x2a, 0x7c, 0x24, 0xab, 0x70, 0xad, 0x70, 0xca, 0x37,
-	0x30, 0x2f, 0xc2, 0x47, 0xb2, 0x08, 0x9b, 0x85, 0x53, 0x0e, 0x6a, 0x5e, 0x83, 0x6b, 0x50, 0x27,
-	0xf0, 0xcc, 0x1f, 0x80, 0xa1, 0x42, 0x43, 0x15, 0xf8, 0xb1, 0x34, 0x96, 0x80, 0x57, 0x4f, 0x46,
-	0x72, 0xec, 0x2b, 0x58, 0x2f, 0x7d, 0xc2, 0xd8, 0xcc, 0xdd, 0x78, 0x60, 0xfb, 0x0e, 0xf7, 0xf2,
-	0x73, 0x8e, 0xa2, 0x51, 0xb6, 0x54, 0x2f, 0x22, 0xcb, 0x10, 0xa5, 0x2d, 0x55, 0x4e, 0x2b, 0xd5,
-	0xd2, 0x69, 0x65, 0x00, 0x1d, 0xd5, 0x9f, 0x7d, 0x0b, 0x6a, 0xb8, 0x01, 0xf2, 0x06, 0x43, 0x8b,
-	0x25, 0x83, 0xd8, 0x15, 0xfc, 0xcd, 0xea, 0x41, 0x2f, 0xea, 0xe1, 0x37, 0xb0, 0x36, 0x1c, 0x8e,
-	0x47, 0xfe, 0x45, 0xb0, 0xe8, 0x26, 0x42, 0x6d, 0xc7, 0xb9, 0xe4, 0x73, 0x3b, 0x3b, 0x49, 0x0a,
-	0x89, 0x4e, 0x6a, 0xf6, 0xb9, 0xc7, 0x65, 0xd9, 0x0a, 0x21, 0x3f, 0x76, 0xd4, 0x8a, 0x63, 0x87,
-	0xf9, 0x39, 0xb4, 0x33, 0x76, 0x5a, 0x36, 0xc9, 0x06, 0xe8, 0xa3, 0xa1, 0x9c, 0x40, 0x1f, 0x0d,
-	0x4d, 0x0f, 0x36, 0x8e, 0xbf, 0xe4, 0xce, 0x70, 0x38, 0x5e, 0x71, 0x49, 0xc2, 0xd4, 0x3c, 0x41,
-	0x87, 0x32, 0x35, 0x2f, 0x63, 0xc0, 0x1a, 0xff, 0x92, 0x3b, 0x94, 0x59, 0xd3, 0xa2, 0xff, 0x74,
-	0xf4, 0x88, 0x6c, 0x87, 0x9f, 0x8c, 0x86, 0xb2, 0x41, 0xe5, 0xb2, 0xf9, 0x5b, 0x0d, 0xb6, 0x8e,
-	0x22, 0x6e, 0x5f, 0xc9, 0x34, 0x57, 0xcd, 0x69, 0x42, 0x27, 0xe2, 0xf3, 0xe0, 0x9a, 0x8f, 0xd5,
-	0x99, 0x4b, 0x3a, 0x3c, 0x76, 0x72, 0x91, 0xbd, 0x4c, 0x21, 0x13, 0xd1, 0x12, 0x5f, 0xb9, 0x21,
-	0x5a, 0x6a, 0xc2, 0x22, 0x45, 0xb3, 0x07, 0xdd, 0xc9, 0x8d, 0x9b, 0x38, 0x97, 0xf4, 0xed, 0x8a,
-	0xe6, 0x26, 0xf3, 0x30, 0x0f, 0x61, 0x4b, 0x5e, 0x58, 0x4b, 0xd7, 0xe9, 0x6f, 0x28, 0xb7, 0xd5,
-	0x76, 0x7e, 0xf6, 0x16, 0x37, 0x34, 0x33, 0x85, 0xed, 0xf2, 0x18, 0x79, 0x61, 0x58, 0x35, 0xe8,
-	0x1d, 0xdc, 0x71, 0x6f, 0x60, 0xf3, 0x34, 0x8d, 0x66, 0xe5, 0x44, 0x7b, 0xd0, 0x74, 0x7d, 0xdb,
-	0x49, 0xdc, 0x6b, 0x2e, 0x3f, 0x83, 0x5c, 0x26, 0x8c, 0x5d, 0x79, 0x41, 0xaf, 0x5a, 0xf4, 0x5f,
-	0x9c, 0x53, 0x3d, 0x4e, 0xa4, 0x94, 0x9f, 0x53, 0x85, 0x4c, 0xe5, 0x28, 0x0e, 0x22, 0x35, 0x59,
-	0x8e, 0x24, 0x21, 0x7e, 0x74, 0x3d, 0x12, 0xd7, 0xc7, 0x41, 0xe0, 0x5f, 0xb8, 0xb3, 0x0c, 0xbf,
-	0x3f, 0x69, 0xf0, 0x70, 0x81, 0xf1, 0x9d, 0x5d, 0xa1, 0x7a, 0xd0, 0x8c, 0x83, 0x34, 0x72, 0x78,
-	0x51, 0x5b, 0x99, 0xac, 0x3e, 0x92, 0xd4, 0x4b, 0x8f, 0x24, 0xfd, 0xef, 0x43, 0x43, 0x3c, 0x2f,
-	0xb0, 0x75, 0x68, 0x8d, 0xfc, 0x6b, 0xdb, 0x73, 0xa7, 0xcf, 0x43, 0xa3, 0xc2, 0x9a, 0x50, 0x9b,
-	0x24, 0x41, 0x68, 0x68, 0xac, 0x05, 0xf5, 0x53, 0x3b, 0x8d, 0xb9, 0xa1, 0x33, 0x80, 0x06, 0xd2,
-	0xca, 0x9c, 0x1b, 0xd5, 0x7e, 0x1f, 0xea, 0x74, 0x15, 0x27, 0xcf, 0x5f, 0x8c, 0x4e, 0x8d, 0x0a,
-	0x6b, 0xc3, 0x9a, 0x75, 0x7c, 0x3a, 0xfe, 0xd9, 0xe0, 0xd8, 0xd0, 0xd0, 0x77, 0xf4, 0xec, 0xe7,
-	0xc7, 0x83, 0x17, 0x86, 0xde, 0xff, 0x25, 0xd4, 0x89, 0xb7, 0x99, 0x01, 0x1d, 0x39, 0x09, 0xc9,
-	0x46, 0x85, 0xad, 0x41, 0xf5, 0x19, 0xbf, 0x31, 0x34, 0x1a, 0x9c, 0xfa, 0x78, 0x2f, 0x12, 0x13,
-	0xd1, 0x9c, 0x53, 0xa3, 0x8a, 0x06, 0xcc, 0x24, 0xe4, 0x53, 0xa3, 0xc6, 0x3a, 0xd0, 0x7c, 0x2c,
-	0x2f, 0x3a, 0x46, 0xbd, 0xff, 0x1c, 0x9a, 0x19, 0xdf, 0xb3, 0x07, 0xd0, 0x96, 0xa1, 0x51, 0x65,
-	0x54, 0x30, 0x6f, 0x62, 0x75, 0x43, 0xc3, 0x14, 0x91, 0xb9, 0x0d, 0x1d, 0xff, 0x21, 0x3d, 0x1b,
-	0x55, 0x4a, 0xfb, 0xd6, 0x77, 0x8c, 0x1a, 0x3a, 0x52, 0xa5, 0x18, 0xd3, 0xfe, 0x0f, 0xa1, 0x95,
-	0x73, 0x15, 0x26, 0xfb, 0xd2, 0xbf, 0xf2, 0x83, 0x1b, 0x9f, 0x74, 0x62, 0x81, 0xc8, 0x08, 0x93,
-	0xb3, 0xb1, 0xa1, 0xe1, 0x84, 0x14, 0xff, 0x31, 0xb5, 0x54, 0x43, 0xef, 0x3f, 0x85, 0x35, 0x59,
-	0xc7, 0x8c, 0xc1, 0x86, 0x4c, 0x46, 0x6a, 0x8c, 0x0a, 0x02, 0x8c, 0xeb, 0x10, 0x53, 0x69, 0x6c,
-	0x03, 0x80, 0x96, 0x28, 0x64, 0x1d, 0xc3, 0x09, 0x6c, 0x85, 0xa2, 0x7a, 0xf8, 0xe7, 0x26, 0x34,
-	0x44, 0xad, 0xb0, 0x01, 0x74, 0xd4, 0x57, 0x32, 0xf6, 0xbe, 0xec, 0x84, 0x77, 0xdf, 0xcd, 0x7a,
-	0x5d, 0xea, 0x65, 0x0b, 0x9e, 0x30, 0xcc, 0x0a, 0x1b, 0xc1, 0x46, 0xf9, 0xc5, 0x89, 0x3d, 0x44,
-	0xef, 0x85, 0xcf, 0x59, 0xbd, 0xde, 0x22, 0x53, 0x1e, 0xea, 0x18, 0xd6, 0x4b, 0x8f, 0x48, 0x8c,
-	0xe6, 0x5d, 0xf4, 0xae, 0xb4, 0x32, 0xa3, 0x9f, 0x42, 0x5b, 0x79, 0x13, 0x61, 0x3b, 0xe8, 0x7a,
-	0xff, 0xc1, 0xa9, 0xf7, 0xfe, 0x3d, 0x7d, 0x1e, 0xe1, 0x0b, 0x80, 0xe2, 0x3d, 0x82, 0xbd, 0x97,
-	0x3b, 0xaa, 0xef, 0x50, 0xbd, 0x9d, 0xbb, 0xea, 0x7c, 0xf8, 0x63, 0x00, 0xf9, 0x18, 0x75, 0x36,
-	0x8e, 0xd9, 0x07, 0xe8, 0xb7, 0xec, 0x71, 0x6a, 0xe5, 0x42, 0x0e, 0xa1, 0xf3, 0x98, 0x27, 0xce,
-	0x65, 0xd6, 0xc2, 0xe8, 0x68, 0xab, 0xb4, 0x9b, 0x5e, 0x5b, 0x2a, 0x50, 0x30, 0x2b, 0xfb, 0xda,
-	0x67, 0x1a, 0xfb, 0x11, 0x00, 0xd6, 0x52, 0x9a, 0x70, 0xe4, 0x64, 0x46, 0x6d, 0xb2, 0xd4, 0x6d,
-	0x56, 0xce, 0x38, 0x80, 0x8e, 0xda, 0x2c, 0x44, 0x45, 0x2c, 0x68, 0x1f, 0x2b, 0x83, 0x3c, 0x85,
-	0xcd, 0x7b, 0x74, 0x2f, 0x50, 0x58, 0xd6, 0x05, 0xbe, 0x2e, 0x27, 0x95, 0xed, 0x45, 0x4e, 0x0b,
-	0x7a, 0x86, 0x08, 0xb2, 0xa8, 0x31, 0x98, 0x15, 0xf6, 0x13, 0x80, 0x82, 0xbb, 0xc5, 0x8e, 0xde,
-	0xe3, 0xf2, 0x95, 0x59, 0x9c, 0xc0, 0xa6, 0xf2, 0x4c, 0x2c, 0x68, 0x56, 0x94, 0xd6, 0xfd, 0xd7,
-	0xe3, 0x95, 0x81, 0x2c, 0xf9, 0xa6, 0xa9, 0xf2, 0xb5, 0x40, 0x67, 0x19, 0xc7, 0xf7, 0x3e, 0x5c,
-	0x62, 0x55, 0x21, 0x52, 0xdf, 0xa4, 0x05, 0x44, 0x0b, 0x5e, 0xa9, 0x57, 0x25, 0x76, 0xd4, 0xfd,
-	0xd7, 0xeb, 0x5d, 0xed, 0xab, 0xd7, 0xbb, 0xda, 0x7f, 0x5e, 0xef, 0x6a, 0x7f, 0x7c, 0xb3, 0x5b,
-	0xf9, 0xea, 0xcd, 0x6e, 0xe5, 0xdf, 0x6f, 0x76, 0x2b, 0xe7, 0x0d, 0x7a, 0x58, 0xff, 0xf6, 0xff,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x4a, 0x1d, 0xd6, 0x8e, 0x6a, 0x17, 0x00, 0x00,
+	// 2360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x6f, 0xe4, 0x58,
+	0x11, 0x6f, 0xbb, 0xbf, 0xab, 0x3b, 0xbd, 0xce, 0xcb, 0xec, 0xac, 0xa7, 0xd9, 0x09, 0xc1, 0x3b,
+	0x9a, 0xcd, 0xe6, 0x10, 0xed, 0x06, 0x10, 0x9f, 0x0b, 0x6c, 0xd2, 0x99, 0x99, 0x40, 0xcf, 0x24,
+	0x71, 0x67, 0x80, 0xab, 0xe3, 0x7e, 0xe9, 0x58, 0xe9, 0xb6, 0x3d, 0xfe, 0x48, 0x36, 0x47, 0xce,
+	0x48, 0x08, 0x09, 0x09, 0x09, 0x71, 0xe6, 0xaf, 0x00, 0x71, 0xe1, 0x00, 0xc7, 0x3d, 0x72, 0x44,
+	0x33, 0x07, 0xfe, 0x09, 0x84, 0x50, 0xd5, 0x7b, 0xb6, 0x9f, 0xd3, 0x1f, 0xbb, 0x87, 0xe1, 0xd2,
+	0x72, 0x7d, 0xbc, 0x7a, 0xf5, 0x7e, 0xaf, 0x5c, 0x55, 0xae, 0x86, 0xde, 0x78, 0x76, 0x13, 0x44,
+	0x57, 0x3c, 0xda, 0x0d, 0xa3, 0x20, 0x09, 0x98, 0x1e, 0x9e, 0x5b, 0x1f, 0xc1, 0xc6, 0x28, 0x71,
+	0xa2, 0x64, 0x94, 0x9e, 0x9f, 0x39, 0xf1, 0x95, 0xcd, 0x5f, 0xa5, 0x3c, 0x4e, 0x18, 0x83, 0x5a,
+	0xe2, 0xc4, 0x57, 0xa6, 0xb6, 0xa5, 0x6d, 0xb7, 0x6d, 0x7a, 0xb6, 0x76, 0x81, 0xbd, 0x0c, 0xc7,
+	0x4e, 0xc2, 0x6d, 0x3e, 0x75, 0x6e, 0x33, 0x4d, 0x13, 0x9a, 0x6e, 0xe0, 0x27, 0xdc, 0x4f, 0xa4,
+	0x72, 0x46, 0x5a, 0x23, 0xd8, 0x78, 0xee, 0x4d, 0xa2, 0xbb, 0x0b, 0x36, 0x01, 0xf6, 0x3d, 0x7f,
+	0x1a, 0x4c, 0x5e, 0x38, 0x33, 0x2e, 0xd7, 0x28, 0x1c, 0xf6, 0x3e, 0xb4, 0x05, 0x75, 0x12, 0xc4,
+	0xa6, 0xbe, 0xa5, 0x6d, 0xaf, 0xd9, 0x05, 0xc3, 0x7a, 0x0a, 0xef, 0x1e, 0x87, 0x1c, 0x8d, 0xde,
+	0xf1, 0xb8, 0x0f, 0x7a, 0x10, 0x92, 0xb9, 0xde, 0x1e, 0xec, 0x86, 0xe7, 0xbb, 0x28, 0x3c, 0x0e,
+	0x6d, 0x3d, 0x08, 0xf1, 0x34, 0x3e, 0x6e, 0xa6, 0x8b, 0xd3, 0xe0, 0xb3, 0x75, 0x0d, 0xf7, 0xef,
+	0x1a, 0x8a, 0xc3, 0xc0, 0x8f, 0xf9, 0x4a, 0x4b, 0xf7, 0xa1, 0x11, 0xf1, 0x38, 0x9d, 0x26, 0x64,
+	0xab, 0x65, 0x4b, 0x0a, 0xf9, 0x02, 0x5a, 0xb3, 0x4a, 0x7b, 0x48, 0x8a, 0x19, 0x50, 0x9d, 0xc5,
+	0x13, 0xb3, 0x46, 0x4c, 0x7c, 0xb4, 0x76, 0xe0, 0x9e, 0x40, 0xf1, 0x2b, 0x20, 0x7e, 0x06, 0xec,
+	0x34, 0xe5, 0xd1, 0xed, 0x28, 0x71, 0x92, 0x34, 0x56, 0x34, 0xfd, 0x02, 0x3a, 0x7a, 0x66, 0x3b,
+	0x60, 0xc4, 0x97, 0x4e, 0x34, 0xf6, 0xfc, 0xc9, 0x49, 0x14, 0x4c, 0x22, 0x1e, 0xc7, 0xd2, 0xc3,
+	0x39, 0xbe, 0xf5, 0x21, 0xac, 0x93, 0xd5, 0xc3, 0x28, 0x0a, 0xa2, 0x15, 0x46, 0xad, 0x3f, 0x6a,
+	0x60, 0x3e, 0x73, 0xfc, 0xf1, 0x34, 0xf3, 0x75, 0x74, 0x3a, 0x5c, 0xe9, 0xc5, 0x03, 0x42, 0x4e,
+	0x27, 0xe4, 0xda, 0x88, 0xdc, 0xe8, 0x74, 0x58, 0x5c, 0x81, 0x13, 0x4d, 0x62, 0xb3, 0xba, 0x55,
+	0x45, 0x75, 0x7c, 0xc6, 0x9b, 0x3e, 0xcf, 0x6f, 0x5a, 0x40, 0x54, 0x30, 0x30, 0x4e, 0xe2, 0x57,
+	0xd3, 0x13, 0x27, 0x49, 0x78, 0xe4, 0x9b, 0x75, 0x12, 0x2b, 0x1c, 0xeb, 0x97, 0x70, 0xef, 0x20,
+	0x98, 0xcd, 0x02, 0xff, 0x17, 0x04, 0x75, 0x7e, 0x7d, 0xc5, 0x15, 0x69, 0x4b, 0xae, 0x48, 0x5f,
+	0x74, 0x45, 0xd5, 0xe2, 0x8a, 0xfe, 0xa6, 0xc1, 0x46, 0x09, 0xf7, 0xb7, 0x65, 0x99, 0x7d, 0x07,
+	0xd6, 0x62, 0x09, 0x25, 0x99, 0x36, 0x6b, 0x5b, 0xd5, 0xed, 0xce, 0xde, 0x3a, 0x61, 0xa5, 0x0a,
+	0xec, 0xb2, 0x1e, 0xfb, 0x04, 0x3a, 0x11, 0xbe, 0x44, 0x72, 0x19, 0xa2, 0xd1, 0xd9, 0x7b, 0x07,
+	0x97, 0xd9, 0x05, 0xdb, 0x56, 0x75, 0xac, 0xbf, 0x68, 0xc0, 0xd4, 0x7b, 0x7e, 0x6b, 0x87, 0xf8,
+	0x16, 0x74, 0xa5, 0x73, 0x64, 0x59, 0x9e, 0xc1, 0x50, 0xce, 0x20, 0x76, 0x2c, 0x69, 0xb1, 0x5d,
+	0x00, 0x72, 0x55, 0xac, 0x11, 0x07, 0xe8, 0xe5, 0x07, 0x10, 0x2b, 0x14, 0x0d, 0xeb, 0x4f, 0x1a,
+	0x74, 0x0e, 0x2e, 0xb9, 0x9b, 0x21, 0x70, 0x1f, 0x1a, 0xa1, 0x13, 0xc7, 0x7c, 0x9c, 0xf9, 0x2d,
+	0x28, 0x76, 0x0f, 0xea, 0x49, 0x90, 0x38, 0x53, 0x72, 0xbb, 0x6e, 0x0b, 0x82, 0x82, 0x27, 0x75,
+	0x5d, 0x1e, 0xc7, 0x17, 0xe9, 0x94, 0x9c, 0xaf, 0xdb, 0x0a, 0x07, 0xad, 0x5d, 0x38, 0xde, 0x94,
+	0x8f, 0x29, 0xee, 0xea, 0xb6, 0xa4, 0x30, 0x9b, 0xdd, 0x38, 0x91, 0xef, 0xf9, 0x13, 0x72, 0xb1,
+	0x6e, 0x67, 0x24, 0xae, 0x18, 0xf3, 0xc4, 0xf1, 0xa6, 0x66, 0x63, 0x4b, 0xdb, 0xee, 0xda, 0x92,
+	0xb2, 0xfe, 0xab, 0x01, 0x0c, 0xd2, 0x59, 0x28, 0xdd, 0xdc, 0x82, 0x0e, 0x79, 0x70, 0xe6, 0x9c,
+	0x4f, 0x79, 0x4c, 0xbe, 0xd6, 0x6d, 0x95, 0xc5, 0x1e, 0x43, 0xef, 0xc2, 0xf3, 0xbd, 0xf8, 0x92,
+	0x8f, 0xa5, 0x92, 0xf0, 0xfc, 0x0e, 0x97, 0x3d, 0x82, 0x35, 0x1e, 0x27, 0xde, 0xcc, 0x49, 0xf8,
+	0xd8, 0x0e, 0x6e, 0x62, 0x3a, 0x45, 0xd5, 0x2e, 0x33, 0xf1, 0xa0, 0xe3, 0x74, 0x16, 0x4a, 0x95,
+	0x1a, 0xa9, 0x28, 0x1c, 0x66, 0x41, 0xf7, 0x26, 0xf2, 0x92, 0x84, 0xfb, 0xfb, 0xb7, 0x09, 0x17,
+	0x91, 0x53, 0xb5, 0x4b, 0x3c, 0xd4, 0x71, 0xd3, 0x28, 0xe2, 0x7e, 0xf2, 0xc4, 0x43, 0x7f, 0x1a,
+	0xf4, 0x8e, 0x96, 0x78, 0x08, 0x0c, 0x9f, 0x3a, 0x21, 0xe2, 0xdf, 0x14, 0x69, 0x5e, 0x92, 0xd6,
+	0x6f, 0x34, 0x80, 0x61, 0xe0, 0x8c, 0x25, 0x00, 0x8f, 0x60, 0x2d, 0x3b, 0x88, 0xd8, 0x51, 0x13,
+	0x6e, 0x97, 0x98, 0xe8, 0x36, 0x61, 0x22, 0x54, 0x74, 0xe1, 0x76, 0xc1, 0x61, 0x7d, 0x68, 0x85,
+	0x59, 0x1e, 0x13, 0xa1, 0x97, 0xd3, 0xb8, 0x76, 0xc6, 0x13, 0x47, 0xd4, 0x04, 0x99, 0x37, 0x14,
+	0x8e, 0xf5, 0x67, 0x0d, 0xd6, 0x46, 0x32, 0xe9, 0x3d, 0x8d, 0x82, 0x94, 0xb2, 0x76, 0xe2, 0x44,
+	0x13, 0x9e, 0x95, 0x28, 0x49, 0x61, 0x52, 0x1a, 0x0c, 0x86, 0xb8, 0x3f, 0x25, 0x25, 0x7c, 0xc6,
+	0x9d, 0x2f, 0xbc, 0x28, 0x4e, 0x4e, 0x82, 0x7c, 0xe7, 0x8c, 0x46, 0x3b, 0xf1, 0xad, 0xef, 0x52,
+	0xd4, 0xe0, 0x0a, 0x49, 0xe1, 0x9a, 0xd4, 0x97, 0x92, 0x3a, 0x49, 0x72, 0x9a, 0xed, 0x2a, 0x27,
+	0x69, 0xd0, 0x9b, 0xc2, 0xe8, 0x4d, 0x91, 0x0e, 0x8e, 0x82, 0x34, 0x72, 0x79, 0x71, 0x3a, 0xeb,
+	0x19, 0xf4, 0xca, 0x32, 0xda, 0x95, 0x9e, 0x32, 0xef, 0x05, 0x85, 0x38, 0x4c, 0x9d, 0x38, 0x91,
+	0x38, 0x88, 0xb7, 0x56, 0xe1, 0x58, 0x7f, 0xad, 0x02, 0x8c, 0x6e, 0x7d, 0xf7, 0x4e, 0x64, 0x1e,
+	0x5e, 0x73, 0x3f, 0xc9, 0xae, 0x45, 0x65, 0xe1, 0x31, 0x44, 0xa0, 0x86, 0xd9, 0x95, 0xe4, 0x34,
+	0xe6, 0xea, 0x88, 0xbb, 0xdc, 0x4f, 0xce, 0x42, 0x81, 0x4b, 0xd5, 0x2e, 0x18, 0x18, 0x41, 0x33,
+	0x27, 0x4e, 0x78, 0x54, 0xba, 0x94, 0x12, 0x0f, 0x4b, 0x94, 0x4a, 0x3f, 0x4d, 0xbc, 0xb1, 0xcc,
+	0xea, 0x73, 0x7c, 0xb4, 0x47, 0xf0, 0x65, 0xf6, 0x1a, 0xc2, 0x9e, 0xca, 0x43, 0x7b, 0x2a, 0x4d,
+	0xf6, 0x44, 0x68, 0xce, 0xf1, 0xd1, 0xde, 0xf9, 0x34, 0x70, 0xaf, 0x3c, 0x7f, 0x42, 0x17, 0xde,
+	0x12, 0x11, 0xae, 0xf2, 0xd8, 0xa7, 0x60, 0xa4, 0x7e, 0xc4, 0xe3, 0x60, 0x7a, 0xcd, 0xc7, 0x14,
+	0x37, 0xb1, 0xd9, 0x56, 0xd2, 0xb3, 0x1a, 0x51, 0xf6, 0x9c, 0xaa, 0x12, 0x1b, 0x20, 0xf2, 0x93,
+	0xa0, 0xd8, 0xf7, 0xa0, 0x17, 0xab, 0x4b, 0x63, 0xb3, 0xb3, 0xcc, 0xe8, 0x1d, 0x45, 0xeb, 0xef,
+	0x3a, 0x74, 0x94, 0xf4, 0x3e, 0x87, 0xb2, 0xf6, 0x15, 0x51, 0xd6, 0x97, 0xa0, 0xbc, 0x95, 0x15,
+	0x95, 0xf4, 0x7c, 0xe0, 0x65, 0x9d, 0x8b, 0xca, 0xca, 0x35, 0x4a, 0xd7, 0xaa, 0xb2, 0xd8, 0x36,
+	0xbc, 0xa3, 0x90, 0xca, 0xa5, 0xde, 0x65, 0xb3, 0x5d, 0x60, 0xc4, 0x3a, 0x70, 0x12, 0xf7, 0xf2,
+	0x65, 0xf8, 0x9c, 0xbc, 0xa1, 0x9b, 0x6d, 0xd9, 0x0b, 0x24, 0xec, 0xeb, 0x50, 0x8f, 0x13, 0x67,
+	0xc2, 0xcd, 0xa6, 0xd2, 0x4f, 0x20, 0xc3, 0x16, 0x7c, 0xf6, 0x51, 0x5e, 0xc9, 0x5a, 0x5b, 0x5a,
+	0x86, 0xe8, 0x49, 0x14, 0x60, 0x8e, 0xb7, 0x49, 0x90, 0x15, 0x37, 0xeb, 0x3f, 0x3a, 0xac, 0x95,
+	0xea, 0xeb, 0xc2, 0xf6, 0x25, 0xdf, 0x51, 0x5f, 0xb2, 0xe3, 0x16, 0xd4, 0x52, 0xdf, 0x4b, 0x08,
+	0xa9, 0xde, 0x5e, 0x17, 0xe5, 0x2f, 0x7d, 0x2f, 0x39, 0xbb, 0x0d, 0xb9, 0x4d, 0x12, 0xc5, 0xa7,
+	0xda, 0x97, 0xf8, 0xc4, 0x3e, 0x86, 0x8d, 0x22, 0x88, 0x06, 0x83, 0xe1, 0x30, 0x70, 0xaf, 0x8e,
+	0x06, 0x12, 0xbd, 0x45, 0x22, 0xc6, 0x44, 0x29, 0xa6, 0x97, 0xe1, 0x59, 0x45, 0x14, 0xe3, 0x0f,
+	0xa1, 0xee, 0x62, 0x95, 0x34, 0x9b, 0x45, 0x4b, 0xa0, 0x94, 0xcd, 0x67, 0x15, 0x5b, 0xc8, 0xd9,
+	0x23, 0xa8, 0x61, 0x59, 0x30, 0x5b, 0x45, 0xe5, 0x2d, 0xca, 0xd6, 0xb3, 0x8a, 0x4d, 0x52, 0xd4,
+	0x9a, 0x06, 0xce, 0xd8, 0x6c, 0x17, 0x5a, 0x45, 0x6e, 0x47, 0x2d, 0x94, 0xa2, 0x16, 0x46, 0xb7,
+	0x09, 0x85, 0x56, 0x91, 0x68, 0x50, 0x0b, 0xa5, 0xfb, 0x2d, 0x68, 0xc4, 0xc4, 0xb1, 0x7e, 0x04,
+	0xeb, 0x25, 0xf4, 0x87, 0x5e, 0x4c, 0x50, 0x09, 0xb1, 0xa9, 0x2d, 0x6b, 0x82, 0xb2, 0xf5, 0x9b,
+	0x00, 0x74, 0x26, 0xd1, 0x49, 0xc8, 0x8e, 0x44, 0x2b, 0x1a, 0xb6, 0x87, 0xd0, 0xc6, 0xb3, 0xac,
+	0x10, 0xe3, 0x21, 0x96, 0x89, 0x43, 0xe8, 0x92, 0xf7, 0xa7, 0xc3, 0x25, 0x1a, 0x6c, 0x0f, 0xee,
+	0x89, 0xfe, 0x20, 0xff, 0x0e, 0xf1, 0x12, 0x2f, 0xf0, 0xe5, 0x8b, 0xb5, 0x50, 0x86, 0xc9, 0x94,
+	0xa3, 0xb9, 0xd1, 0xe9, 0x30, 0xab, 0x23, 0x19, 0x6d, 0x7d, 0x1b, 0xda, 0xb8, 0xa3, 0xd8, 0x6e,
+	0x1b, 0x1a, 0x24, 0xc8, 0x70, 0x30, 0x72, 0x38, 0xa5, 0x43, 0xb6, 0x94, 0x23, 0x0c, 0x45, 0x83,
+	0xb4, 0xe0, 0x20, 0x7f, 0xd0, 0xa1, 0xab, 0x76, 0x60, 0xff, 0xaf, 0x20, 0x67, 0xca, 0x47, 0x4d,
+	0x16, 0x87, 0x8f, 0xb3, 0x38, 0x54, 0x3a, 0xbb, 0xe2, 0xce, 0x8a, 0x30, 0xfc, 0x40, 0x86, 0x61,
+	0x83, 0xd4, 0xd6, 0xb2, 0x30, 0xcc, 0xb4, 0x48, 0x88, 0x4a, 0x14, 0x85, 0xcd, 0x42, 0x29, 0xbf,
+	0xc0, 0x3c, 0x08, 0x3f, 0x90, 0x41, 0xd8, 0x2a, 0x94, 0x72, 0x50, 0xf3, 0x18, 0x6c, 0x42, 0x9d,
+	0xc0, 0xb3, 0xbe, 0x0f, 0x86, 0x0a, 0x0d, 0x45, 0xe0, 0x63, 0x29, 0x2c, 0x01, 0xaf, 0x28, 0xd9,
+	0x72, 0xed, 0x2b, 0x58, 0x2b, 0xbd, 0xc2, 0x58, 0x79, 0xbd, 0xf8, 0xc0, 0xf1, 0x5d, 0x3e, 0xcd,
+	0xfb, 0x51, 0x85, 0xa3, 0x5c, 0xa9, 0x5e, 0x58, 0x96, 0x26, 0x4a, 0x57, 0xaa, 0x74, 0x95, 0xd5,
+	0x52, 0x57, 0x79, 0x00, 0x5d, 0x55, 0x9f, 0x7d, 0x03, 0x6a, 0x78, 0x01, 0xf2, 0xab, 0x94, 0x0e,
+	0x4b, 0x02, 0x71, 0x2b, 0xf8, 0x9b, 0xc5, 0x83, 0x5e, 0xc4, 0xc3, 0xaf, 0x75, 0x68, 0x0e, 0x06,
+	0xc3, 0x23, 0xff, 0x22, 0x58, 0xf4, 0x79, 0x89, 0x9b, 0xc7, 0xee, 0x25, 0x9f, 0x39, 0x72, 0x91,
+	0xa4, 0xa8, 0xa5, 0xc6, 0x1e, 0x54, 0xc6, 0xad, 0x20, 0xf2, 0x66, 0xa9, 0xa6, 0x34, 0x4b, 0x9b,
+	0x00, 0x41, 0x98, 0x78, 0x33, 0x2f, 0x4e, 0x3c, 0x97, 0xae, 0xbe, 0x65, 0x2b, 0x1c, 0xa5, 0x75,
+	0x69, 0x94, 0x5a, 0x17, 0x13, 0x9a, 0xe2, 0x29, 0x36, 0x9b, 0x64, 0x2e, 0x23, 0xa9, 0x4b, 0xc1,
+	0xed, 0xf6, 0xf9, 0x45, 0x10, 0x71, 0xba, 0xdc, 0xb6, 0xad, 0xb2, 0x70, 0x4f, 0x22, 0x3f, 0xbb,
+	0xc0, 0xfa, 0xd1, 0x26, 0x05, 0x85, 0x83, 0x7b, 0x4e, 0x45, 0x2a, 0x05, 0xb1, 0xa7, 0xa0, 0xac,
+	0x4f, 0xa0, 0x93, 0xa5, 0xd2, 0x65, 0x80, 0xf4, 0x40, 0x3f, 0x1a, 0x48, 0x30, 0xf4, 0xa3, 0x81,
+	0xf5, 0x7b, 0x0d, 0x7a, 0x87, 0x9f, 0x73, 0x77, 0x30, 0x18, 0xae, 0xf8, 0x4c, 0x57, 0x76, 0xd4,
+	0xd5, 0x1d, 0x51, 0x97, 0x7f, 0xce, 0x5d, 0x82, 0xb1, 0x65, 0xd3, 0x33, 0xf5, 0x58, 0x91, 0xe3,
+	0xf2, 0xa7, 0x47, 0x03, 0x59, 0x4e, 0x73, 0x1a, 0x71, 0xe7, 0xf9, 0xd7, 0x51, 0x5b, 0x46, 0x5f,
+	0x8e, 0x7b, 0xa3, 0xc0, 0xdd, 0xfa, 0x95, 0x06, 0x1b, 0xfb, 0x11, 0x77, 0xae, 0xe4, 0x89, 0x56,
+	0x79, 0x67, 0x41, 0x37, 0xe2, 0xb3, 0xe0, 0x9a, 0x0f, 0x55, 0x1f, 0x4b, 0x3c, 0xea, 0xee, 0xc5,
+	0x39, 0xa5, 0xb3, 0x19, 0x89, 0x92, 0xf8, 0xca, 0x0b, 0x51, 0x52, 0x13, 0x12, 0x49, 0x5a, 0x0e,
+	0x3c, 0x18, 0x72, 0xe7, 0x9a, 0x97, 0x7b, 0x98, 0xd5, 0x30, 0xc9, 0x2e, 0x5c, 0x2f, 0x75, 0xe1,
+	0x4a, 0x30, 0x54, 0x4b, 0xc1, 0x60, 0xf5, 0xc1, 0x1c, 0xdd, 0x78, 0x89, 0x7b, 0x49, 0x69, 0x4f,
+	0xf4, 0x05, 0x72, 0x07, 0x6b, 0x0f, 0x36, 0xe4, 0xfc, 0xa6, 0x34, 0x5d, 0xfa, 0x9a, 0x32, 0xbc,
+	0xe9, 0xe4, 0x9f, 0x97, 0x62, 0x08, 0x61, 0xa5, 0x70, 0xaf, 0xbc, 0x46, 0x7e, 0x13, 0xaf, 0x5a,
+	0xf4, 0x16, 0x46, 0x3e, 0x37, 0xb0, 0x7e, 0x92, 0x46, 0x93, 0xb2, 0xa3, 0x7d, 0x68, 0x79, 0xbe,
+	0xe3, 0x26, 0xde, 0x35, 0x97, 0x19, 0x24, 0xa7, 0x09, 0x3d, 0x4f, 0xce, 0xab, 0xaa, 0x36, 0x3d,
+	0x8b, 0xef, 0x92, 0x29, 0xa7, 0x7c, 0x9e, 0x7f, 0x97, 0x08, 0x9a, 0x5e, 0x33, 0xd1, 0xc3, 0xd5,
+	0xe4, 0x6b, 0x46, 0x14, 0xe2, 0x47, 0x13, 0x00, 0x31, 0x21, 0x39, 0x08, 0xfc, 0x0b, 0x6f, 0x92,
+	0xe1, 0xf7, 0x3b, 0x0d, 0x1e, 0x2c, 0x10, 0xbe, 0xb5, 0x29, 0x41, 0x1f, 0x5a, 0xe2, 0x1a, 0x8b,
+	0x40, 0xcf, 0x68, 0x75, 0x66, 0x58, 0x2f, 0xcd, 0x0c, 0x77, 0xbe, 0x0b, 0x0d, 0x31, 0x6d, 0x63,
+	0x6b, 0xd0, 0x3e, 0xf2, 0xaf, 0x9d, 0xa9, 0x37, 0x3e, 0x0e, 0x8d, 0x0a, 0x6b, 0x41, 0x6d, 0x94,
+	0x04, 0xa1, 0xa1, 0xb1, 0x36, 0xd4, 0x4f, 0x9c, 0x34, 0xe6, 0x86, 0xce, 0x00, 0x1a, 0x98, 0x91,
+	0x67, 0xdc, 0xa8, 0xee, 0xec, 0x40, 0x9d, 0xa6, 0x4d, 0xa4, 0xf9, 0xb3, 0xa3, 0x13, 0xa3, 0xc2,
+	0x3a, 0xd0, 0xb4, 0x0f, 0x4f, 0x86, 0x9f, 0x1d, 0x1c, 0x1a, 0x1a, 0xea, 0x1e, 0xbd, 0xf8, 0xe9,
+	0xe1, 0xc1, 0x99, 0xa1, 0xef, 0xfc, 0x1c, 0xea, 0x54, 0xf2, 0x98, 0x01, 0x5d, 0xb9, 0x09, 0xd1,
+	0x46, 0x85, 0x35, 0xa1, 0xfa, 0x82, 0xdf, 0x18, 0x1a, 0x2d, 0x4e, 0x7d, 0xfc, 0xf4, 0x17, 0x1b,
+	0xd1, 0x9e, 0x63, 0xa3, 0x8a, 0x02, 0xf4, 0x24, 0xe4, 0x63, 0xa3, 0xc6, 0xba, 0xd0, 0x7a, 0x22,
+	0x3f, 0x6c, 0x8d, 0xfa, 0xce, 0x31, 0xb4, 0xb2, 0x52, 0xc9, 0xde, 0x81, 0x8e, 0x34, 0x8d, 0x2c,
+	0xa3, 0x82, 0x7e, 0x53, 0x41, 0x34, 0x34, 0x74, 0x11, 0x8b, 0x9e, 0xa1, 0xe3, 0x13, 0x56, 0x36,
+	0xa3, 0x4a, 0x6e, 0xdf, 0xfa, 0xae, 0x51, 0x43, 0x45, 0x8a, 0x14, 0x63, 0xbc, 0xf3, 0x03, 0x68,
+	0xe7, 0x69, 0x1e, 0x9d, 0x7d, 0xe9, 0x5f, 0xf9, 0xc1, 0x8d, 0x4f, 0x3c, 0x71, 0x40, 0x4c, 0x4f,
+	0xa3, 0xd3, 0xa1, 0xa1, 0xe1, 0x86, 0x64, 0xff, 0x09, 0x75, 0x23, 0x86, 0xbe, 0xf3, 0x1c, 0x9a,
+	0x32, 0x8e, 0x19, 0x83, 0x9e, 0x74, 0x46, 0x72, 0x8c, 0x0a, 0x02, 0x8c, 0xe7, 0x10, 0x5b, 0x69,
+	0xac, 0x07, 0x40, 0x47, 0x14, 0xb4, 0x8e, 0xe6, 0x04, 0xb6, 0x82, 0x51, 0xdd, 0xfb, 0x77, 0x0b,
+	0x1a, 0x22, 0x56, 0xd8, 0x01, 0x74, 0xd5, 0xa1, 0x31, 0x7b, 0x4f, 0x36, 0x11, 0x77, 0xc7, 0xc8,
+	0x7d, 0x93, 0xda, 0x80, 0x05, 0x53, 0x3a, 0xab, 0xc2, 0x8e, 0xa0, 0x57, 0x1e, 0xc0, 0xb2, 0x07,
+	0xa8, 0xbd, 0x70, 0xba, 0xdb, 0xef, 0x2f, 0x12, 0xe5, 0xa6, 0x0e, 0x61, 0xad, 0x34, 0x53, 0x65,
+	0xb4, 0xef, 0xa2, 0x31, 0xeb, 0x4a, 0x8f, 0x7e, 0x02, 0x1d, 0x65, 0xec, 0xc7, 0xee, 0xa3, 0xea,
+	0xfc, 0xfc, 0xb5, 0xff, 0xde, 0x1c, 0x3f, 0xb7, 0xf0, 0x29, 0x40, 0x31, 0x72, 0x63, 0xef, 0xe6,
+	0x8a, 0xea, 0xa8, 0xb5, 0x7f, 0xff, 0x2e, 0x3b, 0x5f, 0xfe, 0x04, 0x40, 0xce, 0x5b, 0x4f, 0x87,
+	0x31, 0x7b, 0x1f, 0xf5, 0x96, 0xcd, 0x5f, 0x57, 0x1e, 0x64, 0x0f, 0xba, 0x4f, 0x78, 0xe2, 0x5e,
+	0x66, 0xc5, 0x9f, 0xbe, 0x0a, 0x94, 0xe2, 0xd7, 0xef, 0x48, 0x06, 0x12, 0x56, 0x65, 0x5b, 0xfb,
+	0x58, 0x63, 0x3f, 0x04, 0xc0, 0x58, 0x4a, 0x13, 0x8e, 0x69, 0x9f, 0x66, 0x14, 0xe5, 0xd2, 0xb7,
+	0x72, 0xc7, 0x03, 0xe8, 0xaa, 0xf5, 0x48, 0x44, 0xc4, 0x82, 0x0a, 0xb5, 0xd2, 0xc8, 0x31, 0xb0,
+	0xf9, 0x8a, 0xc2, 0x1e, 0x52, 0xfb, 0xb7, 0xac, 0xd2, 0xac, 0x34, 0xf8, 0x1c, 0xd6, 0xe7, 0xea,
+	0x87, 0x80, 0x75, 0x59, 0x59, 0xf9, 0xb2, 0x43, 0xaa, 0xe5, 0x43, 0x1c, 0x72, 0x41, 0x11, 0xea,
+	0x9b, 0xf3, 0x82, 0xdc, 0xc8, 0x8f, 0x01, 0x8a, 0x62, 0x20, 0x42, 0x64, 0xae, 0x38, 0xac, 0xf4,
+	0xe2, 0x29, 0xac, 0x2b, 0x7f, 0xc3, 0x88, 0xbc, 0x2d, 0x62, 0x75, 0xfe, 0xdf, 0x99, 0x95, 0x86,
+	0x6c, 0xf9, 0x3f, 0x80, 0x5a, 0x00, 0x04, 0x3a, 0xcb, 0x8a, 0x46, 0xff, 0xe1, 0x12, 0xa9, 0x0a,
+	0x91, 0xfa, 0x9f, 0x8f, 0x80, 0x68, 0xc1, 0xbf, 0x40, 0xab, 0x1c, 0xdb, 0x37, 0xff, 0xf1, 0x7a,
+	0x53, 0xfb, 0xe2, 0xf5, 0xa6, 0xf6, 0xaf, 0xd7, 0x9b, 0xda, 0x6f, 0xdf, 0x6c, 0x56, 0xbe, 0x78,
+	0xb3, 0x59, 0xf9, 0xe7, 0x9b, 0xcd, 0xca, 0x79, 0x83, 0xfe, 0xb8, 0xfa, 0xe6, 0xff, 0x06, 0x00,
+	0x79, 0x9e, 0x63, 0xeb, 0xca, 0x1a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3293,6 +3573,9 @@ type WorkerClient interface {
 	// BreakDDLLock request a dm-worker to break a DDL lock
 	// including remove DDLLockInfo and/or execute/skip DDL
 	BreakDDLLock(ctx context.Context, in *BreakDDLLockRequest, opts ...grpc.CallOption) (*CommonWorkerResponse, error)
+	// LeaveShardingGroup request a dm-worker to remove idle upstream tables from a sharding group
+	// so the sharding group can be synced without them
+	LeaveShardingGroup(ctx context.Context, in *LeaveShardingGroupRequest, opts ...grpc.CallOption) (*CommonWorkerResponse, error)
 	// SwitchRelayMaster request a dm-worker's relay unit switching to another master server
 	SwitchRelayMaster(ctx context.Context, in *SwitchRelayMasterRequest, opts ...grpc.CallOption) (*CommonWorkerResponse, error)
 	// OperateRelay operates relay unit
@@ -3416,6 +3699,15 @@ func (c *workerClient) BreakDDLLock(ctx context.Context, in *BreakDDLLockRequest
 	return out, nil
 }
 
+func (c *workerClient) LeaveShardingGroup(ctx context.Context, in *LeaveShardingGroupRequest, opts ...grpc.CallOption) (*CommonWorkerResponse, error) {
+	out := new(CommonWorkerResponse)
+	err := c.cc.Invoke(ctx, "/pb.Worker/LeaveShardingGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workerClient) SwitchRelayMaster(ctx context.Context, in *SwitchRelayMasterRequest, opts ...grpc.CallOption) (*CommonWorkerResponse, error) {
 	out := new(CommonWorkerResponse)
 	err := c.cc.Invoke(ctx, "/pb.Worker/SwitchRelayMaster", in, out, opts...)
@@ -3487,6 +3779,9 @@ type WorkerServer interface {
 	// BreakDDLLock request a dm-worker to break a DDL lock
 	// including remove DDLLockInfo and/or execute/skip DDL
 	BreakDDLLock(context.Context, *BreakDDLLockRequest) (*CommonWorkerResponse, error)
+	// LeaveShardingGroup request a dm-worker to remove idle upstream tables from a sharding group
+	// so the sharding group can be synced without them
+	LeaveShardingGroup(context.Context, *LeaveShardingGroupRequest) (*CommonWorkerResponse, error)
 	// SwitchRelayMaster request a dm-worker's relay unit switching to another master server
 	SwitchRelayMaster(context.Context, *SwitchRelayMasterRequest) (*CommonWorkerResponse, error)
 	// OperateRelay operates relay unit
@@ -3673,6 +3968,24 @@ func _Worker_BreakDDLLock_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker_LeaveShardingGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveShardingGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).LeaveShardingGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Worker/LeaveShardingGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).LeaveShardingGroup(ctx, req.(*LeaveShardingGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Worker_SwitchRelayMaster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SwitchRelayMasterRequest)
 	if err := dec(in); err != nil {
@@ -3817,6 +4130,10 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BreakDDLLock",
 			Handler:    _Worker_BreakDDLLock_Handler,
 		},
+		{
+			MethodName: "LeaveShardingGroup",
+			Handler:    _Worker_LeaveShardingGroup_Handler,
+		},
 		{
 			MethodName: "SwitchRelayMaster",
 			Handler:    _Worker_SwitchRelayMaster_Handler,
@@ -4049,6 +4366,16 @@ func (m *QueryStatusRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Name)))
 		i += copy(dAtA[i:], m.Name)
 	}
+	if m.ShardingProgress {
+		dAtA[i] = 0x10
+		i++
+		if m.ShardingProgress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -4365,18 +4692,64 @@ func (m *DumpStatus) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	return i, nil
-}
-
-func (m *LoadStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.TotalTables != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(m.TotalTables))
 	}
-	return dAtA[:n], nil
-}
+	if m.FinishedTables != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(m.FinishedTables))
+	}
+	if m.EstimatedRows != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(m.EstimatedRows))
+	}
+	if m.DumpedRows != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(m.DumpedRows))
+	}
+	if m.WrittenBytes != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(m.WrittenBytes))
+	}
+	if len(m.CurrentFiles) > 0 {
+		for _, s := range m.CurrentFiles {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Elapsed) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Elapsed)))
+		i += copy(dAtA[i:], m.Elapsed)
+	}
+	return i, nil
+}
+
+func (m *LoadStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
 
 func (m *LoadStatus) MarshalTo(dAtA []byte) (int, error) {
 	var i int
@@ -4480,6 +4853,48 @@ func (m *ShardingGroup) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.Progress) > 0 {
+		for _, msg := range m.Progress {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintDmworker(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ShardingSource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardingSource) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Source) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
+	if len(m.LastBinlog) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.LastBinlog)))
+		i += copy(dAtA[i:], m.LastBinlog)
+	}
 	return i, nil
 }
 
@@ -4574,6 +4989,18 @@ func (m *SyncStatus) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.ShardingGroups) > 0 {
+		for _, msg := range m.ShardingGroups {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintDmworker(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -5221,6 +5648,55 @@ func (m *DDLInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.Optimistic {
+		dAtA[i] = 0x28
+		i++
+		if m.Optimistic {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Source) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
+		i += copy(dAtA[i:], m.Source)
+	}
+	if len(m.Sources) > 0 {
+		for _, s := range m.Sources {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.TableBefore) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.TableBefore)))
+		i += copy(dAtA[i:], m.TableBefore)
+	}
+	if len(m.TableAfter) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.TableAfter)))
+		i += copy(dAtA[i:], m.TableAfter)
+	}
+	if len(m.LockID) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.LockID)))
+		i += copy(dAtA[i:], m.LockID)
+	}
 	return i, nil
 }
 
@@ -5297,6 +5773,27 @@ func (m *ExecDDLRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDmworker(dAtA, i, uint64(len(m.TraceGID)))
 		i += copy(dAtA[i:], m.TraceGID)
 	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if len(m.DDLs) > 0 {
+		for _, s := range m.DDLs {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
 	return i, nil
 }
 
@@ -5350,6 +5847,51 @@ func (m *BreakDDLLockRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LeaveShardingGroupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveShardingGroupRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Task) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Task)))
+		i += copy(dAtA[i:], m.Task)
+	}
+	if len(m.Target) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Target)))
+		i += copy(dAtA[i:], m.Target)
+	}
+	if len(m.Sources) > 0 {
+		for _, s := range m.Sources {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
 func (m *SwitchRelayMasterRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5664,6 +6206,9 @@ func (m *QueryStatusRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	if m.ShardingProgress {
+		n += 2
+	}
 	return n
 }
 
@@ -5824,6 +6369,31 @@ func (m *DumpStatus) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.TotalTables != 0 {
+		n += 1 + sovDmworker(uint64(m.TotalTables))
+	}
+	if m.FinishedTables != 0 {
+		n += 1 + sovDmworker(uint64(m.FinishedTables))
+	}
+	if m.EstimatedRows != 0 {
+		n += 1 + sovDmworker(uint64(m.EstimatedRows))
+	}
+	if m.DumpedRows != 0 {
+		n += 1 + sovDmworker(uint64(m.DumpedRows))
+	}
+	if m.WrittenBytes != 0 {
+		n += 1 + sovDmworker(uint64(m.WrittenBytes))
+	}
+	if len(m.CurrentFiles) > 0 {
+		for _, s := range m.CurrentFiles {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	l = len(m.Elapsed)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -5882,6 +6452,29 @@ func (m *ShardingGroup) Size() (n int) {
 			n += 1 + l + sovDmworker(uint64(l))
 		}
 	}
+	if len(m.Progress) > 0 {
+		for _, e := range m.Progress {
+			l = e.Size()
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ShardingSource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.LastBinlog)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -5931,6 +6524,12 @@ func (m *SyncStatus) Size() (n int) {
 	if m.Synced {
 		n += 2
 	}
+	if len(m.ShardingGroups) > 0 {
+		for _, e := range m.ShardingGroups {
+			l = e.Size()
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6321,6 +6920,31 @@ func (m *DDLInfo) Size() (n int) {
 			n += 1 + l + sovDmworker(uint64(l))
 		}
 	}
+	if m.Optimistic {
+		n += 2
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if len(m.Sources) > 0 {
+		for _, s := range m.Sources {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	l = len(m.TableBefore)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.TableAfter)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.LockID)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -6362,6 +6986,16 @@ func (m *ExecDDLRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDmworker(uint64(l))
 	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if len(m.DDLs) > 0 {
+		for _, s := range m.DDLs {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6388,23 +7022,46 @@ func (m *BreakDDLLockRequest) Size() (n int) {
 	return n
 }
 
-func (m *SwitchRelayMasterRequest) Size() (n int) {
+func (m *LeaveShardingGroupRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Task)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if len(m.Sources) > 0 {
+		for _, s := range m.Sources {
+			l = len(s)
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *OperateRelayRequest) Size() (n int) {
+func (m *SwitchRelayMasterRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Op != 0 {
-		n += 1 + sovDmworker(uint64(m.Op))
+	return n
+}
+
+func (m *OperateRelayRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Op != 0 {
+		n += 1 + sovDmworker(uint64(m.Op))
 	}
 	return n
 }
@@ -7185,6 +7842,26 @@ func (m *QueryStatusRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardingProgress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ShardingProgress = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -8257,6 +8934,165 @@ func (m *DumpStatus) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: DumpStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalTables", wireType)
+			}
+			m.TotalTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalTables |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedTables", wireType)
+			}
+			m.FinishedTables = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FinishedTables |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedRows", wireType)
+			}
+			m.EstimatedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EstimatedRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DumpedRows", wireType)
+			}
+			m.DumpedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DumpedRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WrittenBytes", wireType)
+			}
+			m.WrittenBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WrittenBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentFiles", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CurrentFiles = append(m.CurrentFiles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elapsed", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Elapsed = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -8625,6 +9461,40 @@ func (m *ShardingGroup) Unmarshal(dAtA []byte) error {
 			}
 			m.Unsynced = append(m.Unsynced, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Progress = append(m.Progress, &ShardingSource{})
+			if err := m.Progress[len(m.Progress)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -8649,7 +9519,7 @@ func (m *ShardingGroup) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SyncStatus) Unmarshal(dAtA []byte) error {
+func (m *ShardingSource) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8672,17 +9542,17 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SyncStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardingSource: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SyncStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardingSource: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalEvents", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			m.TotalEvents = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -8692,35 +9562,29 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalEvents |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalTps", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
 			}
-			m.TotalTps = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDmworker
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TotalTps |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RecentTps", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.RecentTps = 0
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBinlog", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDmworker
@@ -8730,18 +9594,141 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RecentTps |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlog", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastBinlog = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalEvents", wireType)
+			}
+			m.TotalEvents = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalEvents |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalTps", wireType)
+			}
+			m.TotalTps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalTps |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecentTps", wireType)
+			}
+			m.RecentTps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RecentTps |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterBinlog", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
 					return ErrIntOverflowDmworker
 				}
 				if iNdEx >= l {
@@ -8949,6 +9936,40 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Synced = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardingGroups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShardingGroups = append(m.ShardingGroups, &ShardingGroup{})
+			if err := m.ShardingGroups[len(m.ShardingGroups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -11071,62 +12092,61 @@ func (m *DDLInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.DDLs = append(m.DDLs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDmworker(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Optimistic", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthDmworker
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthDmworker
+			m.Optimistic = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DDLLockInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDmworker
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DDLLockInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DDLLockInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sources", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11154,11 +12174,11 @@ func (m *DDLLockInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Task = string(dAtA[iNdEx:postIndex])
+			m.Sources = append(m.Sources, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TableBefore", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11186,14 +12206,195 @@ func (m *DDLLockInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.TableBefore = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDmworker(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableAfter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableAfter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DDLLockInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DDLLockInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DDLLockInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Task = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
 			if skippy < 0 {
 				return ErrInvalidLengthDmworker
 			}
@@ -11357,6 +12558,70 @@ func (m *ExecDDLRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.TraceGID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DDLs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DDLs = append(m.DDLs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -11538,6 +12803,155 @@ func (m *BreakDDLLockRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LeaveShardingGroupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveShardingGroupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveShardingGroupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Task = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sources", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sources = append(m.Sources, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SwitchRelayMasterRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -49,10 +50,13 @@ func NewConfig() *Config {
 	fs.BoolVar(&cfg.printSampleConfig, "print-sample-config", false, "print sample config file of dm-worker")
 	fs.StringVar(&cfg.ConfigFile, "config", "", "path to config file")
 	fs.StringVar(&cfg.WorkerAddr, "worker-addr", "", "worker API server and status addr")
+	fs.StringVar(&cfg.AdvertiseAddr, "advertise-addr", "", `advertise address for dm-master to access (default "${worker-addr}")`)
+	fs.StringVar(&cfg.MasterAddr, "master-addr", "", "comma separated addresses of dm-master group to register to, do not register if empty")
 	fs.StringVar(&cfg.LogLevel, "L", "info", "log level: debug, info, warn, error, fatal")
 	fs.StringVar(&cfg.LogFile, "log-file", "", "log file path")
 	//fs.StringVar(&cfg.LogRotate, "log-rotate", "day", "log file rotate type, hour/day")
 	fs.StringVar(&cfg.RelayDir, "relay-dir", "./relay_log", "relay log directory")
+	fs.StringVar(&cfg.MetaDir, "meta-dir", "", `directory used to store meta of sub tasks (default "dm_worker_meta" beside ${relay-dir})`)
 	fs.Int64Var(&cfg.Purge.Interval, "purge-interval", 60*60, "interval (seconds) try to check whether needing to purge relay log files")
 	fs.Int64Var(&cfg.Purge.Expires, "purge-expires", 0, "try to purge relay log files if their modified time is older than this (hours)")
 	fs.Int64Var(&cfg.Purge.RemainSpace, "purge-remain-space", 15, "try to purge relay log files if remain space is less than this (GB)")
@@ -72,11 +76,14 @@ type Config struct {
 	LogFile   string `toml:"log-file" json:"log-file"`
 	LogRotate string `toml:"log-rotate" json:"log-rotate"`
 
-	WorkerAddr string `toml:"worker-addr" json:"worker-addr"`
+	WorkerAddr    string `toml:"worker-addr" json:"worker-addr"`
+	AdvertiseAddr string `toml:"advertise-addr" json:"advertise-addr"`
+	MasterAddr    string `toml:"master-addr" json:"master-addr"`
 
 	EnableGTID  bool   `toml:"enable-gtid" json:"enable-gtid"`
 	AutoFixGTID bool   `toml:"auto-fix-gtid" json:"auto-fix-gtid"`
 	RelayDir    string `toml:"relay-dir" json:"relay-dir"`
+	MetaDir     string `toml:"meta-dir" json:"meta-dir"`
 	ServerID    int    `toml:"server-id" json:"server-id"`
 	Flavor      string `toml:"flavor" json:"flavor"`
 	Charset     string `toml:"charset" json:"charset"`
@@ -202,6 +209,15 @@ func (c *Config) Parse(arguments []string) error {
 	// assign tracer id to source id
 	c.Tracer.Source = c.SourceID
 
+	if c.AdvertiseAddr == "" {
+		c.AdvertiseAddr = c.WorkerAddr
+	}
+
+	// keep meta of sub tasks with relay log, rather than depending on the working directory
+	if c.MetaDir == "" {
+		c.MetaDir = filepath.Join(filepath.Dir(filepath.Clean(c.RelayDir)), defaultMetaDirName)
+	}
+
 	return c.verify()
 }
 
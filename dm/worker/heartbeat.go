@@ -0,0 +1,155 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var (
+	defaultHeartbeatInterval = time.Second
+	masterRequestTimeout     = 5 * time.Second
+)
+
+// capabilities are process units supported by dm-worker
+var capabilities = []string{
+	pb.UnitType_Check.String(),
+	pb.UnitType_Dump.String(),
+	pb.UnitType_Load.String(),
+	pb.UnitType_Sync.String(),
+	pb.UnitType_Relay.String(),
+}
+
+// heartbeat registers dm-worker to dm-master group and keeps sending heartbeat until ctx canceled,
+// dm-worker registers again if dm-master lost its registration, like after dm-master failover
+func (s *Server) heartbeat(ctx context.Context, masterAddrs []string) {
+	var (
+		idx        int
+		conn       *grpc.ClientConn
+		cli        pb.MasterClient
+		registered bool
+		interval   = defaultHeartbeatInterval
+	)
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for {
+		if cli == nil {
+			var err error
+			addr := masterAddrs[idx%len(masterAddrs)]
+			conn, err = grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(3*time.Second))
+			if err != nil {
+				log.Errorf("[server] dial dm-master %s error %v", addr, err)
+			} else {
+				cli = pb.NewMasterClient(conn)
+			}
+		}
+
+		if cli != nil {
+			var err error
+			if registered {
+				registered, err = s.sendHeartbeat(ctx, cli)
+			} else {
+				var newInterval time.Duration
+				newInterval, err = s.register(ctx, cli)
+				if err == nil {
+					registered = true
+					interval = newInterval
+				}
+			}
+
+			if err != nil {
+				log.Warnf("[server] communicate with dm-master %s error %v, try next dm-master", masterAddrs[idx%len(masterAddrs)], errors.ErrorStack(err))
+				conn.Close()
+				conn, cli = nil, nil
+				idx++
+			} else if !registered {
+				log.Warnf("[server] dm-worker is not registered in dm-master, register again")
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// register registers dm-worker to dm-master, returns heartbeat interval
+func (s *Server) register(ctx context.Context, cli pb.MasterClient) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, masterRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.RegisterWorker(ctx, &pb.RegisterWorkerRequest{
+		Address:      s.cfg.AdvertiseAddr,
+		SourceID:     s.cfg.SourceID,
+		Capabilities: capabilities,
+		Version:      utils.ReleaseVersion,
+	})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !resp.Result {
+		return 0, errors.Errorf("register to dm-master fail %s", resp.Msg)
+	}
+	log.Infof("[server] registered to dm-master with address %s, source %s", s.cfg.AdvertiseAddr, s.cfg.SourceID)
+	if resp.HeartbeatInterval <= 0 {
+		return defaultHeartbeatInterval, nil
+	}
+	return time.Duration(resp.HeartbeatInterval) * time.Second, nil
+}
+
+// sendHeartbeat sends a heartbeat to dm-master, returns whether dm-worker is still registered
+func (s *Server) sendHeartbeat(ctx context.Context, cli pb.MasterClient) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, masterRequestTimeout)
+	defer cancel()
+
+	resp, err := cli.Heartbeat(ctx, &pb.HeartbeatRequest{
+		Address:  s.cfg.AdvertiseAddr,
+		SourceID: s.cfg.SourceID,
+	})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !resp.Result {
+		return false, errors.Errorf("send heartbeat to dm-master fail %s", resp.Msg)
+	}
+	return resp.Registered, nil
+}
+
+// parseMasterAddrs parses comma separated addresses of dm-master group
+func parseMasterAddrs(s string) []string {
+	addrs := make([]string, 0)
+	for _, addr := range strings.Split(s, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
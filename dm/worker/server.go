@@ -43,6 +43,7 @@ type Server struct {
 	sync.Mutex
 	wg     sync.WaitGroup
 	closed sync2.AtomicBool
+	cancel context.CancelFunc
 
 	cfg *Config
 
@@ -83,6 +84,17 @@ func (s *Server) Start() error {
 		s.worker.Start()
 	}()
 
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	if masterAddrs := parseMasterAddrs(s.cfg.MasterAddr); len(masterAddrs) > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			// register to dm-master group and keep alive
+			s.heartbeat(ctx, masterAddrs)
+		}()
+	}
+
 	// create a cmux
 	m := cmux.New(s.rootLis)
 	m.SetReadTimeout(cmuxReadTimeout) // set a timeout, ref: https://github.com/pingcap/tidb-binlog/pull/352
@@ -129,6 +141,9 @@ func (s *Server) Close() {
 	}
 
 	// close worker and wait for return
+	if s.cancel != nil {
+		s.cancel()
+	}
 	s.worker.Close()
 	s.wg.Wait()
 
@@ -232,7 +247,7 @@ func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusRequest) (*
 
 	resp := &pb.QueryStatusResponse{
 		Result:        true,
-		SubTaskStatus: s.worker.QueryStatus(req.Name),
+		SubTaskStatus: s.worker.QueryStatus(req.Name, req.ShardingProgress),
 		RelayStatus:   s.worker.relayHolder.Status(),
 	}
 
@@ -329,6 +344,22 @@ func (s *Server) BreakDDLLock(ctx context.Context, req *pb.BreakDDLLockRequest)
 	return resp, nil
 }
 
+// LeaveShardingGroup implements WorkerServer.LeaveShardingGroup
+func (s *Server) LeaveShardingGroup(ctx context.Context, req *pb.LeaveShardingGroupRequest) (*pb.CommonWorkerResponse, error) {
+	log.Infof("[server] receive LeaveShardingGroup request %+v", req)
+
+	resp := &pb.CommonWorkerResponse{
+		Result: true,
+	}
+	err := s.worker.LeaveShardingGroup(ctx, req)
+	if err != nil {
+		resp.Result = false
+		resp.Msg = errors.ErrorStack(err)
+		log.Errorf("[server] %v LeaveShardingGroup error %v", req, errors.ErrorStack(err))
+	}
+	return resp, nil
+}
+
 // HandleSQLs implements WorkerServer.HandleSQLs
 func (s *Server) HandleSQLs(ctx context.Context, req *pb.HandleSubTaskSQLsRequest) (*pb.CommonWorkerResponse, error) {
 	log.Infof("[server] receive HandleSQLs request %+v", req)
@@ -354,6 +354,16 @@ func (st *SubTask) Result() *pb.ProcessResult {
 	return st.result
 }
 
+// RunPaused makes the sub task paused without processing,
+// like a sub task restored with requested stage Paused, it starts processing after resumed
+func (st *SubTask) RunPaused() {
+	st.setStage(pb.Stage_Paused)
+	st.setResult(nil)
+	// cancel is used by Close to close units even if never processed
+	st.ctx, st.cancel = context.WithCancel(context.Background())
+	log.Infof("[subtask] %s paused with %s dm-unit before running", st.cfg.Name, st.CurrUnit().Type())
+}
+
 // Close stops the sub task
 func (st *SubTask) Close() {
 	log.Infof("[subtask] %s is closing", st.cfg.Name)
@@ -509,6 +519,9 @@ func (st *SubTask) SaveDDLLockInfo(info *pb.DDLLockInfo) error {
 	st.Lock()
 	defer st.Unlock()
 	if st.ddlLockInfo != nil {
+		if st.ddlLockInfo.ID == info.ID {
+			return nil // replied for the re-sent DDL info
+		}
 		return errors.AlreadyExistsf("DDLLockInfo for task %s", info.Task)
 	}
 	st.ddlLockInfo = info
@@ -537,6 +550,24 @@ func (st *SubTask) ClearDDLLockInfo() {
 	st.ddlLockInfo = nil
 }
 
+// LeaveShardingGroup removes idle sources from the sharding group of the target table in the syncer
+func (st *SubTask) LeaveShardingGroup(ctx context.Context, target string, sources []string) error {
+	cu := st.CurrUnit()
+	syncer2, ok := cu.(*syncer.Syncer)
+	if !ok {
+		return errors.Errorf("only syncer support LeaveShardingGroup, but current unit is %s", cu.Type().String())
+	}
+	return errors.Trace(syncer2.LeaveShardingGroup(ctx, target, sources))
+}
+
+// ShardingProgress returns sharding groups with binlog progress of their un-synced sources, maybe nil
+func (st *SubTask) ShardingProgress() []*pb.ShardingGroup {
+	if syncer2, ok := st.CurrUnit().(*syncer.Syncer); ok {
+		return syncer2.ShardingProgress()
+	}
+	return nil
+}
+
 // DDLLockInfo returns current DDLLockInfo, maybe nil
 func (st *SubTask) DDLLockInfo() *pb.DDLLockInfo {
 	st.RLock()
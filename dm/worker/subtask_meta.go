@@ -0,0 +1,216 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go/ioutil2"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+const (
+	// defaultMetaDirName is the name of dm-worker's meta dir if not specified, it's beside the relay dir
+	defaultMetaDirName = "dm_worker_meta"
+	// subTaskMetaFilename is the file name of sub task meta under dm-worker's meta dir
+	subTaskMetaFilename = "subtask.meta"
+)
+
+// SubTaskMeta represents persistent information of a sub task
+type SubTaskMeta struct {
+	Name   string `toml:"name" json:"name"`
+	Config string `toml:"config" json:"config"` // sub task config in TOML format, the same as received from dm-master
+	Stage  string `toml:"stage" json:"stage"`   // requested stage, Running or Paused
+}
+
+// SubTaskMetaStore keeps meta of sub tasks in a local file,
+// so dm-worker can restore sub tasks after restarted
+type SubTaskMetaStore struct {
+	sync.RWMutex
+	dir      string
+	subTasks map[string]*SubTaskMeta
+
+	SubTasks []*SubTaskMeta `toml:"sub-task" json:"sub-task"` // only used for encoding / decoding
+}
+
+// NewSubTaskMetaStore creates a new SubTaskMetaStore under dir
+func NewSubTaskMetaStore(dir string) *SubTaskMetaStore {
+	return &SubTaskMetaStore{
+		dir:      dir,
+		subTasks: make(map[string]*SubTaskMeta),
+	}
+}
+
+// Load loads sub task meta from local file
+func (s *SubTaskMetaStore) Load() error {
+	s.Lock()
+	defer s.Unlock()
+
+	err := os.MkdirAll(s.dir, 0755)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.subTasks = make(map[string]*SubTaskMeta)
+	s.SubTasks = nil
+
+	fd, err := os.Open(s.filename())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer fd.Close()
+
+	_, err = toml.DecodeReader(fd, s)
+	if err != nil {
+		return errors.Annotatef(err, "decode sub task meta file %s", s.filename())
+	}
+
+	for _, st := range s.SubTasks {
+		s.subTasks[st.Name] = st
+	}
+	s.SubTasks = nil
+	return nil
+}
+
+// All returns copies of all sub task metas, sorted by name
+func (s *SubTaskMetaStore) All() []*SubTaskMeta {
+	s.RLock()
+	defer s.RUnlock()
+
+	subTasks := s.sorted()
+	for i, st := range subTasks {
+		clone := *st
+		subTasks[i] = &clone
+	}
+	return subTasks
+}
+
+// Set saves config and requested stage for a sub task
+func (s *SubTaskMetaStore) Set(name, cfg string, stage pb.Stage) error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.update(name, &SubTaskMeta{
+		Name:   name,
+		Config: cfg,
+		Stage:  stage.String(),
+	})
+}
+
+// UpdateConfig updates config for a sub task, and keeps its requested stage
+func (s *SubTaskMetaStore) UpdateConfig(name, cfg string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.subTasks[name]
+	if !ok {
+		return errors.NotFoundf("sub task %s in meta", name)
+	}
+	return s.update(name, &SubTaskMeta{
+		Name:   name,
+		Config: cfg,
+		Stage:  old.Stage,
+	})
+}
+
+// UpdateStage updates requested stage for a sub task
+func (s *SubTaskMetaStore) UpdateStage(name string, stage pb.Stage) error {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.subTasks[name]
+	if !ok {
+		return errors.NotFoundf("sub task %s in meta", name)
+	}
+	return s.update(name, &SubTaskMeta{
+		Name:   name,
+		Config: old.Config,
+		Stage:  stage.String(),
+	})
+}
+
+// Remove removes meta of a sub task
+func (s *SubTaskMetaStore) Remove(name string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.subTasks[name]; !ok {
+		return nil
+	}
+	return s.update(name, nil)
+}
+
+// update replaces (or removes if meta is nil) meta for a sub task and flushes it,
+// in-memory meta will be rolled back if flush failed
+func (s *SubTaskMetaStore) update(name string, meta *SubTaskMeta) error {
+	old, ok := s.subTasks[name]
+	if meta == nil {
+		delete(s.subTasks, name)
+	} else {
+		s.subTasks[name] = meta
+	}
+
+	err := s.flush()
+	if err != nil {
+		if ok {
+			s.subTasks[name] = old
+		} else {
+			delete(s.subTasks, name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// flush writes all sub task metas into local file
+func (s *SubTaskMetaStore) flush() error {
+	s.SubTasks = s.sorted()
+	defer func() {
+		s.SubTasks = nil
+	}()
+
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	err := enc.Encode(s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = ioutil2.WriteFileAtomic(s.filename(), buf.Bytes(), 0644)
+	return errors.Trace(err)
+}
+
+func (s *SubTaskMetaStore) sorted() []*SubTaskMeta {
+	subTasks := make([]*SubTaskMeta, 0, len(s.subTasks))
+	for _, st := range s.subTasks {
+		subTasks = append(subTasks, st)
+	}
+	sort.Slice(subTasks, func(i, j int) bool {
+		return subTasks[i].Name < subTasks[j].Name
+	})
+	return subTasks
+}
+
+func (s *SubTaskMetaStore) filename() string {
+	return filepath.Join(s.dir, subTaskMetaFilename)
+}
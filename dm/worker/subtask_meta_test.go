@@ -0,0 +1,117 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/dm/dm/pb"
+)
+
+var _ = Suite(&testSubTaskMetaSuite{})
+
+func TestSuite(t *testing.T) {
+	TestingT(t)
+}
+
+type testSubTaskMetaSuite struct {
+}
+
+func (t *testSubTaskMetaSuite) TestSubTaskMetaStore(c *C) {
+	dir := filepath.Join(c.MkDir(), "meta")
+	store := NewSubTaskMetaStore(dir)
+	c.Assert(store.Load(), IsNil)
+	c.Assert(store.All(), HasLen, 0)
+
+	cfg1 := "name = \"test1\"\nsource-id = \"source-1\"\n"
+	cfg2 := "name = \"test2\"\nsource-id = \"source-1\"\n"
+	c.Assert(store.Set("test2", cfg2, pb.Stage_Running), IsNil)
+	c.Assert(store.Set("test1", cfg1, pb.Stage_Running), IsNil)
+
+	all := store.All()
+	c.Assert(all, HasLen, 2)
+	c.Assert(all[0].Name, Equals, "test1")
+	c.Assert(all[0].Config, Equals, cfg1)
+	c.Assert(all[1].Name, Equals, "test2")
+
+	// modify the returned copy should not affect the store
+	all[0].Stage = pb.Stage_Paused.String()
+	c.Assert(store.All()[0].Stage, Equals, pb.Stage_Running.String())
+
+	// pause a sub task, and update config of it
+	c.Assert(store.UpdateStage("test2", pb.Stage_Paused), IsNil)
+	c.Assert(store.UpdateStage("not-exist", pb.Stage_Paused), NotNil)
+	cfg2 = "name = \"test2\"\nsource-id = \"source-2\"\n"
+	c.Assert(store.UpdateConfig("test2", cfg2), IsNil)
+	c.Assert(store.UpdateConfig("not-exist", cfg2), NotNil)
+
+	// restore from the local file
+	store2 := NewSubTaskMetaStore(dir)
+	c.Assert(store2.Load(), IsNil)
+	c.Assert(store2.All(), DeepEquals, store.All())
+	all = store2.All()
+	c.Assert(all[1].Config, Equals, cfg2)
+	c.Assert(all[1].Stage, Equals, pb.Stage_Paused.String())
+
+	// remove sub tasks
+	c.Assert(store2.Remove("test1"), IsNil)
+	c.Assert(store2.Remove("not-exist"), IsNil)
+	all = store2.All()
+	c.Assert(all, HasLen, 1)
+	c.Assert(all[0].Name, Equals, "test2")
+	c.Assert(store2.Remove("test2"), IsNil)
+	c.Assert(store2.All(), HasLen, 0)
+
+	store3 := NewSubTaskMetaStore(dir)
+	c.Assert(store3.Load(), IsNil)
+	c.Assert(store3.All(), HasLen, 0)
+}
+
+func (t *testSubTaskMetaSuite) TestSubTaskMetaStoreError(c *C) {
+	dir := c.MkDir()
+
+	// invalid meta file
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, subTaskMetaFilename), []byte("invalid"), 0644), IsNil)
+	store := NewSubTaskMetaStore(dir)
+	c.Assert(store.Load(), NotNil)
+
+	// in-memory meta is rolled back if flush failed
+	c.Assert(os.Remove(filepath.Join(dir, subTaskMetaFilename)), IsNil)
+	c.Assert(store.Load(), IsNil)
+	c.Assert(store.Set("test", "name = \"test\"\n", pb.Stage_Running), IsNil)
+	c.Assert(os.RemoveAll(dir), IsNil)
+	c.Assert(store.Set("test", "name = \"test\"\n", pb.Stage_Paused), NotNil)
+	c.Assert(store.Set("test2", "name = \"test2\"\n", pb.Stage_Running), NotNil)
+	all := store.All()
+	c.Assert(all, HasLen, 1)
+	c.Assert(all[0].Stage, Equals, pb.Stage_Running.String())
+}
+
+func (t *testSubTaskMetaSuite) TestDefaultMetaDir(c *C) {
+	cfgFile := filepath.Join(c.MkDir(), "dm-worker.toml")
+	c.Assert(ioutil.WriteFile(cfgFile, []byte("source-id = \"source-1\"\n"), 0644), IsNil)
+
+	cfg := NewConfig()
+	c.Assert(cfg.Parse([]string{"-config", cfgFile, "-relay-dir=/data/dm/relay_log/"}), IsNil)
+	c.Assert(cfg.MetaDir, Equals, "/data/dm/dm_worker_meta")
+
+	cfg = NewConfig()
+	c.Assert(cfg.Parse([]string{"-config", cfgFile, "-relay-dir=/data/dm/relay_log", "-meta-dir=/data/meta"}), IsNil)
+	c.Assert(cfg.MetaDir, Equals, "/data/meta")
+}
@@ -55,6 +55,7 @@ type Worker struct {
 	relayHolder *RelayHolder
 	relayPurger *purger.Purger
 	tracer      *tracing.Tracer
+	meta        *SubTaskMetaStore
 }
 
 // NewWorker creates a new Worker
@@ -63,6 +64,7 @@ func NewWorker(cfg *Config) *Worker {
 		cfg:         cfg,
 		subTasks:    make(map[string]*SubTask),
 		relayHolder: NewRelayHolder(cfg),
+		meta:        NewSubTaskMetaStore(cfg.MetaDir),
 	}
 
 	operators := []purger.RelayOperator{
@@ -87,6 +89,11 @@ func (w *Worker) Init() error {
 		return errors.Trace(err)
 	}
 
+	err = w.meta.Load()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	InitConditionHub(w)
 
 	return nil
@@ -111,6 +118,9 @@ func (w *Worker) Start() {
 		w.tracer.Start()
 	}
 
+	// restore sub tasks started before restarting
+	w.restoreSubTasks()
+
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 	for {
@@ -155,6 +165,11 @@ func (w *Worker) Close() {
 
 // StartSubTask creates a sub task an run it
 func (w *Worker) StartSubTask(cfg *config.SubTaskConfig) error {
+	return w.startSubTask(cfg, pb.Stage_Running)
+}
+
+// startSubTask creates a sub task, runs it if stage is Running, or keeps it paused if stage is Paused
+func (w *Worker) startSubTask(cfg *config.SubTaskConfig, stage pb.Stage) error {
 	if w.closed.Get() == closedTrue {
 		return errors.NotValidf("worker already closed")
 	}
@@ -176,11 +191,14 @@ func (w *Worker) StartSubTask(cfg *config.SubTaskConfig) error {
 
 	log.Infof("[worker] starting sub task with config: %v", cfg)
 
+	// keep the config before decrypting password, save it into meta later
+	cfgToml, err := cfg.Toml()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// try decrypt password for To DB
-	var (
-		pswdTo string
-		err    error
-	)
+	var pswdTo string
 	if len(cfg.To.Password) > 0 {
 		pswdTo, err = utils.Decrypt(cfg.To.Password)
 		if err != nil {
@@ -197,10 +215,44 @@ func (w *Worker) StartSubTask(cfg *config.SubTaskConfig) error {
 
 	w.subTasks[cfg.Name] = st
 
-	st.Run()
+	if stage == pb.Stage_Paused {
+		st.RunPaused()
+	} else {
+		stage = pb.Stage_Running
+		st.Run()
+	}
+
+	err = w.meta.Set(cfg.Name, cfgToml, stage)
+	if err != nil {
+		log.Errorf("[worker] save meta for sub task %s error %v", cfg.Name, errors.ErrorStack(err))
+	}
 	return nil
 }
 
+// restoreSubTasks re-creates sub tasks recorded in meta,
+// sub tasks will continue from their checkpoints, or be created in Paused stage directly if they were paused
+func (w *Worker) restoreSubTasks() {
+	for _, stMeta := range w.meta.All() {
+		cfg := config.NewSubTaskConfig()
+		err := cfg.Decode(stMeta.Config)
+		if err != nil {
+			log.Errorf("[worker] decode config for sub task %s from meta error %v", stMeta.Name, errors.ErrorStack(err))
+			continue
+		}
+
+		stage := pb.Stage_Running
+		if stMeta.Stage == pb.Stage_Paused.String() {
+			stage = pb.Stage_Paused
+		}
+		err = w.startSubTask(cfg, stage)
+		if err != nil {
+			log.Errorf("[worker] restore sub task %s error %v", stMeta.Name, errors.ErrorStack(err))
+			continue
+		}
+		log.Infof("[worker] sub task %s restored with stage %s", stMeta.Name, stage)
+	}
+}
+
 // copyConfigFromWorker copies config items from dm-worker to sub task
 func (w *Worker) copyConfigFromWorker(cfg *config.SubTaskConfig) {
 	cfg.From = w.cfg.From
@@ -233,6 +285,11 @@ func (w *Worker) StopSubTask(name string) error {
 
 	st.Close()
 	delete(w.subTasks, name)
+
+	err := w.meta.Remove(name)
+	if err != nil {
+		log.Errorf("[worker] remove meta for sub task %s error %v", name, errors.ErrorStack(err))
+	}
 	return nil
 }
 
@@ -247,7 +304,16 @@ func (w *Worker) PauseSubTask(name string) error {
 		return errors.NotFoundf("sub task with name %s", name)
 	}
 
-	return st.Pause()
+	err := st.Pause()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = w.meta.UpdateStage(name, pb.Stage_Paused)
+	if err != nil {
+		log.Errorf("[worker] update meta for sub task %s error %v", name, errors.ErrorStack(err))
+	}
+	return nil
 }
 
 // ResumeSubTask resumes a paused sub task
@@ -261,7 +327,16 @@ func (w *Worker) ResumeSubTask(name string) error {
 		return errors.NotFoundf("sub task with name %s", name)
 	}
 
-	return st.Resume()
+	err := st.Resume()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = w.meta.UpdateStage(name, pb.Stage_Running)
+	if err != nil {
+		log.Errorf("[worker] update meta for sub task %s error %v", name, errors.ErrorStack(err))
+	}
+	return nil
 }
 
 // UpdateSubTask update config for a sub task
@@ -275,17 +350,43 @@ func (w *Worker) UpdateSubTask(cfg *config.SubTaskConfig) error {
 		return errors.NotFoundf("sub task with name %s", cfg.Name)
 	}
 
-	return st.Update(cfg)
+	cfgToml, err := cfg.Toml()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = st.Update(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = w.meta.UpdateConfig(cfg.Name, cfgToml)
+	if err != nil {
+		log.Errorf("[worker] update meta for sub task %s error %v", cfg.Name, errors.ErrorStack(err))
+	}
+	return nil
 }
 
 // QueryStatus query worker's sub tasks' status
-func (w *Worker) QueryStatus(name string) []*pb.SubTaskStatus {
+func (w *Worker) QueryStatus(name string, shardingProgress bool) []*pb.SubTaskStatus {
 	if w.closed.Get() == closedTrue {
 		log.Warn("[worker] querying status from a closed worker")
 		return nil
 	}
 
-	return w.Status(name)
+	status := w.Status(name)
+	if shardingProgress {
+		for _, stStatus := range status {
+			syncStatus := stStatus.GetSync()
+			if syncStatus == nil {
+				continue
+			}
+			if st := w.findSubTask(stStatus.Name); st != nil {
+				syncStatus.ShardingGroups = st.ShardingProgress()
+			}
+		}
+	}
+	return status
 }
 
 // QueryError query worker's sub tasks' error
@@ -375,10 +476,17 @@ func (w *Worker) doFetchDDLInfo(ctx context.Context, ch chan<- *pb.DDLInfo) {
 		w.RLock()
 		for _, st := range w.subTasks {
 			// NOTE: Can you guarantee that each DDLInfo you get is different?
-			if st.GetDDLInfo() == nil {
+			info := st.GetDDLInfo()
+			if info == nil {
 				continue
 			}
-			ch <- st.GetDDLInfo()
+			if lockInfo := st.DDLLockInfo(); lockInfo != nil {
+				// re-send with the recorded DDL lock ID, so dm-master can match it with the DDL lock
+				resent := *info
+				resent.LockID = lockInfo.ID
+				info = &resent
+			}
+			ch <- info
 			break
 		}
 		w.RUnlock()
@@ -488,6 +596,20 @@ func (w *Worker) BreakDDLLock(ctx context.Context, req *pb.BreakDDLLockRequest)
 	return st.ExecuteDDL(ctx, execReq)
 }
 
+// LeaveShardingGroup removes idle sources from the sharding group of the sub task
+func (w *Worker) LeaveShardingGroup(ctx context.Context, req *pb.LeaveShardingGroupRequest) error {
+	if w.closed.Get() == closedTrue {
+		return errors.NotValidf("worker already closed")
+	}
+
+	st := w.findSubTask(req.Task)
+	if st == nil {
+		return errors.NotFoundf("sub task %v", req.Task)
+	}
+
+	return errors.Trace(st.LeaveShardingGroup(ctx, req.Target, req.Sources))
+}
+
 // SwitchRelayMaster switches relay unit's master server
 func (w *Worker) SwitchRelayMaster(ctx context.Context, req *pb.SwitchRelayMasterRequest) error {
 	if w.closed.Get() == closedTrue {
@@ -23,9 +23,11 @@ import (
 	"unsafe"
 
 	parserpkg "github.com/pingcap/dm/pkg/parser"
+	"github.com/pingcap/dm/pkg/transform"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
 	cm "github.com/pingcap/tidb-tools/pkg/column-mapping"
 	router "github.com/pingcap/tidb-tools/pkg/table-router"
 )
@@ -161,6 +163,14 @@ func parseRowValues(str []byte, table *tableInfo, columnMapping *cm.Mapping) ([]
 		}
 	}
 
+	if table.transform != nil {
+		var err error
+		values, isChars, err = transformRowValues(values, isChars, table.transform)
+		if err != nil {
+			return nil, errors.Annotatef(err, "transform row data %v for table %+v", values, table)
+		}
+	}
+
 	for i := range values {
 		val, ok := values[i].(string)
 		if !ok {
@@ -181,6 +191,101 @@ func parseRowValues(str []byte, table *tableInfo, columnMapping *cm.Mapping) ([]
 	return row, nil
 }
 
+// transformRowValues transforms values in SQL literals by column transformation,
+// only values of masked columns are unescaped before masked, and escaped and quoted after masked
+func transformRowValues(values []interface{}, isChars []byte, t *transform.Table) ([]interface{}, []byte, error) {
+	input := make([]interface{}, len(values))
+	copy(input, values)
+	for i, idx := range t.Indexes {
+		if !t.Masked(i) || idx >= len(values) {
+			continue
+		}
+		val := values[idx].(string)
+		if isChars[idx] != 0x0 {
+			input[idx] = unescapeString(val)
+		} else if strings.EqualFold(val, "NULL") {
+			input[idx] = nil
+		}
+	}
+
+	output, err := t.TransformRow(input)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	outputIsChars := make([]byte, 0, len(output))
+	for i, idx := range t.Indexes {
+		if !t.Masked(i) {
+			outputIsChars = append(outputIsChars, isChars[idx])
+			continue
+		}
+		if output[i] == nil {
+			output[i] = "NULL"
+			outputIsChars = append(outputIsChars, 0x0)
+			continue
+		}
+		output[i] = escapeString(output[i].(string))
+		outputIsChars = append(outputIsChars, '"')
+	}
+	return output, outputIsChars, nil
+}
+
+// unescapeString unescapes the content of a quoted string literal
+func unescapeString(str string) string {
+	if strings.IndexByte(str, '\\') < 0 {
+		return str
+	}
+	buf := make([]byte, 0, len(str))
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' || i+1 == len(str) {
+			buf = append(buf, str[i])
+			continue
+		}
+		i++
+		switch str[i] {
+		case '0':
+			buf = append(buf, 0)
+		case 'b':
+			buf = append(buf, '\b')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case 'Z':
+			buf = append(buf, '\032')
+		case '%', '_':
+			// `\%` and `\_` are kept as is
+			buf = append(buf, '\\', str[i])
+		default:
+			buf = append(buf, str[i])
+		}
+	}
+	return string(buf)
+}
+
+// escapeString escapes the string to the content of a quoted string literal like `mysql_real_escape_string`
+func escapeString(str string) string {
+	buf := make([]byte, 0, len(str))
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case 0:
+			buf = append(buf, '\\', '0')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\\', '\'', '"':
+			buf = append(buf, '\\', str[i])
+		case '\032':
+			buf = append(buf, '\\', 'Z')
+		default:
+			buf = append(buf, str[i])
+		}
+	}
+	return string(buf)
+}
+
 // ExportStatement returns schema structure in sqlFile
 func ExportStatement(sqlFile string) ([]byte, error) {
 	fd, err := os.Open(sqlFile)
@@ -254,6 +359,7 @@ func parseTable(r *router.Table, schema, table, file string) (*tableInfo, error)
 
 	var (
 		columns          = make([]string, 0, len(ct.Cols))
+		columnTypes      = make([]string, 0, len(ct.Cols))
 		hasGeneragedCols = false
 		columnNameFields = ""
 	)
@@ -268,6 +374,7 @@ func parseTable(r *router.Table, schema, table, file string) (*tableInfo, error)
 		}
 		if !skip {
 			columns = append(columns, col.Name.Name.O)
+			columnTypes = append(columnTypes, col.Tp.InfoSchemaStr())
 		}
 	}
 	if hasGeneragedCols {
@@ -285,10 +392,56 @@ func parseTable(r *router.Table, schema, table, file string) (*tableInfo, error)
 		targetSchema:   dstSchema,
 		targetTable:    dstTable,
 		columnNameList: columns,
+		columnTypeList: columnTypes,
 		insertHeadStmt: fmt.Sprintf("INSERT INTO `%s` %sVALUES", dstTable, columnNameFields),
 	}, nil
 }
 
+// transformTable sets the column transformation of the table, and inserts values into transformed columns
+func transformTable(t *transform.Transformer, table *tableInfo) error {
+	tt, err := t.Table(table.sourceSchema, table.sourceTable, table.columnNameList, table.columnTypeList)
+	if err != nil || tt == nil {
+		return errors.Trace(err)
+	}
+
+	escapeColumns := make([]string, 0, len(tt.Columns))
+	for _, column := range tt.Columns {
+		escapeColumns = append(escapeColumns, fmt.Sprintf("`%s`", column))
+	}
+	table.transform = tt
+	table.insertHeadStmt = fmt.Sprintf("INSERT INTO `%s` (%s) VALUES", table.targetTable, strings.Join(escapeColumns, ","))
+	return nil
+}
+
+// transformCreateTable transforms columns in the CREATE TABLE statement of the upstream table,
+// other statements are returned as is
+func transformCreateTable(t *transform.Transformer, query, schema, table string) (string, error) {
+	stmts, err := parserpkg.Parse(parser.New(), query, "", "")
+	if err != nil {
+		return "", errors.Annotatef(err, "parse statement %s", query)
+	}
+	if len(stmts) != 1 {
+		return query, nil
+	}
+	if _, ok := stmts[0].(*ast.CreateTableStmt); !ok {
+		return query, nil
+	}
+
+	_, err = t.TransformDDL(schema, table, stmts[0])
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	bf := new(bytes.Buffer)
+	err = stmts[0].Restore(&format.RestoreCtx{
+		Flags: format.DefaultRestoreFlags,
+		In:    bf,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "restore statement %s", query)
+	}
+	return bf.String() + ";", nil
+}
+
 // refine it later
 func reassemble(data []byte, table *tableInfo, columnMapping *cm.Mapping) (string, error) {
 	rows, err := parseInsertStmt(data, table, columnMapping)
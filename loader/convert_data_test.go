@@ -17,6 +17,8 @@ import (
 	. "github.com/pingcap/check"
 	cm "github.com/pingcap/tidb-tools/pkg/column-mapping"
 	"github.com/pingcap/tidb-tools/pkg/table-router"
+
+	"github.com/pingcap/dm/pkg/transform"
 )
 
 var _ = Suite(&testConvertDataSuite{})
@@ -153,6 +155,26 @@ func (t *testConvertDataSuite) TestParseTable(c *C) {
 			"t_set",
 			"t_json",
 		},
+		columnTypeList: []string{
+			"bigint(11)",
+			"tinyint(1)",
+			"bigint(20)",
+			"double",
+			"decimal(38,19)",
+			"bit(64)",
+			"date",
+			"datetime",
+			"timestamp",
+			"time",
+			"year(4)",
+			"char(1)",
+			"varchar(10)",
+			"blob",
+			"text",
+			"enum('enum1','enum2','enum3')",
+			"set('a','b','c')",
+			"json",
+		},
 		insertHeadStmt: "INSERT INTO `t` VALUES",
 	}
 
@@ -178,6 +200,10 @@ func (t *testConvertDataSuite) TestParseTableWithGeneratedColumn(c *C) {
 			"id",
 			"t_json",
 		},
+		columnTypeList: []string{
+			"bigint(11)",
+			"varchar(100)",
+		},
 		insertHeadStmt: "INSERT INTO `t` (`id`,`t_json`) VALUES",
 	}
 
@@ -188,3 +214,57 @@ func (t *testConvertDataSuite) TestParseTableWithGeneratedColumn(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(tableInfo, DeepEquals, expectedTableInfo)
 }
+
+func (t *testConvertDataSuite) TestReassembleWithTransform(c *C) {
+	transformer, err := transform.NewTransformer(false, []*transform.Rule{{
+		SchemaPattern: "test*",
+		TablePattern:  "t*",
+		DropColumns:   []string{"ssn"},
+		RenameColumns: map[string]string{"addr": "address"},
+		MaskColumns: []*transform.MaskRule{
+			{Column: "phone", Function: transform.MaskKeepLast, Length: 2},
+			{Column: "addr", Function: transform.MaskHash},
+		},
+	}})
+	c.Assert(err, IsNil)
+
+	table := &tableInfo{
+		sourceSchema:   "test2",
+		sourceTable:    "t3",
+		targetSchema:   "test",
+		targetTable:    "t",
+		columnNameList: []string{"id", "ssn", "phone", "addr"},
+		columnTypeList: []string{"int(11)", "varchar(20)", "varchar(20)", "text"},
+		insertHeadStmt: "INSERT INTO `t` VALUES",
+	}
+	c.Assert(transformTable(transformer, table), IsNil)
+	c.Assert(table.insertHeadStmt, Equals, "INSERT INTO `t` (`id`,`phone`,`address`) VALUES")
+
+	sql := `INSERT INTO t1 VALUES
+(10,"123-45-6789","13800\"38000","abc"),
+(9,NULL,NULL,NULL);
+`
+	expected := "INSERT INTO `t` (`id`,`phone`,`address`) VALUES" +
+		`(10,"*********00","ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"),(9,NULL,NULL);`
+	query, err := reassemble([]byte(sql), table, nil)
+	c.Assert(err, IsNil)
+	c.Assert(query, Equals, expected)
+
+	// not matched
+	table = &tableInfo{sourceSchema: "db", sourceTable: "t3", columnNameList: []string{"id"}, insertHeadStmt: "INSERT INTO `t3` VALUES"}
+	c.Assert(transformTable(transformer, table), IsNil)
+	c.Assert(table.transform, IsNil)
+	c.Assert(table.insertHeadStmt, Equals, "INSERT INTO `t3` VALUES")
+
+	query, err = transformCreateTable(transformer, "CREATE TABLE `t3` (`id` int, `ssn` varchar(20), `addr` text, PRIMARY KEY (`id`));", "test2", "t3")
+	c.Assert(err, IsNil)
+	c.Assert(query, Equals, "CREATE TABLE `t3` (`id` INT,`address` TEXT,PRIMARY KEY(`id`));")
+}
+
+func (t *testConvertDataSuite) TestEscapeString(c *C) {
+	str := "a\x00b\n\r\\'\"\x1a%_"
+	escaped := escapeString(str)
+	c.Assert(escaped, Equals, `a\0b\n\r\\\'\"\Z%_`)
+	c.Assert(unescapeString(escaped), Equals, str)
+	c.Assert(unescapeString(`\%\_\t\b\x`), Equals, "\\%\\_\t\bx")
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/dm/dm/unit"
 	fr "github.com/pingcap/dm/pkg/func-rollback"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/transform"
 	"github.com/pingcap/dm/pkg/utils"
 )
 
@@ -253,8 +254,8 @@ func (w *Worker) dispatchSQL(ctx context.Context, file string, offset int64, tab
 				continue
 			}
 
-			if w.loader.columnMapping != nil {
-				// column mapping and route table
+			if w.loader.columnMapping != nil || table.transform != nil {
+				// column mapping, column transform and route table
 				query, err = reassemble(data, table, w.loader.columnMapping)
 				if err != nil {
 					return errors.Annotatef(err, "file %s", file)
@@ -294,7 +295,9 @@ type tableInfo struct {
 	targetSchema   string
 	targetTable    string
 	columnNameList []string
+	columnTypeList []string // column types like `int(11) unsigned`, for normalizing values before masked
 	insertHeadStmt string
+	transform      *transform.Table // nil if no column transform rule matched
 }
 
 // Loader can load your mydumper data into TiDB database.
@@ -318,6 +321,7 @@ type Loader struct {
 	tableRouter   *router.Table
 	bwList        *filter.Filter
 	columnMapping *cm.Mapping
+	transformer   *transform.Transformer
 
 	pool   []*Worker
 	closed sync2.AtomicBool
@@ -389,6 +393,13 @@ func (l *Loader) Init() (err error) {
 		}
 	}
 
+	if len(l.cfg.ColumnTransformRules) > 0 {
+		l.transformer, err = transform.NewTransformer(l.cfg.CaseSensitive, l.cfg.ColumnTransformRules)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return nil
 }
 
@@ -569,7 +580,7 @@ func (l *Loader) Resume(ctx context.Context, pr chan pb.ProcessResult) {
 }
 
 // Update implements Unit.Update
-// now, only support to update config for routes, filters, column-mappings, black-white-list
+// now, only support to update config for routes, filters, column-mappings, column-transforms, black-white-list
 // now no config diff implemented, so simply re-init use new config
 // no binlog filter for loader need to update
 func (l *Loader) Update(cfg *config.SubTaskConfig) error {
@@ -578,6 +589,7 @@ func (l *Loader) Update(cfg *config.SubTaskConfig) error {
 		oldBwList        *filter.Filter
 		oldTableRouter   *router.Table
 		oldColumnMapping *cm.Mapping
+		oldTransformer   *transform.Transformer
 	)
 
 	defer func() {
@@ -593,6 +605,9 @@ func (l *Loader) Update(cfg *config.SubTaskConfig) error {
 		if oldColumnMapping != nil {
 			l.columnMapping = oldColumnMapping
 		}
+		if oldTransformer != nil {
+			l.transformer = oldTransformer
+		}
 	}()
 
 	// update black-white-list
@@ -613,10 +628,18 @@ func (l *Loader) Update(cfg *config.SubTaskConfig) error {
 		return errors.Trace(err)
 	}
 
+	// update column-transforms, tables parsed before are not changed
+	oldTransformer = l.transformer
+	l.transformer, err = transform.NewTransformer(cfg.CaseSensitive, cfg.ColumnTransformRules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// update l.cfg
 	l.cfg.BWList = cfg.BWList
 	l.cfg.RouteRules = cfg.RouteRules
 	l.cfg.ColumnMappingRules = cfg.ColumnMappingRules
+	l.cfg.ColumnTransformRules = cfg.ColumnTransformRules
 	return nil
 }
 
@@ -860,6 +883,12 @@ func (l *Loader) restoreStructure(conn *Conn, sqlFile string, schema string, tab
 			// for table
 			if table != "" {
 				sqls = append(sqls, fmt.Sprintf("USE `%s`;", dstSchema))
+				if l.transformer.Matched(schema, table) {
+					query, err = transformCreateTable(l.transformer, query, schema, table)
+					if err != nil {
+						return errors.Trace(err)
+					}
+				}
 				query = renameShardingTable(query, table, dstTable)
 			} else {
 				query = renameShardingSchema(query, schema, dstSchema)
@@ -948,10 +977,14 @@ func (l *Loader) restoreData(ctx context.Context) error {
 			dataFiles := tables[table]
 			tableFile := fmt.Sprintf("%s/%s.%s-schema.sql", l.cfg.Dir, db, table)
 			if _, ok := l.tableInfos[tableName(db, table)]; !ok {
-				l.tableInfos[tableName(db, table)], err = parseTable(l.tableRouter, db, table, tableFile)
-				if err != nil {
-					return errors.Annotatef(err, "parse table %s/%s", db, table)
+				info, err2 := parseTable(l.tableRouter, db, table, tableFile)
+				if err2 != nil {
+					return errors.Annotatef(err2, "parse table %s/%s", db, table)
+				}
+				if err2 = transformTable(l.transformer, info); err2 != nil {
+					return errors.Annotatef(err2, "transform table %s/%s", db, table)
 				}
+				l.tableInfos[tableName(db, table)] = info
 			}
 
 			if l.checkPoint.IsTableFinished(db, table) {
@@ -0,0 +1,485 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydumper
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // for mysql
+	"github.com/pingcap/errors"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	gmysql "github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var (
+	readTimeout        = "1m"
+	metadataFile       = "metadata"
+	statementSize      = 1000000 // max size of an INSERT statement, same as mydumper's default `--statement-size`
+	metadataTimeFormat = "2006-01-02 15:04:05"
+)
+
+// snapshot is a consistent snapshot of the upstream, shared by connections in transactions
+type snapshot struct {
+	conns   []*sql.Conn
+	pos     gmysql.Position
+	gs      gtid.Set
+	started time.Time
+}
+
+// close rollbacks transactions and returns connections to the pool
+func (s *snapshot) close() {
+	for _, conn := range s.conns {
+		if _, err := conn.ExecContext(context.Background(), "ROLLBACK"); err != nil {
+			log.Warnf("[mydumper] rollback snapshot transaction error %v", err)
+		}
+		conn.Close()
+	}
+}
+
+// dump dumps all tables not filtered into the output dir.
+// if `metadata` of previous dumping exists, tables finished in previous dumping are kept,
+// and other tables are re-dumped in a new snapshot
+func (m *Mydumper) dump(ctx context.Context) error {
+	prev, err := m.loadMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if prev == nil {
+		// NOTE: remove output dir before start a fresh dumping
+		// every time re-dump, loader should re-prepare
+		if err = os.RemoveAll(m.cfg.Dir); err != nil {
+			return errors.Annotatef(err, "remove output dir %s", m.cfg.Dir)
+		}
+	}
+	err = os.MkdirAll(m.cfg.Dir, 0755)
+	if err != nil {
+		return errors.Annotatef(err, "create output dir %s", m.cfg.Dir)
+	}
+
+	snap, err := m.snapshot(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snap.close()
+	log.Infof("[mydumper] started consistent snapshot at %s %s", snap.pos, gtidSetString(snap.gs))
+
+	meta := &dumpMeta{started: snap.started, pos: snap.pos, gs: snap.gs, latestPos: snap.pos}
+	if prev != nil {
+		if snap.pos.Compare(prev.latestPos) < 0 {
+			return errors.Errorf("snapshot at %s is older than the latest snapshot %s of previous dumping, the upstream may be changed, please remove %s and re-dump", snap.pos, prev.latestPos, m.cfg.Dir)
+		}
+		log.Infof("[mydumper] resume dumping started at %s, tables finished before are kept", prev.pos)
+		meta.started, meta.pos, meta.gs = prev.started, prev.pos, prev.gs
+	}
+	// write metadata before dumping, so tables dumped in this snapshot can be verified when resuming
+	err = m.writeMetadata(meta, time.Time{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tables, err := m.dumpSchemas(ctx, snap.conns[0], prev)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = m.dumpTables(ctx, snap, tables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return errors.Trace(m.writeMetadata(meta, time.Now()))
+}
+
+// snapshot starts transactions with consistent snapshot in all connections,
+// like mydumper, it blocks all writes with `FLUSH TABLES WITH READ LOCK` until all transactions started,
+// so the binlog position got at that time is consistent with data in snapshot
+func (m *Mydumper) snapshot(ctx context.Context) (*snapshot, error) {
+	lockConn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer lockConn.Close()
+
+	_, err = lockConn.ExecContext(ctx, "FLUSH TABLES WITH READ LOCK")
+	if err != nil {
+		return nil, errors.Annotate(err, "lock all tables (need RELOAD privilege)")
+	}
+	defer func() {
+		if _, err2 := lockConn.ExecContext(context.Background(), "UNLOCK TABLES"); err2 != nil {
+			log.Errorf("[mydumper] unlock tables error %v", err2)
+		}
+	}()
+
+	snap := &snapshot{started: time.Now()}
+	queries := []string{
+		"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ",
+		"START TRANSACTION /*!40108 WITH CONSISTENT SNAPSHOT */",
+	}
+	if !m.cfg.SkipTzUTC {
+		queries = append(queries, "SET time_zone = '+00:00'")
+	}
+	for i := 0; i < m.threads(); i++ {
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			snap.close()
+			return nil, errors.Trace(err)
+		}
+		snap.conns = append(snap.conns, conn)
+		for _, query := range queries {
+			if _, err = conn.ExecContext(ctx, query); err != nil {
+				snap.close()
+				return nil, errors.Annotatef(err, "start snapshot with %s", query)
+			}
+		}
+	}
+
+	snap.pos, snap.gs, err = utils.GetMasterStatus(m.db, m.cfg.Flavor)
+	if err != nil {
+		snap.close()
+		return nil, errors.Annotate(err, "get master status")
+	}
+	return snap, nil
+}
+
+// dumpSchemas dumps create statements of databases and tables, returns tables whose data need to be dumped.
+// if prev is not nil, tables finished in previous dumping are skipped
+func (m *Mydumper) dumpSchemas(ctx context.Context, conn *sql.Conn, prev *dumpMeta) ([]*filter.Table, error) {
+	schemas, err := queryColumn(ctx, conn, "SHOW DATABASES", 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	files, err := listFiles(m.cfg.Dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	dataTables := make([]*filter.Table, 0, 16)
+	for _, schema := range schemas {
+		skip, err := m.skipTable(&filter.Table{Schema: schema}, bf.CreateDatabase)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if skip {
+			continue
+		}
+
+		// only base tables, views are not supported by Loader
+		tables, err := queryColumn(ctx, conn, fmt.Sprintf("SHOW FULL TABLES FROM %s WHERE Table_type = 'BASE TABLE'", quoteName(schema)), 0)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		createTables := make([]string, 0, len(tables))
+		hasTables := false
+		for _, table := range tables {
+			tbl := &filter.Table{Schema: schema, Name: table}
+			skip, err = m.skipTable(tbl, bf.CreateTable)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if skip {
+				continue
+			}
+			hasTables = true
+
+			skip, err = m.skipTable(tbl, bf.InsertEvent)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if skip {
+				createTables = append(createTables, table)
+				continue
+			}
+
+			rows, err := estimateRows(ctx, conn, tbl)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			m.progress.estimatedRows.Add(rows)
+			m.progress.totalTables.Add(1)
+			if prev != nil {
+				if record, ok := m.tableFinished(tbl, prev, files); ok {
+					// keep its schema file, which is consistent with data
+					log.Infof("[mydumper] table `%s`.`%s` was finished at %s:%d, skip it", schema, table, record.BinlogName, record.BinlogPos)
+					m.progress.finishedTables.Add(1)
+					m.progress.dumpedRows.Add(record.Rows)
+					continue
+				}
+			}
+			if err = m.removeTableFiles(tbl, files); err != nil {
+				return nil, errors.Trace(err)
+			}
+			createTables = append(createTables, table)
+			dataTables = append(dataTables, tbl)
+		}
+		if !hasTables && len(tables) > 0 {
+			// all tables filtered, the database is not needed
+			continue
+		}
+
+		create, err := queryColumn(ctx, conn, fmt.Sprintf("SHOW CREATE DATABASE %s", quoteName(schema)), 1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		err = writeFile(filepath.Join(m.cfg.Dir, fmt.Sprintf("%s-schema-create.sql", schema)), "", create[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		for _, table := range createTables {
+			create, err = queryColumn(ctx, conn, fmt.Sprintf("SHOW CREATE TABLE %s", dbutil.TableName(schema, table)), 1)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			err = writeFile(filepath.Join(m.cfg.Dir, schemaFileName(&filter.Table{Schema: schema, Name: table})), m.fileHeader(false), create[0])
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	return dataTables, nil
+}
+
+// dumpTables dumps data of tables concurrently, one table is dumped in one connection
+func (m *Mydumper) dumpTables(ctx context.Context, snap *snapshot, tables []*filter.Table) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tableCh := make(chan *filter.Table, len(tables))
+	for _, tbl := range tables {
+		tableCh <- tbl
+	}
+	close(tableCh)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for _, conn := range snap.conns {
+		wg.Add(1)
+		go func(conn *sql.Conn) {
+			defer wg.Done()
+			for tbl := range tableCh {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := m.dumpTable(ctx, snap, conn, tbl); err != nil {
+					errOnce.Do(func() {
+						firstErr = errors.Annotatef(err, "dump table `%s`.`%s`", tbl.Schema, tbl.Name)
+						cancel()
+					})
+					return
+				}
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return errors.Trace(ctx.Err())
+}
+
+// dumpTable dumps data of the table into chunk files, and saves its record after finished
+func (m *Mydumper) dumpTable(ctx context.Context, snap *snapshot, conn *sql.Conn, tbl *filter.Table) error {
+	begin := time.Now()
+	columns, hasGenerated, err := dumpColumns(ctx, conn, tbl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// generated columns can not be inserted, so list other columns explicitly like mydumper
+	insertHead := fmt.Sprintf("INSERT INTO %s VALUES\n", quoteName(tbl.Name))
+	selectField := "*"
+	if hasGenerated {
+		quoted := make([]string, 0, len(columns))
+		for _, col := range columns {
+			quoted = append(quoted, quoteName(col))
+		}
+		selectField = strings.Join(quoted, ",")
+		insertHead = fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", quoteName(tbl.Name), selectField)
+	}
+
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s", selectField, dbutil.TableName(tbl.Schema, tbl.Name)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	numeric := make([]bool, 0, len(types))
+	for _, tp := range types {
+		numeric = append(numeric, isNumericType(tp.DatabaseTypeName()))
+	}
+
+	w := newChunkWriter(m.cfg.Dir, tbl, m.cfg.ChunkFilesize*1024*1024, m.fileHeader(true), insertHead, m.progress)
+	defer w.close()
+
+	var (
+		data   = make([]sql.RawBytes, len(types))
+		values = make([]interface{}, len(types))
+		row    bytes.Buffer
+		count  int64
+	)
+	for i := range values {
+		values[i] = &data[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(values...); err != nil {
+			return errors.Trace(err)
+		}
+		row.Reset()
+		row.WriteByte('(')
+		for i, value := range data {
+			if i > 0 {
+				row.WriteByte(',')
+			}
+			writeValue(&row, value, numeric[i])
+		}
+		row.WriteByte(')')
+		if err = w.writeRow(row.Bytes()); err != nil {
+			return errors.Trace(err)
+		}
+		count++
+		m.progress.dumpedRows.Add(1)
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Trace(err)
+	}
+	if err = w.close(); err != nil {
+		return errors.Trace(err)
+	}
+	files := append([]string{schemaFileName(tbl)}, w.files()...)
+	if err = m.saveRecord(tbl, snap, count, files); err != nil {
+		return errors.Trace(err)
+	}
+	m.progress.finishedTables.Add(1)
+
+	log.Infof("[mydumper] dumped %d rows of table `%s`.`%s` into %d files, takes %v", count, tbl.Schema, tbl.Name, w.part, time.Since(begin))
+	return nil
+}
+
+// fileHeader returns header of dumped files, data files need time zone if dumped in UTC
+func (m *Mydumper) fileHeader(isData bool) string {
+	header := "/*!40101 SET NAMES binary*/;\n/*!40014 SET FOREIGN_KEY_CHECKS=0*/;\n"
+	if isData && !m.cfg.SkipTzUTC {
+		header += "/*!40103 SET TIME_ZONE='+00:00' */;\n"
+	}
+	return header + "\n"
+}
+
+// dumpColumns returns names of columns need to dump, and whether the table has generated columns
+func dumpColumns(ctx context.Context, conn *sql.Conn, tbl *filter.Table) ([]string, bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT COLUMN_NAME, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", tbl.Schema, tbl.Name)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	defer rows.Close()
+
+	var (
+		columns      []string
+		hasGenerated bool
+		name, extra  string
+	)
+	for rows.Next() {
+		if err = rows.Scan(&name, &extra); err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		if strings.Contains(strings.ToUpper(extra), "GENERATED") {
+			hasGenerated = true
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return columns, hasGenerated, errors.Trace(rows.Err())
+}
+
+// estimateRows returns estimated count of rows in the table, it may be quite inaccurate for InnoDB
+func estimateRows(ctx context.Context, conn *sql.Conn, tbl *filter.Table) (int64, error) {
+	var rows sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?", tbl.Schema, tbl.Name).Scan(&rows)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return rows.Int64, errors.Annotatef(err, "estimate rows of `%s`.`%s`", tbl.Schema, tbl.Name)
+}
+
+// queryColumn returns values of the column with index idx in all rows of the query
+func queryColumn(ctx context.Context, conn *sql.Conn, query string, idx int) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Annotatef(err, "query %s", query)
+	}
+	defer rows.Close()
+
+	rowColumns, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []string
+	for rows.Next() {
+		data := make([]sql.RawBytes, len(rowColumns))
+		values := make([]interface{}, len(rowColumns))
+		for i := range values {
+			values[i] = &data[i]
+		}
+		if err = rows.Scan(values...); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, string(data[idx]))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result) == 0 && idx > 0 {
+		return nil, errors.NotFoundf("result of query %s", query)
+	}
+	return result, nil
+}
+
+func schemaFileName(tbl *filter.Table) string {
+	return fmt.Sprintf("%s.%s-schema.sql", tbl.Schema, tbl.Name)
+}
+
+// writeFile writes the statement into the file with header
+func writeFile(path, header, stmt string) error {
+	err := ioutil.WriteFile(path, []byte(header+stmt+";\n"), 0644)
+	return errors.Annotatef(err, "write file %s", path)
+}
+
+func gtidSetString(gs gtid.Set) string {
+	if gs == nil {
+		return ""
+	}
+	return gs.String()
+}
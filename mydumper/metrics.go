@@ -18,6 +18,46 @@ import (
 )
 
 var (
+	totalTablesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "mydumper",
+			Name:      "total_tables",
+			Help:      "tables to dump in total",
+		}, []string{"task"})
+
+	finishedTablesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "mydumper",
+			Name:      "finished_tables",
+			Help:      "tables finished dumping",
+		}, []string{"task"})
+
+	estimatedRowsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "mydumper",
+			Name:      "estimated_rows",
+			Help:      "estimated rows to dump in total",
+		}, []string{"task"})
+
+	dumpedRowsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "mydumper",
+			Name:      "dumped_rows",
+			Help:      "rows dumped",
+		}, []string{"task"})
+
+	writtenBytesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "mydumper",
+			Name:      "written_bytes",
+			Help:      "bytes written into dumped files",
+		}, []string{"task"})
+
 	// should alert
 	mydumperExitWithErrorCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -30,5 +70,10 @@ var (
 
 // RegisterMetrics registers metrics.
 func RegisterMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(totalTablesGauge)
+	registry.MustRegister(finishedTablesGauge)
+	registry.MustRegister(estimatedRowsGauge)
+	registry.MustRegister(dumpedRowsGauge)
+	registry.MustRegister(writtenBytesGauge)
 	registry.MustRegister(mydumperExitWithErrorCounter)
 }
@@ -15,40 +15,66 @@ package mydumper
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"os"
-	"os/exec"
-	"strconv"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/pingcap/errors"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go/sync2"
+
 	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dm/unit"
 	"github.com/pingcap/dm/pkg/log"
-	"github.com/siddontang/go/sync2"
 )
 
-// Mydumper is a simple wrapper for mydumper binary
+// Mydumper dumps data from the upstream MySQL in the same file layout as mydumper,
+// so the output can be loaded by Loader:
+//   - metadata                       binlog position (and GTID set) of the snapshot
+//   - {db}-schema-create.sql         create database statement
+//   - {db}.{table}-schema.sql        create table statement
+//   - {db}.{table}.{part}.sql        data chunks
+//   - {db}.{table}.finished          record of a table finished dumping, see `tableRecord`
+//
+// all tables are dumped in one consistent snapshot concurrently, see `snapshot`.
+// after paused or failed, only tables not finished are re-dumped in a new snapshot, see `dump`
 type Mydumper struct {
 	cfg *config.SubTaskConfig
 
-	args   []string
+	db           *sql.DB
+	bwList       *filter.Filter
+	binlogFilter *bf.BinlogEvent
+
+	progress *progress
+
 	closed sync2.AtomicBool
 }
 
 // NewMydumper creates a new Mydumper
 func NewMydumper(cfg *config.SubTaskConfig) *Mydumper {
 	m := &Mydumper{
-		cfg: cfg,
+		cfg:      cfg,
+		progress: newProgress(),
 	}
-	m.args = m.constructArgs()
 	return m
 }
 
 // Init implements Unit.Init
 func (m *Mydumper) Init() error {
-	return nil // always return nil
+	var err error
+	m.bwList = filter.New(m.cfg.CaseSensitive, m.cfg.BWList)
+	m.binlogFilter, err = bf.NewBinlogEvent(m.cfg.CaseSensitive, m.cfg.FilterRules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	db := m.cfg.From
+	dbDSN := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=binary&readTimeout=%s", db.User, db.Password, db.Host, db.Port, readTimeout)
+	m.db, err = sql.Open("mysql", dbDSN)
+	return errors.Trace(err)
 }
 
 // Process implements Unit.Process
@@ -59,26 +85,28 @@ func (m *Mydumper) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	errs := make([]*pb.ProcessError, 0, 1)
 	isCanceled := false
 
-	// NOTE: remove output dir before start dumping
-	// every time re-dump, loader should re-prepare
-	err := os.RemoveAll(m.cfg.Dir)
-	if err != nil {
-		log.Errorf("[mydumper] remove output dir %s fail %v", m.cfg.Dir, err)
-	}
-
-	// Cmd cannot be reused, so we create a new cmd when begin processing
-	cmd := exec.CommandContext(ctx, m.cfg.MydumperPath, m.args...)
-	log.Infof("[mydumper] starting mydumper using args %v", cmd.Args)
-	output, err := cmd.CombinedOutput()
-
-	if err != nil {
-		mydumperExitWithErrorCounter.WithLabelValues(m.cfg.Name).Inc()
-		errs = append(errs, unit.NewProcessError(pb.ErrorType_UnknownError, fmt.Sprintf("%s. %s", err.Error(), output)))
-	} else {
-		select {
-		case <-ctx.Done():
-			isCanceled = true
-		default:
+	log.Infof("[mydumper] start dumping to %s with %d threads", m.cfg.Dir, m.threads())
+	m.progress.reset()
+	statusCtx, statusCancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.PrintStatus(statusCtx)
+	}()
+
+	err := m.dump(ctx)
+	m.progress.finish()
+	statusCancel()
+	wg.Wait()
+
+	select {
+	case <-ctx.Done():
+		isCanceled = true
+	default:
+		if err != nil {
+			mydumperExitWithErrorCounter.WithLabelValues(m.cfg.Name).Inc()
+			errs = append(errs, unit.NewProcessError(pb.ErrorType_UnknownError, errors.ErrorStack(err)))
 		}
 	}
 
@@ -95,7 +123,12 @@ func (m *Mydumper) Close() {
 	if m.closed.Get() {
 		return
 	}
-	// do nothing, external will cancel the command (if running)
+	// external will cancel the dumping (if running)
+	if m.db != nil {
+		if err := m.db.Close(); err != nil {
+			log.Errorf("[mydumper] close upstream DB error %v", err)
+		}
+	}
 	m.closed.Set(true)
 }
 
@@ -105,7 +138,7 @@ func (m *Mydumper) Pause() {
 		log.Warn("[mydumper] try to pause, but already closed")
 		return
 	}
-	// do nothing, external will cancel the command (if running)
+	// do nothing, external will cancel the dumping (if running)
 }
 
 // Resume implements Unit.Resume
@@ -114,7 +147,7 @@ func (m *Mydumper) Resume(ctx context.Context, pr chan pb.ProcessResult) {
 		log.Warn("[mydumper] try to resume, but already closed")
 		return
 	}
-	// just call Process
+	// just call Process, finished tables will be kept
 	m.Process(ctx, pr)
 }
 
@@ -124,17 +157,6 @@ func (m *Mydumper) Update(cfg *config.SubTaskConfig) error {
 	return nil
 }
 
-// Status implements Unit.Status
-func (m *Mydumper) Status() interface{} {
-	// NOTE: try to add some status, like dumped file count
-	return &pb.DumpStatus{}
-}
-
-// Error implements Unit.Error
-func (m *Mydumper) Error() interface{} {
-	return &pb.DumpError{}
-}
-
 // Type implements Unit.Type
 func (m *Mydumper) Type() pb.UnitType {
 	return pb.UnitType_Dump
@@ -145,56 +167,26 @@ func (m *Mydumper) IsFreshTask() (bool, error) {
 	return true, nil
 }
 
-// constructArgs constructs arguments for exec.Command
-func (m *Mydumper) constructArgs() []string {
-	cfg := m.cfg
-	db := cfg.From
-	ret := []string{
-		"--host",
-		db.Host,
-		"--port",
-		strconv.Itoa(db.Port),
-		"--user",
-		db.User,
-		"--password",
-		db.Password,
-		"--outputdir",
-		cfg.Dir, // use LoaderConfig.Dir as --outputdir
-	}
-
-	ret = append(ret, m.logArgs(cfg)...)
-
-	if cfg.Threads > 0 {
-		ret = append(ret, "--threads", strconv.Itoa(cfg.Threads))
-	}
-	if cfg.ChunkFilesize > 0 {
-		ret = append(ret, "--chunk-filesize", strconv.FormatInt(cfg.ChunkFilesize, 10))
-	}
-	if cfg.SkipTzUTC {
-		ret = append(ret, "--skip-tz-utc")
+// threads returns count of concurrent connections to dump data
+func (m *Mydumper) threads() int {
+	if m.cfg.Threads > 0 {
+		return m.cfg.Threads
 	}
-	extraArgs := strings.Fields(cfg.ExtraArgs)
-	if len(extraArgs) > 0 {
-		ret = append(ret, ParseArgLikeBash(extraArgs)...)
-	}
-	return ret
+	return 1
 }
 
-// logArgs constructs arguments for log from SubTaskConfig
-func (m *Mydumper) logArgs(cfg *config.SubTaskConfig) []string {
-	args := make([]string, 0, 4)
-	if len(cfg.LogFile) > 0 {
-		// mydumper overwrite log file, ref: https://github.com/maxbube/mydumper/blob/a1ddcba64b6af807cf9de468b8ca59b54ca6a2a9/mydumper.c#L232
-		// so we need to use a different log file (mydumper-taskname.log) until we update mydumper to append log
-		args = append(args, "--logfile", fmt.Sprintf("mydumper-%s.log", cfg.Name))
+// skipTable checks whether table (or schema if table name is empty) should be skipped for an event type,
+// by black-white list and binlog event filter rules
+func (m *Mydumper) skipTable(tbl *filter.Table, et bf.EventType) (bool, error) {
+	if filter.IsSystemSchema(tbl.Schema) {
+		return true, nil
+	}
+	if len(m.bwList.ApplyOn([]*filter.Table{tbl})) == 0 {
+		return true, nil
 	}
-	switch strings.ToLower(cfg.LogLevel) {
-	case "fatal", "error":
-		args = append(args, "--verbose", "1")
-	case "warn", "warning":
-		args = append(args, "--verbose", "2")
-	case "info", "debug":
-		args = append(args, "--verbose", "3")
+	action, err := m.binlogFilter.Filter(tbl.Schema, tbl.Name, et, "")
+	if err != nil {
+		return false, errors.Annotatef(err, "filter %s on `%s`.`%s`", et, tbl.Schema, tbl.Name)
 	}
-	return args
+	return action == bf.Ignore, nil
 }
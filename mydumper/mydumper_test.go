@@ -14,10 +14,14 @@
 package mydumper
 
 import (
-	"strings"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	. "github.com/pingcap/check"
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"github.com/pingcap/tidb-tools/pkg/filter"
 
 	"github.com/pingcap/dm/dm/config"
 )
@@ -41,23 +45,127 @@ func (m *testMydumperSuite) SetUpSuite(c *C) {
 			Port:     3306,
 		},
 		MydumperConfig: config.MydumperConfig{
-			MydumperPath:  "./bin/mydumper",
 			Threads:       4,
 			SkipTzUTC:     true,
 			ChunkFilesize: 64,
 		},
+		Flavor: "mysql",
 		LoaderConfig: config.LoaderConfig{
 			Dir: "./dumped_data",
 		},
 	}
 }
 
-func (m *testMydumperSuite) TestArgs(c *C) {
-	expected := strings.Fields("--host 127.0.0.1 --port 3306 --user root --password 123 " +
-		"--outputdir ./dumped_data --threads 4 --chunk-filesize 64 --skip-tz-utc " +
-		"--regex ^(?!(mysql|information_schema|performance_schema))")
-	m.cfg.MydumperConfig.ExtraArgs = "--regex '^(?!(mysql|information_schema|performance_schema))'"
-	mydumper := NewMydumper(m.cfg)
-	args := mydumper.constructArgs()
-	c.Assert(args, DeepEquals, expected)
+func (m *testMydumperSuite) TestSkipTable(c *C) {
+	cfg := *m.cfg
+	cfg.BWList = &filter.Rules{
+		IgnoreDBs: []string{"ignore_db"},
+	}
+	cfg.FilterRules = []*bf.BinlogEventRule{
+		{SchemaPattern: "test", TablePattern: "no_data*", Events: []bf.EventType{bf.AllDML}, Action: bf.Ignore},
+		{SchemaPattern: "test", TablePattern: "no_table", Events: []bf.EventType{bf.CreateTable}, Action: bf.Ignore},
+	}
+	mydumper := NewMydumper(&cfg)
+	c.Assert(mydumper.Init(), IsNil)
+	defer mydumper.Close()
+
+	cases := []struct {
+		schema, table string
+		et            bf.EventType
+		skip          bool
+	}{
+		{"mysql", "", bf.CreateDatabase, true},
+		{"ignore_db", "", bf.CreateDatabase, true},
+		{"ignore_db", "t1", bf.CreateTable, true},
+		{"test", "", bf.CreateDatabase, false},
+		{"test", "t1", bf.CreateTable, false},
+		{"test", "t1", bf.InsertEvent, false},
+		{"test", "no_data1", bf.CreateTable, false},
+		{"test", "no_data1", bf.InsertEvent, true},
+		{"test", "no_table", bf.CreateTable, true},
+	}
+	for _, cs := range cases {
+		skip, err := mydumper.skipTable(&filter.Table{Schema: cs.schema, Name: cs.table}, cs.et)
+		c.Assert(err, IsNil)
+		c.Assert(skip, Equals, cs.skip, Commentf("%+v", cs))
+	}
+}
+
+func (m *testMydumperSuite) TestChunkWriter(c *C) {
+	dir := c.MkDir()
+	tbl := &filter.Table{Schema: "test", Name: "t1"}
+	header := "/*!40101 SET NAMES binary*/;\n\n"
+
+	// no file created if no rows
+	p := newProgress()
+	w := newChunkWriter(dir, tbl, 0, header, "INSERT INTO `t1` VALUES\n", p)
+	c.Assert(w.close(), IsNil)
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+
+	// all rows in one file
+	c.Assert(w.writeRow([]byte(`(1,"hello")`)), IsNil)
+	c.Assert(w.writeRow([]byte(`(2,"world")`)), IsNil)
+	c.Assert(w.close(), IsNil)
+	c.Assert(w.close(), IsNil)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "test.t1.sql"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, header+"INSERT INTO `t1` VALUES\n(1,\"hello\"),\n(2,\"world\");\n")
+
+	// split into statements and chunks
+	originStatementSize := statementSize
+	statementSize = 20
+	defer func() {
+		statementSize = originStatementSize
+	}()
+	c.Assert(p.writtenBytes.Get(), Equals, int64(len(data)))
+	c.Assert(p.currentFiles, HasLen, 0)
+
+	w = newChunkWriter(dir, tbl, 60, header, "INSERT INTO `t1` VALUES\n", p)
+	for i := 0; i < 3; i++ {
+		c.Assert(w.writeRow([]byte(fmt.Sprintf("(%d)", i))), IsNil)
+	}
+	c.Assert(p.status().CurrentFiles, DeepEquals, []string{"test.t1.00002.sql"})
+	c.Assert(w.close(), IsNil)
+	c.Assert(w.part, Equals, 2)
+	data, err = ioutil.ReadFile(filepath.Join(dir, "test.t1.00001.sql"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, header+"INSERT INTO `t1` VALUES\n(0);\nINSERT INTO `t1` VALUES\n(1);\n")
+	data, err = ioutil.ReadFile(filepath.Join(dir, "test.t1.00002.sql"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, header+"INSERT INTO `t1` VALUES\n(2);\n")
+}
+
+func (m *testMydumperSuite) TestProgress(c *C) {
+	p := newProgress()
+	s := p.status()
+	c.Assert(s.Elapsed, Equals, "0s")
+	c.Assert(s.CurrentFiles, HasLen, 0)
+
+	p.reset()
+	p.totalTables.Set(3)
+	p.finishedTables.Add(1)
+	p.estimatedRows.Add(100)
+	p.dumpedRows.Add(10)
+	p.writtenBytes.Add(1024)
+	p.addFile("test.t2.sql")
+	p.addFile("test.t1.sql")
+	s = p.status()
+	c.Assert(s.TotalTables, Equals, int32(3))
+	c.Assert(s.FinishedTables, Equals, int32(1))
+	c.Assert(s.EstimatedRows, Equals, int64(100))
+	c.Assert(s.DumpedRows, Equals, int64(10))
+	c.Assert(s.WrittenBytes, Equals, int64(1024))
+	c.Assert(s.CurrentFiles, DeepEquals, []string{"test.t1.sql", "test.t2.sql"})
+
+	p.removeFile("test.t1.sql")
+	p.finish()
+	elapsed := p.elapsed()
+	c.Assert(elapsed, Equals, p.elapsed())
+	c.Assert(p.status().CurrentFiles, DeepEquals, []string{"test.t2.sql"})
+
+	p.reset()
+	c.Assert(p.status().TotalTables, Equals, int32(0))
+	c.Assert(p.status().CurrentFiles, HasLen, 0)
 }
@@ -0,0 +1,229 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydumper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	gmysql "github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/ioutil2"
+
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var recordSuffix = ".finished"
+
+// dumpMeta is the content of `metadata`.
+// when the dump is resumed, finished tables are kept and others are re-dumped in a new snapshot,
+// so tables may be dumped in snapshots in range [pos, latestPos],
+// syncer starts from pos and is reentrant until reaching latestPos
+type dumpMeta struct {
+	started   time.Time
+	pos       gmysql.Position
+	gs        gtid.Set
+	latestPos gmysql.Position
+}
+
+// tableRecord records a table finished dumping, it's saved as `{db}.{table}.finished`
+type tableRecord struct {
+	BinlogName string           `json:"binlog-name"`
+	BinlogPos  uint32           `json:"binlog-pos"`
+	BinlogGTID string           `json:"binlog-gtid"`
+	Rows       int64            `json:"rows"`
+	Files      map[string]int64 `json:"files"` // file name -> size, including the schema file
+}
+
+// loadMetadata loads `metadata` written by previous dumping, returns nil if not exists or invalid
+func (m *Mydumper) loadMetadata() (*dumpMeta, error) {
+	metafile := filepath.Join(m.cfg.Dir, metadataFile)
+	if !utils.IsFileExists(metafile) {
+		return nil, nil
+	}
+
+	pos, err := utils.ParseMetaData(metafile)
+	if err != nil {
+		log.Warnf("[mydumper] invalid metadata of previous dumping, re-dump all tables: %v", err)
+		return nil, nil
+	}
+	meta := &dumpMeta{started: time.Now(), pos: *pos, latestPos: *pos}
+
+	gtidStr, err := utils.ParseMetaDataGTID(metafile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(gtidStr) > 0 {
+		meta.gs, err = gtid.ParserGTID(m.cfg.Flavor, gtidStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	latestPos, err := utils.ParseMetaDataLatestPos(metafile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if latestPos != nil {
+		meta.latestPos = *latestPos
+	}
+	return meta, nil
+}
+
+// writeMetadata writes `metadata` file in mydumper's format, which can be parsed by `utils.ParseMetaData`.
+// it's written before dumping tables with zero finished time, and re-written after all tables finished
+func (m *Mydumper) writeMetadata(meta *dumpMeta, finished time.Time) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Started dump at: %s\n", meta.started.Format(metadataTimeFormat))
+	buf.WriteString("SHOW MASTER STATUS:\n")
+	fmt.Fprintf(&buf, "\tLog: %s\n", meta.pos.Name)
+	fmt.Fprintf(&buf, "\tPos: %d\n", meta.pos.Pos)
+	if gs := gtidSetString(meta.gs); len(gs) > 0 {
+		fmt.Fprintf(&buf, "\tGTID:%s\n", gs)
+	}
+	buf.WriteString("\n")
+	if meta.latestPos.Compare(meta.pos) > 0 {
+		buf.WriteString(utils.MetaDataLatestSnapshot + "\n")
+		fmt.Fprintf(&buf, "\tLog: %s\n", meta.latestPos.Name)
+		fmt.Fprintf(&buf, "\tPos: %d\n", meta.latestPos.Pos)
+		buf.WriteString("\n")
+	}
+	if !finished.IsZero() {
+		fmt.Fprintf(&buf, "Finished dump at: %s\n", finished.Format(metadataTimeFormat))
+	}
+
+	err := ioutil2.WriteFileAtomic(filepath.Join(m.cfg.Dir, metadataFile), buf.Bytes(), 0644)
+	return errors.Annotate(err, "write metadata")
+}
+
+// tableFinished checks whether the table is finished in previous dumping,
+// the record is valid only if it was dumped in snapshots of meta and all its files are not changed
+func (m *Mydumper) tableFinished(tbl *filter.Table, meta *dumpMeta, files map[string]int64) (*tableRecord, bool) {
+	name := recordName(tbl)
+	if _, ok := files[name]; !ok {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(filepath.Join(m.cfg.Dir, name))
+	if err != nil {
+		log.Warnf("[mydumper] read record %s error %v, re-dump the table", name, err)
+		return nil, false
+	}
+	record := &tableRecord{}
+	if err = json.Unmarshal(data, record); err != nil {
+		log.Warnf("[mydumper] invalid record %s %v, re-dump the table", name, err)
+		return nil, false
+	}
+
+	pos := gmysql.Position{Name: record.BinlogName, Pos: record.BinlogPos}
+	if pos.Compare(meta.pos) < 0 || pos.Compare(meta.latestPos) > 0 {
+		log.Warnf("[mydumper] table `%s`.`%s` was dumped at %s, not in snapshots between %s and %s of metadata, re-dump the table", tbl.Schema, tbl.Name, pos, meta.pos, meta.latestPos)
+		return nil, false
+	}
+	for file, size := range record.Files {
+		if size2, ok := files[file]; !ok || size2 != size {
+			log.Warnf("[mydumper] file %s of table `%s`.`%s` is missing or changed, re-dump the table", file, tbl.Schema, tbl.Name)
+			return nil, false
+		}
+	}
+	return record, true
+}
+
+// saveRecord saves record of the table after its files written
+func (m *Mydumper) saveRecord(tbl *filter.Table, snap *snapshot, rows int64, files []string) error {
+	record := &tableRecord{
+		BinlogName: snap.pos.Name,
+		BinlogPos:  snap.pos.Pos,
+		BinlogGTID: gtidSetString(snap.gs),
+		Rows:       rows,
+		Files:      make(map[string]int64, len(files)),
+	}
+	for _, file := range files {
+		size, err := utils.GetFileSize(filepath.Join(m.cfg.Dir, file))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		record.Files[file] = size
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ioutil2.WriteFileAtomic(filepath.Join(m.cfg.Dir, recordName(tbl)), data, 0644))
+}
+
+// removeTableFiles removes the record and data files of the table before re-dumping it
+func (m *Mydumper) removeTableFiles(tbl *filter.Table, files map[string]int64) error {
+	record := recordName(tbl)
+	prefix := fmt.Sprintf("%s.%s.", tbl.Schema, tbl.Name)
+	for file := range files {
+		if file != record && !isDataFile(file, prefix) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.cfg.Dir, file)); err != nil && !os.IsNotExist(err) {
+			return errors.Annotatef(err, "remove file %s", file)
+		}
+		delete(files, file)
+	}
+	return nil
+}
+
+// listFiles returns names and sizes of files in the output dir
+func listFiles(dir string) (map[string]int64, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	files := make(map[string]int64, len(infos))
+	for _, info := range infos {
+		if !info.IsDir() {
+			files[info.Name()] = info.Size()
+		}
+	}
+	return files, nil
+}
+
+func recordName(tbl *filter.Table) string {
+	return fmt.Sprintf("%s.%s%s", tbl.Schema, tbl.Name, recordSuffix)
+}
+
+// isDataFile checks whether the file is `{prefix}sql` or `{prefix}{part}.sql`, see `chunkWriter`
+func isDataFile(file, prefix string) bool {
+	if !strings.HasPrefix(file, prefix) || !strings.HasSuffix(file, ".sql") {
+		return false
+	}
+	part := strings.TrimSuffix(strings.TrimPrefix(file, prefix), "sql")
+	if len(part) == 0 {
+		return true
+	}
+	part = strings.TrimSuffix(part, ".")
+	if len(part) == 0 {
+		return false
+	}
+	for _, ch := range part {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydumper
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	gmysql "github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+func (m *testMydumperSuite) TestMetadata(c *C) {
+	cfg := *m.cfg
+	cfg.Dir = c.MkDir()
+	mydumper := NewMydumper(&cfg)
+
+	// no metadata
+	meta, err := mydumper.loadMetadata()
+	c.Assert(err, IsNil)
+	c.Assert(meta, IsNil)
+
+	gs, err := gtid.ParserGTID("mysql", "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14,406a3f61-690d-11e7-87c5-6c92bf46f384:1-94321383")
+	c.Assert(err, IsNil)
+	meta = &dumpMeta{
+		started:   time.Now(),
+		pos:       gmysql.Position{Name: "mysql-bin.000003", Pos: 3295817},
+		gs:        gs,
+		latestPos: gmysql.Position{Name: "mysql-bin.000003", Pos: 3295817},
+	}
+	c.Assert(mydumper.writeMetadata(meta, time.Now()), IsNil)
+
+	metafile := filepath.Join(cfg.Dir, metadataFile)
+	pos, err := utils.ParseMetaData(metafile)
+	c.Assert(err, IsNil)
+	c.Assert(*pos, Equals, meta.pos)
+	gtidStr, err := utils.ParseMetaDataGTID(metafile)
+	c.Assert(err, IsNil)
+	gs2, err := gtid.ParserGTID("mysql", gtidStr)
+	c.Assert(err, IsNil)
+	c.Assert(gs2.Equal(gs), IsTrue)
+	latestPos, err := utils.ParseMetaDataLatestPos(metafile)
+	c.Assert(err, IsNil)
+	c.Assert(latestPos, IsNil)
+
+	// resumed in a later snapshot
+	meta.latestPos = gmysql.Position{Name: "mysql-bin.000004", Pos: 154}
+	c.Assert(mydumper.writeMetadata(meta, time.Time{}), IsNil)
+	meta2, err := mydumper.loadMetadata()
+	c.Assert(err, IsNil)
+	c.Assert(meta2.pos, Equals, meta.pos)
+	c.Assert(meta2.latestPos, Equals, meta.latestPos)
+	c.Assert(meta2.gs.Equal(gs), IsTrue)
+
+	// invalid metadata is ignored
+	c.Assert(ioutil.WriteFile(metafile, []byte("invalid"), 0644), IsNil)
+	meta, err = mydumper.loadMetadata()
+	c.Assert(err, IsNil)
+	c.Assert(meta, IsNil)
+}
+
+func (m *testMydumperSuite) TestRecord(c *C) {
+	cfg := *m.cfg
+	cfg.Dir = c.MkDir()
+	mydumper := NewMydumper(&cfg)
+
+	tbl := &filter.Table{Schema: "test", Name: "t1"}
+	dataFiles := []string{"test.t1-schema.sql", "test.t1.00001.sql", "test.t1.00002.sql"}
+	otherFiles := []string{"test.t10.00001.sql", "test.t1.bak.sql", "test.t2.sql"}
+	for _, file := range append(dataFiles, otherFiles...) {
+		c.Assert(ioutil.WriteFile(filepath.Join(cfg.Dir, file), []byte(file), 0644), IsNil)
+	}
+	snap := &snapshot{pos: gmysql.Position{Name: "mysql-bin.000003", Pos: 1000}}
+	c.Assert(mydumper.saveRecord(tbl, snap, 10, dataFiles), IsNil)
+
+	files, err := listFiles(cfg.Dir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 7)
+
+	cases := []struct {
+		pos, latestPos gmysql.Position
+		finished       bool
+	}{
+		{gmysql.Position{Name: "mysql-bin.000003", Pos: 1000}, gmysql.Position{Name: "mysql-bin.000003", Pos: 1000}, true},
+		{gmysql.Position{Name: "mysql-bin.000002", Pos: 4}, gmysql.Position{Name: "mysql-bin.000004", Pos: 4}, true},
+		{gmysql.Position{Name: "mysql-bin.000003", Pos: 1001}, gmysql.Position{Name: "mysql-bin.000004", Pos: 4}, false},
+		{gmysql.Position{Name: "mysql-bin.000002", Pos: 4}, gmysql.Position{Name: "mysql-bin.000003", Pos: 999}, false},
+	}
+	for _, cs := range cases {
+		record, finished := mydumper.tableFinished(tbl, &dumpMeta{pos: cs.pos, latestPos: cs.latestPos}, files)
+		c.Assert(finished, Equals, cs.finished)
+		if finished {
+			c.Assert(record.Rows, Equals, int64(10))
+		}
+	}
+
+	// file changed
+	meta := &dumpMeta{pos: snap.pos, latestPos: snap.pos}
+	files["test.t1.00002.sql"]++
+	_, finished := mydumper.tableFinished(tbl, meta, files)
+	c.Assert(finished, IsFalse)
+	delete(files, "test.t1.00002.sql")
+	_, finished = mydumper.tableFinished(tbl, meta, files)
+	c.Assert(finished, IsFalse)
+
+	// remove record and data files, but the schema file and files of other tables are kept
+	files, err = listFiles(cfg.Dir)
+	c.Assert(err, IsNil)
+	c.Assert(mydumper.removeTableFiles(tbl, files), IsNil)
+	files, err = listFiles(cfg.Dir)
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 4)
+	for _, file := range append([]string{"test.t1-schema.sql"}, otherFiles...) {
+		c.Assert(files, HasKey, file)
+	}
+	_, finished = mydumper.tableFinished(tbl, meta, files)
+	c.Assert(finished, IsFalse)
+}
+
+func (m *testMydumperSuite) TestIsDataFile(c *C) {
+	cases := []struct {
+		file   string
+		isData bool
+	}{
+		{"test.t1.sql", true},
+		{"test.t1.00001.sql", true},
+		{"test.t1.123456.sql", true},
+		{"test.t1-schema.sql", false},
+		{"test.t1..sql", false},
+		{"test.t1.a1.sql", false},
+		{"test.t1.finished", false},
+		{"test.t10.sql", false},
+		{"test2.t1.sql", false},
+	}
+	for _, cs := range cases {
+		c.Assert(isDataFile(cs.file, "test.t1."), Equals, cs.isData, Commentf("%s", cs.file))
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydumper
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/siddontang/go/sync2"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+const (
+	printStatusInterval = time.Second * 5
+)
+
+// progress records progress of dumping, it's updated by the dumper concurrently
+type progress struct {
+	totalTables    sync2.AtomicInt32
+	finishedTables sync2.AtomicInt32
+	estimatedRows  sync2.AtomicInt64
+	dumpedRows     sync2.AtomicInt64
+	writtenBytes   sync2.AtomicInt64
+
+	sync.RWMutex
+	begin        time.Time
+	end          time.Time // zero if dumping not finished
+	currentFiles map[string]struct{}
+}
+
+func newProgress() *progress {
+	return &progress{
+		currentFiles: make(map[string]struct{}),
+	}
+}
+
+// reset resets progress when (re-)starting dumping
+func (p *progress) reset() {
+	p.totalTables.Set(0)
+	p.finishedTables.Set(0)
+	p.estimatedRows.Set(0)
+	p.dumpedRows.Set(0)
+	p.writtenBytes.Set(0)
+
+	p.Lock()
+	defer p.Unlock()
+	p.begin = time.Now()
+	p.end = time.Time{}
+	p.currentFiles = make(map[string]struct{})
+}
+
+// finish stops the elapsed time
+func (p *progress) finish() {
+	p.Lock()
+	defer p.Unlock()
+	p.end = time.Now()
+}
+
+func (p *progress) addFile(name string) {
+	p.Lock()
+	defer p.Unlock()
+	p.currentFiles[name] = struct{}{}
+}
+
+func (p *progress) removeFile(name string) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.currentFiles, name)
+}
+
+// elapsed returns time elapsed since dumping started
+func (p *progress) elapsed() time.Duration {
+	p.RLock()
+	defer p.RUnlock()
+	if p.begin.IsZero() {
+		return 0
+	}
+	if p.end.IsZero() {
+		return time.Since(p.begin)
+	}
+	return p.end.Sub(p.begin)
+}
+
+func (p *progress) status() *pb.DumpStatus {
+	s := &pb.DumpStatus{
+		TotalTables:    p.totalTables.Get(),
+		FinishedTables: p.finishedTables.Get(),
+		EstimatedRows:  p.estimatedRows.Get(),
+		DumpedRows:     p.dumpedRows.Get(),
+		WrittenBytes:   p.writtenBytes.Get(),
+		Elapsed:        p.elapsed().Round(time.Second).String(),
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+	for name := range p.currentFiles {
+		s.CurrentFiles = append(s.CurrentFiles, name)
+	}
+	sort.Strings(s.CurrentFiles)
+	return s
+}
+
+// Status implements Unit.Status
+func (m *Mydumper) Status() interface{} {
+	return m.progress.status()
+}
+
+// Error implements Unit.Error
+func (m *Mydumper) Error() interface{} {
+	return &pb.DumpError{}
+}
+
+// PrintStatus prints progress of dumping and updates metrics periodically
+func (m *Mydumper) PrintStatus(ctx context.Context) {
+	ticker := time.NewTicker(printStatusInterval)
+	defer ticker.Stop()
+
+	var done bool
+	for {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-ticker.C:
+		}
+
+		s := m.progress.status()
+		log.Infof("[mydumper] finished_tables = %d, total_tables = %d, dumped_rows = %d, estimated_rows = %d, written_bytes = %d, elapsed = %s",
+			s.FinishedTables, s.TotalTables, s.DumpedRows, s.EstimatedRows, s.WrittenBytes, s.Elapsed)
+		totalTablesGauge.WithLabelValues(m.cfg.Name).Set(float64(s.TotalTables))
+		finishedTablesGauge.WithLabelValues(m.cfg.Name).Set(float64(s.FinishedTables))
+		estimatedRowsGauge.WithLabelValues(m.cfg.Name).Set(float64(s.EstimatedRows))
+		dumpedRowsGauge.WithLabelValues(m.cfg.Name).Set(float64(s.DumpedRows))
+		writtenBytesGauge.WithLabelValues(m.cfg.Name).Set(float64(s.WrittenBytes))
+		if done {
+			return
+		}
+	}
+}
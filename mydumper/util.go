@@ -13,6 +13,11 @@
 
 package mydumper
 
+import (
+	"bytes"
+	"strings"
+)
+
 // ParseArgLikeBash parses list arguments like bash, which helps us to run
 // executable command via os/exec more likely running from bash
 func ParseArgLikeBash(args []string) []string {
@@ -37,3 +42,54 @@ func trimOutQuotes(arg string) string {
 	}
 	return arg
 }
+
+// quoteName quotes name of database, table or column with backquotes
+func quoteName(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// isNumericType checks whether values of the column type can be written without quotes,
+// typeName is the type name returned by `sql.ColumnType.DatabaseTypeName`
+func isNumericType(typeName string) bool {
+	switch strings.TrimPrefix(strings.ToUpper(typeName), "UNSIGNED ") {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "DECIMAL", "FLOAT", "DOUBLE", "YEAR":
+		return true
+	}
+	return false
+}
+
+// writeValue writes a value got from text protocol as SQL literal, nil value is written as NULL,
+// non-numeric value is quoted and escaped like `mysql_real_escape_string`
+func writeValue(buf *bytes.Buffer, value []byte, numeric bool) {
+	if value == nil {
+		buf.WriteString("NULL")
+		return
+	}
+	if numeric {
+		buf.Write(value)
+		return
+	}
+
+	buf.WriteByte('"')
+	for _, b := range value {
+		switch b {
+		case 0:
+			buf.WriteString(`\0`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\'':
+			buf.WriteString(`\'`)
+		case '"':
+			buf.WriteString(`\"`)
+		case '\032':
+			buf.WriteString(`\Z`)
+		default:
+			buf.WriteByte(b)
+		}
+	}
+	buf.WriteByte('"')
+}
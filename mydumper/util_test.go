@@ -14,6 +14,8 @@
 package mydumper
 
 import (
+	"bytes"
+
 	. "github.com/pingcap/check"
 )
 
@@ -37,3 +39,32 @@ func (m *testMydumperSuite) TestParseArgs(c *C) {
 		c.Assert(parsed, DeepEquals, t.expected)
 	}
 }
+
+func (m *testMydumperSuite) TestWriteValue(c *C) {
+	var tests = []struct {
+		value    []byte
+		numeric  bool
+		expected string
+	}{
+		{nil, true, "NULL"},
+		{nil, false, "NULL"},
+		{[]byte("-123.45"), true, "-123.45"},
+		{[]byte(""), false, `""`},
+		{[]byte("a'b\"c\\d"), false, `"a\'b\"c\\d"`},
+		{[]byte("line1\nline2\r\x00\x1a"), false, `"line1\nline2\r\0\Z"`},
+	}
+
+	var buf bytes.Buffer
+	for _, t := range tests {
+		buf.Reset()
+		writeValue(&buf, t.value, t.numeric)
+		c.Assert(buf.String(), Equals, t.expected)
+	}
+
+	c.Assert(isNumericType("INT"), IsTrue)
+	c.Assert(isNumericType("unsigned bigint"), IsTrue)
+	c.Assert(isNumericType("DECIMAL"), IsTrue)
+	c.Assert(isNumericType("VARCHAR"), IsFalse)
+	c.Assert(isNumericType("BIT"), IsFalse)
+	c.Assert(quoteName("a`b"), Equals, "`a``b`")
+}
@@ -0,0 +1,167 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydumper
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+)
+
+// chunkWriter writes rows of a table as INSERT statements into data files,
+// a row is written in one line, like
+//
+//	INSERT INTO `t1` VALUES
+//	(1,"hello"),
+//	(2,"world");
+//
+// if chunkSize > 0, a new file `{db}.{table}.{part}.sql` is created when the file size exceeds chunkSize,
+// otherwise all rows are written into `{db}.{table}.sql`. no file is created if no rows written.
+type chunkWriter struct {
+	dir        string
+	table      *filter.Table
+	chunkSize  int64
+	header     string
+	insertHead string
+	progress   *progress
+
+	part     int   // count of files created
+	fileSize int64 // size of the current file
+	stmtSize int   // size of the current statement, 0 if no statement started
+	file     *os.File
+	w        *bufio.Writer
+}
+
+func newChunkWriter(dir string, table *filter.Table, chunkSize int64, header, insertHead string, p *progress) *chunkWriter {
+	return &chunkWriter{
+		dir:        dir,
+		table:      table,
+		chunkSize:  chunkSize,
+		header:     header,
+		insertHead: insertHead,
+		progress:   p,
+	}
+}
+
+// fileName returns name of the data file for part
+func (c *chunkWriter) fileName(part int) string {
+	if c.chunkSize > 0 {
+		return fmt.Sprintf("%s.%s.%05d.sql", c.table.Schema, c.table.Name, part)
+	}
+	return fmt.Sprintf("%s.%s.sql", c.table.Schema, c.table.Name)
+}
+
+// files returns names of files written
+func (c *chunkWriter) files() []string {
+	files := make([]string, 0, c.part)
+	for i := 1; i <= c.part; i++ {
+		files = append(files, c.fileName(i))
+	}
+	return files
+}
+
+// writeRow writes a row like `(1,"hello")`
+func (c *chunkWriter) writeRow(row []byte) error {
+	if c.file == nil {
+		if err := c.openFile(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if c.stmtSize == 0 {
+		if err := c.write([]byte(c.insertHead)); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err := c.write([]byte(",\n")); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.write(row); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.stmtSize >= statementSize {
+		if err := c.endStatement(); err != nil {
+			return errors.Trace(err)
+		}
+		if c.chunkSize > 0 && c.fileSize >= c.chunkSize {
+			return errors.Trace(c.closeFile())
+		}
+	}
+	return nil
+}
+
+// close ends the statement and closes the file, it can be called multiple times
+func (c *chunkWriter) close() error {
+	if c.file == nil {
+		return nil
+	}
+	return errors.Trace(c.closeFile())
+}
+
+func (c *chunkWriter) openFile() error {
+	c.part++
+	path := filepath.Join(c.dir, c.fileName(c.part))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Annotatef(err, "create data file %s", path)
+	}
+	c.file = file
+	c.w = bufio.NewWriter(file)
+	c.fileSize = 0
+	c.stmtSize = 0
+	c.progress.addFile(c.fileName(c.part))
+	_, err = c.w.WriteString(c.header)
+	c.addSize(len(c.header))
+	return errors.Trace(err)
+}
+
+func (c *chunkWriter) closeFile() error {
+	err := c.endStatement()
+	if err == nil {
+		err = c.w.Flush()
+	}
+	err2 := c.file.Close()
+	c.file, c.w = nil, nil
+	c.progress.removeFile(c.fileName(c.part))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(err2)
+}
+
+func (c *chunkWriter) endStatement() error {
+	if c.stmtSize == 0 {
+		return nil
+	}
+	_, err := c.w.WriteString(";\n")
+	c.addSize(2)
+	c.stmtSize = 0
+	return errors.Trace(err)
+}
+
+func (c *chunkWriter) write(data []byte) error {
+	n, err := c.w.Write(data)
+	c.addSize(n)
+	c.stmtSize += n
+	return errors.Trace(err)
+}
+
+func (c *chunkWriter) addSize(n int) {
+	c.fileSize += int64(n)
+	c.progress.writtenBytes.Add(int64(n))
+}
@@ -29,6 +29,8 @@ type Set interface {
 	// => [xx:1-2, yy:1-3, xy:1-3]
 	// more examples ref test cases
 	Replace(other Set, masters []interface{}) error
+	// add a single GTID into the set, like `uuid:gno` for MySQL or `domain-server-seq` for MariaDB
+	Update(gtidStr string) error
 	Clone() Set
 	Origin() mysql.GTIDSet
 	Equal(other Set) bool
@@ -126,6 +128,10 @@ func (g *mySQLGTIDSet) get(uuid string) (*mysql.UUIDSet, bool) {
 	return uuidSet, ok
 }
 
+func (g *mySQLGTIDSet) Update(gtidStr string) error {
+	return errors.Trace(g.set.Update(gtidStr))
+}
+
 func (g *mySQLGTIDSet) Clone() Set {
 	return &mySQLGTIDSet{
 		set: g.set.Clone().(*mysql.MysqlGTIDSet),
@@ -241,6 +247,10 @@ func (m *mariadbGTIDSet) get(domainID uint32) (*mysql.MariadbGTID, bool) {
 	return gtid, ok
 }
 
+func (m *mariadbGTIDSet) Update(gtidStr string) error {
+	return errors.Trace(m.set.Update(gtidStr))
+}
+
 func (m *mariadbGTIDSet) Clone() Set {
 	return &mariadbGTIDSet{
 		set: m.set.Clone().(*mysql.MariadbGTIDSet),
@@ -178,3 +178,32 @@ func (s *testGTIDSuite) TestMairaGTIDContain(c *C) {
 	c.Assert(g1.Contain(g2), IsFalse)
 	c.Assert(g2.Contain(g1), IsFalse)
 }
+
+func (s *testGTIDSuite) TestGTIDUpdate(c *C) {
+	cases := []struct {
+		flavor   string
+		gtidStr  string
+		gtids    []string
+		expected string
+	}{
+		{"mysql", "", []string{"53ea0ed1-9bf8-11e6-8bea-64006a897c73:1"}, "53ea0ed1-9bf8-11e6-8bea-64006a897c73:1"},
+		{"mysql", "53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-5", []string{"53ea0ed1-9bf8-11e6-8bea-64006a897c73:6", "53ea0ed1-9bf8-11e6-8bea-64006a897c72:1"}, "53ea0ed1-9bf8-11e6-8bea-64006a897c72:1,53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-6"},
+		{"mariadb", "", []string{"1-1-1"}, "1-1-1"},
+		{"mariadb", "1-1-1,2-2-2", []string{"1-1-2", "3-3-3"}, "1-1-2,2-2-2,3-3-3"},
+	}
+
+	for _, cs := range cases {
+		gs, err := ParserGTID(cs.flavor, cs.gtidStr)
+		c.Assert(err, IsNil)
+		for _, gtidStr := range cs.gtids {
+			c.Assert(gs.Update(gtidStr), IsNil)
+		}
+		expected, err := ParserGTID(cs.flavor, cs.expected)
+		c.Assert(err, IsNil)
+		c.Assert(gs.Equal(expected), IsTrue)
+	}
+
+	gs, err := ParserGTID("mysql", "")
+	c.Assert(err, IsNil)
+	c.Assert(gs.Update("invalid"), NotNil)
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+)
+
+var (
+	alterDatabasePattern       = regexp.MustCompile("(?is)^\\s*ALTER\\s+(?:DATABASE|SCHEMA)((?:\\s|`).*)?$")
+	alterDatabaseNamePattern   = regexp.MustCompile("^\\s*(`(?:[^`]|``)+`|\\w+)")
+	alterDatabaseOptionPattern = regexp.MustCompile("(?i)^\\s*(?:DEFAULT\\s+)?(CHARACTER\\s+SET|CHARSET|COLLATE)\\s*(?:=\\s*)?('[^']*'|\"[^\"]*\"|\\w+)")
+)
+
+// AlterDatabaseStmt is a statement to change the default character set or collation of a database.
+// See https://dev.mysql.com/doc/refman/5.7/en/alter-database.html
+// ALTER DATABASE is not supported by the parser now, so it's parsed by ParseAlterDatabase,
+// and reuses CreateDatabaseStmt to be a DDL node
+type AlterDatabaseStmt struct {
+	ast.CreateDatabaseStmt
+}
+
+// Restore implements Node interface.
+func (n *AlterDatabaseStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER DATABASE ")
+	ctx.WriteName(n.Name)
+	for _, option := range n.Options {
+		ctx.WritePlain(" ")
+		err := option.Restore(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterDatabaseStmt) Accept(v ast.Visitor) (ast.Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterDatabaseStmt)
+	return v.Leave(n)
+}
+
+// ParseAlterDatabase parses ALTER DATABASE statement changing character set or collation,
+// returns false if the sql is not an ALTER DATABASE statement
+func ParseAlterDatabase(sql string) (*AlterDatabaseStmt, bool, error) {
+	sql = strings.TrimRight(strings.TrimSpace(sql), ";")
+	matches := alterDatabasePattern.FindStringSubmatch(sql)
+	if matches == nil {
+		return nil, false, nil
+	}
+
+	stmt := &AlterDatabaseStmt{}
+	stmt.SetText(sql)
+	rest := matches[1]
+	if m := alterDatabaseNamePattern.FindStringSubmatch(rest); m != nil {
+		switch strings.ToUpper(m[1]) {
+		case "DEFAULT", "CHARACTER", "CHARSET", "COLLATE":
+			// database name omitted, use the current database
+		default:
+			stmt.Name = m[1]
+			if strings.HasPrefix(stmt.Name, "`") {
+				stmt.Name = strings.Replace(stmt.Name[1:len(stmt.Name)-1], "``", "`", -1)
+			}
+			rest = rest[len(m[0]):]
+		}
+	}
+
+	for len(strings.TrimSpace(rest)) > 0 {
+		m := alterDatabaseOptionPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, true, errors.NotSupportedf("database option %s in %s", strings.TrimSpace(rest), sql)
+		}
+		option := &ast.DatabaseOption{
+			Tp:    ast.DatabaseOptionCharset,
+			Value: strings.ToLower(strings.Trim(m[2], "'\"")),
+		}
+		if strings.EqualFold(m[1], "COLLATE") {
+			option.Tp = ast.DatabaseOptionCollate
+		}
+		stmt.Options = append(stmt.Options, option)
+		rest = rest[len(m[0]):]
+	}
+	if len(stmt.Options) == 0 {
+		return nil, true, errors.NotValidf("ALTER DATABASE without any database option %s", sql)
+	}
+	return stmt, true, nil
+}
@@ -47,6 +47,8 @@ var sqls = []string{
 	"alter table `t1` add column c1 int, drop column c2",
 	"alter table `s1`.`t1` add column c1 int, rename to `t2`, drop column c2",
 	"alter table `s1`.`t1` add column c1 int, rename to `xx`.`t2`, drop column c2",
+	"alter database `s1` character set = utf8mb4 collate utf8mb4_bin",
+	"alter schema default charset 'utf8';",
 }
 
 func TestSuite(t *testing.T) {
@@ -70,6 +72,8 @@ func (t *testParserSuite) TestParser(c *C) {
 		"alter table bar ADD SPATIAL INDEX (`g`)",
 		"alter table bar ORDER BY id1, id2",
 		"alter table bar add index (`name`), add FOREIGN KEY (product_category, product_id) REFERENCES product(category, id) ON UPDATE CASCADE ON DELETE RESTRICT",
+		"alter database `s1` read only = 1",
+		"alter database `s1`",
 	}
 
 	for _, sql := range unsupportedSQLs {
@@ -104,6 +108,8 @@ func (t *testParserSuite) TestResolveDDL(c *C) {
 		{"ALTER TABLE `test`.`t1` ADD COLUMN `c1` INT", "ALTER TABLE `test`.`t1` DROP COLUMN `c2`"},
 		{"ALTER TABLE `s1`.`t1` ADD COLUMN `c1` INT", "ALTER TABLE `s1`.`t1` RENAME AS `test`.`t2`", "ALTER TABLE `test`.`t2` DROP COLUMN `c2`"},
 		{"ALTER TABLE `s1`.`t1` ADD COLUMN `c1` INT", "ALTER TABLE `s1`.`t1` RENAME AS `xx`.`t2`", "ALTER TABLE `xx`.`t2` DROP COLUMN `c2`"},
+		{"ALTER DATABASE `s1` CHARACTER SET = utf8mb4 COLLATE = utf8mb4_bin"},
+		{"ALTER DATABASE `test` CHARACTER SET = utf8"},
 	}
 
 	expectedTableName := [][][]*filter.Table{
@@ -130,6 +136,8 @@ func (t *testParserSuite) TestResolveDDL(c *C) {
 		{{genTableName("test", "t1")}, {genTableName("test", "t1")}},
 		{{genTableName("s1", "t1")}, {genTableName("s1", "t1"), genTableName("test", "t2")}, {genTableName("test", "t2")}},
 		{{genTableName("s1", "t1")}, {genTableName("s1", "t1"), genTableName("xx", "t2")}, {genTableName("xx", "t2")}},
+		{{genTableName("s1", "")}},
+		{{genTableName("test", "")}},
 	}
 
 	targetTableNames := [][][]*filter.Table{
@@ -156,6 +164,8 @@ func (t *testParserSuite) TestResolveDDL(c *C) {
 		{{genTableName("xtest", "xt1")}, {genTableName("xtest", "xt1")}},
 		{{genTableName("xs1", "xt1")}, {genTableName("xs1", "xt1"), genTableName("xtest", "xt2")}, {genTableName("xtest", "xt2")}},
 		{{genTableName("xs1", "xt1")}, {genTableName("xs1", "xt1"), genTableName("xxx", "xt2")}, {genTableName("xxx", "xt2")}},
+		{{genTableName("xs1", "")}},
+		{{genTableName("xtest", "")}},
 	}
 
 	targetSQLs := [][]string{
@@ -182,6 +192,8 @@ func (t *testParserSuite) TestResolveDDL(c *C) {
 		{"ALTER TABLE `xtest`.`xt1` ADD COLUMN `c1` INT", "ALTER TABLE `xtest`.`xt1` DROP COLUMN `c2`"},
 		{"ALTER TABLE `xs1`.`xt1` ADD COLUMN `c1` INT", "ALTER TABLE `xs1`.`xt1` RENAME AS `xtest`.`xt2`", "ALTER TABLE `xtest`.`xt2` DROP COLUMN `c2`"},
 		{"ALTER TABLE `xs1`.`xt1` ADD COLUMN `c1` INT", "ALTER TABLE `xs1`.`xt1` RENAME AS `xxx`.`xt2`", "ALTER TABLE `xxx`.`xt2` DROP COLUMN `c2`"},
+		{"ALTER DATABASE `xs1` CHARACTER SET = utf8mb4 COLLATE = utf8mb4_bin"},
+		{"ALTER DATABASE `xtest` CHARACTER SET = utf8"},
 	}
 
 	for i, sql := range sqls {
@@ -210,3 +222,20 @@ func (t *testParserSuite) TestResolveDDL(c *C) {
 	}
 
 }
+
+func (t *testParserSuite) TestParseAlterDatabase(c *C) {
+	stmt, ok, err := ParseAlterDatabase("ALTER SCHEMA `a``b` DEFAULT CHARACTER SET utf8mb4 DEFAULT COLLATE = \"utf8mb4_general_ci\"")
+	c.Assert(ok, IsTrue)
+	c.Assert(err, IsNil)
+	c.Assert(stmt.Name, Equals, "a`b")
+	c.Assert(stmt.Options, HasLen, 2)
+	c.Assert(stmt.Options[1].Value, Equals, "utf8mb4_general_ci")
+
+	// not an ALTER DATABASE statement
+	_, ok, err = ParseAlterDatabase("ALTER DATABASES")
+	c.Assert(ok, IsFalse)
+	c.Assert(err, IsNil)
+	_, ok, err = ParseAlterDatabase("ALTER TABLE `db`.`tb` DEFAULT CHARSET utf8")
+	c.Assert(ok, IsFalse)
+	c.Assert(err, IsNil)
+}
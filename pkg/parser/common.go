@@ -36,7 +36,17 @@ var (
 )
 
 // Parse wraps parser.Parse(), makes `parser` suitable for dm
+// ALTER DATABASE statement which is not supported by the parser is parsed by ParseAlterDatabase
 func Parse(p *parser.Parser, sql, charset, collation string) (stmt []ast.StmtNode, err error) {
+	alter, ok, err := ParseAlterDatabase(sql)
+	if ok {
+		if err != nil {
+			log.Errorf("parsing sql %s:%v", sql, err)
+			return nil, errors.Trace(err)
+		}
+		return []ast.StmtNode{alter}, nil
+	}
+
 	stmts, warnings, err := p.Parse(sql, charset, collation)
 
 	for _, warning := range warnings {
@@ -50,6 +60,16 @@ func Parse(p *parser.Parser, sql, charset, collation string) (stmt []ast.StmtNod
 	return stmts, errors.Trace(err)
 }
 
+// ParseOneStmt wraps parser.ParseOneStmt(), and also parses ALTER DATABASE statement like Parse
+func ParseOneStmt(p *parser.Parser, sql, charset, collation string) (ast.StmtNode, error) {
+	alter, ok, err := ParseAlterDatabase(sql)
+	if ok {
+		return alter, errors.Trace(err)
+	}
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	return stmt, errors.Trace(err)
+}
+
 // FetchDDLTableNames returns table names in ddl
 // the result contains [tableName] excepted create table like and rename table
 // for `create table like` DDL, result contains [sourceTableName, sourceRefTableName]
@@ -61,6 +81,8 @@ func FetchDDLTableNames(schema string, stmt ast.StmtNode) ([]*filter.Table, erro
 		res = append(res, genTableName(v.Name, ""))
 	case *ast.DropDatabaseStmt:
 		res = append(res, genTableName(v.Name, ""))
+	case *AlterDatabaseStmt:
+		res = append(res, genTableName(v.Name, ""))
 	case *ast.CreateTableStmt:
 		res = append(res, genTableName(v.Table.Schema.O, v.Table.Name.O))
 		if v.ReferTable != nil {
@@ -108,6 +130,9 @@ func RenameDDLTable(stmt ast.StmtNode, targetTableNames []*filter.Table) (string
 	case *ast.DropDatabaseStmt:
 		v.Name = targetTableNames[0].Schema
 
+	case *AlterDatabaseStmt:
+		v.Name = targetTableNames[0].Schema
+
 	case *ast.CreateTableStmt:
 		v.Table.Schema = model.NewCIStr(targetTableNames[0].Schema)
 		v.Table.Name = model.NewCIStr(targetTableNames[0].Name)
@@ -192,6 +217,10 @@ func SplitDDL(stmt ast.StmtNode, schema string) (sqls []string, err error) {
 		v.IfNotExists = true
 	case *ast.DropDatabaseStmt:
 		v.IfExists = true
+	case *AlterDatabaseStmt:
+		if v.Name == "" {
+			v.Name = schema
+		}
 	case *ast.DropTableStmt:
 		v.IfExists = true
 
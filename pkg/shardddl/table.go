@@ -0,0 +1,414 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardddl
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// Table is the structure of an upstream table tracked by syncer,
+// saved in checkpoint and sent to dm-master to compute the joined schema in optimistic shard mode
+type Table struct {
+	Columns []*Column           `json:"columns"`
+	Indexes map[string][]string `json:"indexes"` // unique key name -> column names
+}
+
+// Column is a column of Table
+type Column struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"` // like `int(11) unsigned` got from `SHOW COLUMNS`
+	NotNull  bool    `json:"not-null"`
+	Unsigned bool    `json:"unsigned"`
+	Extra    string  `json:"extra"`
+	Default  *string `json:"default,omitempty"` // like `Default` got from `SHOW COLUMNS`, nil if no default value or NULL
+}
+
+// equal returns whether the two columns have the same definition
+func (c *Column) equal(other *Column) bool {
+	return strings.EqualFold(c.Type, other.Type) && c.NotNull == other.NotNull && c.Unsigned == other.Unsigned &&
+		equalDefault(c.Default, other.Default)
+}
+
+// String returns the definition of the column, like `int(11) NOT NULL DEFAULT '0'`
+func (c *Column) String() string {
+	def := c.Type
+	if c.NotNull {
+		def += " NOT NULL"
+	}
+	if c.Default != nil {
+		def += fmt.Sprintf(" DEFAULT '%s'", *c.Default)
+	}
+	return def
+}
+
+// definition returns the definition of the column in DDLs, like `int(11) NOT NULL DEFAULT '0'`,
+// default values like CURRENT_TIMESTAMP are not quoted, and quotes in other default values are escaped
+func (c *Column) definition() string {
+	def := c.Type
+	if c.NotNull {
+		def += " NOT NULL"
+	}
+	if c.Default != nil {
+		if currentTimestampPattern.MatchString(*c.Default) {
+			def += " DEFAULT " + *c.Default
+		} else {
+			def += fmt.Sprintf(" DEFAULT '%s'", strings.Replace(*c.Default, "'", "''", -1))
+		}
+	}
+	// like `auto_increment` and `on update CURRENT_TIMESTAMP`, generated columns are not supported
+	if extra := strings.ToLower(c.Extra); extra == "auto_increment" || strings.HasPrefix(extra, "on update ") {
+		def += " " + strings.ToUpper(c.Extra)
+	}
+	return def
+}
+
+var currentTimestampPattern = regexp.MustCompile(`(?i)^current_timestamp(\(\d*\))?$`)
+
+// equalDefault returns whether the two default values are the same
+func equalDefault(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// defaultString returns the default value in messages
+func defaultString(v *string) string {
+	if v == nil {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%s'", *v)
+}
+
+// ParseTable parses Table in JSON
+func ParseTable(data string) (*Table, error) {
+	t := &Table{}
+	err := json.Unmarshal([]byte(data), t)
+	return t, errors.Annotatef(err, "table structure %s", data)
+}
+
+// String returns Table in JSON
+func (t *Table) String() string {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Sprintf("invalid table structure %v", err)
+	}
+	return string(data)
+}
+
+// column returns the column with the name (case insensitive), nil if not exists
+func (t *Table) column(name string) *Column {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Equal returns whether the two tables have the same columns and unique keys,
+// columns are compared regardless of their order
+func (t *Table) Equal(other *Table) bool {
+	if len(t.Columns) != len(other.Columns) || len(t.Indexes) != len(other.Indexes) {
+		return false
+	}
+	for _, c := range t.Columns {
+		if c2 := other.column(c.Name); c2 == nil || !c.equal(c2) {
+			return false
+		}
+	}
+	for name, cols := range t.Indexes {
+		cols2, ok := other.Indexes[name]
+		if !ok || !equalColumnNames(cols, cols2) {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffDDLs returns DDLs changing the joined schema of the target table from before to after in downstream,
+// one change per DDL, like adding a column in after but not in before, or modifying a column widened in after.
+// DDLs of shard tables can't be executed as is, they may change the joined schema partly,
+// like adding two columns in a DDL while one of them is already added by other shard tables
+func DiffDDLs(schema, table string, before, after *Table) []string {
+	var (
+		prefix = fmt.Sprintf("ALTER TABLE %s ", quoteName(schema, table))
+		ddls   []string
+	)
+	// unique keys are dropped before changing columns, and added after changing columns
+	for _, name := range sortedIndexNames(before) {
+		if cols, ok := after.Indexes[name]; !ok || !equalColumnNames(cols, before.Indexes[name]) {
+			ddls = append(ddls, prefix+dropIndex(name))
+		}
+	}
+	for _, c := range after.Columns {
+		b := before.column(c.Name)
+		switch {
+		case b == nil:
+			ddls = append(ddls, fmt.Sprintf("%sADD COLUMN %s %s", prefix, quoteName(c.Name), c.definition()))
+		case !b.equal(c):
+			ddls = append(ddls, fmt.Sprintf("%sMODIFY COLUMN %s %s", prefix, quoteName(c.Name), c.definition()))
+		}
+	}
+	for _, c := range before.Columns {
+		if after.column(c.Name) == nil {
+			ddls = append(ddls, fmt.Sprintf("%sDROP COLUMN %s", prefix, quoteName(c.Name)))
+		}
+	}
+	for _, name := range sortedIndexNames(after) {
+		if cols, ok := before.Indexes[name]; !ok || !equalColumnNames(cols, after.Indexes[name]) {
+			ddls = append(ddls, prefix+addIndex(name, after.Indexes[name]))
+		}
+	}
+	return ddls
+}
+
+func sortedIndexNames(t *Table) []string {
+	names := make([]string, 0, len(t.Indexes))
+	for name := range t.Indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func dropIndex(name string) string {
+	if strings.EqualFold(name, "PRIMARY") {
+		return "DROP PRIMARY KEY"
+	}
+	return "DROP INDEX " + quoteName(name)
+}
+
+func addIndex(name string, cols []string) string {
+	quoted := make([]string, 0, len(cols))
+	for _, col := range cols {
+		quoted = append(quoted, quoteName(col))
+	}
+	if strings.EqualFold(name, "PRIMARY") {
+		return fmt.Sprintf("ADD PRIMARY KEY (%s)", strings.Join(quoted, ","))
+	}
+	return fmt.Sprintf("ADD UNIQUE KEY %s (%s)", quoteName(name), strings.Join(quoted, ","))
+}
+
+// quoteName quotes names with backquotes, and joins them with dots, like `db`.`tbl`
+func quoteName(names ...string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "`"+strings.Replace(name, "`", "``", -1)+"`")
+	}
+	return strings.Join(quoted, ".")
+}
+
+// Join computes the joined schema of shard tables, which all DMLs of the shard tables can be written into.
+// columns of all tables are included in the order they appear, a column is NOT NULL only if it's NOT NULL in all tables,
+// and its type is the widest one of all tables, like `bigint` for `int` and `bigint`, `varchar(20)` for `varchar(10)` and `varchar(20)`.
+// unique keys of all tables are included too.
+// returns an error if shard tables are conflicting, like a column with incompatible types or different default values,
+// or unique keys with the same name on different columns
+func Join(tables ...*Table) (*Table, error) {
+	joined := &Table{Indexes: make(map[string][]string)}
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			jc := joined.column(c.Name)
+			if jc == nil {
+				clone := *c
+				joined.Columns = append(joined.Columns, &clone)
+				continue
+			}
+			if jc.Unsigned != c.Unsigned {
+				return nil, errors.Errorf("column %s is unsigned in some shard tables but signed in others", c.Name)
+			}
+			if !equalDefault(jc.Default, c.Default) {
+				return nil, errors.Errorf("column %s has default value %s in some shard tables but %s in others", c.Name, defaultString(jc.Default), defaultString(c.Default))
+			}
+			tp, err := joinType(jc.Type, c.Type)
+			if err != nil {
+				return nil, errors.Annotatef(err, "column %s", c.Name)
+			}
+			jc.Type = tp
+			jc.NotNull = jc.NotNull && c.NotNull
+			if len(jc.Extra) == 0 {
+				jc.Extra = c.Extra
+			}
+		}
+		for name, cols := range t.Indexes {
+			jcols, ok := joined.Indexes[name]
+			if !ok {
+				joined.Indexes[name] = cols
+			} else if !equalColumnNames(jcols, cols) {
+				return nil, errors.Errorf("unique key %s is on columns %v in some shard tables but on %v in others", name, jcols, cols)
+			}
+		}
+	}
+	return joined, nil
+}
+
+func equalColumnNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// typeRanks are families of types which can be widened, from narrow to wide
+var typeRanks = [][]string{
+	{"tinyint", "smallint", "mediumint", "int", "bigint"},
+	{"float", "double"},
+	{"tinytext", "text", "mediumtext", "longtext"},
+	{"tinyblob", "blob", "mediumblob", "longblob"},
+	{"char", "varchar"},
+	{"binary", "varbinary"},
+}
+
+var typePattern = regexp.MustCompile(`^\s*(\w+)\s*(?:\(([^)]*)\))?(.*)$`)
+
+// columnType is a parsed column type, like `decimal(10,2) unsigned`
+type columnType struct {
+	name   string
+	args   []int // like length and decimals, nil if not specified
+	opaque bool  // arguments are not numbers, like values of enum
+	raw    string
+	rest   string // like ` unsigned zerofill`
+}
+
+func parseType(tp string) columnType {
+	ct := columnType{raw: strings.ToLower(strings.TrimSpace(tp))}
+	m := typePattern.FindStringSubmatch(ct.raw)
+	if m == nil {
+		ct.name = ct.raw
+		return ct
+	}
+	ct.name, ct.rest = m[1], m[3]
+	if len(m[2]) > 0 {
+		for _, arg := range strings.Split(m[2], ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(arg))
+			if err != nil {
+				ct.args, ct.opaque = nil, true
+				break
+			}
+			ct.args = append(ct.args, n)
+		}
+	}
+	return ct
+}
+
+func (ct columnType) String() string {
+	if len(ct.args) == 0 {
+		return ct.name + ct.rest
+	}
+	args := make([]string, 0, len(ct.args))
+	for _, n := range ct.args {
+		args = append(args, strconv.Itoa(n))
+	}
+	return fmt.Sprintf("%s(%s)%s", ct.name, strings.Join(args, ","), ct.rest)
+}
+
+// rank returns the family and the rank of the type in the family, -1 if not in any family
+func (ct columnType) rank() (int, int) {
+	for i, family := range typeRanks {
+		for j, name := range family {
+			if name == ct.name {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+// joinType returns the wider type of the two column types
+func joinType(a, b string) (string, error) {
+	ta, tb := parseType(a), parseType(b)
+	if ta.raw == tb.raw {
+		return a, nil
+	}
+	if ta.opaque || tb.opaque || ta.rest != tb.rest {
+		return "", errors.Errorf("type %s is incompatible with %s", a, b)
+	}
+
+	joined := ta
+	if ta.name != tb.name {
+		fa, ra := ta.rank()
+		fb, rb := tb.rank()
+		if fa < 0 || fa != fb {
+			return "", errors.Errorf("type %s is incompatible with %s", a, b)
+		}
+		if rb > ra {
+			joined.name = tb.name
+		}
+	}
+
+	switch {
+	case ta.name == "decimal" && tb.name == "decimal":
+		// keep both digits of the integer part and the fractional part
+		pa, sa := decimalArgs(ta.args)
+		pb, sb := decimalArgs(tb.args)
+		s := maxInt(sa, sb)
+		joined.args = []int{maxInt(pa-sa, pb-sb) + s, s}
+	case len(ta.args) <= 1 && len(tb.args) <= 1:
+		// length, or fractional seconds precision
+		switch {
+		case len(ta.args) == 0:
+			joined.args = tb.args
+		case len(tb.args) == 1:
+			joined.args = []int{maxInt(ta.args[0], tb.args[0])}
+		}
+	case ta.name == tb.name && equalInts(ta.args, tb.args):
+	default:
+		return "", errors.Errorf("type %s is incompatible with %s", a, b)
+	}
+	return joined.String(), nil
+}
+
+// decimalArgs returns precision and scale of decimal, default (10,0)
+func decimalArgs(args []int) (int, int) {
+	switch len(args) {
+	case 0:
+		return 10, 0
+	case 1:
+		return args[0], 0
+	default:
+		return args[0], args[1]
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardddl
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testTableSuite{})
+
+func TestSuite(t *testing.T) {
+	TestingT(t)
+}
+
+type testTableSuite struct {
+}
+
+func (t *testTableSuite) TestJoinType(c *C) {
+	cases := []struct {
+		a, b     string
+		expected string // empty if incompatible
+	}{
+		{"int(11)", "int(11)", "int(11)"},
+		{"int(11)", "bigint(20)", "bigint(20)"},
+		{"bigint(20) unsigned", "tinyint(4) unsigned", "bigint(20) unsigned"},
+		{"int(11)", "int(11) unsigned", ""},
+		{"varchar(10)", "varchar(20)", "varchar(20)"},
+		{"char(10)", "varchar(5)", "varchar(10)"},
+		{"varchar(10)", "text", ""},
+		{"text", "longtext", "longtext"},
+		{"decimal(10,2)", "decimal(8,4)", "decimal(12,4)"},
+		{"decimal(10,2)", "int(11)", ""},
+		{"datetime", "datetime(3)", "datetime(3)"},
+		{"enum('a','b')", "enum('a','b','c')", ""},
+		{"float", "double", "double"},
+		{"json", "blob", ""},
+	}
+	for _, cs := range cases {
+		tp, err := joinType(cs.a, cs.b)
+		if len(cs.expected) == 0 {
+			c.Assert(err, NotNil, Commentf("%s, %s", cs.a, cs.b))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%s, %s", cs.a, cs.b))
+		c.Assert(tp, Equals, cs.expected)
+	}
+}
+
+func (t *testTableSuite) TestJoin(c *C) {
+	t1, err := ParseTable(`{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"name","type":"varchar(10)","not-null":true}],"indexes":{"PRIMARY":["id"]}}`)
+	c.Assert(err, IsNil)
+	t2, err := ParseTable(`{"columns":[{"name":"id","type":"bigint(20)","not-null":true},{"name":"name","type":"varchar(20)"},{"name":"age","type":"int(11)"}],"indexes":{"PRIMARY":["id"],"uk_age":["age"]}}`)
+	c.Assert(err, IsNil)
+
+	joined, err := Join(t1, t2)
+	c.Assert(err, IsNil)
+	c.Assert(joined.String(), Equals, `{"columns":[{"name":"id","type":"bigint(20)","not-null":true,"unsigned":false,"extra":""},{"name":"name","type":"varchar(20)","not-null":false,"unsigned":false,"extra":""},{"name":"age","type":"int(11)","not-null":false,"unsigned":false,"extra":""}],"indexes":{"PRIMARY":["id"],"uk_age":["age"]}}`)
+
+	// join is commutative regardless of the column order
+	joined2, err := Join(t2, t1)
+	c.Assert(err, IsNil)
+	c.Assert(joined2.Equal(joined), IsTrue)
+	c.Assert(joined.Equal(t1), IsFalse)
+
+	// the same unique key on different columns
+	t3, err := ParseTable(`{"columns":[{"name":"id","type":"int(11)"},{"name":"name","type":"varchar(10)"}],"indexes":{"PRIMARY":["id"],"uk_age":["name"]}}`)
+	c.Assert(err, IsNil)
+	_, err = Join(t1, t2, t3)
+	c.Assert(err, NotNil)
+
+	// incompatible column types
+	t4, err := ParseTable(`{"columns":[{"name":"id","type":"int(11)"},{"name":"name","type":"text"}],"indexes":{}}`)
+	c.Assert(err, IsNil)
+	_, err = Join(t1, t4)
+	c.Assert(err, NotNil)
+
+	// different default values
+	t5, err := ParseTable(`{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"name","type":"varchar(10)","not-null":true,"default":""}],"indexes":{"PRIMARY":["id"]}}`)
+	c.Assert(err, IsNil)
+	c.Assert(t5.Columns[1].String(), Equals, "varchar(10) NOT NULL DEFAULT ''")
+	c.Assert(t5.Equal(t1), IsFalse)
+	_, err = Join(t1, t5)
+	c.Assert(err, ErrorMatches, "column name has default value NULL in some shard tables but '' in others")
+	t6, err := ParseTable(`{"columns":[{"name":"id","type":"int(11)","not-null":true},{"name":"name","type":"varchar(20)","default":"unknown"}],"indexes":{"PRIMARY":["id"]}}`)
+	c.Assert(err, IsNil)
+	_, err = Join(t5, t6)
+	c.Assert(err, ErrorMatches, "column name has default value '' in some shard tables but 'unknown' in others")
+	joined, err = Join(t5, t5)
+	c.Assert(err, IsNil)
+	c.Assert(joined.Equal(t5), IsTrue)
+
+	_, err = ParseTable(`{"columns":`)
+	c.Assert(err, NotNil)
+}
+
+func (t *testTableSuite) TestDiffDDLs(c *C) {
+	parse := func(data string) *Table {
+		tbl, err := ParseTable(data)
+		c.Assert(err, IsNil)
+		return tbl
+	}
+	t1 := parse(`{"columns":[{"name":"id","type":"int(11)","not-null":true,"extra":"auto_increment"},{"name":"c","type":"int(11)"}],"indexes":{"PRIMARY":["id"]}}`)
+	t2 := parse(`{"columns":[{"name":"id","type":"int(11)","not-null":true,"extra":"auto_increment"}],"indexes":{"PRIMARY":["id"]}}`)
+	joinedBefore, err := Join(t1, t2)
+	c.Assert(err, IsNil)
+	c.Assert(DiffDDLs("db", "tb", joinedBefore, joinedBefore), HasLen, 0)
+
+	// t2 adds column c with a wider type than t1, and a new column d in the same DDL
+	t2Added := parse(`{"columns":[{"name":"id","type":"int(11)","not-null":true,"extra":"auto_increment"},{"name":"c","type":"bigint(20)"},` +
+		`{"name":"d","type":"varchar(10)","not-null":true,"default":"it's"}],"indexes":{"PRIMARY":["id"],"uk":["d"]}}`)
+	joinedAfter, err := Join(t1, t2Added)
+	c.Assert(err, IsNil)
+	c.Assert(DiffDDLs("db", "tb", joinedBefore, joinedAfter), DeepEquals, []string{
+		"ALTER TABLE `db`.`tb` MODIFY COLUMN `c` bigint(20)",
+		"ALTER TABLE `db`.`tb` ADD COLUMN `d` varchar(10) NOT NULL DEFAULT 'it''s'",
+		"ALTER TABLE `db`.`tb` ADD UNIQUE KEY `uk` (`d`)",
+	})
+
+	// all shard tables drop column c, widen id, and change the primary key
+	t3 := parse(`{"columns":[{"name":"id","type":"bigint(20)","not-null":true,"extra":"auto_increment"},` +
+		`{"name":"ts","type":"timestamp","not-null":true,"default":"CURRENT_TIMESTAMP","extra":"on update CURRENT_TIMESTAMP"}],"indexes":{"PRIMARY":["id","ts"]}}`)
+	c.Assert(DiffDDLs("db", "tb", joinedBefore, t3), DeepEquals, []string{
+		"ALTER TABLE `db`.`tb` DROP PRIMARY KEY",
+		"ALTER TABLE `db`.`tb` MODIFY COLUMN `id` bigint(20) NOT NULL AUTO_INCREMENT",
+		"ALTER TABLE `db`.`tb` ADD COLUMN `ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP",
+		"ALTER TABLE `db`.`tb` DROP COLUMN `c`",
+		"ALTER TABLE `db`.`tb` ADD PRIMARY KEY (`id`,`ts`)",
+	})
+}
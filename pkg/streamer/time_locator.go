@@ -0,0 +1,210 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// BinlogScanner scans events of binlog files, used to locate binlog positions by time
+type BinlogScanner interface {
+	// Files returns names of binlog files in order
+	Files() ([]string, error)
+	// Scan scans events of the binlog file from the beginning, until onEvent returns false or the end of the file
+	Scan(file string, onEvent func(e *replication.BinlogEvent) (bool, error)) error
+}
+
+// LocateBinlogTime locates the first transaction boundary at or after the timestamp in binlog files,
+// returns the position before the first transaction (or DDL) committed at or after the timestamp.
+// files are binary-searched by their first event timestamps, then the file is scanned from the beginning,
+// and the end of the last file is returned if no such transaction.
+// the GTID set executed until the position is also returned for MySQL with GTID enabled, otherwise nil
+func LocateBinlogTime(scanner BinlogScanner, flavor string, ts uint32) (mysql.Position, gtid.Set, error) {
+	files, err := scanner.Files()
+	if err != nil {
+		return mysql.Position{}, nil, errors.Trace(err)
+	}
+	if len(files) == 0 {
+		return mysql.Position{}, nil, errors.NotFoundf("binlog files")
+	}
+
+	// the first file started after the timestamp
+	var searchErr error
+	idx := sort.Search(len(files), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		var first uint32
+		searchErr = scanner.Scan(files[i], func(e *replication.BinlogEvent) (bool, error) {
+			first = e.Header.Timestamp
+			return first == 0, nil // skip the fake rotate event
+		})
+		return first > ts
+	})
+	if searchErr != nil {
+		return mysql.Position{}, nil, errors.Trace(searchErr)
+	}
+	if idx > 0 {
+		idx--
+	}
+
+	var loc *timeLocator
+	for _, file := range files[idx:] {
+		loc = &timeLocator{flavor: flavor, ts: ts, pos: mysql.Position{Name: file, Pos: 4}}
+		err = scanner.Scan(file, loc.onEvent)
+		if err != nil {
+			return mysql.Position{}, nil, errors.Annotatef(err, "scan binlog file %s", file)
+		}
+		if loc.found {
+			break
+		}
+	}
+	log.Infof("[streamer] locate binlog time %d at %v, GTID set %v, found %v", ts, loc.pos, loc.gs, loc.found)
+	return loc.pos, loc.gs, nil
+}
+
+// timeLocator finds the first transaction boundary at or after the timestamp in a binlog file
+type timeLocator struct {
+	flavor string
+	ts     uint32
+
+	pos     mysql.Position // the last transaction boundary
+	gs      gtid.Set       // GTID set executed until pos
+	inTxn   bool
+	pending string // GTID of the transaction in scanning
+	found   bool
+}
+
+func (l *timeLocator) onEvent(e *replication.BinlogEvent) (bool, error) {
+	if e.Header.Flags&0x0040 != 0 {
+		// events to fill the gap in relay log file
+		return true, nil
+	}
+
+	var start, end bool
+	switch ev := e.Event.(type) {
+	case *replication.GTIDEvent:
+		start = true
+		if ev.GNO > 0 {
+			l.pending = fmt.Sprintf("%s:%d", formatSID(ev.SID), ev.GNO)
+		}
+	case *replication.MariadbGTIDEvent:
+		start = true
+		l.pending = ev.GTID.String()
+	case *replication.QueryEvent:
+		if strings.EqualFold(string(ev.Query), "BEGIN") {
+			start = true
+		} else {
+			// DDL, or COMMIT for non-transactional tables
+			start, end = !l.inTxn, true
+		}
+	case *replication.XIDEvent:
+		end = true
+	case *replication.GenericEvent:
+		if e.Header.EventType == replication.PREVIOUS_GTIDS_EVENT && l.flavor == mysql.MySQLFlavor {
+			gs, err := mysql.DecodeMysqlGTIDSet(ev.Data)
+			if err != nil {
+				return false, errors.Annotate(err, "decode Previous_GTIDs event")
+			}
+			l.gs, err = gtid.ParserGTID(l.flavor, gs.String())
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+	}
+
+	if start && !l.inTxn && e.Header.Timestamp >= l.ts {
+		l.found = true
+		return false, nil
+	}
+	if start {
+		l.inTxn = true
+	}
+	if end {
+		l.inTxn = false
+		l.pos.Pos = e.Header.LogPos
+		if l.gs != nil && len(l.pending) > 0 {
+			if err := l.gs.Update(l.pending); err != nil {
+				return false, errors.Annotatef(err, "update GTID set %s", l.gs)
+			}
+		}
+		l.pending = ""
+	}
+	return true, nil
+}
+
+// formatSID formats server UUID in GTID event as `xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`
+func formatSID(sid []byte) string {
+	if len(sid) != 16 {
+		return fmt.Sprintf("%x", sid)
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sid[0:4], sid[4:6], sid[6:8], sid[8:10], sid[10:16])
+}
+
+// errStopScan is returned by the event handler to stop parsing binlog files
+var errStopScan = errors.New("stop scanning binlog file")
+
+// localBinlogScanner scans relay log files of the current upstream in the relay directory
+type localBinlogScanner struct {
+	dir string // relay sub directory
+}
+
+// NewLocalBinlogScanner creates a BinlogScanner for relay log files of the latest upstream in the relay directory
+func NewLocalBinlogScanner(relayDir string) (BinlogScanner, error) {
+	indexPath := filepath.Join(relayDir, utils.UUIDIndexFilename)
+	uuids, err := utils.ParseUUIDIndex(indexPath)
+	if err != nil {
+		return nil, errors.Annotatef(err, "UUID index file path %s", indexPath)
+	}
+	if len(uuids) == 0 {
+		return nil, errors.New("no valid relay sub directory exists")
+	}
+	return &localBinlogScanner{dir: filepath.Join(relayDir, uuids[len(uuids)-1])}, nil
+}
+
+// Files implements BinlogScanner.Files
+func (s *localBinlogScanner) Files() ([]string, error) {
+	files, err := CollectAllBinlogFiles(s.dir)
+	return files, errors.Trace(err)
+}
+
+// Scan implements BinlogScanner.Scan
+func (s *localBinlogScanner) Scan(file string, onEvent func(e *replication.BinlogEvent) (bool, error)) error {
+	p := replication.NewBinlogParser()
+	err := p.ParseFile(filepath.Join(s.dir, file), 4, func(e *replication.BinlogEvent) error {
+		next, err := onEvent(e)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !next {
+			return errStopScan
+		}
+		return nil
+	})
+	if errors.Cause(err) == errStopScan {
+		return nil
+	}
+	return errors.Trace(err)
+}
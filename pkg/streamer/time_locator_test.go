@@ -0,0 +1,114 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streamer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+)
+
+// memBinlogScanner scans binlog events in memory
+type memBinlogScanner struct {
+	files  []string
+	events map[string][]*replication.BinlogEvent
+}
+
+func (s *memBinlogScanner) Files() ([]string, error) {
+	return s.files, nil
+}
+
+func (s *memBinlogScanner) Scan(file string, onEvent func(e *replication.BinlogEvent) (bool, error)) error {
+	for _, e := range s.events[file] {
+		next, err := onEvent(e)
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestEvent(tp replication.EventType, ts, logPos uint32, ev replication.Event) *replication.BinlogEvent {
+	return &replication.BinlogEvent{
+		Header: &replication.EventHeader{Timestamp: ts, EventType: tp, LogPos: logPos},
+		Event:  ev,
+	}
+}
+
+func (s *testStreamerSuite) TestLocateBinlogTime(c *C) {
+	sid := []byte{0x3c, 0xcc, 0x39, 0xf2, 0x3a, 0x7b, 0x11, 0xe9, 0xa9, 0x94, 0x02, 0x42, 0xac, 0x11, 0x00, 0x02}
+	uuid := "3ccc39f2-3a7b-11e9-a994-0242ac110002"
+	prev, err := mysql.ParseMysqlGTIDSet(uuid + ":1-10")
+	c.Assert(err, IsNil)
+
+	// a transaction with GTID, committed at ts
+	txn := func(ts, pos uint32, gno int64) []*replication.BinlogEvent {
+		return []*replication.BinlogEvent{
+			newTestEvent(replication.GTID_EVENT, ts, pos+10, &replication.GTIDEvent{SID: sid, GNO: gno}),
+			newTestEvent(replication.QUERY_EVENT, ts, pos+20, &replication.QueryEvent{Query: []byte("BEGIN")}),
+			newTestEvent(replication.WRITE_ROWS_EVENTv2, ts, pos+30, &replication.RowsEvent{}),
+			newTestEvent(replication.XID_EVENT, ts, pos+40, &replication.XIDEvent{}),
+		}
+	}
+	head := func(ts uint32) []*replication.BinlogEvent {
+		return []*replication.BinlogEvent{
+			newTestEvent(replication.ROTATE_EVENT, 0, 0, &replication.RotateEvent{}), // fake rotate event
+			newTestEvent(replication.FORMAT_DESCRIPTION_EVENT, ts, 120, &replication.FormatDescriptionEvent{}),
+			newTestEvent(replication.PREVIOUS_GTIDS_EVENT, ts, 190, &replication.GenericEvent{Data: prev.Encode()}),
+		}
+	}
+
+	scanner := &memBinlogScanner{
+		files:  []string{"mysql-bin.000001", "mysql-bin.000002"},
+		events: make(map[string][]*replication.BinlogEvent),
+	}
+	events := head(100)
+	events = append(events, txn(110, 200, 11)...)
+	events = append(events, txn(120, 300, 12)...)
+	scanner.events["mysql-bin.000001"] = events
+	events = head(130)
+	events = append(events, newTestEvent(replication.GTID_EVENT, 140, 210, &replication.GTIDEvent{SID: sid, GNO: 11}))
+	events = append(events, newTestEvent(replication.QUERY_EVENT, 140, 250, &replication.QueryEvent{Query: []byte("CREATE TABLE t (id INT)")}))
+	events = append(events, txn(150, 300, 12)...)
+	scanner.events["mysql-bin.000002"] = events
+
+	cases := []struct {
+		ts   uint32
+		pos  mysql.Position
+		gtid string
+	}{
+		{50, mysql.Position{Name: "mysql-bin.000001", Pos: 4}, uuid + ":1-10"},
+		{110, mysql.Position{Name: "mysql-bin.000001", Pos: 4}, uuid + ":1-10"},
+		{115, mysql.Position{Name: "mysql-bin.000001", Pos: 240}, uuid + ":1-11"},
+		{135, mysql.Position{Name: "mysql-bin.000002", Pos: 4}, uuid + ":1-10"},
+		{145, mysql.Position{Name: "mysql-bin.000002", Pos: 250}, uuid + ":1-11"},
+		{200, mysql.Position{Name: "mysql-bin.000002", Pos: 340}, uuid + ":1-12"},
+	}
+	for _, cs := range cases {
+		pos, gs, err2 := LocateBinlogTime(scanner, mysql.MySQLFlavor, cs.ts)
+		c.Assert(err2, IsNil)
+		c.Assert(pos, DeepEquals, cs.pos, Commentf("ts %d", cs.ts))
+		c.Assert(gs, NotNil)
+		c.Assert(gs.String(), Equals, cs.gtid, Commentf("ts %d", cs.ts))
+	}
+
+	// no GTID set for MariaDB
+	pos, gs, err := LocateBinlogTime(scanner, mysql.MariaDBFlavor, 115)
+	c.Assert(err, IsNil)
+	c.Assert(pos, DeepEquals, mysql.Position{Name: "mysql-bin.000001", Pos: 240})
+	c.Assert(gs, IsNil)
+
+	_, _, err = LocateBinlogTime(&memBinlogScanner{}, mysql.MySQLFlavor, 115)
+	c.Assert(err, NotNil)
+}
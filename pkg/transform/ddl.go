@@ -0,0 +1,121 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+)
+
+// TransformDDL transforms columns in the DDL statement on the upstream table in place,
+// dropped columns and indexes on them are removed, and renamed columns are renamed.
+// returns true if the DDL should be skipped because it only changes dropped columns.
+// supported are CREATE TABLE, CREATE INDEX and ALTER TABLE with only one spec (see `parser.SplitDDL`),
+// other statements are not changed
+func (t *Transformer) TransformDDL(schema, table string, stmt ast.StmtNode) (bool, error) {
+	r, err := t.match(schema, table)
+	if err != nil || r == nil {
+		return false, errors.Trace(err)
+	}
+
+	switch v := stmt.(type) {
+	case *ast.CreateTableStmt:
+		cols := make([]*ast.ColumnDef, 0, len(v.Cols))
+		for _, col := range v.Cols {
+			if r.renameColumn(col.Name) {
+				cols = append(cols, col)
+			}
+		}
+		if len(v.Cols) > 0 && len(cols) == 0 {
+			return false, errors.Errorf("all columns of %s.%s are dropped by column transform rules", schema, table)
+		}
+		v.Cols = cols
+
+		constraints := make([]*ast.Constraint, 0, len(v.Constraints))
+		for _, cons := range v.Constraints {
+			if r.renameIndexColumns(cons.Keys) {
+				constraints = append(constraints, cons)
+			}
+		}
+		v.Constraints = constraints
+	case *ast.CreateIndexStmt:
+		return !r.renameIndexColumns(v.IndexColNames), nil
+	case *ast.AlterTableStmt:
+		if len(v.Specs) != 1 {
+			return false, errors.NotSupportedf("transform columns in ALTER TABLE with %d specs", len(v.Specs))
+		}
+		return r.transformAlterTableSpec(v.Specs[0]), nil
+	}
+	return false, nil
+}
+
+func (r *tableRule) transformAlterTableSpec(spec *ast.AlterTableSpec) bool {
+	if spec.Position != nil && spec.Position.Tp == ast.ColumnPositionAfter && !r.renameColumn(spec.Position.RelativeColumn) {
+		// the relative column is dropped, add it to the end
+		spec.Position.Tp = ast.ColumnPositionNone
+		spec.Position.RelativeColumn = nil
+	}
+
+	switch spec.Tp {
+	case ast.AlterTableAddColumns:
+		cols := make([]*ast.ColumnDef, 0, len(spec.NewColumns))
+		for _, col := range spec.NewColumns {
+			if r.renameColumn(col.Name) {
+				cols = append(cols, col)
+			}
+		}
+		spec.NewColumns = cols
+		return len(cols) == 0
+	case ast.AlterTableDropColumn:
+		return !r.renameColumn(spec.OldColumnName)
+	case ast.AlterTableChangeColumn:
+		// the new column is treated as dropped if the old one is dropped
+		if !r.renameColumn(spec.OldColumnName) {
+			return true
+		}
+		return len(spec.NewColumns) > 0 && !r.renameColumn(spec.NewColumns[0].Name)
+	case ast.AlterTableModifyColumn, ast.AlterTableAlterColumn:
+		return len(spec.NewColumns) > 0 && !r.renameColumn(spec.NewColumns[0].Name)
+	case ast.AlterTableAddConstraint:
+		return spec.Constraint != nil && !r.renameIndexColumns(spec.Constraint.Keys)
+	}
+	return false
+}
+
+// renameColumn renames the upstream column to the downstream column, returns false if it's dropped
+func (r *tableRule) renameColumn(col *ast.ColumnName) bool {
+	if col == nil {
+		return true
+	}
+	name, ok := r.targetColumn(col.Name.O)
+	if !ok {
+		return false
+	}
+	col.Name = model.NewCIStr(name)
+	return true
+}
+
+// renameIndexColumns renames columns of the index, returns false if any of them is dropped
+func (r *tableRule) renameIndexColumns(cols []*ast.IndexColName) bool {
+	for _, col := range cols {
+		if _, ok := r.targetColumn(col.Column.Name.O); !ok {
+			return false
+		}
+	}
+	for _, col := range cols {
+		r.renameColumn(col.Column)
+	}
+	return true
+}
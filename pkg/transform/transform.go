@@ -0,0 +1,354 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	selector "github.com/pingcap/tidb-tools/pkg/table-rule-selector"
+)
+
+// masking functions
+const (
+	// MaskHash replaces the value with the hex encoded SHA-256 of the value prefixed by the salt in `value`
+	MaskHash = "hash"
+	// MaskNull replaces the value with NULL
+	MaskNull = "null"
+	// MaskConstant replaces the value with the constant in `value`
+	MaskConstant = "constant"
+	// MaskKeepFirst keeps the first `length` characters of the value, and replaces others with `*`
+	MaskKeepFirst = "keep-first"
+	// MaskKeepLast keeps the last `length` characters of the value, and replaces others with `*`
+	MaskKeepLast = "keep-last"
+)
+
+// MaskRule is a rule to replace values of a column by a masking function
+type MaskRule struct {
+	Column   string `yaml:"column" toml:"column" json:"column"`
+	Function string `yaml:"function" toml:"function" json:"function"`
+	Value    string `yaml:"value" toml:"value" json:"value"`
+	Length   int    `yaml:"length" toml:"length" json:"length"`
+}
+
+// Valid checks validity of the mask rule
+func (m *MaskRule) Valid() error {
+	if len(m.Column) == 0 {
+		return errors.New("column of mask rule should not be empty")
+	}
+	switch m.Function {
+	case MaskHash, MaskNull, MaskConstant:
+	case MaskKeepFirst, MaskKeepLast:
+		if m.Length < 0 {
+			return errors.Errorf("length %d of mask function %s for column %s should not be negative", m.Length, m.Function, m.Column)
+		}
+	default:
+		return errors.NotSupportedf("mask function %s for column %s", m.Function, m.Column)
+	}
+	return nil
+}
+
+// Injective returns whether different values are masked to different values,
+// only columns masked injectively can still identify rows in keys
+func (m *MaskRule) Injective() bool {
+	return m.Function == MaskHash
+}
+
+// Mask returns the masked value, NULL is kept except for `constant`.
+// non-NULL values are masked on their string representations,
+// values should be normalized by NormalizeValue first, so the same value got from binlog and dump files is masked to the same value
+func (m *MaskRule) Mask(value interface{}) interface{} {
+	if m.Function == MaskConstant {
+		return m.Value
+	}
+	if value == nil || m.Function == MaskNull {
+		return nil
+	}
+
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		str = fmt.Sprintf("%v", v)
+	}
+
+	switch m.Function {
+	case MaskHash:
+		sum := sha256.Sum256([]byte(m.Value + str))
+		return hex.EncodeToString(sum[:])
+	case MaskKeepFirst, MaskKeepLast:
+		runes := []rune(str)
+		if len(runes) <= m.Length {
+			return str
+		}
+		masked := []rune(strings.Repeat("*", len(runes)))
+		if m.Function == MaskKeepFirst {
+			copy(masked, runes[:m.Length])
+		} else {
+			copy(masked[len(runes)-m.Length:], runes[len(runes)-m.Length:])
+		}
+		return string(masked)
+	}
+	return value
+}
+
+// Rule is a rule to transform columns of tables matched by schema and table patterns.
+// columns are upstream ones, dropped columns are not replicated to downstream,
+// renamed columns are replicated to downstream columns with new names,
+// and values of masked columns are replaced by masking functions.
+// if multiple rules matched a table, they are merged, and a column can only be transformed by one of them
+type Rule struct {
+	SchemaPattern string            `yaml:"schema-pattern" toml:"schema-pattern" json:"schema-pattern"`
+	TablePattern  string            `yaml:"table-pattern" toml:"table-pattern" json:"table-pattern"`
+	DropColumns   []string          `yaml:"drop-columns" toml:"drop-columns" json:"drop-columns"`
+	RenameColumns map[string]string `yaml:"rename-columns" toml:"rename-columns" json:"rename-columns"`
+	MaskColumns   []*MaskRule       `yaml:"mask-columns" toml:"mask-columns" json:"mask-columns"`
+}
+
+// Valid checks validity of the rule
+func (r *Rule) Valid() error {
+	if len(r.SchemaPattern) == 0 {
+		return errors.New("schema pattern of column transform rule should not be empty")
+	}
+	for _, m := range r.MaskColumns {
+		if err := m.Valid(); err != nil {
+			return errors.Annotatef(err, "column transform rule %s.%s", r.SchemaPattern, r.TablePattern)
+		}
+	}
+	for old, name := range r.RenameColumns {
+		if len(old) == 0 || len(name) == 0 {
+			return errors.Errorf("column transform rule %s.%s renames column %s to %s, column name should not be empty", r.SchemaPattern, r.TablePattern, old, name)
+		}
+	}
+	return nil
+}
+
+// tableRule is the merged transformation of matched rules for a table, keyed by lower case column names
+type tableRule struct {
+	drops   map[string]struct{}
+	renames map[string]string
+	masks   map[string]*MaskRule
+}
+
+func (r *tableRule) add(rule *Rule) error {
+	exists := func(column string) error {
+		_, ok1 := r.drops[column]
+		_, ok2 := r.renames[column]
+		_, ok3 := r.masks[column]
+		if ok1 || ok2 || ok3 {
+			return errors.AlreadyExistsf("transformation for column %s", column)
+		}
+		return nil
+	}
+	for _, col := range rule.DropColumns {
+		col = strings.ToLower(col)
+		if err := exists(col); err != nil {
+			return errors.Trace(err)
+		}
+		r.drops[col] = struct{}{}
+	}
+	for col, name := range rule.RenameColumns {
+		col = strings.ToLower(col)
+		if _, ok := r.renames[col]; ok {
+			return errors.AlreadyExistsf("rename for column %s", col)
+		}
+		if _, ok := r.drops[col]; ok {
+			return errors.Errorf("column %s is both dropped and renamed", col)
+		}
+		r.renames[col] = name
+	}
+	for _, m := range rule.MaskColumns {
+		col := strings.ToLower(m.Column)
+		if _, ok := r.masks[col]; ok {
+			return errors.AlreadyExistsf("mask for column %s", col)
+		}
+		if _, ok := r.drops[col]; ok {
+			return errors.Errorf("column %s is both dropped and masked", col)
+		}
+		r.masks[col] = m
+	}
+	return nil
+}
+
+// targetColumn returns the downstream column name of the upstream column, returns false if it's dropped
+func (r *tableRule) targetColumn(column string) (string, bool) {
+	lower := strings.ToLower(column)
+	if _, ok := r.drops[lower]; ok {
+		return "", false
+	}
+	if name, ok := r.renames[lower]; ok {
+		return name, true
+	}
+	return column, true
+}
+
+// Transformer transforms columns of tables by rules
+type Transformer struct {
+	caseSensitive bool
+	selector      selector.Selector
+}
+
+// NewTransformer returns a Transformer
+func NewTransformer(caseSensitive bool, rules []*Rule) (*Transformer, error) {
+	t := &Transformer{
+		caseSensitive: caseSensitive,
+		selector:      selector.NewTrieSelector(),
+	}
+
+	// multiple rules may be set for the same schema and table patterns
+	var (
+		patterns     [][]string
+		patternRules = make(map[string][]*Rule)
+	)
+	for _, rule := range rules {
+		if err := rule.Valid(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		schema, table := rule.SchemaPattern, rule.TablePattern
+		if !caseSensitive {
+			schema, table = strings.ToLower(schema), strings.ToLower(table)
+		}
+		key := fmt.Sprintf("%s.%s", schema, table)
+		if _, ok := patternRules[key]; !ok {
+			patterns = append(patterns, []string{schema, table})
+		}
+		patternRules[key] = append(patternRules[key], rule)
+	}
+
+	for _, pattern := range patterns {
+		schema, table := pattern[0], pattern[1]
+		err := t.selector.Insert(schema, table, patternRules[fmt.Sprintf("%s.%s", schema, table)], false)
+		if err != nil {
+			return nil, errors.Annotatef(err, "column transform rule %s.%s", schema, table)
+		}
+	}
+	return t, nil
+}
+
+// match returns the merged transformation for the upstream table, returns nil if no rule matched
+func (t *Transformer) match(schema, table string) (*tableRule, error) {
+	if t == nil {
+		return nil, nil
+	}
+	if !t.caseSensitive {
+		schema, table = strings.ToLower(schema), strings.ToLower(table)
+	}
+	matched := t.selector.Match(schema, table)
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	r := &tableRule{
+		drops:   make(map[string]struct{}),
+		renames: make(map[string]string),
+		masks:   make(map[string]*MaskRule),
+	}
+	for _, rules := range matched {
+		for _, rule := range rules.([]*Rule) {
+			if err := r.add(rule); err != nil {
+				return nil, errors.Annotatef(err, "column transform rules for %s.%s", schema, table)
+			}
+		}
+	}
+	return r, nil
+}
+
+// Matched returns whether any rule matched the upstream table
+func (t *Transformer) Matched(schema, table string) bool {
+	if t == nil {
+		return false
+	}
+	if !t.caseSensitive {
+		schema, table = strings.ToLower(schema), strings.ToLower(table)
+	}
+	return len(t.selector.Match(schema, table)) > 0
+}
+
+// Table is the transformation for an upstream table with specified columns
+type Table struct {
+	// Columns are names of downstream columns
+	Columns []string
+	// Indexes are indexes in upstream columns of downstream columns
+	Indexes []int
+	// masks are masking rules of downstream columns, nil if not masked
+	masks []*MaskRule
+	// types are upstream column types of downstream columns, values are normalized by them before masked
+	types []string
+}
+
+// Table returns the transformation for the upstream table with upstream columns and their types like `int(11) unsigned`,
+// returns nil if no rule matched
+func (t *Transformer) Table(schema, table string, columns, types []string) (*Table, error) {
+	r, err := t.match(schema, table)
+	if err != nil || r == nil {
+		return nil, errors.Trace(err)
+	}
+
+	tt := &Table{
+		Columns: make([]string, 0, len(columns)),
+		Indexes: make([]int, 0, len(columns)),
+		masks:   make([]*MaskRule, 0, len(columns)),
+		types:   make([]string, 0, len(columns)),
+	}
+	for i, col := range columns {
+		name, ok := r.targetColumn(col)
+		if !ok {
+			continue
+		}
+		tt.Columns = append(tt.Columns, name)
+		tt.Indexes = append(tt.Indexes, i)
+		tt.masks = append(tt.masks, r.masks[strings.ToLower(col)])
+		tp := ""
+		if i < len(types) {
+			tp = types[i]
+		}
+		tt.types = append(tt.types, tp)
+	}
+	if len(tt.Columns) == 0 {
+		return nil, errors.Errorf("all columns of %s.%s are dropped by column transform rules", schema, table)
+	}
+	return tt, nil
+}
+
+// Masked returns whether the downstream column in the index is masked
+func (t *Table) Masked(idx int) bool {
+	return t.masks[idx] != nil
+}
+
+// Injective returns whether the downstream column in the index is not masked or masked injectively
+func (t *Table) Injective(idx int) bool {
+	return t.masks[idx] == nil || t.masks[idx].Injective()
+}
+
+// TransformRow transforms a row of upstream column values to downstream column values
+func (t *Table) TransformRow(row []interface{}) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(t.Indexes))
+	for i, idx := range t.Indexes {
+		if idx >= len(row) {
+			return nil, errors.Errorf("row %v has less columns than %d", row, idx+1)
+		}
+		value := row[idx]
+		if t.masks[i] != nil {
+			value = t.masks[i].Mask(NormalizeValue(value, t.types[i]))
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
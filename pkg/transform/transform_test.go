@@ -0,0 +1,217 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/format"
+	_ "github.com/pingcap/tidb/types/parser_driver" // for import parser driver
+)
+
+var _ = Suite(&testTransformSuite{})
+
+func TestSuite(t *testing.T) {
+	TestingT(t)
+}
+
+type testTransformSuite struct{}
+
+func (s *testTransformSuite) TestMask(c *C) {
+	cases := []struct {
+		rule     *MaskRule
+		value    interface{}
+		expected interface{}
+	}{
+		{&MaskRule{Function: MaskNull}, "abc", nil},
+		{&MaskRule{Function: MaskConstant, Value: "x"}, nil, "x"},
+		{&MaskRule{Function: MaskHash}, nil, nil},
+		{&MaskRule{Function: MaskHash}, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{&MaskRule{Function: MaskHash}, []byte("abc"), "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{&MaskRule{Function: MaskHash, Value: "a"}, "bc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{&MaskRule{Function: MaskKeepFirst, Length: 3}, "13800138000", "138********"},
+		{&MaskRule{Function: MaskKeepLast, Length: 4}, int64(13800138000), "*******8000"},
+		{&MaskRule{Function: MaskKeepLast, Length: 1}, "张三丰", "**丰"},
+		{&MaskRule{Function: MaskKeepFirst, Length: 5}, "abc", "abc"},
+		{&MaskRule{Function: MaskKeepFirst, Length: 0}, "abc", "***"},
+	}
+	for _, cs := range cases {
+		c.Assert(cs.rule.Mask(cs.value), DeepEquals, cs.expected, Commentf("%+v", cs.rule))
+	}
+
+	c.Assert((&MaskRule{Column: "a", Function: "unknown"}).Valid(), NotNil)
+	c.Assert((&MaskRule{Column: "a", Function: MaskKeepLast, Length: -1}).Valid(), NotNil)
+	c.Assert((&MaskRule{Function: MaskHash}).Valid(), NotNil)
+}
+
+func (s *testTransformSuite) TestTable(c *C) {
+	rules := []*Rule{
+		{
+			SchemaPattern: "db_*",
+			TablePattern:  "user",
+			DropColumns:   []string{"SSN"},
+			RenameColumns: map[string]string{"addr": "address"},
+		},
+		{
+			SchemaPattern: "db_1",
+			TablePattern:  "*",
+			MaskColumns: []*MaskRule{
+				{Column: "phone", Function: MaskKeepLast, Length: 4},
+				{Column: "addr", Function: MaskNull},
+			},
+		},
+	}
+	t, err := NewTransformer(false, rules)
+	c.Assert(err, IsNil)
+
+	columns := []string{"id", "ssn", "phone", "addr"}
+	tt, err := t.Table("other", "user", columns, nil)
+	c.Assert(err, IsNil)
+	c.Assert(tt, IsNil)
+	c.Assert(t.Matched("other", "user"), IsFalse)
+
+	tt, err = t.Table("db_2", "user", columns, nil)
+	c.Assert(err, IsNil)
+	c.Assert(tt.Columns, DeepEquals, []string{"id", "phone", "address"})
+	c.Assert(tt.Indexes, DeepEquals, []int{0, 2, 3})
+	row, err := tt.TransformRow([]interface{}{1, "123-45-6789", "13800138000", "road"})
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, []interface{}{1, "13800138000", "road"})
+
+	// rules of two patterns matched
+	tt, err = t.Table("DB_1", "User", columns, nil)
+	c.Assert(err, IsNil)
+	c.Assert(tt.Columns, DeepEquals, []string{"id", "phone", "address"})
+	c.Assert(tt.Masked(0), IsFalse)
+	c.Assert(tt.Masked(1), IsTrue)
+	c.Assert(tt.Injective(0), IsTrue)
+	c.Assert(tt.Injective(1), IsFalse) // keep-last
+	row, err = tt.TransformRow([]interface{}{1, "123-45-6789", "13800138000", "road"})
+	c.Assert(err, IsNil)
+	c.Assert(row, DeepEquals, []interface{}{1, "*******8000", nil})
+	_, err = tt.TransformRow([]interface{}{1, "123-45-6789"})
+	c.Assert(err, NotNil)
+
+	// all columns dropped
+	_, err = t.Table("db_2", "user", []string{"ssn"}, nil)
+	c.Assert(err, NotNil)
+
+	// column dropped and masked
+	t, err = NewTransformer(false, append(rules, &Rule{
+		SchemaPattern: "db_1",
+		TablePattern:  "user",
+		MaskColumns:   []*MaskRule{{Column: "ssn", Function: MaskHash}},
+	}))
+	c.Assert(err, IsNil)
+	_, err = t.Table("db_1", "user", columns, nil)
+	c.Assert(err, ErrorMatches, ".*both dropped and masked.*")
+
+	// invalid rule
+	_, err = NewTransformer(false, []*Rule{{SchemaPattern: "db", RenameColumns: map[string]string{"a": ""}}})
+	c.Assert(err, NotNil)
+}
+
+func (s *testTransformSuite) TestNormalizeValue(c *C) {
+	// values in SQL literals of dump files and values in binlog of the same rows
+	cases := []struct {
+		tp     string
+		dumped interface{}
+		binlog interface{}
+	}{
+		{"int(11)", "-1", int32(-1)},
+		{"bigint(20) unsigned", "18446744073709551615", "18446744073709551615"},
+		{"float", "1.1", float32(1.1)},
+		{"float", "3.14159", float32(3.1415926)},
+		{"float(7,3)", "1.100", float32(1.1)},
+		{"double", "0.30000000000000004", float64(0.30000000000000004)},
+		{"decimal(10,2)", "1.50", "1.5"},
+		{"decimal(10,0)", "-3", "-3"},
+		{"bit(16)", "\x01\x02", int64(258)},
+		{"enum('a','b''c')", "b'c", int64(2)},
+		{"set('a','b','c')", "a,c", int64(5)},
+		{"set('a','b','c')", "", int64(0)},
+		{"json", `{"a": [1, "x"], "b": null}`, []byte(`{"b":null,"a":[1,"x"]}`)},
+		{"datetime(3)", "2019-03-14 10:01:02.120", time.Date(2019, 3, 14, 10, 1, 2, 120000000, time.UTC)},
+		{"date", "2019-03-14", "2019-03-14"},
+		{"varbinary(10)", "abc", []byte("abc")},
+		{"", "1", int64(1)},
+	}
+	rule := &MaskRule{Function: MaskHash, Value: "salt"}
+	for _, cs := range cases {
+		c.Assert(rule.Mask(NormalizeValue(cs.dumped, cs.tp)), Equals, rule.Mask(NormalizeValue(cs.binlog, cs.tp)), Commentf("%+v", cs))
+	}
+	c.Assert(NormalizeValue(nil, "int(11)"), IsNil)
+	c.Assert(NormalizeValue(float32(1.1), "float"), Equals, "1.1")
+	c.Assert(NormalizeValue("1.5", "decimal(10,2)"), Equals, "1.50")
+	c.Assert(NormalizeValue(int64(2), "enum('a','b')"), Equals, "b")
+}
+
+func (s *testTransformSuite) TestTransformDDL(c *C) {
+	t, err := NewTransformer(false, []*Rule{{
+		SchemaPattern: "db",
+		TablePattern:  "tb",
+		DropColumns:   []string{"ssn"},
+		RenameColumns: map[string]string{"addr": "address"},
+		MaskColumns:   []*MaskRule{{Column: "phone", Function: MaskHash}},
+	}})
+	c.Assert(err, IsNil)
+
+	cases := []struct {
+		sql      string
+		skip     bool
+		expected string
+	}{
+		{
+			"CREATE TABLE tb (id INT PRIMARY KEY, ssn VARCHAR(20), addr TEXT, phone VARCHAR(64), UNIQUE KEY (ssn), KEY (addr(10), id))",
+			false,
+			"CREATE TABLE `tb` (`id` INT PRIMARY KEY,`address` TEXT,`phone` VARCHAR(64),INDEX(`address`(10), `id`))",
+		},
+		{"ALTER TABLE tb ADD COLUMN ssn INT", true, ""},
+		{"ALTER TABLE tb ADD COLUMN c INT AFTER ssn", false, "ALTER TABLE `tb` ADD COLUMN `c` INT"},
+		{"ALTER TABLE tb ADD COLUMN c INT AFTER addr", false, "ALTER TABLE `tb` ADD COLUMN `c` INT AFTER `address`"},
+		{"ALTER TABLE tb DROP COLUMN ssn", true, ""},
+		{"ALTER TABLE tb DROP COLUMN addr", false, "ALTER TABLE `tb` DROP COLUMN `address`"},
+		{"ALTER TABLE tb MODIFY COLUMN ssn BIGINT", true, ""},
+		{"ALTER TABLE tb CHANGE COLUMN addr addr VARCHAR(100)", false, "ALTER TABLE `tb` CHANGE COLUMN `address` `address` VARCHAR(100)"},
+		{"ALTER TABLE tb ADD INDEX idx(ssn)", true, ""},
+		{"ALTER TABLE tb ADD INDEX idx(addr)", false, "ALTER TABLE `tb` ADD INDEX `idx`(`address`)"},
+		{"CREATE INDEX idx ON tb (ssn)", true, ""},
+		{"ALTER TABLE tb ENGINE = InnoDB", false, "ALTER TABLE `tb` ENGINE = InnoDB"},
+	}
+	p := parser.New()
+	for _, cs := range cases {
+		stmt, err := p.ParseOneStmt(cs.sql, "", "")
+		c.Assert(err, IsNil)
+		skip, err := t.TransformDDL("db", "tb", stmt)
+		c.Assert(err, IsNil)
+		c.Assert(skip, Equals, cs.skip, Commentf("%s", cs.sql))
+		if skip {
+			continue
+		}
+		bf := new(bytes.Buffer)
+		c.Assert(stmt.Restore(&format.RestoreCtx{Flags: format.DefaultRestoreFlags, In: bf}), IsNil)
+		c.Assert(bf.String(), Equals, cs.expected, Commentf("%s", cs.sql))
+	}
+
+	// not matched
+	stmt, err := p.ParseOneStmt("ALTER TABLE tb DROP COLUMN ssn", "", "")
+	c.Assert(err, IsNil)
+	skip, err := t.TransformDDL("db", "tb2", stmt)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsFalse)
+}
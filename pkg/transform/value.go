@@ -0,0 +1,214 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NormalizeValue returns the string representation of the value by the column type like `decimal(10,2)` or `enum('a','b')`,
+// which is the same with the value in SQL literals of dump files.
+// values in binlog are in different representations, e.g. FLOAT in float32, DECIMAL without trailing zeros,
+// ENUM and SET in index and bitmap, BIT in integer and JSON in compact text.
+// NULL is returned as nil, and the value is returned as string if the column type is unknown
+func NormalizeValue(value interface{}, tp string) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	tp = strings.ToLower(strings.TrimSpace(tp))
+	base, args := tp, ""
+	if i := strings.IndexByte(tp, '('); i >= 0 {
+		base = tp[:i]
+		args = tp[i+1:]
+	} else if i = strings.IndexByte(tp, ' '); i >= 0 {
+		base = tp[:i]
+	}
+
+	switch base {
+	case "float", "double", "real":
+		if f, ok := floatValue(value); ok {
+			return formatFloat(f, base == "float", args)
+		}
+	case "decimal", "numeric":
+		return formatDecimal(valueString(value), args)
+	case "bit":
+		switch v := value.(type) {
+		case int64:
+			return strconv.FormatUint(uint64(v), 10)
+		case string, []byte:
+			// raw bytes in big endian
+			var x uint64
+			for _, b := range []byte(valueString(v)) {
+				x = x<<8 | uint64(b)
+			}
+			return strconv.FormatUint(x, 10)
+		}
+	case "enum", "set":
+		if x, ok := value.(int64); ok {
+			return enumSetValue(x, ParseEnumSetElems(args), base == "set")
+		}
+	case "json":
+		var v interface{}
+		if err := json.Unmarshal([]byte(valueString(value)), &v); err == nil {
+			if data, err := json.Marshal(v); err == nil {
+				return string(data)
+			}
+		}
+	case "datetime", "timestamp", "date":
+		if t, ok := value.(time.Time); ok {
+			return formatTime(t, base == "date", args)
+		}
+	}
+	return valueString(value)
+}
+
+// ParseEnumSetElems parses quoted elements of ENUM or SET type after the opening parenthesis, like `'a','b')`,
+// quotes in elements are escaped by doubling or backslash
+func ParseEnumSetElems(s string) []string {
+	var (
+		elems   []string
+		elem    strings.Builder
+		inQuote bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case !inQuote && c == '\'':
+			inQuote = true
+		case !inQuote:
+			// separators and the closing parenthesis
+		case c == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			elem.WriteByte(c)
+			i++
+		case c == '\'':
+			inQuote = false
+			elems = append(elems, elem.String())
+			elem.Reset()
+		case c == '\\' && i+1 < len(s):
+			elem.WriteByte(s[i+1])
+			i++
+		default:
+			elem.WriteByte(c)
+		}
+	}
+	return elems
+}
+
+func valueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func floatValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string, []byte:
+		f, err := strconv.ParseFloat(valueString(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// scale returns D of type arguments like `10,2)`, returns -1 if not specified
+func scale(args string) int {
+	if i := strings.IndexByte(args, ')'); i >= 0 {
+		args = args[:i]
+	}
+	i := strings.IndexByte(args, ',')
+	if i < 0 {
+		return -1
+	}
+	d, err := strconv.Atoi(strings.TrimSpace(args[i+1:]))
+	if err != nil {
+		return -1
+	}
+	return d
+}
+
+// formatFloat formats the float like MySQL, FLOAT has 6 significant digits, and DOUBLE has the shortest representation
+func formatFloat(f float64, isFloat bool, args string) string {
+	if d := scale(args); d >= 0 {
+		return strconv.FormatFloat(f, 'f', d, 64)
+	}
+	if isFloat {
+		return strconv.FormatFloat(float64(float32(f)), 'g', 6, 32)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatDecimal pads or truncates the fractional part of the decimal to D digits
+func formatDecimal(s string, args string) string {
+	d := scale(args)
+	if d < 0 {
+		d = 0
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	if d == 0 {
+		return intPart
+	}
+	if len(frac) > d {
+		frac = frac[:d]
+	} else {
+		frac += strings.Repeat("0", d-len(frac))
+	}
+	return intPart + "." + frac
+}
+
+func enumSetValue(x int64, elems []string, isSet bool) string {
+	if !isSet {
+		if x <= 0 || int(x) > len(elems) {
+			return "" // invalid value inserted in non-strict mode
+		}
+		return elems[x-1]
+	}
+	set := make([]string, 0, 1)
+	for i, elem := range elems {
+		if x&(1<<uint(i)) != 0 {
+			set = append(set, elem)
+		}
+	}
+	return strings.Join(set, ",")
+}
+
+// formatTime formats the time with fractional seconds precision in type arguments like `3)`
+func formatTime(t time.Time, isDate bool, args string) string {
+	if isDate {
+		return t.Format("2006-01-02")
+	}
+	layout := "2006-01-02 15:04:05"
+	if i := strings.IndexByte(args, ')'); i >= 0 {
+		args = args[:i]
+	}
+	if fsp, err := strconv.Atoi(args); err == nil && fsp > 0 {
+		layout += "." + strings.Repeat("0", fsp)
+	}
+	return t.Format(layout)
+}
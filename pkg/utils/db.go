@@ -262,3 +262,8 @@ func IsErrDupEntry(err error) bool {
 func IsNoSuchThreadError(err error) bool {
 	return IsMySQLError(err, tmysql.ErrNoSuchThread)
 }
+
+// IsErrDupFieldName checks whether err is DupFieldName error
+func IsErrDupFieldName(err error) bool {
+	return IsMySQLError(err, tmysql.ErrDupFieldName)
+}
@@ -16,6 +16,7 @@ package utils
 import (
 	"bufio"
 	"io"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
@@ -24,6 +25,12 @@ import (
 	"github.com/siddontang/go-mysql/mysql"
 )
 
+// MetaDataLatestSnapshot is the header of the latest snapshot's binlog position in the dump unit's output meta file.
+// tables may be dumped in different snapshots when the dump is resumed,
+// binlog position of the first snapshot is recorded as `SHOW MASTER STATUS` and syncer starts from there,
+// binlog position of the latest snapshot is recorded under this header and syncer should be reentrant until reaching it.
+const MetaDataLatestSnapshot = "SHOW MASTER STATUS (latest snapshot):"
+
 // ParseMetaData parses mydumper's output meta file and returns binlog position
 func ParseMetaData(filename string) (*mysql.Position, error) {
 	fd, err := os.Open(filename)
@@ -70,3 +77,87 @@ func ParseMetaData(filename string) (*mysql.Position, error) {
 
 	return nil, errors.Errorf("parse metadata for %s fail", filename)
 }
+
+// ParseMetaDataGTID parses GTID set of `SHOW MASTER STATUS` from mydumper's output meta file,
+// returns empty string if GTID is not enabled in the upstream
+func ParseMetaDataGTID(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var (
+		gtidStr  string
+		inMaster bool
+		inGTID   bool
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "SHOW MASTER STATUS") {
+			inMaster = true
+			continue
+		} else if !inMaster {
+			continue
+		}
+
+		if inGTID {
+			// GTID set with multiple UUIDs is split into multiple lines after `,`
+			gtidStr += line
+		} else if strings.HasPrefix(line, "GTID:") {
+			inGTID = true
+			gtidStr = strings.TrimSpace(strings.TrimPrefix(line, "GTID:"))
+		} else if len(line) == 0 || strings.Contains(line, "SHOW SLAVE STATUS") {
+			break
+		}
+		if inGTID && !strings.HasSuffix(gtidStr, ",") {
+			return gtidStr, nil
+		}
+	}
+	if !inMaster {
+		return "", errors.Errorf("parse metadata for %s fail", filename)
+	}
+	return gtidStr, nil
+}
+
+// ParseMetaDataLatestPos parses binlog position of the latest snapshot from the dump unit's output meta file,
+// returns nil if not recorded, see `MetaDataLatestSnapshot`
+func ParseMetaDataLatestPos(filename string) (*mysql.Position, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var (
+		logName  string
+		inLatest bool
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == MetaDataLatestSnapshot {
+			inLatest = true
+			continue
+		} else if !inLatest {
+			continue
+		} else if len(line) == 0 {
+			break
+		}
+
+		parts := strings.Split(line, ": ")
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[0] == "Log" {
+			logName = parts[1]
+		} else if parts[0] == "Pos" && len(logName) > 0 {
+			pos64, err := strconv.ParseUint(parts[1], 10, 32)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return &mysql.Position{Name: logName, Pos: uint32(pos64)}, nil
+		}
+	}
+	if !inLatest {
+		return nil, nil
+	}
+	return nil, errors.Errorf("parse latest snapshot in metadata for %s fail", filename)
+}
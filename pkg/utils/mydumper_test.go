@@ -72,3 +72,134 @@ Finished dump at: 2018-12-27 19:51:22`,
 		c.Assert(pos, DeepEquals, tc.pos)
 	}
 }
+
+func (t *testUtilsSuite) TestParseMetaDataGTID(c *C) {
+	f, err := ioutil.TempFile("", "metadata")
+	c.Assert(err, IsNil)
+	defer os.Remove(f.Name())
+
+	testCases := []struct {
+		source string
+		gtid   string
+	}{
+		{
+			`Started dump at: 2018-12-28 07:20:49
+SHOW MASTER STATUS:
+        Log: bin.000001
+        Pos: 2479
+        GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13
+
+Finished dump at: 2018-12-28 07:20:51`,
+			"97b5142f-e19c-11e8-808c-0242ac110005:1-13",
+		},
+		{
+			`Started dump at: 2018-12-28 07:20:49
+SHOW MASTER STATUS:
+        Log: bin.000001
+        Pos: 2479
+        GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13,
+a8c04fbc-e19c-11e8-808c-0242ac110005:1-7
+
+SHOW SLAVE STATUS:
+        Host: 10.128.27.98
+        Log: mysql-bin.000003
+        Pos: 329635
+        GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-10
+
+Finished dump at: 2018-12-28 07:20:51`,
+			"97b5142f-e19c-11e8-808c-0242ac110005:1-13,a8c04fbc-e19c-11e8-808c-0242ac110005:1-7",
+		},
+		{
+			`Started dump at: 2018-12-27 19:51:22
+SHOW MASTER STATUS:
+        Log: mysql-bin.000003
+        Pos: 3295817
+        GTID:
+
+Finished dump at: 2018-12-27 19:51:22`,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := ioutil.WriteFile(f.Name(), []byte(tc.source), 0644)
+		c.Assert(err, IsNil)
+		gtidStr, err := ParseMetaDataGTID(f.Name())
+		c.Assert(err, IsNil)
+		c.Assert(gtidStr, Equals, tc.gtid)
+	}
+}
+
+func (t *testUtilsSuite) TestParseMetaDataLatestPos(c *C) {
+	f, err := ioutil.TempFile("", "metadata")
+	c.Assert(err, IsNil)
+	defer os.Remove(f.Name())
+
+	testCases := []struct {
+		source string
+		pos    *mysql.Position
+		hasErr bool
+	}{
+		{
+			`Started dump at: 2018-12-28 07:20:49
+SHOW MASTER STATUS:
+        Log: bin.000001
+        Pos: 2479
+        GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13
+
+Finished dump at: 2018-12-28 07:20:51`,
+			nil,
+			false,
+		},
+		{
+			`Started dump at: 2018-12-28 07:20:49
+SHOW MASTER STATUS:
+        Log: bin.000001
+        Pos: 2479
+        GTID:97b5142f-e19c-11e8-808c-0242ac110005:1-13
+
+SHOW MASTER STATUS (latest snapshot):
+        Log: bin.000002
+        Pos: 154
+
+Finished dump at: 2018-12-28 09:20:51`,
+			&mysql.Position{
+				Name: "bin.000002",
+				Pos:  154,
+			},
+			false,
+		},
+		{
+			`Started dump at: 2018-12-28 07:20:49
+SHOW MASTER STATUS:
+        Log: bin.000001
+        Pos: 2479
+
+SHOW MASTER STATUS (latest snapshot):
+        Log: bin.000002
+`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := ioutil.WriteFile(f.Name(), []byte(tc.source), 0644)
+		c.Assert(err, IsNil)
+		pos, err := ParseMetaDataLatestPos(f.Name())
+		if tc.hasErr {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(pos, DeepEquals, tc.pos)
+
+		// not affect parsing the first snapshot
+		pos, err = ParseMetaData(f.Name())
+		c.Assert(err, IsNil)
+		c.Assert(pos, DeepEquals, &mysql.Position{Name: "bin.000001", Pos: 2479})
+		gtidStr, err := ParseMetaDataGTID(f.Name())
+		c.Assert(err, IsNil)
+		c.Assert(gtidStr, Equals, "97b5142f-e19c-11e8-808c-0242ac110005:1-13")
+	}
+}
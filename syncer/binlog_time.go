@@ -0,0 +1,123 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/streamer"
+)
+
+// remoteBinlogScanner scans binlog files of the upstream through the replication protocol
+type remoteBinlogScanner struct {
+	db    *sql.DB
+	cfg   replication.BinlogSyncerConfig
+	sizes map[string]int64
+}
+
+// Files implements streamer.BinlogScanner.Files
+func (s *remoteBinlogScanner) Files() ([]string, error) {
+	logs, err := getBinaryLogs(s.db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	files := make([]string, 0, len(logs))
+	s.sizes = make(map[string]int64, len(logs))
+	for _, l := range logs {
+		files = append(files, l.name)
+		s.sizes[l.name] = l.size
+	}
+	return files, nil
+}
+
+// Scan implements streamer.BinlogScanner.Scan
+func (s *remoteBinlogScanner) Scan(file string, onEvent func(e *replication.BinlogEvent) (bool, error)) error {
+	size := s.sizes[file]
+	if size <= 4 {
+		return nil
+	}
+
+	syncer := replication.NewBinlogSyncer(s.cfg)
+	defer syncer.Close()
+	st, err := syncer.StartSync(mysql.Position{Name: file, Pos: 4})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+		e, err := st.GetEvent(ctx)
+		cancel()
+		if err != nil {
+			return errors.Annotatef(err, "get event from %s", file)
+		}
+		if ev, ok := e.Event.(*replication.RotateEvent); ok && e.Header.Timestamp != 0 && string(ev.NextLogName) != file {
+			// rotate to the next file
+			return nil
+		}
+		next, err := onEvent(e)
+		if err != nil || !next {
+			return errors.Trace(err)
+		}
+		if int64(e.Header.LogPos) >= size {
+			return nil
+		}
+	}
+}
+
+// locateBinlogTime locates the binlog position by `binlog-time` in meta for the fresh task in increment mode,
+// and fills binlog-name, binlog-pos and binlog-gtid of meta with the position
+func (s *Syncer) locateBinlogTime() error {
+	if s.cfg.Mode != config.ModeIncrement || s.cfg.Meta == nil || len(s.cfg.Meta.BinLogTime) == 0 || len(s.cfg.Meta.BinLogName) > 0 {
+		return nil
+	}
+	fresh, err := s.IsFreshTask()
+	if err != nil || !fresh {
+		return errors.Trace(err)
+	}
+
+	t, err := s.cfg.Meta.ParseBinLogTime(s.timezone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var scanner streamer.BinlogScanner
+	if s.binlogType == LocalBinlog {
+		scanner, err = streamer.NewLocalBinlogScanner(s.cfg.RelayDir)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		scanner = &remoteBinlogScanner{db: s.fromDB.db, cfg: s.syncCfg}
+	}
+	pos, gs, err := streamer.LocateBinlogTime(scanner, s.cfg.Flavor, uint32(t.Unix()))
+	if err != nil {
+		return errors.Annotatef(err, "locate binlog-time %s", s.cfg.Meta.BinLogTime)
+	}
+
+	meta := *s.cfg.Meta
+	meta.BinLogName, meta.BinLogPos = pos.Name, pos.Pos
+	if s.cfg.EnableGTID && gs != nil {
+		meta.BinLogGTID = gs.String()
+	}
+	s.cfg.Meta = &meta
+	log.Infof("[syncer] located binlog-time %s at %v, GTID set %s", meta.BinLogTime, pos, meta.BinLogGTID)
+	return nil
+}
@@ -13,70 +13,117 @@
 
 package syncer
 
-import "github.com/pingcap/errors"
+import (
+	"sync"
 
-// causality provides a simple mechanism to improve the concurrency of SQLs execution under the premise of ensuring correctness.
-// causality groups sqls that maybe contain causal relationships, and syncer executes them linearly.
-// if some conflicts exist in more than one groups, then syncer waits all SQLs that are grouped be executed and reset causality.
-// this mechanism meets quiescent consistency to ensure correctness.
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+// causality schedules jobs into queues by tracking dependencies between jobs with their keys,
+// jobs changing rows of the same key have causal relationships and must be executed in order.
+//   - for each key, causality records the latest job not finished which changes rows of it
+//   - a new job depends on the recorded jobs of its keys, so all dependencies form a DAG
+//   - the job is dispatched into the queue of one of its dependencies, jobs in the same queue are executed in order
+//   - for dependencies in other queues, the queue worker waits for them finished before executing the job
+//
+// so a conflict only blocks the queue of the conflicting job until its dependencies finished,
+// instead of waiting for all queues executed.
 type causality struct {
-	relations map[string]string
+	sync.Mutex
+	relations  map[string]*causalNode
+	queueCount int
 }
 
-func newCausality() *causality {
+// causalNode is a node of the dependency DAG for a job
+type causalNode struct {
+	queue int
+	keys  []string
+	deps  []*causalNode // dependencies in other queues, should be finished before executing the job
+	done  chan struct{}
+}
+
+func newCausality(queueCount int) *causality {
 	return &causality{
-		relations: make(map[string]string),
+		relations:  make(map[string]*causalNode),
+		queueCount: queueCount,
 	}
 }
 
-func (c *causality) add(keys []string) error {
-	if len(keys) == 0 {
-		return nil
-	}
+// add adds a job with keys, and returns its node in which the queue for the job is chosen
+func (c *causality) add(keys []string) *causalNode {
+	c.Lock()
+	defer c.Unlock()
 
-	if c.detectConflict(keys) {
-		return errors.New("some conflicts in causality, must be resolved")
+	node := &causalNode{
+		keys: keys,
+		done: make(chan struct{}),
 	}
-	// find causal key
-	selectedRelation := keys[0]
-	var nonExistKeys []string
+	var deps []*causalNode
 	for _, key := range keys {
-		if val, ok := c.relations[key]; ok {
-			selectedRelation = val
-		} else {
-			nonExistKeys = append(nonExistKeys, key)
+		if dep, ok := c.relations[key]; ok && !containsNode(deps, dep) {
+			deps = append(deps, dep)
 		}
+		c.relations[key] = node
 	}
-	// set causal relations for those non-exist keys
-	for _, key := range nonExistKeys {
-		c.relations[key] = selectedRelation
+
+	if len(deps) == 0 {
+		var key string
+		if len(keys) > 0 {
+			key = keys[0]
+		}
+		node.queue = int(utils.GenHashKey(key)) % c.queueCount
+		return node
+	}
+
+	// dispatch into the queue of the first dependency, then the job needn't to wait for it at least
+	node.queue = deps[0].queue
+	for _, dep := range deps[1:] {
+		if dep.queue != node.queue {
+			node.deps = append(node.deps, dep)
+		}
 	}
-	return nil
+	return node
 }
 
-func (c *causality) get(key string) string {
-	return c.relations[key]
+// finish marks the job of node finished, jobs depend on it can be executed
+func (c *causality) finish(node *causalNode) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, key := range node.keys {
+		if c.relations[key] == node {
+			delete(c.relations, key)
+		}
+	}
+	close(node.done)
 }
 
+// reset resets all relations, it should be called only after all jobs finished
 func (c *causality) reset() {
-	c.relations = make(map[string]string)
+	c.Lock()
+	defer c.Unlock()
+	c.relations = make(map[string]*causalNode)
 }
 
-// detectConflict detects whether there is a conflict
-func (c *causality) detectConflict(keys []string) bool {
-	if len(keys) == 0 {
-		return false
+// len returns count of keys with unfinished jobs
+func (c *causality) len() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.relations)
+}
+
+// wait waits for dependencies in other queues finished
+func (n *causalNode) wait() {
+	for _, dep := range n.deps {
+		<-dep.done
 	}
+}
 
-	var existedRelation string
-	for _, key := range keys {
-		if val, ok := c.relations[key]; ok {
-			if existedRelation != "" && val != existedRelation {
-				return true
-			}
-			existedRelation = val
+func containsNode(nodes []*causalNode, node *causalNode) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
 		}
 	}
-
 	return false
 }
@@ -14,25 +14,67 @@
 package syncer
 
 import (
+	"time"
+
 	. "github.com/pingcap/check"
 )
 
-func (s *testSyncerSuite) TestCausality(c *C) {
-	ca := newCausality()
-	caseData := []string{"test_1", "test_2", "test_3"}
-	excepted := map[string]string{
-		"test_1": "test_1",
-		"test_2": "test_1",
-		"test_3": "test_1",
+var _ = Suite(&testCausalitySuite{})
+
+type testCausalitySuite struct{}
+
+func (t *testCausalitySuite) TestCausality(c *C) {
+	ca := newCausality(16)
+
+	// jobs without dependency
+	n1 := ca.add([]string{"test_1", "test_2", "test_3"})
+	c.Assert(n1.deps, HasLen, 0)
+	c.Assert(ca.len(), Equals, 3)
+	n4 := ca.add([]string{"test_4"})
+	c.Assert(n4.deps, HasLen, 0)
+	for n4.queue == n1.queue {
+		// find a key in another queue
+		ca.finish(n4)
+		n4 = ca.add([]string{n4.keys[0] + "_"})
 	}
-	c.Assert(ca.add(caseData), IsNil)
-	c.Assert(ca.relations, DeepEquals, excepted)
-	c.Assert(ca.add([]string{"test_4"}), IsNil)
-	excepted["test_4"] = "test_4"
-	c.Assert(ca.relations, DeepEquals, excepted)
-	conflictData := []string{"test_4", "test_3"}
-	c.Assert(ca.detectConflict(conflictData), IsTrue)
-	c.Assert(ca.add(conflictData), NotNil)
+	c.Assert(ca.len(), Equals, 4)
+
+	// depends on jobs in the same queue, dispatched into the queue and no need to wait
+	n5 := ca.add([]string{"test_2", "test_5"})
+	c.Assert(n5.queue, Equals, n1.queue)
+	c.Assert(n5.deps, HasLen, 0)
+
+	// depends on jobs in different queues
+	n6 := ca.add([]string{"test_5", n4.keys[0], "test_1"})
+	c.Assert(n6.queue, Equals, n5.queue)
+	c.Assert(n6.deps, DeepEquals, []*causalNode{n4})
+
+	waited := make(chan struct{})
+	go func() {
+		n6.wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		c.Fatal("should wait for dependencies")
+	case <-time.After(10 * time.Millisecond):
+	}
+	ca.finish(n4)
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		c.Fatal("wait for finished dependencies timeout")
+	}
+
+	// keys are removed after the latest job of them finished
+	ca.finish(n1)
+	c.Assert(ca.len(), Equals, 4) // test_3 removed
+	ca.finish(n5)
+	c.Assert(ca.len(), Equals, 3) // test_2 removed
+	ca.finish(n6)
+	c.Assert(ca.len(), Equals, 0)
+	c.Assert(ca.add([]string{"test_1"}).deps, HasLen, 0)
+
 	ca.reset()
-	c.Assert(ca.relations, HasLen, 0)
+	c.Assert(ca.len(), Equals, 0)
 }
@@ -14,6 +14,7 @@
 package syncer
 
 import (
+	"database/sql"
 	"fmt"
 	"path"
 	"sync"
@@ -24,6 +25,7 @@ import (
 	"github.com/siddontang/go-mysql/mysql"
 
 	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/utils"
 )
 
@@ -45,48 +47,83 @@ var (
 	maxCheckPointSaveTime = 30 * time.Second
 )
 
-// NOTE: now we sync from relay log, so not add GTID support yet
+// binlogPoint is a binlog position with the GTID set executed until it,
+// GTID set is nil if GTID is not enabled for syncing.
+// for table's checkpoint, it also holds the tracked structure of the table at the position
 type binlogPoint struct {
 	sync.RWMutex
 	mysql.Position
+	gtidSet   gtid.Set
+	tableInfo string // tracked table structure in JSON, empty if not tracked
 
-	flushedPos mysql.Position // pos which flushed permanently
+	flushedPos       mysql.Position // pos which flushed permanently
+	flushedGTIDSet   gtid.Set
+	flushedTableInfo string
 }
 
-func newBinlogPoint(pos mysql.Position, flushedPos mysql.Position) *binlogPoint {
+func newBinlogPoint(pos mysql.Position, gs gtid.Set, flushedPos mysql.Position, flushedGS gtid.Set) *binlogPoint {
 	return &binlogPoint{
-		Position:   pos,
-		flushedPos: flushedPos,
+		Position:       pos,
+		gtidSet:        cloneGTIDSet(gs),
+		flushedPos:     flushedPos,
+		flushedGTIDSet: cloneGTIDSet(flushedGS),
 	}
 }
 
-func (b *binlogPoint) save(pos mysql.Position) {
+// save saves pos and GTID set, GTID set keeps unchanged if gs is nil
+func (b *binlogPoint) save(pos mysql.Position, gs gtid.Set) {
 	b.Lock()
 	defer b.Unlock()
-	if pos.Compare(b.Position) < 0 {
+	if pos.Compare(b.Position) < 0 && !newerGTIDSet(gs, b.gtidSet) {
 		// support to save equal pos, but not older pos
+		// except for newer GTID set, binlog name may change after switched to another upstream by GTID
 		log.Warnf("[binlogPoint] try to save %v is older than current pos %v", pos, b.Position)
 		return
 	}
 	b.Position = pos
+	if gs != nil {
+		b.gtidSet = gs.Clone()
+	}
+}
+
+// saveTableInfo saves tracked table structure at the point
+func (b *binlogPoint) saveTableInfo(info string) {
+	b.Lock()
+	defer b.Unlock()
+	b.tableInfo = info
 }
 
 func (b *binlogPoint) flush() {
 	b.Lock()
 	defer b.Unlock()
 	b.flushedPos = b.Position
+	b.flushedGTIDSet = cloneGTIDSet(b.gtidSet)
+	b.flushedTableInfo = b.tableInfo
 }
 
 func (b *binlogPoint) rollback() {
 	b.Lock()
 	defer b.Unlock()
 	b.Position = b.flushedPos
+	b.gtidSet = cloneGTIDSet(b.flushedGTIDSet)
+	b.tableInfo = b.flushedTableInfo
 }
 
 func (b *binlogPoint) outOfDate() bool {
 	b.RLock()
 	defer b.RUnlock()
-	return b.Position.Compare(b.flushedPos) > 0
+	return b.Position.Compare(b.flushedPos) != 0 || !equalGTIDSet(b.gtidSet, b.flushedGTIDSet) || b.tableInfo != b.flushedTableInfo
+}
+
+// isNewer checks whether pos with GTID set gs is newer than the point,
+// GTID set is compared first if both have GTID set
+func (b *binlogPoint) isNewer(pos mysql.Position, gs gtid.Set) bool {
+	b.RLock()
+	defer b.RUnlock()
+	if gs != nil && b.gtidSet != nil && !equalGTIDSet(gs, b.gtidSet) {
+		return !b.gtidSet.Contain(gs)
+	}
+	return pos.Compare(b.Position) > 0
 }
 
 // MySQLPos returns point as mysql.Position
@@ -103,11 +140,64 @@ func (b *binlogPoint) FlushedMySQLPos() mysql.Position {
 	return b.flushedPos
 }
 
+// GTIDSet returns a copy of GTID set of the point, nil if no GTID set
+func (b *binlogPoint) GTIDSet() gtid.Set {
+	b.RLock()
+	defer b.RUnlock()
+	return cloneGTIDSet(b.gtidSet)
+}
+
+// FlushedGTIDSet returns a copy of GTID set of the flushed point, nil if no GTID set
+func (b *binlogPoint) FlushedGTIDSet() gtid.Set {
+	b.RLock()
+	defer b.RUnlock()
+	return cloneGTIDSet(b.flushedGTIDSet)
+}
+
+// TableInfo returns tracked table structure at the point, empty if not tracked
+func (b *binlogPoint) TableInfo() string {
+	b.RLock()
+	defer b.RUnlock()
+	return b.tableInfo
+}
+
 func (b *binlogPoint) String() string {
 	b.RLock()
 	defer b.RUnlock()
 
-	return fmt.Sprintf("%v(flushed %v)", b.Position, b.flushedPos)
+	if b.gtidSet == nil && b.flushedGTIDSet == nil {
+		return fmt.Sprintf("%v(flushed %v)", b.Position, b.flushedPos)
+	}
+	return fmt.Sprintf("%v %s(flushed %v %s)", b.Position, gtidSetString(b.gtidSet), b.flushedPos, gtidSetString(b.flushedGTIDSet))
+}
+
+func cloneGTIDSet(gs gtid.Set) gtid.Set {
+	if gs == nil {
+		return nil
+	}
+	return gs.Clone()
+}
+
+func equalGTIDSet(gs1, gs2 gtid.Set) bool {
+	if gs1 == nil || gs2 == nil {
+		return gs1 == nil && gs2 == nil
+	}
+	return gs1.Equal(gs2)
+}
+
+// newerGTIDSet checks whether gs1 contains all GTIDs in gs2 and more
+func newerGTIDSet(gs1, gs2 gtid.Set) bool {
+	if gs1 == nil || gs2 == nil {
+		return false
+	}
+	return gs1.Contain(gs2) && !gs1.Equal(gs2)
+}
+
+func gtidSetString(gs gtid.Set) string {
+	if gs == nil {
+		return ""
+	}
+	return gs.String()
 }
 
 // CheckPoint represents checkpoints status for syncer
@@ -133,17 +223,27 @@ type CheckPoint interface {
 	LoadMeta() error
 
 	// SaveTablePoint saves checkpoint for specified table in memory
-	SaveTablePoint(sourceSchema, sourceTable string, pos mysql.Position)
+	// gs is the GTID set executed until pos, nil if GTID is not enabled
+	SaveTablePoint(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set)
+
+	// SaveTableInfo saves tracked structure (in JSON) of specified table along with its checkpoint in memory
+	// it should be called after the table's checkpoint saved, empty info means the table is not tracked
+	SaveTableInfo(sourceSchema, sourceTable string, info string)
+
+	// TableInfo returns tracked structure (in JSON) of specified table saved along with its checkpoint
+	// empty if not saved
+	TableInfo(sourceSchema, sourceTable string) string
 
 	// DeleteTablePoint deletes checkpoint for specified table in memory and storage
 	DeleteTablePoint(sourceSchema, sourceTable string) error
 
 	// IsNewerTablePoint checks whether job's checkpoint is newer than previous saved checkpoint
-	IsNewerTablePoint(sourceSchema, sourceTable string, pos mysql.Position) bool
+	IsNewerTablePoint(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set) bool
 
 	// SaveGlobalPoint saves the global binlog stream's checkpoint
+	// gs is the GTID set executed until pos, nil if GTID is not enabled or unknown
 	// corresponding to Meta.Save
-	SaveGlobalPoint(pos mysql.Position)
+	SaveGlobalPoint(pos mysql.Position, gs gtid.Set)
 
 	// FlushGlobalPointsExcept flushes the global checkpoint and tables' checkpoints except exceptTables
 	// @exceptTables: [[schema, table]... ]
@@ -158,6 +258,13 @@ type CheckPoint interface {
 	// corresponding to to Meta.Pos
 	FlushedGlobalPoint() mysql.Position
 
+	// GlobalPointGTID returns the GTID set of the global binlog stream's checkpoint, nil if no GTID set saved
+	// corresponding to Meta.GTID
+	GlobalPointGTID() gtid.Set
+
+	// FlushedGlobalPointGTID returns the GTID set of the flushed global binlog stream's checkpoint
+	FlushedGlobalPointGTID() gtid.Set
+
 	// CheckGlobalPoint checks whether we should save global checkpoint
 	// corresponding to Meta.Check
 	CheckGlobalPoint() bool
@@ -171,13 +278,16 @@ type CheckPoint interface {
 	// @tables: [[schema, table]... ]
 	GenUpdateForTableSQLs(tables [][]string) ([]string, [][]interface{})
 
+	// GenUpdateForPointSQL generates checkpoint SQL for specified position of the table, or global checkpoint if isGlobal is true
+	// it's used to save checkpoints in the same transaction with DMLs when `exactly-once` enabled, in-memory checkpoints are not changed
+	GenUpdateForPointSQL(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set, isGlobal bool) (string, []interface{})
+
 	// String return text of global position
 	String() string
 }
 
 // RemoteCheckPoint implements CheckPoint
 // which using target database to store info
-// NOTE: now we sync from relay log, so not add GTID support yet
 type RemoteCheckPoint struct {
 	sync.RWMutex
 
@@ -210,7 +320,7 @@ func NewRemoteCheckPoint(cfg *config.SubTaskConfig, id string) CheckPoint {
 		table:       fmt.Sprintf("%s_syncer_checkpoint", cfg.Name),
 		id:          id,
 		points:      make(map[string]map[string]*binlogPoint),
-		globalPoint: newBinlogPoint(minCheckpoint, minCheckpoint),
+		globalPoint: newBinlogPoint(minCheckpoint, nil, minCheckpoint, nil),
 	}
 
 	return cp
@@ -250,7 +360,7 @@ func (cp *RemoteCheckPoint) Clear() error {
 		return errors.Trace(err)
 	}
 
-	cp.globalPoint = newBinlogPoint(minCheckpoint, minCheckpoint)
+	cp.globalPoint = newBinlogPoint(minCheckpoint, nil, minCheckpoint, nil)
 
 	cp.points = make(map[string]map[string]*binlogPoint)
 
@@ -258,15 +368,15 @@ func (cp *RemoteCheckPoint) Clear() error {
 }
 
 // SaveTablePoint implements CheckPoint.SaveTablePoint
-func (cp *RemoteCheckPoint) SaveTablePoint(sourceSchema, sourceTable string, pos mysql.Position) {
+func (cp *RemoteCheckPoint) SaveTablePoint(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set) {
 	cp.Lock()
 	defer cp.Unlock()
-	cp.saveTablePoint(sourceSchema, sourceTable, pos)
+	cp.saveTablePoint(sourceSchema, sourceTable, pos, gs)
 }
 
 // saveTablePoint saves single table's checkpoint without mutex.Lock
-func (cp *RemoteCheckPoint) saveTablePoint(sourceSchema, sourceTable string, pos mysql.Position) {
-	if cp.globalPoint.Compare(pos) > 0 {
+func (cp *RemoteCheckPoint) saveTablePoint(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set) {
+	if cp.globalPoint.Compare(pos) > 0 && !newerGTIDSet(gs, cp.globalPoint.GTIDSet()) {
 		panic(fmt.Sprintf("table checkpoint %+v less than global checkpoint %+v", pos, cp.globalPoint))
 	}
 
@@ -279,10 +389,33 @@ func (cp *RemoteCheckPoint) saveTablePoint(sourceSchema, sourceTable string, pos
 	}
 	point, ok := mSchema[sourceTable]
 	if !ok {
-		mSchema[sourceTable] = newBinlogPoint(pos, minCheckpoint)
+		mSchema[sourceTable] = newBinlogPoint(pos, gs, minCheckpoint, nil)
 	} else {
-		point.save(pos)
+		point.save(pos, gs)
+	}
+}
+
+// SaveTableInfo implements CheckPoint.SaveTableInfo
+func (cp *RemoteCheckPoint) SaveTableInfo(sourceSchema, sourceTable string, info string) {
+	cp.RLock()
+	defer cp.RUnlock()
+	point, ok := cp.points[sourceSchema][sourceTable]
+	if !ok {
+		log.Warnf("[checkpoint] no checkpoint for table %s.%s, ignore saving its structure", sourceSchema, sourceTable)
+		return
+	}
+	point.saveTableInfo(info)
+}
+
+// TableInfo implements CheckPoint.TableInfo
+func (cp *RemoteCheckPoint) TableInfo(sourceSchema, sourceTable string) string {
+	cp.RLock()
+	defer cp.RUnlock()
+	point, ok := cp.points[sourceSchema][sourceTable]
+	if !ok {
+		return ""
 	}
+	return point.TableInfo()
 }
 
 // DeleteTablePoint implements CheckPoint.DeleteTablePoint
@@ -309,7 +442,7 @@ func (cp *RemoteCheckPoint) DeleteTablePoint(sourceSchema, sourceTable string) e
 }
 
 // IsNewerTablePoint implements CheckPoint.IsNewerTablePoint
-func (cp *RemoteCheckPoint) IsNewerTablePoint(sourceSchema, sourceTable string, pos mysql.Position) bool {
+func (cp *RemoteCheckPoint) IsNewerTablePoint(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set) bool {
 	cp.RLock()
 	defer cp.RUnlock()
 	mSchema, ok := cp.points[sourceSchema]
@@ -320,15 +453,14 @@ func (cp *RemoteCheckPoint) IsNewerTablePoint(sourceSchema, sourceTable string,
 	if !ok {
 		return true
 	}
-	oldPos := point.MySQLPos()
-	return pos.Compare(oldPos) > 0
+	return point.isNewer(pos, gs)
 }
 
 // SaveGlobalPoint implements CheckPoint.SaveGlobalPoint
-func (cp *RemoteCheckPoint) SaveGlobalPoint(pos mysql.Position) {
+func (cp *RemoteCheckPoint) SaveGlobalPoint(pos mysql.Position, gs gtid.Set) {
 	cp.Lock()
 	defer cp.Unlock()
-	cp.globalPoint.save(pos)
+	cp.globalPoint.save(pos, gs)
 }
 
 // FlushPointsExcept implements CheckPoint.FlushPointsExcept
@@ -353,7 +485,7 @@ func (cp *RemoteCheckPoint) FlushPointsExcept(exceptTables [][]string) error {
 
 	if cp.globalPoint.outOfDate() {
 		posG := cp.GlobalPoint()
-		sqlG, argG := cp.genUpdateSQL(globalCpSchema, globalCpTable, posG.Name, posG.Pos, true)
+		sqlG, argG := cp.genUpdateSQL(globalCpSchema, globalCpTable, posG, cp.GlobalPointGTID(), "", true)
 		sqls = append(sqls, sqlG)
 		args = append(args, argG)
 	}
@@ -368,8 +500,7 @@ func (cp *RemoteCheckPoint) FlushPointsExcept(exceptTables [][]string) error {
 				}
 			}
 			if point.outOfDate() {
-				pos := point.MySQLPos()
-				sql2, arg := cp.genUpdateSQL(schema, table, pos.Name, pos.Pos, false)
+				sql2, arg := cp.genUpdateSQL(schema, table, point.MySQLPos(), point.GTIDSet(), point.TableInfo(), false)
 				sqls = append(sqls, sql2)
 				args = append(args, arg)
 
@@ -403,6 +534,16 @@ func (cp *RemoteCheckPoint) FlushedGlobalPoint() mysql.Position {
 	return cp.globalPoint.FlushedMySQLPos()
 }
 
+// GlobalPointGTID implements CheckPoint.GlobalPointGTID
+func (cp *RemoteCheckPoint) GlobalPointGTID() gtid.Set {
+	return cp.globalPoint.GTIDSet()
+}
+
+// FlushedGlobalPointGTID implements CheckPoint.FlushedGlobalPointGTID
+func (cp *RemoteCheckPoint) FlushedGlobalPointGTID() gtid.Set {
+	return cp.globalPoint.FlushedGTIDSet()
+}
+
 // String implements CheckPoint.String
 func (cp *RemoteCheckPoint) String() string {
 	return cp.globalPoint.String()
@@ -444,14 +585,23 @@ func (cp *RemoteCheckPoint) GenUpdateForTableSQLs(tables [][]string) ([]string,
 		if !ok {
 			continue
 		}
-		pos := point.MySQLPos()
-		sql2, arg := cp.genUpdateSQL(schema, table, pos.Name, pos.Pos, false)
+		sql2, arg := cp.genUpdateSQL(schema, table, point.MySQLPos(), point.GTIDSet(), point.TableInfo(), false)
 		sqls = append(sqls, sql2)
 		args = append(args, arg)
 	}
 	return sqls, args
 }
 
+// GenUpdateForPointSQL implements CheckPoint.GenUpdateForPointSQL
+func (cp *RemoteCheckPoint) GenUpdateForPointSQL(sourceSchema, sourceTable string, pos mysql.Position, gs gtid.Set, isGlobal bool) (string, []interface{}) {
+	var tableInfo string
+	if !isGlobal {
+		// keep the tracked table structure
+		tableInfo = cp.TableInfo(sourceSchema, sourceTable)
+	}
+	return cp.genUpdateSQL(sourceSchema, sourceTable, pos, gs, tableInfo, isGlobal)
+}
+
 func (cp *RemoteCheckPoint) prepare() error {
 	if err := cp.createSchema(); err != nil {
 		return errors.Trace(err)
@@ -479,6 +629,8 @@ func (cp *RemoteCheckPoint) createTable() error {
 			cp_table VARCHAR(128) NOT NULL,
 			binlog_name VARCHAR(128),
 			binlog_pos INT UNSIGNED,
+			binlog_gtid TEXT,
+			table_info TEXT,
 			is_global BOOLEAN,
 			create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -487,12 +639,28 @@ func (cp *RemoteCheckPoint) createTable() error {
 	args := make([]interface{}, 0)
 	err := cp.db.executeSQL([]string{sql2}, [][]interface{}{args}, maxRetryCount)
 	log.Infof("[syncer] %s", sql2)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// add `binlog_gtid` and `table_info` for checkpoint table created by previous version
+	for _, col := range []string{"binlog_gtid TEXT AFTER binlog_pos", "table_info TEXT AFTER binlog_gtid"} {
+		sql2 = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, col)
+		err = cp.db.executeSQL([]string{sql2}, [][]interface{}{args}, maxRetryCount)
+		if utils.IsErrDupFieldName(err) {
+			continue
+		}
+		log.Infof("[syncer] %s", sql2)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // Load implements CheckPoint.Load
 func (cp *RemoteCheckPoint) Load() error {
-	query := fmt.Sprintf("SELECT `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `is_global` FROM `%s`.`%s` WHERE `id`='%s'", cp.schema, cp.table, cp.id)
+	query := fmt.Sprintf("SELECT `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `binlog_gtid`, `table_info`, `is_global` FROM `%s`.`%s` WHERE `id`='%s'", cp.schema, cp.table, cp.id)
 	rows, err := cp.db.querySQL(query, maxRetryCount)
 	if err != nil {
 		return errors.Trace(err)
@@ -506,10 +674,12 @@ func (cp *RemoteCheckPoint) Load() error {
 		cpTable    string
 		binlogName string
 		binlogPos  uint32
+		binlogGTID sql.NullString
+		tableInfo  sql.NullString
 		isGlobal   bool
 	)
 	for rows.Next() {
-		err := rows.Scan(&cpSchema, &cpTable, &binlogName, &binlogPos, &isGlobal)
+		err := rows.Scan(&cpSchema, &cpTable, &binlogName, &binlogPos, &binlogGTID, &tableInfo, &isGlobal)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -517,34 +687,50 @@ func (cp *RemoteCheckPoint) Load() error {
 			Name: binlogName,
 			Pos:  binlogPos,
 		}
-		if isGlobal {
-			if pos.Compare(minCheckpoint) > 0 {
-				cp.globalPoint = newBinlogPoint(pos, pos)
-				log.Infof("[checkpoint] get global checkpoint %+v from DB", cp.globalPoint)
-			}
-			continue // skip global checkpoint
-		}
-		mSchema, ok := cp.points[cpSchema]
-		if !ok {
-			mSchema = make(map[string]*binlogPoint)
-			cp.points[cpSchema] = mSchema
+		err = cp.loadPoint(cpSchema, cpTable, pos, binlogGTID.String, tableInfo.String, isGlobal)
+		if err != nil {
+			return errors.Trace(err)
 		}
-		mSchema[cpTable] = newBinlogPoint(pos, pos)
 	}
 	return errors.Trace(rows.Err())
 }
 
+// loadPoint loads a flushed checkpoint of the table, or the global checkpoint if isGlobal is true
+func (cp *RemoteCheckPoint) loadPoint(cpSchema, cpTable string, pos mysql.Position, gtidStr, tableInfo string, isGlobal bool) error {
+	gs, err := cp.parseGTIDSet(gtidStr)
+	if err != nil {
+		return errors.Annotatef(err, "checkpoint of %s.%s", cpSchema, cpTable)
+	}
+	if isGlobal {
+		if pos.Compare(minCheckpoint) > 0 {
+			cp.globalPoint = newBinlogPoint(pos, gs, pos, gs)
+			log.Infof("[checkpoint] get global checkpoint %+v", cp.globalPoint)
+		}
+		return nil
+	}
+	mSchema, ok := cp.points[cpSchema]
+	if !ok {
+		mSchema = make(map[string]*binlogPoint)
+		cp.points[cpSchema] = mSchema
+	}
+	point := newBinlogPoint(pos, gs, pos, gs)
+	point.tableInfo, point.flushedTableInfo = tableInfo, tableInfo
+	mSchema[cpTable] = point
+	return nil
+}
+
 // LoadMeta implements CheckPoint.LoadMeta
 func (cp *RemoteCheckPoint) LoadMeta() error {
 	var (
-		pos *mysql.Position
-		err error
+		pos     *mysql.Position
+		gtidStr string
+		err     error
 	)
 	switch cp.cfg.Mode {
 	case config.ModeAll:
 		// NOTE: syncer must continue the syncing follow loader's tail, so we parse mydumper's output
 		// refine when master / slave switching added and checkpoint mechanism refactored
-		pos, err = cp.parseMetaData()
+		pos, gtidStr, err = cp.parseMetaData()
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -560,6 +746,7 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 			Name: cp.cfg.Meta.BinLogName,
 			Pos:  cp.cfg.Meta.BinLogPos,
 		}
+		gtidStr = cp.cfg.Meta.BinLogGTID
 	default:
 		// (only used by syncer singleton) load meta from meta-file
 		if len(cp.cfg.MetaFile) == 0 {
@@ -572,11 +759,18 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 		}
 		pos2 := meta.Pos()
 		pos = &pos2
+		if gs := meta.GTID(); gs != nil {
+			gtidStr = gs.String()
+		}
 	}
 
 	// if meta loaded, we will start syncing from meta's pos
 	if pos != nil {
-		cp.globalPoint = newBinlogPoint(*pos, *pos)
+		gs, err := cp.parseGTIDSet(gtidStr)
+		if err != nil {
+			return errors.Annotate(err, "meta")
+		}
+		cp.globalPoint = newBinlogPoint(*pos, gs, *pos, gs)
 		log.Infof("[checkpoint] loaded checkpoints %+v from meta", cp.globalPoint)
 	}
 
@@ -584,22 +778,45 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 }
 
 // genUpdateSQL generates SQL and arguments for update checkpoint
-func (cp *RemoteCheckPoint) genUpdateSQL(cpSchema, cpTable string, binlogName string, binlogPos uint32, isGlobal bool) (string, []interface{}) {
+// tableInfo is the tracked table structure in JSON, saved as NULL if empty
+func (cp *RemoteCheckPoint) genUpdateSQL(cpSchema, cpTable string, pos mysql.Position, gs gtid.Set, tableInfo string, isGlobal bool) (string, []interface{}) {
 	// use `INSERT INTO ... ON DUPLICATE KEY UPDATE` rather than `REPLACE INTO`
 	// to keep `create_time`, `update_time` correctly
-	sql2 := fmt.Sprintf("INSERT INTO `%s`.`%s` (`id`, `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `is_global`) VALUES(?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `binlog_name`=?, `binlog_pos`=?",
+	sql2 := fmt.Sprintf("INSERT INTO `%s`.`%s` (`id`, `cp_schema`, `cp_table`, `binlog_name`, `binlog_pos`, `binlog_gtid`, `table_info`, `is_global`) VALUES(?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `binlog_name`=?, `binlog_pos`=?, `binlog_gtid`=?, `table_info`=?",
 		cp.schema, cp.table)
 	if isGlobal {
 		cpSchema = globalCpSchema
 		cpTable = globalCpTable
 	}
-	args := []interface{}{cp.id, cpSchema, cpTable, binlogName, binlogPos, isGlobal, binlogName, binlogPos}
+	var binlogGTID interface{} // NULL if no GTID set
+	if gs != nil {
+		binlogGTID = gs.String()
+	}
+	var info interface{} // NULL if not tracked
+	if len(tableInfo) > 0 {
+		info = tableInfo
+	}
+	args := []interface{}{cp.id, cpSchema, cpTable, pos.Name, pos.Pos, binlogGTID, info, isGlobal, pos.Name, pos.Pos, binlogGTID, info}
 	return sql2, args
 }
 
-func (cp *RemoteCheckPoint) parseMetaData() (*mysql.Position, error) {
+// parseGTIDSet parses GTID set saved in checkpoint, returns nil for empty GTID set
+func (cp *RemoteCheckPoint) parseGTIDSet(gtidStr string) (gtid.Set, error) {
+	if len(gtidStr) == 0 {
+		return nil, nil
+	}
+	gs, err := gtid.ParserGTID(cp.cfg.Flavor, gtidStr)
+	return gs, errors.Trace(err)
+}
+
+func (cp *RemoteCheckPoint) parseMetaData() (*mysql.Position, string, error) {
 	// `metadata` is mydumper's output meta file name
 	filename := path.Join(cp.cfg.Dir, "metadata")
 	log.Infof("parsing metadata from %s", filename)
-	return utils.ParseMetaData(filename)
+	pos, err := utils.ParseMetaData(filename)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	gtidStr, err := utils.ParseMetaDataGTID(filename)
+	return pos, gtidStr, errors.Trace(err)
 }
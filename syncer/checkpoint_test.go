@@ -23,6 +23,7 @@ import (
 	"github.com/siddontang/go-mysql/mysql"
 
 	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/gtid"
 )
 
 // NOTE: there are binlog events conflict with other test cases
@@ -103,7 +104,7 @@ func (s *testSyncerSuite) testGlobalCheckPoint(c *C, cp CheckPoint) {
 		Name: "mysql-bin.000005",
 		Pos:  2052,
 	}
-	cp.SaveGlobalPoint(pos2)
+	cp.SaveGlobalPoint(pos2, nil)
 	c.Assert(cp.GlobalPoint(), Equals, pos2)
 	c.Assert(cp.FlushedGlobalPoint(), Equals, pos1)
 
@@ -113,7 +114,7 @@ func (s *testSyncerSuite) testGlobalCheckPoint(c *C, cp CheckPoint) {
 	c.Assert(cp.FlushedGlobalPoint(), Equals, pos1)
 
 	// save again
-	cp.SaveGlobalPoint(pos2)
+	cp.SaveGlobalPoint(pos2, nil)
 	c.Assert(cp.GlobalPoint(), Equals, pos2)
 	c.Assert(cp.FlushedGlobalPoint(), Equals, pos1)
 
@@ -126,7 +127,7 @@ func (s *testSyncerSuite) testGlobalCheckPoint(c *C, cp CheckPoint) {
 	// try load from DB
 	pos3 := pos2
 	pos3.Pos = pos2.Pos + 1000 // > pos2 to enable save
-	cp.SaveGlobalPoint(pos3)
+	cp.SaveGlobalPoint(pos3, nil)
 	err = cp.Load()
 	c.Assert(err, IsNil)
 	c.Assert(cp.GlobalPoint(), Equals, pos2)
@@ -159,44 +160,118 @@ func (s *testSyncerSuite) testTableCheckPoint(c *C, cp CheckPoint) {
 	)
 
 	// not exist
-	newer := cp.IsNewerTablePoint(schema, table, pos1)
+	newer := cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsTrue)
 
 	// save
-	cp.SaveTablePoint(schema, table, pos2)
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	cp.SaveTablePoint(schema, table, pos2, nil)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsFalse)
 
 	// rollback, to min
 	cp.Rollback()
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsTrue)
 
 	// save again
-	cp.SaveTablePoint(schema, table, pos2)
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	cp.SaveTablePoint(schema, table, pos2, nil)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsFalse)
 
 	// flush + rollback
 	cp.FlushPointsExcept(nil)
 	cp.Rollback()
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsFalse)
 
 	// clear, to min
 	err := cp.Clear()
 	c.Assert(err, IsNil)
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsTrue)
 
 	// save
-	cp.SaveTablePoint(schema, table, pos2)
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	cp.SaveTablePoint(schema, table, pos2, nil)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsFalse)
 
 	// flush but except + rollback
 	cp.FlushPointsExcept([][]string{{schema, table}})
 	cp.Rollback()
-	newer = cp.IsNewerTablePoint(schema, table, pos1)
+	newer = cp.IsNewerTablePoint(schema, table, pos1, nil)
 	c.Assert(newer, IsTrue)
 }
+
+func (t *testUtilSuite) TestBinlogPointGTID(c *C) {
+	parseGTID := func(gtidStr string) gtid.Set {
+		gs, err := gtid.ParserGTID(mysql.MySQLFlavor, gtidStr)
+		c.Assert(err, IsNil)
+		return gs
+	}
+	var (
+		pos1 = mysql.Position{Name: "mysql-bin.000003", Pos: 1943}
+		pos2 = mysql.Position{Name: "mysql-bin.000003", Pos: 2052}
+		pos3 = mysql.Position{Name: "mysql-bin.000001", Pos: 123} // binlog of another upstream
+		gs1  = parseGTID("53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-14")
+		gs2  = parseGTID("53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-15")
+		gs3  = parseGTID("53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-16")
+	)
+
+	point := newBinlogPoint(pos1, gs1, pos1, gs1)
+	c.Assert(point.outOfDate(), IsFalse)
+	c.Assert(point.isNewer(pos2, nil), IsTrue)
+	c.Assert(point.isNewer(pos2, gs1), IsTrue)
+	c.Assert(point.isNewer(pos3, gs2), IsTrue)
+	c.Assert(point.isNewer(pos2, parseGTID("53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-10")), IsFalse)
+
+	// save and rollback
+	point.save(pos2, gs2)
+	c.Assert(point.outOfDate(), IsTrue)
+	c.Assert(point.MySQLPos(), Equals, pos2)
+	c.Assert(point.GTIDSet().Equal(gs2), IsTrue)
+	point.rollback()
+	c.Assert(point.MySQLPos(), Equals, pos1)
+	c.Assert(point.GTIDSet().Equal(gs1), IsTrue)
+
+	// older pos with the same GTID set is not saved, GTID set keeps unchanged if nil
+	point.save(pos2, gs2)
+	point.flush()
+	c.Assert(point.outOfDate(), IsFalse)
+	point.save(pos1, gs2)
+	c.Assert(point.MySQLPos(), Equals, pos2)
+	point.save(pos2, nil)
+	c.Assert(point.GTIDSet().Equal(gs2), IsTrue)
+
+	// older pos with newer GTID set is saved after switched to another upstream
+	point.save(pos3, gs3)
+	c.Assert(point.MySQLPos(), Equals, pos3)
+	c.Assert(point.GTIDSet().Equal(gs3), IsTrue)
+	c.Assert(point.outOfDate(), IsTrue)
+	c.Assert(point.FlushedGTIDSet().Equal(gs2), IsTrue)
+
+	// no GTID set
+	point = newBinlogPoint(pos1, nil, pos1, nil)
+	c.Assert(point.GTIDSet(), IsNil)
+	c.Assert(point.isNewer(pos3, gs3), IsFalse)
+	point.save(pos3, gs3)
+	c.Assert(point.MySQLPos(), Equals, pos1)
+}
+
+func (t *testUtilSuite) TestBinlogPointTableInfo(c *C) {
+	pos := mysql.Position{Name: "mysql-bin.000003", Pos: 1943}
+	point := newBinlogPoint(pos, nil, pos, nil)
+	c.Assert(point.TableInfo(), Equals, "")
+
+	point.saveTableInfo(`{"columns":[]}`)
+	c.Assert(point.outOfDate(), IsTrue)
+	c.Assert(point.TableInfo(), Equals, `{"columns":[]}`)
+	point.rollback()
+	c.Assert(point.TableInfo(), Equals, "")
+
+	point.saveTableInfo(`{"columns":[]}`)
+	point.flush()
+	c.Assert(point.outOfDate(), IsFalse)
+	point.saveTableInfo("")
+	point.rollback()
+	c.Assert(point.TableInfo(), Equals, `{"columns":[]}`)
+}
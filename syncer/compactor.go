@@ -0,0 +1,275 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxMergedRows is the max count of rows merged into one multi-value statement
+var maxMergedRows = 1024
+
+// rowChange is the change of a row, used to compact changes when `compact-dml` enabled,
+// or to write row changes by sinks not executing SQLs (like the file sink).
+// it's only compactable for tables with only one unique index (primary key or not null unique key),
+// so changes of different rows never conflict with each other and can be re-ordered.
+// an update in safe mode is executed as DELETE and REPLACE, same as `genUpdateSQLs`
+type rowChange struct {
+	tp         opType // insert (as REPLACE), update or del
+	safeMode   bool   // whether generated in safe mode
+	schema     string // target schema
+	table      string // target table
+	columns    []*column
+	values     []interface{} // values of columns after changed, nil for del
+	keyColumns []*column     // columns of the unique index, nil if not compactable
+	oldKey     []interface{} // values of keyColumns before changed, nil for insert
+	newKey     []interface{} // values of keyColumns after changed, nil for del
+
+	originalColumns []*column     // all columns
+	before          []interface{} // values of originalColumns before changed, nil for insert
+	after           []interface{} // values of originalColumns after changed, nil for del
+}
+
+// compactable returns the unique index to identify rows if changes of the table can be compacted
+func compactable(indexColumns map[string][]*column) []*column {
+	if len(indexColumns) != 1 {
+		return nil
+	}
+	return findFitIndex(indexColumns)
+}
+
+// newRowChange creates a change of the row, oldRow and newRow are values of all original columns.
+// it returns nil if the table can not be compacted and no row images needed, then the change is executed as is
+func newRowChange(tp opType, param *genDMLParam, keyColumns []*column, values, oldRow, newRow []interface{}) *rowChange {
+	if len(keyColumns) == 0 && !param.rowImages {
+		return nil
+	}
+	change := &rowChange{
+		tp:              tp,
+		safeMode:        param.safeMode,
+		schema:          param.schema,
+		table:           param.table,
+		columns:         param.columns,
+		values:          values,
+		keyColumns:      keyColumns,
+		originalColumns: param.originalColumns,
+		before:          oldRow,
+		after:           newRow,
+	}
+	if oldRow != nil {
+		_, change.oldKey = getColumnData(param.originalColumns, keyColumns, oldRow)
+	}
+	if newRow != nil {
+		_, change.newKey = getColumnData(param.originalColumns, keyColumns, newRow)
+	}
+	return change
+}
+
+// key returns the identity of the changed row
+func (r *rowChange) key() string {
+	values := r.newKey
+	if r.tp == del {
+		values = r.oldKey
+	}
+	return fmt.Sprintf("`%s`.`%s`:%s", r.schema, r.table, genKeyList(r.keyColumns, values))
+}
+
+// split splits an update changing the unique key into delete and insert
+func (r *rowChange) split() []*rowChange {
+	if r.tp != update || genKeyList(r.keyColumns, r.oldKey) == genKeyList(r.keyColumns, r.newKey) {
+		return []*rowChange{r}
+	}
+	delChange := *r
+	delChange.tp, delChange.values, delChange.newKey = del, nil, nil
+	insertChange := *r
+	insertChange.tp, insertChange.oldKey = insert, nil
+	return []*rowChange{&delChange, &insertChange}
+}
+
+// merge merges the later change of the same row into r, the merged change is in safe mode if any of them is
+func (r *rowChange) merge(later *rowChange) *rowChange {
+	merged := *later
+	merged.safeMode = r.safeMode || later.safeMode
+	switch {
+	case r.tp == insert && later.tp == update:
+		// the row is not existed before insert, so it's still an insert
+		merged.tp, merged.oldKey = insert, nil
+	case r.tp == del && later.tp == insert && merged.safeMode:
+		// keep the DELETE in safe mode, like an update split by `genUpdateSQLs`
+		merged.tp, merged.oldKey, merged.before = update, r.oldKey, r.before
+	}
+	// otherwise the latest state of the row is determined by the later change only
+	return &merged
+}
+
+// compactedJob is a compacted change with the latest job of it
+type compactedJob struct {
+	change *rowChange
+	job    *job
+}
+
+// compactJobs compacts changes of the same rows, and merges them into multi-value statements.
+// jobs without changes (like SQLs from operators, or tables can not be compacted) are kept in order,
+// changes between them are compacted in a segment
+func compactJobs(jobs []*job) []*job {
+	var (
+		result  = make([]*job, 0, len(jobs))
+		changes = make(map[string]*compactedJob)
+		order   = make([]string, 0, len(jobs))
+	)
+	flushSegment := func() {
+		if len(order) == 0 {
+			return
+		}
+		compacted := make([]*compactedJob, 0, len(order))
+		for _, key := range order {
+			compacted = append(compacted, changes[key])
+		}
+		result = append(result, mergeCompactedJobs(compacted)...)
+		changes = make(map[string]*compactedJob)
+		order = order[:0]
+	}
+
+	for _, j := range jobs {
+		if j.change == nil || len(j.change.keyColumns) == 0 {
+			flushSegment()
+			result = append(result, j)
+			continue
+		}
+		for _, change := range j.change.split() {
+			key := change.key()
+			if prev, ok := changes[key]; ok {
+				prev.change = prev.change.merge(change)
+				prev.job = j
+				continue
+			}
+			changes[key] = &compactedJob{change: change, job: j}
+			order = append(order, key)
+		}
+	}
+	flushSegment()
+	return result
+}
+
+// mergeCompactedJobs merges compacted changes into multi-value statements.
+// changes are of different rows, so deletes, updates and inserts are generated in order for each table,
+// and updates in safe mode are merged into deletes and inserts
+func mergeCompactedJobs(compacted []*compactedJob) []*job {
+	var (
+		tables   []string
+		tableOps = make(map[string]map[opType][]*compactedJob)
+	)
+	for _, cj := range compacted {
+		table := fmt.Sprintf("`%s`.`%s`", cj.change.schema, cj.change.table)
+		ops, ok := tableOps[table]
+		if !ok {
+			ops = make(map[opType][]*compactedJob)
+			tableOps[table] = ops
+			tables = append(tables, table)
+		}
+		if cj.change.tp == update && cj.change.safeMode {
+			// executed as DELETE and REPLACE in safe mode
+			ops[del] = append(ops[del], cj)
+			ops[insert] = append(ops[insert], cj)
+			continue
+		}
+		ops[cj.change.tp] = append(ops[cj.change.tp], cj)
+	}
+
+	result := make([]*job, 0, len(compacted))
+	for _, table := range tables {
+		ops := tableOps[table]
+		for _, cjs := range splitCompactedJobs(ops[del]) {
+			result = append(result, genMultiDeleteJob(table, cjs))
+		}
+		for _, cj := range ops[update] {
+			result = append(result, genCompactedUpdateJob(table, cj))
+		}
+		for _, cjs := range splitCompactedJobs(ops[insert]) {
+			result = append(result, genMultiReplaceJob(table, cjs))
+		}
+	}
+	return result
+}
+
+// splitCompactedJobs splits changes into groups can be merged into one statement,
+// changes in one group have the same columns, and no more than maxMergedRows
+func splitCompactedJobs(cjs []*compactedJob) [][]*compactedJob {
+	var (
+		groups [][]*compactedJob
+		last   []*compactedJob
+	)
+	for _, cj := range cjs {
+		if len(last) == 0 || len(last) >= maxMergedRows || genColumnList(last[0].change.columns) != genColumnList(cj.change.columns) {
+			if len(last) > 0 {
+				groups = append(groups, last)
+			}
+			last = make([]*compactedJob, 0, len(cjs))
+		}
+		last = append(last, cj)
+	}
+	if len(last) > 0 {
+		groups = append(groups, last)
+	}
+	return groups
+}
+
+// newCompactedJob creates a job for merged changes, use the latest job's information
+func newCompactedJob(tp opType, sql string, args []interface{}, cjs []*compactedJob) *job {
+	latest := cjs[0].job
+	for _, cj := range cjs[1:] {
+		if cj.job.currentPos.Compare(latest.currentPos) > 0 {
+			latest = cj.job
+		}
+	}
+	merged := *latest
+	merged.tp, merged.sql, merged.args, merged.change = tp, sql, args, nil
+	return &merged
+}
+
+func genMultiReplaceJob(table string, cjs []*compactedJob) *job {
+	columns := cjs[0].change.columns
+	placeholders := fmt.Sprintf("(%s)", genColumnPlaceholders(len(columns)))
+	values := make([]string, 0, len(cjs))
+	args := make([]interface{}, 0, len(cjs)*len(columns))
+	for _, cj := range cjs {
+		values = append(values, placeholders)
+		args = append(args, cj.change.values...)
+	}
+	sql := fmt.Sprintf("REPLACE INTO %s (%s) VALUES %s;", table, genColumnList(columns), strings.Join(values, ","))
+	return newCompactedJob(insert, sql, args, cjs)
+}
+
+func genMultiDeleteJob(table string, cjs []*compactedJob) *job {
+	keyColumns := cjs[0].change.keyColumns
+	placeholders := fmt.Sprintf("(%s)", genColumnPlaceholders(len(keyColumns)))
+	values := make([]string, 0, len(cjs))
+	args := make([]interface{}, 0, len(cjs)*len(keyColumns))
+	for _, cj := range cjs {
+		values = append(values, placeholders)
+		args = append(args, cj.change.oldKey...)
+	}
+	sql := fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (%s);", table, genColumnList(keyColumns), strings.Join(values, ","))
+	return newCompactedJob(del, sql, args, cjs)
+}
+
+func genCompactedUpdateJob(table string, cj *compactedJob) *job {
+	change := cj.change
+	args := make([]interface{}, 0, len(change.values)+len(change.oldKey))
+	args = append(args, change.values...)
+	args = append(args, change.oldKey...)
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s LIMIT 1;", table, genKVs(change.columns), genWhere(change.keyColumns, change.oldKey))
+	return newCompactedJob(update, sql, args, []*compactedJob{cj})
+}
@@ -0,0 +1,180 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+)
+
+var _ = Suite(&testCompactorSuite{})
+
+type testCompactorSuite struct {
+	columns []*column
+	pos     uint32
+}
+
+func (t *testCompactorSuite) SetUpTest(c *C) {
+	t.columns = []*column{
+		{idx: 0, name: "id", NotNull: true, tp: "int"},
+		{idx: 1, name: "name", tp: "varchar(20)"},
+	}
+	t.pos = 4
+}
+
+func (t *testCompactorSuite) param(indexColumns map[string][]*column, data ...[]interface{}) *genDMLParam {
+	return &genDMLParam{
+		schema:               "db",
+		table:                "tb",
+		data:                 data,
+		originalData:         data,
+		columns:              t.columns,
+		originalColumns:      t.columns,
+		originalIndexColumns: indexColumns,
+		compact:              true,
+	}
+}
+
+func (t *testCompactorSuite) genJobs(c *C, tp opType, param *genDMLParam) []*job {
+	var (
+		sqls    []string
+		args    [][]interface{}
+		changes []*rowChange
+		err     error
+	)
+	switch tp {
+	case insert:
+		sqls, _, args, changes, err = genInsertSQLs(param)
+	case update:
+		sqls, _, args, changes, err = genUpdateSQLs(param)
+	case del:
+		sqls, _, args, changes, err = genDeleteSQLs(param)
+	}
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, len(sqls))
+
+	jobs := make([]*job, 0, len(sqls))
+	for i := range sqls {
+		t.pos += 10
+		pos := mysql.Position{Name: "mysql-bin.000001", Pos: t.pos}
+		j := newJob(tp, "db", "tb", "db", "tb", sqls[i], args[i], "", pos, pos, nil, "")
+		j.change = changes[i]
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+func (t *testCompactorSuite) TestCompactJobs(c *C) {
+	indexColumns := map[string][]*column{"primary": {t.columns[0]}}
+	var jobs []*job
+	// insert 1, 2, 3
+	jobs = append(jobs, t.genJobs(c, insert, t.param(indexColumns, []interface{}{1, "a"}, []interface{}{2, "b"}, []interface{}{3, "c"}))...)
+	// update 1 -> 1, insert then update is still insert
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{1, "a"}, []interface{}{1, "aa"}))...)
+	// update 4 -> 4, the row is not inserted in this batch
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{4, "d"}, []interface{}{4, "dd"}))...)
+	// delete 2
+	jobs = append(jobs, t.genJobs(c, del, t.param(indexColumns, []interface{}{2, "b"}))...)
+	// update 5 -> 6, changes the primary key
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{5, "e"}, []interface{}{6, "e"}))...)
+	// delete 7 then insert 7
+	jobs = append(jobs, t.genJobs(c, del, t.param(indexColumns, []interface{}{7, "g"}))...)
+	jobs = append(jobs, t.genJobs(c, insert, t.param(indexColumns, []interface{}{7, "gg"}))...)
+	c.Assert(jobs, HasLen, 9)
+
+	compacted := compactJobs(jobs)
+	c.Assert(compacted, HasLen, 3)
+	c.Assert(compacted[0].tp, Equals, del)
+	c.Assert(compacted[0].sql, Equals, "DELETE FROM `db`.`tb` WHERE (`id`) IN ((?),(?));")
+	c.Assert(compacted[0].args, DeepEquals, []interface{}{2, 5})
+	c.Assert(compacted[1].tp, Equals, update)
+	c.Assert(compacted[1].sql, Equals, "UPDATE `db`.`tb` SET `id` = ?, `name` = ? WHERE `id` = ? LIMIT 1;")
+	c.Assert(compacted[1].args, DeepEquals, []interface{}{4, "dd", 4})
+	c.Assert(compacted[2].tp, Equals, insert)
+	c.Assert(compacted[2].sql, Equals, "REPLACE INTO `db`.`tb` (`id`,`name`) VALUES (?,?),(?,?),(?,?),(?,?);")
+	c.Assert(compacted[2].args, DeepEquals, []interface{}{1, "aa", 3, "c", 6, "e", 7, "gg"})
+	// the position of the latest merged job is kept
+	c.Assert(compacted[2].currentPos, Equals, jobs[8].currentPos)
+	c.Assert(compacted[0].currentPos, Equals, jobs[6].currentPos)
+	for _, j := range compacted {
+		c.Assert(j.change, IsNil)
+	}
+}
+
+func (t *testCompactorSuite) TestCompactJobsSafeMode(c *C) {
+	indexColumns := map[string][]*column{"primary": {t.columns[0]}}
+	safeParam := func(data ...[]interface{}) *genDMLParam {
+		param := t.param(indexColumns, data...)
+		param.safeMode = true
+		return param
+	}
+	var jobs []*job
+	// insert 1, then update 1 -> 1 in safe mode
+	jobs = append(jobs, t.genJobs(c, insert, t.param(indexColumns, []interface{}{1, "a"}))...)
+	jobs = append(jobs, t.genJobs(c, update, safeParam([]interface{}{1, "a"}, []interface{}{1, "b"}))...)
+	// update 4 -> 4 in safe mode, then update 4 -> 4 again after safe mode disabled
+	jobs = append(jobs, t.genJobs(c, update, safeParam([]interface{}{4, "d"}, []interface{}{4, "dd"}))...)
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{4, "dd"}, []interface{}{4, "ddd"}))...)
+	// update 5 -> 5 not in safe mode
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{5, "e"}, []interface{}{5, "ee"}))...)
+	c.Assert(jobs, HasLen, 7)
+
+	// updates in safe mode are still executed as DELETE and REPLACE
+	compacted := compactJobs(jobs)
+	c.Assert(compacted, HasLen, 3)
+	c.Assert(compacted[0].tp, Equals, del)
+	c.Assert(compacted[0].sql, Equals, "DELETE FROM `db`.`tb` WHERE (`id`) IN ((?),(?));")
+	c.Assert(compacted[0].args, DeepEquals, []interface{}{1, 4})
+	c.Assert(compacted[1].tp, Equals, update)
+	c.Assert(compacted[1].args, DeepEquals, []interface{}{5, "ee", 5})
+	c.Assert(compacted[2].tp, Equals, insert)
+	c.Assert(compacted[2].sql, Equals, "REPLACE INTO `db`.`tb` (`id`,`name`) VALUES (?,?),(?,?);")
+	c.Assert(compacted[2].args, DeepEquals, []interface{}{1, "b", 4, "ddd"})
+}
+
+func (t *testCompactorSuite) TestCompactJobsBarrier(c *C) {
+	// tables with multiple unique indexes can't be compacted
+	multiIndexColumns := map[string][]*column{"primary": {t.columns[0]}, "uk": {t.columns[1]}}
+	indexColumns := map[string][]*column{"primary": {t.columns[0]}}
+
+	var jobs []*job
+	jobs = append(jobs, t.genJobs(c, insert, t.param(indexColumns, []interface{}{1, "a"}))...)
+	barriers := t.genJobs(c, insert, t.param(multiIndexColumns, []interface{}{2, "b"}))
+	c.Assert(barriers[0].change, IsNil)
+	jobs = append(jobs, barriers...)
+	jobs = append(jobs, t.genJobs(c, update, t.param(indexColumns, []interface{}{1, "a"}, []interface{}{1, "aa"}))...)
+
+	compacted := compactJobs(jobs)
+	c.Assert(compacted, HasLen, 3)
+	c.Assert(compacted[0].sql, Equals, "REPLACE INTO `db`.`tb` (`id`,`name`) VALUES (?,?);")
+	c.Assert(compacted[0].args, DeepEquals, []interface{}{1, "a"})
+	c.Assert(compacted[1], Equals, barriers[0])
+	c.Assert(compacted[2].tp, Equals, update)
+	c.Assert(compacted[2].args, DeepEquals, []interface{}{1, "aa", 1})
+}
+
+func (t *testCompactorSuite) TestMaxMergedRows(c *C) {
+	origin := maxMergedRows
+	maxMergedRows = 2
+	defer func() {
+		maxMergedRows = origin
+	}()
+
+	indexColumns := map[string][]*column{"primary": {t.columns[0]}}
+	jobs := t.genJobs(c, insert, t.param(indexColumns, []interface{}{1, "a"}, []interface{}{2, "b"}, []interface{}{3, "c"}))
+	compacted := compactJobs(jobs)
+	c.Assert(compacted, HasLen, 2)
+	c.Assert(compacted[0].args, DeepEquals, []interface{}{1, "a", 2, "b"})
+	c.Assert(compacted[1].args, DeepEquals, []interface{}{3, "c"})
+}
@@ -38,6 +38,8 @@ type column struct {
 	unsigned bool
 	tp       string
 	extra    string
+
+	defaultValue *string // nil if no default value or default NULL
 }
 
 type table struct {
@@ -382,6 +384,9 @@ func getTableColumns(db *Conn, table *table, maxRetry int) error {
 		for i := range values {
 			values[i] = &data[i]
 		}
+		// RawBytes can't distinguish an empty default value from NULL
+		var defaultValue sql.NullString
+		values[4] = &defaultValue
 
 		err = rows.Scan(values...)
 		if err != nil {
@@ -393,6 +398,9 @@ func getTableColumns(db *Conn, table *table, maxRetry int) error {
 		column.name = string(data[0])
 		column.tp = string(data[1])
 		column.extra = string(data[5])
+		if defaultValue.Valid {
+			column.defaultValue = &defaultValue.String
+		}
 
 		if strings.ToLower(string(data[2])) == "no" {
 			column.NotNull = true
@@ -20,8 +20,11 @@ import (
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
 	"github.com/siddontang/go-mysql/replication"
 
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/utils"
 )
 
@@ -158,7 +161,7 @@ func (s *Syncer) resolveDDLSQL(p *parser.Parser, stmt ast.StmtNode, schema strin
 }
 
 func (s *Syncer) handleDDL(p *parser.Parser, schema, sql string) (string, [][]*filter.Table, ast.StmtNode, error) {
-	stmt, err := p.ParseOneStmt(sql, "", "")
+	stmt, err := parserpkg.ParseOneStmt(p, sql, "", "")
 	if err != nil {
 		return "", nil, nil, errors.Annotatef(err, "ddl %s", sql)
 	}
@@ -197,7 +200,7 @@ func (s *Syncer) handleOnlineDDL(p *parser.Parser, schema, sql string) ([]string
 		return []string{sql}, nil, nil
 	}
 
-	stmt, err := p.ParseOneStmt(sql, "", "")
+	stmt, err := parserpkg.ParseOneStmt(p, sql, "", "")
 	if err != nil {
 		return nil, nil, errors.Annotatef(err, "ddl %s", sql)
 	}
@@ -273,6 +276,67 @@ func (s *Syncer) dropSchemaInSharding(sourceSchema string) error {
 	return nil
 }
 
+// handleTruncateDropInSharding handles TRUNCATE TABLE, DROP TABLE and DROP DATABASE in sharding groups according to shard-truncate-drop-policy,
+// returns whether the DDL is ignored
+func (s *Syncer) handleTruncateDropInSharding(stmt ast.StmtNode, sql string, tableNames [][]*filter.Table) (bool, error) {
+	switch s.cfg.ShardTruncateDropPolicy {
+	case config.ShardPolicyCoordinate:
+		// synced like other sharding DDLs
+		return false, nil
+	case config.ShardPolicyError:
+		return false, errors.NotSupportedf("DDL %s in sharding group with shard-truncate-drop-policy %s", sql, s.cfg.ShardTruncateDropPolicy)
+	}
+
+	switch stmt.(type) {
+	case *ast.DropDatabaseStmt:
+		err := s.dropSchemaInSharding(tableNames[0][0].Schema)
+		return true, errors.Trace(err)
+	case *ast.DropTableStmt:
+		sourceID, _ := GenTableID(tableNames[0][0].Schema, tableNames[0][0].Name)
+		err := s.sgk.LeaveGroup(tableNames[1][0].Schema, tableNames[1][0].Name, []string{sourceID})
+		if err != nil {
+			return true, errors.Trace(err)
+		}
+		err = s.checkpoint.DeleteTablePoint(tableNames[0][0].Schema, tableNames[0][0].Name)
+		return true, errors.Trace(err)
+	default:
+		log.Infof("[syncer] ignore truncate table statement %s in sharding group", sql)
+		return true, nil
+	}
+}
+
+// renameTableInSharding moves the renamed source table from the sharding group of its old target table
+// to the sharding group of its new target table routed by router rules, along with its checkpoint and tracked structure.
+// the sharding group of the new target table is created if not exists, and cached routes of the source table are cleared.
+// the DDL is not executed in downstream, because the target table is shared by other shard tables
+func (s *Syncer) renameTableInSharding(tableNames [][]*filter.Table, pos mysql.Position, gs gtid.Set) error {
+	var (
+		oldTable, newTable   = tableNames[0][0], tableNames[0][1]
+		oldTarget, newTarget = tableNames[1][0], tableNames[1][1]
+	)
+	oldID, _ := GenTableID(oldTable.Schema, oldTable.Name)
+	newID, _ := GenTableID(newTable.Schema, newTable.Name)
+
+	err := s.sgk.LeaveGroup(oldTarget.Schema, oldTarget.Name, []string{oldID})
+	if err != nil {
+		return errors.Annotatef(err, "rename shard table %s to %s", oldID, newID)
+	}
+	_, _, _, _, err = s.sgk.AddGroup(newTarget.Schema, newTarget.Name, []string{newID}, true)
+	if err != nil {
+		return errors.Annotatef(err, "rename shard table %s to %s", oldID, newID)
+	}
+	log.Infof("[syncer] move shard table %s of target table %s to %s of target table %s", oldID, oldTarget, newID, newTarget)
+	s.clearTables(oldTable.Schema, oldTable.Name)
+	s.clearTables(newTable.Schema, newTable.Name)
+
+	err = s.checkpoint.DeleteTablePoint(oldTable.Schema, oldTable.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.checkpoint.SaveTablePoint(newTable.Schema, newTable.Name, pos, gs)
+	return errors.Trace(s.saveTrackedTable(newTable.Schema, newTable.Name))
+}
+
 func (s *Syncer) clearOnlineDDL(targetSchema, targetTable string) error {
 	group := s.sgk.Group(targetSchema, targetTable)
 	if group == nil {
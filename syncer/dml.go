@@ -51,6 +51,8 @@ type genDMLParam struct {
 	schema               string
 	table                string
 	safeMode             bool                 // only used in update
+	compact              bool                 // generate row changes for compacting, see `rowChange`
+	rowImages            bool                 // generate row changes with row images for sinks writing row changes
 	data                 [][]interface{}      // pruned data
 	originalData         [][]interface{}      // all data
 	columns              []*column            // pruned columns
@@ -100,7 +102,7 @@ func extractValueFromData(data []interface{}, columns []*column) []interface{} {
 	return value
 }
 
-func genInsertSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, error) {
+func genInsertSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, []*rowChange, error) {
 	var (
 		schema               = param.schema
 		table                = param.table
@@ -113,11 +115,13 @@ func genInsertSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 	sqls := make([]string, 0, len(dataSeq))
 	keys := make([][]string, 0, len(dataSeq))
 	values := make([][]interface{}, 0, len(dataSeq))
+	var changes []*rowChange
 	columnList := genColumnList(columns)
 	columnPlaceholders := genColumnPlaceholders(len(columns))
+	keyColumns := compactable(originalIndexColumns)
 	for dataIdx, data := range dataSeq {
 		if len(data) != len(columns) {
-			return nil, nil, nil, errors.Errorf("insert columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(data))
+			return nil, nil, nil, nil, errors.Errorf("insert columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(data))
 		}
 
 		value := extractValueFromData(data, columns)
@@ -134,12 +138,15 @@ func genInsertSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 		sqls = append(sqls, sql)
 		values = append(values, value)
 		keys = append(keys, ks)
+		if param.compact || param.rowImages {
+			changes = append(changes, newRowChange(insert, param, keyColumns, value, nil, originalValue))
+		}
 	}
 
-	return sqls, keys, values, nil
+	return sqls, keys, values, changes, nil
 }
 
-func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, error) {
+func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, []*rowChange, error) {
 	var (
 		schema               = param.schema
 		table                = param.table
@@ -153,9 +160,11 @@ func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 	sqls := make([]string, 0, len(data)/2)
 	keys := make([][]string, 0, len(data)/2)
 	values := make([][]interface{}, 0, len(data)/2)
+	var changes []*rowChange
 	columnList := genColumnList(columns)
 	columnPlaceholders := genColumnPlaceholders(len(columns))
 	defaultIndexColumns := findFitIndex(originalIndexColumns)
+	keyColumns := compactable(originalIndexColumns)
 
 	for i := 0; i < len(data); i += 2 {
 		oldData := data[i]
@@ -164,11 +173,11 @@ func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 		oriChangedData := originalData[i+1]
 
 		if len(oldData) != len(changedData) {
-			return nil, nil, nil, errors.Errorf("update data mismatch in length: %d (columns) vs %d (data)", len(oldData), len(changedData))
+			return nil, nil, nil, nil, errors.Errorf("update data mismatch in length: %d (columns) vs %d (data)", len(oldData), len(changedData))
 		}
 
 		if len(oldData) != len(columns) {
-			return nil, nil, nil, errors.Errorf("update columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(oldData))
+			return nil, nil, nil, nil, errors.Errorf("update columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(oldData))
 		}
 
 		oldValues := extractValueFromData(oldData, columns)
@@ -201,6 +210,11 @@ func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 			sqls = append(sqls, sql)
 			values = append(values, changedValues)
 			keys = append(keys, ks)
+			if param.compact || param.rowImages {
+				changes = append(changes,
+					newRowChange(del, param, keyColumns, nil, oriOldValues, nil),
+					newRowChange(insert, param, keyColumns, changedValues, nil, oriChangedValues))
+			}
 			continue
 		}
 
@@ -232,12 +246,15 @@ func genUpdateSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 		sqls = append(sqls, sql)
 		values = append(values, value)
 		keys = append(keys, ks)
+		if param.compact || param.rowImages {
+			changes = append(changes, newRowChange(update, param, keyColumns, changedValues, oriOldValues, oriChangedValues))
+		}
 	}
 
-	return sqls, keys, values, nil
+	return sqls, keys, values, changes, nil
 }
 
-func genDeleteSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, error) {
+func genDeleteSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, []*rowChange, error) {
 	var (
 		schema       = param.schema
 		table        = param.table
@@ -248,11 +265,13 @@ func genDeleteSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 	sqls := make([]string, 0, len(dataSeq))
 	keys := make([][]string, 0, len(dataSeq))
 	values := make([][]interface{}, 0, len(dataSeq))
+	var changes []*rowChange
 	defaultIndexColumns := findFitIndex(indexColumns)
+	keyColumns := compactable(indexColumns)
 
 	for _, data := range dataSeq {
 		if len(data) != len(columns) {
-			return nil, nil, nil, errors.Errorf("delete columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(data))
+			return nil, nil, nil, nil, errors.Errorf("delete columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(data))
 		}
 
 		value := extractValueFromData(data, columns)
@@ -261,6 +280,9 @@ func genDeleteSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 			defaultIndexColumns = getAvailableIndexColumn(indexColumns, value)
 		}
 		ks := genMultipleKeys(columns, value, indexColumns)
+		if param.compact || param.rowImages {
+			changes = append(changes, newRowChange(del, param, keyColumns, nil, value, nil))
+		}
 
 		sql, value := genDeleteSQL(schema, table, value, columns, defaultIndexColumns)
 		sqls = append(sqls, sql)
@@ -268,7 +290,7 @@ func genDeleteSQLs(param *genDMLParam) ([]string, [][]string, [][]interface{}, e
 		keys = append(keys, ks)
 	}
 
-	return sqls, keys, values, nil
+	return sqls, keys, values, changes, nil
 }
 
 func genDeleteSQL(schema string, table string, value []interface{}, columns []*column, indexColumns []*column) (string, []interface{}) {
@@ -0,0 +1,175 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
+)
+
+/*
+ * when `exactly-once` enabled, checkpoints are saved in the same downstream transaction with DMLs:
+ *
+ * table checkpoints:
+ *   upstream transactions are dispatched as txn jobs (like `preserve-txn`) and never split into different batches,
+ *   all transactions changing the same table are executed in order, because the table is added into their causality keys.
+ *   so for each table changed by a batch, all its binlog events before the checkpoint saved in the batch have been executed,
+ *   and events after it are not executed. events not newer than table checkpoints are skipped after restarted.
+ *
+ * global checkpoint:
+ *   transactions are executed concurrently in different queues, so the global checkpoint saved in a batch is
+ *   the latest transaction boundary (XID) before which all transactions have been executed, see `appliedPoints`.
+ *
+ * so binlog events re-synced after restarted are either skipped or not executed before, and safe-mode is not needed.
+ */
+
+// appliedPoint is a candidate of the global checkpoint at a transaction boundary
+type appliedPoint struct {
+	seq int64 // sequence of the latest transaction dispatched before the point
+	pos mysql.Position
+	gs  gtid.Set
+}
+
+// appliedPoints tracks transactions dispatched and executed, to find the latest global checkpoint
+// before which all transactions have been executed downstream
+type appliedPoints struct {
+	sync.Mutex
+	seq      int64              // sequence of the latest dispatched transaction
+	applied  int64              // transactions with sequence not larger than it have been executed
+	finished map[int64]struct{} // executed transactions after `applied`
+	points   []*appliedPoint    // candidates of the global checkpoint in order
+}
+
+func newAppliedPoints() *appliedPoints {
+	return &appliedPoints{
+		finished: make(map[int64]struct{}),
+	}
+}
+
+// reset resets all tracked transactions, it should be called only after all jobs finished
+func (a *appliedPoints) reset() {
+	a.Lock()
+	defer a.Unlock()
+	a.seq = 0
+	a.applied = 0
+	a.finished = make(map[int64]struct{})
+	a.points = nil
+}
+
+// dispatch returns the sequence for a transaction to dispatch
+func (a *appliedPoints) dispatch() int64 {
+	a.Lock()
+	defer a.Unlock()
+	a.seq++
+	return a.seq
+}
+
+// save saves a candidate of the global checkpoint after transactions dispatched before it
+func (a *appliedPoints) save(pos mysql.Position, gs gtid.Set) {
+	a.Lock()
+	defer a.Unlock()
+	a.points = append(a.points, &appliedPoint{seq: a.seq, pos: pos, gs: gs})
+}
+
+// safePoint returns the latest global checkpoint after transactions of executing sequences executed,
+// returns nil if no such point
+func (a *appliedPoints) safePoint(executing []int64) *appliedPoint {
+	a.Lock()
+	defer a.Unlock()
+
+	executingSet := make(map[int64]struct{}, len(executing))
+	for _, seq := range executing {
+		executingSet[seq] = struct{}{}
+	}
+	applied := a.applied
+	for {
+		_, ok1 := a.finished[applied+1]
+		_, ok2 := executingSet[applied+1]
+		if !ok1 && !ok2 {
+			break
+		}
+		applied++
+	}
+
+	var point *appliedPoint
+	for _, p := range a.points {
+		if p.seq > applied {
+			break
+		}
+		point = p
+	}
+	return point
+}
+
+// finish marks transactions of sequences executed
+func (a *appliedPoints) finish(seqs []int64) {
+	a.Lock()
+	defer a.Unlock()
+
+	for _, seq := range seqs {
+		a.finished[seq] = struct{}{}
+	}
+	for {
+		if _, ok := a.finished[a.applied+1]; !ok {
+			break
+		}
+		delete(a.finished, a.applied+1)
+		a.applied++
+	}
+
+	// only keep the latest candidate which transactions before it all executed
+	idx := 0
+	for idx+1 < len(a.points) && a.points[idx+1].seq <= a.applied {
+		idx++
+	}
+	a.points = a.points[idx:]
+}
+
+// genCheckpointJobs generates jobs to save checkpoints executed by jobs in the same transaction,
+// seqs are sequences of transactions in jobs
+func (s *Syncer) genCheckpointJobs(jobs []*job, seqs []int64) []*job {
+	var (
+		tables []string
+		points = make(map[string]*job) // `schema`.`table` -> latest job of the table
+	)
+	for _, j := range jobs {
+		if len(j.sourceSchema) == 0 {
+			continue
+		}
+		table := fmt.Sprintf("`%s`.`%s`", j.sourceSchema, j.sourceTable)
+		latest, ok := points[table]
+		if !ok {
+			tables = append(tables, table)
+		}
+		if !ok || j.currentPos.Compare(latest.currentPos) > 0 {
+			points[table] = j
+		}
+	}
+
+	cpJobs := make([]*job, 0, len(tables)+1)
+	for _, table := range tables {
+		j := points[table]
+		sql, args := s.checkpoint.GenUpdateForPointSQL(j.sourceSchema, j.sourceTable, j.currentPos, j.gtidSet, false)
+		cpJobs = append(cpJobs, newCheckpointJob(sql, args, j.currentPos))
+	}
+	if point := s.appliedPoints.safePoint(seqs); point != nil {
+		sql, args := s.checkpoint.GenUpdateForPointSQL("", "", point.pos, point.gs, true)
+		cpJobs = append(cpJobs, newCheckpointJob(sql, args, point.pos))
+	}
+	return cpJobs
+}
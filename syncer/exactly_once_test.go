@@ -0,0 +1,99 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testExactlyOnceSuite{})
+
+type testExactlyOnceSuite struct{}
+
+func (t *testExactlyOnceSuite) TestAppliedPoints(c *C) {
+	a := newAppliedPoints()
+	pos0 := mysql.Position{Name: "mysql-bin.000001", Pos: 4}
+	pos1 := mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+	pos2 := mysql.Position{Name: "mysql-bin.000001", Pos: 200}
+	pos3 := mysql.Position{Name: "mysql-bin.000001", Pos: 300}
+
+	c.Assert(a.safePoint(nil), IsNil)
+	a.save(pos0, nil)
+	c.Assert(a.safePoint(nil).pos, Equals, pos0)
+
+	seq1 := a.dispatch()
+	a.save(pos1, nil)
+	seq2 := a.dispatch()
+	a.save(pos2, nil)
+	seq3 := a.dispatch()
+	a.save(pos3, nil)
+	c.Assert([]int64{seq1, seq2, seq3}, DeepEquals, []int64{1, 2, 3})
+
+	// transaction 2 executing, but 1 not executed
+	c.Assert(a.safePoint([]int64{seq2}).pos, Equals, pos0)
+	a.finish([]int64{seq2})
+	c.Assert(a.safePoint(nil).pos, Equals, pos0)
+
+	// transaction 1 executing, 1 and 2 both executed after it
+	c.Assert(a.safePoint([]int64{seq1}).pos, Equals, pos2)
+	a.finish([]int64{seq1})
+	c.Assert(a.safePoint(nil).pos, Equals, pos2)
+	c.Assert(a.points, HasLen, 2) // older candidates are pruned
+	c.Assert(a.finished, HasLen, 0)
+
+	a.finish([]int64{seq3})
+	c.Assert(a.safePoint(nil).pos, Equals, pos3)
+
+	a.reset()
+	c.Assert(a.safePoint(nil), IsNil)
+	c.Assert(a.dispatch(), Equals, int64(1))
+}
+
+func (t *testExactlyOnceSuite) TestGenCheckpointJobs(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test", MetaSchema: "dm_meta"}
+	s := &Syncer{
+		cfg:           cfg,
+		checkpoint:    NewRemoteCheckPoint(cfg, "101"),
+		appliedPoints: newAppliedPoints(),
+	}
+	pos0 := mysql.Position{Name: "mysql-bin.000001", Pos: 4}
+	pos1 := mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+	pos2 := mysql.Position{Name: "mysql-bin.000001", Pos: 200}
+	pos3 := mysql.Position{Name: "mysql-bin.000001", Pos: 300}
+	s.appliedPoints.save(pos0, nil)
+	seq := s.appliedPoints.dispatch()
+	s.appliedPoints.save(pos3, nil)
+
+	jobs := []*job{
+		newJob(insert, "db", "tb1", "db", "tb1", "INSERT", nil, "", pos0, pos1, nil, ""),
+		newJob(update, "db", "tb2", "db", "tb2", "UPDATE", nil, "", pos0, pos2, nil, ""),
+		newJob(del, "db", "tb1", "db", "tb1", "DELETE", nil, "", pos0, pos2, nil, ""),
+	}
+
+	// transaction not finished, only table checkpoints saved
+	cpJobs := s.genCheckpointJobs(jobs, nil)
+	c.Assert(cpJobs, HasLen, 3)
+	c.Assert(cpJobs[0].args[1:5], DeepEquals, []interface{}{"db", "tb1", pos2.Name, pos2.Pos})
+	c.Assert(cpJobs[1].args[1:5], DeepEquals, []interface{}{"db", "tb2", pos2.Name, pos2.Pos})
+	c.Assert(cpJobs[2].args[1:5], DeepEquals, []interface{}{globalCpSchema, globalCpTable, pos0.Name, pos0.Pos})
+	c.Assert(cpJobs[2].args[7], IsTrue)
+
+	// the global checkpoint after the transaction
+	cpJobs = s.genCheckpointJobs(jobs, []int64{seq})
+	c.Assert(cpJobs, HasLen, 3)
+	c.Assert(cpJobs[2].args[1:5], DeepEquals, []interface{}{globalCpSchema, globalCpTable, pos3.Name, pos3.Pos})
+}
@@ -0,0 +1,685 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/opcode"
+	selector "github.com/pingcap/tidb-tools/pkg/table-rule-selector"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/transform"
+)
+
+// exprFilterRule is the parsed expressions of an expression filter rule, nil if not set
+type exprFilterRule struct {
+	insert    *filterExpr
+	updateOld *filterExpr
+	updateNew *filterExpr
+	del       *filterExpr
+}
+
+// filterExpr is a parsed expression, with patterns of LIKE compiled when parsing if they are literals
+type filterExpr struct {
+	node  ast.ExprNode
+	likes map[*ast.PatternLikeExpr]*regexp.Regexp
+}
+
+// filterColumn is a column referred in expressions, values of ENUM and SET are converted to strings by elems
+type filterColumn struct {
+	name     string // in lower case
+	unsigned bool
+	tp       string
+	elems    []string // elements of ENUM or SET
+	isSet    bool
+}
+
+// exprFilter skips rows of DML events by expressions over column values, see `config.ExpressionFilter`.
+// expressions are evaluated like MySQL on values in binlog, with NULL handled in three-valued logic, supported are
+//   - literals, columns and parentheses
+//   - AND, OR, XOR, NOT
+//   - =, <=>, !=, <, <=, >, >=, IS [NOT] NULL, [NOT] IN (...), [NOT] BETWEEN ... AND ..., [NOT] LIKE
+//   - +, -, *, /, %
+//
+// strings are compared case-insensitively like the default collations of MySQL, including LIKE,
+// and converted to numbers when compared with numbers. values of ENUM and SET are compared as strings
+type exprFilter struct {
+	caseSensitive bool
+	selector      selector.Selector
+}
+
+func newExprFilter(caseSensitive bool, rules []*config.ExpressionFilter) (*exprFilter, error) {
+	f := &exprFilter{
+		caseSensitive: caseSensitive,
+		selector:      selector.NewTrieSelector(),
+	}
+	// multiple rules may be set for the same schema and table patterns
+	var (
+		patterns     [][]string
+		patternRules = make(map[string][]*exprFilterRule)
+	)
+	for _, rule := range rules {
+		r := &exprFilterRule{}
+		for _, item := range []struct {
+			expr string
+			node **filterExpr
+		}{
+			{rule.InsertValueExpr, &r.insert},
+			{rule.UpdateOldValueExpr, &r.updateOld},
+			{rule.UpdateNewValueExpr, &r.updateNew},
+			{rule.DeleteValueExpr, &r.del},
+		} {
+			if len(item.expr) == 0 {
+				continue
+			}
+			node, err := parseFilterExpr(item.expr)
+			if err != nil {
+				return nil, errors.Annotatef(err, "expression filter of %s.%s", rule.Schema, rule.Table)
+			}
+			*item.node = node
+		}
+
+		schema, table := rule.Schema, rule.Table
+		if !caseSensitive {
+			schema, table = strings.ToLower(schema), strings.ToLower(table)
+		}
+		key := fmt.Sprintf("%s.%s", schema, table)
+		if _, ok := patternRules[key]; !ok {
+			patterns = append(patterns, []string{schema, table})
+		}
+		patternRules[key] = append(patternRules[key], r)
+	}
+
+	for _, pattern := range patterns {
+		schema, table := pattern[0], pattern[1]
+		err := f.selector.Insert(schema, table, patternRules[fmt.Sprintf("%s.%s", schema, table)], false)
+		if err != nil {
+			return nil, errors.Annotatef(err, "expression filter of %s.%s", schema, table)
+		}
+	}
+	return f, nil
+}
+
+// parseFilterExpr parses the boolean expression, checks whether it's supported, and compiles patterns of LIKE
+func parseFilterExpr(expr string) (*filterExpr, error) {
+	stmt, err := parser.New().ParseOneStmt(fmt.Sprintf("SELECT * FROM t WHERE %s", expr), "", "")
+	if err != nil {
+		return nil, errors.Annotatef(err, "parse expression %s", expr)
+	}
+	e := &filterExpr{
+		node:  stmt.(*ast.SelectStmt).Where,
+		likes: make(map[*ast.PatternLikeExpr]*regexp.Regexp),
+	}
+	if err = e.check(e.node); err != nil {
+		return nil, errors.Annotatef(err, "expression %s", expr)
+	}
+	return e, nil
+}
+
+// check checks whether the expression is supported, and compiles literal patterns of LIKE
+func (e *filterExpr) check(node ast.ExprNode) error {
+	var children []ast.ExprNode
+	switch n := node.(type) {
+	case ast.ValueExpr, *ast.ColumnNameExpr:
+	case *ast.ParenthesesExpr:
+		children = append(children, n.Expr)
+	case *ast.UnaryOperationExpr:
+		if n.Op != opcode.Not && n.Op != opcode.Minus && n.Op != opcode.Plus {
+			return errors.NotSupportedf("operator %s", n.Op)
+		}
+		children = append(children, n.V)
+	case *ast.BinaryOperationExpr:
+		switch n.Op {
+		case opcode.LogicAnd, opcode.LogicOr, opcode.LogicXor,
+			opcode.EQ, opcode.NullEQ, opcode.NE, opcode.LT, opcode.LE, opcode.GT, opcode.GE,
+			opcode.Plus, opcode.Minus, opcode.Mul, opcode.Div, opcode.Mod:
+		default:
+			return errors.NotSupportedf("operator %s", n.Op)
+		}
+		children = append(children, n.L, n.R)
+	case *ast.IsNullExpr:
+		children = append(children, n.Expr)
+	case *ast.PatternInExpr:
+		if n.Sel != nil {
+			return errors.NotSupportedf("sub query")
+		}
+		children = append(children, n.Expr)
+		children = append(children, n.List...)
+	case *ast.BetweenExpr:
+		children = append(children, n.Expr, n.Left, n.Right)
+	case *ast.PatternLikeExpr:
+		if v, ok := n.Pattern.(ast.ValueExpr); ok {
+			if pattern := normalizeFilterValue(v.GetValue()); pattern != nil {
+				re, err := likeToRegexp(toStringFilterValue(pattern), n.Escape)
+				if err != nil {
+					return err
+				}
+				e.likes[n] = re
+			}
+		}
+		children = append(children, n.Expr, n.Pattern)
+	default:
+		return errors.NotSupportedf("expression %T", node)
+	}
+
+	for _, child := range children {
+		if err := e.check(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterRows returns rows not skipped by expressions, rows of update are pairs of old and new values
+func (f *exprFilter) filterRows(schema, table string, columns []*column, eventType replication.EventType, rows [][]interface{}) ([][]interface{}, error) {
+	if !f.caseSensitive {
+		schema, table = strings.ToLower(schema), strings.ToLower(table)
+	}
+	matched := f.selector.Match(schema, table)
+	if len(matched) == 0 {
+		return rows, nil
+	}
+	var rules []*exprFilterRule
+	for _, rs := range matched {
+		rules = append(rules, rs.([]*exprFilterRule)...)
+	}
+	filterColumns := newFilterColumns(columns)
+
+	step := 1
+	skip := func(rule *exprFilterRule, rows [][]interface{}) (bool, error) {
+		return evalFilterExprOnRow(rule.insert, filterColumns, rows[0])
+	}
+	switch eventType {
+	case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2:
+		step = 2
+		skip = func(rule *exprFilterRule, rows [][]interface{}) (bool, error) {
+			if rule.updateOld == nil && rule.updateNew == nil {
+				return false, nil
+			}
+			// both old and new values should be matched if both set
+			for _, item := range []struct {
+				expr *filterExpr
+				row  []interface{}
+			}{{rule.updateOld, rows[0]}, {rule.updateNew, rows[1]}} {
+				if item.expr == nil {
+					continue
+				}
+				ok, err := evalFilterExprOnRow(item.expr, filterColumns, item.row)
+				if err != nil || !ok {
+					return false, err
+				}
+			}
+			return true, nil
+		}
+	case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
+		skip = func(rule *exprFilterRule, rows [][]interface{}) (bool, error) {
+			return evalFilterExprOnRow(rule.del, filterColumns, rows[0])
+		}
+	}
+
+	result := make([][]interface{}, 0, len(rows))
+	for i := 0; i+step <= len(rows); i += step {
+		skipped := false
+		for _, rule := range rules {
+			ok, err := skip(rule, rows[i:i+step])
+			if err != nil {
+				return nil, errors.Annotatef(err, "evaluate expression filter on %s.%s", schema, table)
+			}
+			if ok {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			result = append(result, rows[i:i+step]...)
+		}
+	}
+	return result, nil
+}
+
+// newFilterColumns returns columns referred in expressions, elements of ENUM and SET are parsed from their types
+func newFilterColumns(columns []*column) []*filterColumn {
+	filterColumns := make([]*filterColumn, 0, len(columns))
+	for _, col := range columns {
+		fc := &filterColumn{
+			name:     strings.ToLower(col.name),
+			unsigned: col.unsigned,
+			tp:       col.tp,
+		}
+		tp := strings.ToLower(col.tp)
+		switch {
+		case strings.HasPrefix(tp, "enum("):
+			fc.elems = transform.ParseEnumSetElems(col.tp[len("enum("):])
+		case strings.HasPrefix(tp, "set("):
+			fc.elems = transform.ParseEnumSetElems(col.tp[len("set("):])
+			fc.isSet = true
+		}
+		filterColumns = append(filterColumns, fc)
+	}
+	return filterColumns
+}
+
+// value converts the value in binlog of the column, ENUM and SET are in binlog as index and bitmap
+func (c *filterColumn) value(v interface{}) interface{} {
+	if x, ok := v.(int64); ok && len(c.elems) > 0 {
+		if !c.isSet {
+			if x <= 0 || int(x) > len(c.elems) {
+				return "" // invalid value inserted in non-strict mode
+			}
+			return c.elems[x-1]
+		}
+		set := make([]string, 0, 1)
+		for i, elem := range c.elems {
+			if x&(1<<uint(i)) != 0 {
+				set = append(set, elem)
+			}
+		}
+		return strings.Join(set, ",")
+	}
+	if x, ok := v.(int64); ok && c.unsigned {
+		return uint64(x)
+	}
+	return normalizeFilterValue(castUnsigned(v, c.unsigned, c.tp))
+}
+
+// evalFilterExprOnRow returns whether the row is matched by the expression, NULL is not matched
+func evalFilterExprOnRow(expr *filterExpr, columns []*filterColumn, row []interface{}) (bool, error) {
+	if expr == nil {
+		return false, nil
+	}
+	if len(row) != len(columns) {
+		return false, errors.Errorf("columns and data mismatch in length: %d (columns) vs %d (data)", len(columns), len(row))
+	}
+	values := make(map[string]interface{}, len(columns))
+	for i, col := range columns {
+		values[col.name] = col.value(row[i])
+	}
+	v, err := expr.eval(expr.node, values)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return isTrueFilterValue(v), nil
+}
+
+// eval evaluates the expression on values of columns,
+// returns nil (NULL), int64, uint64, float64 or string, booleans are returned as int64 1 or 0
+func (e *filterExpr) eval(node ast.ExprNode, values map[string]interface{}) (interface{}, error) {
+	switch n := node.(type) {
+	case ast.ValueExpr:
+		return normalizeFilterValue(n.GetValue()), nil
+	case *ast.ColumnNameExpr:
+		v, ok := values[n.Name.Name.L]
+		if !ok {
+			return nil, errors.NotFoundf("column %s", n.Name.Name.O)
+		}
+		return v, nil
+	case *ast.ParenthesesExpr:
+		return e.eval(n.Expr, values)
+	case *ast.UnaryOperationExpr:
+		v, err := e.eval(n.V, values)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		switch n.Op {
+		case opcode.Not:
+			return boolFilterValue(!isTrueFilterValue(v)), nil
+		case opcode.Minus:
+			if i, ok := v.(int64); ok && i != math.MinInt64 {
+				return -i, nil
+			}
+			return -toFloatFilterValue(v), nil
+		}
+		return v, nil
+	case *ast.BinaryOperationExpr:
+		return e.evalBinary(n, values)
+	case *ast.IsNullExpr:
+		v, err := e.eval(n.Expr, values)
+		if err != nil {
+			return nil, err
+		}
+		return boolFilterValue((v == nil) != n.Not), nil
+	case *ast.PatternInExpr:
+		v, err := e.eval(n.Expr, values)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		hasNull := false
+		for _, item := range n.List {
+			iv, err := e.eval(item, values)
+			if err != nil {
+				return nil, err
+			}
+			if iv == nil {
+				hasNull = true
+			} else if compareFilterValues(v, iv) == 0 {
+				return boolFilterValue(!n.Not), nil
+			}
+		}
+		if hasNull {
+			return nil, nil
+		}
+		return boolFilterValue(n.Not), nil
+	case *ast.BetweenExpr:
+		v, err := e.eval(n.Expr, values)
+		if err != nil {
+			return nil, err
+		}
+		left, err := e.eval(n.Left, values)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.eval(n.Right, values)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil || left == nil || right == nil {
+			return nil, nil
+		}
+		between := compareFilterValues(v, left) >= 0 && compareFilterValues(v, right) <= 0
+		return boolFilterValue(between != n.Not), nil
+	case *ast.PatternLikeExpr:
+		v, err := e.eval(n.Expr, values)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := e.eval(n.Pattern, values)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil || pattern == nil {
+			return nil, nil
+		}
+		re, ok := e.likes[n]
+		if !ok {
+			// not a literal, compile it for the row
+			re, err = likeToRegexp(toStringFilterValue(pattern), n.Escape)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return boolFilterValue(re.MatchString(toStringFilterValue(v)) != n.Not), nil
+	}
+	return nil, errors.NotSupportedf("expression %T", node)
+}
+
+func (e *filterExpr) evalBinary(n *ast.BinaryOperationExpr, values map[string]interface{}) (interface{}, error) {
+	l, err := e.eval(n.L, values)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.eval(n.R, values)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Op {
+	case opcode.LogicAnd:
+		if (l != nil && !isTrueFilterValue(l)) || (r != nil && !isTrueFilterValue(r)) {
+			return boolFilterValue(false), nil
+		}
+		if l == nil || r == nil {
+			return nil, nil
+		}
+		return boolFilterValue(true), nil
+	case opcode.LogicOr:
+		if (l != nil && isTrueFilterValue(l)) || (r != nil && isTrueFilterValue(r)) {
+			return boolFilterValue(true), nil
+		}
+		if l == nil || r == nil {
+			return nil, nil
+		}
+		return boolFilterValue(false), nil
+	case opcode.NullEQ:
+		if l == nil || r == nil {
+			return boolFilterValue(l == nil && r == nil), nil
+		}
+		return boolFilterValue(compareFilterValues(l, r) == 0), nil
+	}
+
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	switch n.Op {
+	case opcode.LogicXor:
+		return boolFilterValue(isTrueFilterValue(l) != isTrueFilterValue(r)), nil
+	case opcode.EQ:
+		return boolFilterValue(compareFilterValues(l, r) == 0), nil
+	case opcode.NE:
+		return boolFilterValue(compareFilterValues(l, r) != 0), nil
+	case opcode.LT:
+		return boolFilterValue(compareFilterValues(l, r) < 0), nil
+	case opcode.LE:
+		return boolFilterValue(compareFilterValues(l, r) <= 0), nil
+	case opcode.GT:
+		return boolFilterValue(compareFilterValues(l, r) > 0), nil
+	case opcode.GE:
+		return boolFilterValue(compareFilterValues(l, r) >= 0), nil
+	}
+
+	li, lok := l.(int64)
+	ri, rok := r.(int64)
+	lf, rf := toFloatFilterValue(l), toFloatFilterValue(r)
+	switch n.Op {
+	case opcode.Plus:
+		if lok && rok {
+			return li + ri, nil
+		}
+		return lf + rf, nil
+	case opcode.Minus:
+		if lok && rok {
+			return li - ri, nil
+		}
+		return lf - rf, nil
+	case opcode.Mul:
+		if lok && rok {
+			return li * ri, nil
+		}
+		return lf * rf, nil
+	case opcode.Div:
+		if rf == 0 {
+			return nil, nil
+		}
+		return lf / rf, nil
+	case opcode.Mod:
+		if lok && rok {
+			if ri == 0 {
+				return nil, nil
+			}
+			return li % ri, nil
+		}
+		if rf == 0 {
+			return nil, nil
+		}
+		return math.Mod(lf, rf), nil
+	}
+	return nil, errors.NotSupportedf("operator %s", n.Op)
+}
+
+// normalizeFilterValue converts values in binlog or literals to nil, int64, uint64, float64 or string
+func normalizeFilterValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil, int64, uint64, float64, string:
+		return x
+	case bool:
+		return boolFilterValue(x)
+	case int:
+		return int64(x)
+	case int8:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case uint:
+		return uint64(x)
+	case uint8:
+		return uint64(x)
+	case uint16:
+		return uint64(x)
+	case uint32:
+		return uint64(x)
+	case float32:
+		return float64(x)
+	case []byte:
+		return string(x)
+	case fmt.Stringer:
+		// like decimal literals
+		s := x.String()
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func boolFilterValue(b bool) interface{} {
+	if b {
+		return int64(1)
+	}
+	return int64(0)
+}
+
+func isTrueFilterValue(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	return toFloatFilterValue(v) != 0
+}
+
+func toFloatFilterValue(v interface{}) float64 {
+	switch x := v.(type) {
+	case int64:
+		return float64(x)
+	case uint64:
+		return float64(x)
+	case float64:
+		return x
+	case string:
+		// like MySQL, use the longest numeric prefix of the string
+		s := strings.TrimSpace(x)
+		for i := len(s); i > 0; i-- {
+			if f, err := strconv.ParseFloat(s[:i], 64); err == nil {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+func toStringFilterValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// compareFilterValues compares two non-NULL values, strings are compared case-insensitively,
+// or as numbers if compared with numbers
+func compareFilterValues(a, b interface{}) int {
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if aok && bok {
+		return strings.Compare(strings.ToLower(as), strings.ToLower(bs))
+	}
+
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return compareInt64(x, y)
+		case uint64:
+			if x < 0 {
+				return -1
+			}
+			return compareUint64(uint64(x), y)
+		}
+	case uint64:
+		switch y := b.(type) {
+		case int64:
+			if y < 0 {
+				return 1
+			}
+			return compareUint64(x, uint64(y))
+		case uint64:
+			return compareUint64(x, y)
+		}
+	}
+
+	af, bf := toFloatFilterValue(a), toFloatFilterValue(b)
+	switch {
+	case af < bf:
+		return -1
+	case af > bf:
+		return 1
+	}
+	return 0
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// likeToRegexp converts the pattern of LIKE to a case-insensitive regular expression
+func likeToRegexp(pattern string, escape byte) (*regexp.Regexp, error) {
+	var buf strings.Builder
+	buf.WriteString("(?is)^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case escape != 0 && c == rune(escape):
+			escaped = true
+		case c == '%':
+			buf.WriteString(".*")
+		case c == '_':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if escaped {
+		buf.WriteString(regexp.QuoteMeta(string(escape)))
+	}
+	buf.WriteString("$")
+	re, err := regexp.Compile(buf.String())
+	return re, errors.Annotatef(err, "like pattern %s", pattern)
+}
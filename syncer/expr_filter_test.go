@@ -0,0 +1,202 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/ast"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testExprFilterSuite{})
+
+type testExprFilterSuite struct{}
+
+func (t *testExprFilterSuite) TestEvalFilterExpr(c *C) {
+	columns := []*column{
+		{idx: 0, name: "id", tp: "int"},
+		{idx: 1, name: "status", tp: "varchar(20)"},
+		{idx: 2, name: "tenant_id", tp: "int"},
+		{idx: 3, name: "price", tp: "double"},
+		{idx: 4, name: "big", tp: "bigint unsigned", unsigned: true},
+		{idx: 5, name: "state", tp: "enum('active','Deleted','it''s')"},
+		{idx: 6, name: "tags", tp: "set('a','b','c')"},
+	}
+	filterColumns := newFilterColumns(columns)
+	row := []interface{}{int32(1), "deleted", int32(42), float64(9.5), int64(-1), int64(2), int64(5)}
+	nullRow := []interface{}{int32(2), nil, nil, nil, nil, nil, nil}
+
+	cases := []struct {
+		expr    string
+		matched bool
+		null    bool // matched for the row with NULLs
+	}{
+		{"status = 'deleted' OR tenant_id != 42", true, false},
+		{"status = 'deleted' AND tenant_id != 42", false, false},
+		{"NOT (tenant_id = 42)", false, false},
+		{"status IS NULL", false, true},
+		{"status IS NOT NULL", true, false},
+		{"status <=> NULL", false, true},
+		{"id = 2 OR status = 'x'", false, true},
+		{"id IN (1, 3)", true, false},
+		{"id NOT IN (2, NULL)", false, false},
+		{"price BETWEEN 9 AND 10", true, false},
+		{"price * 2 > 18 AND id + 1 = 2", true, false},
+		{"price / 0 IS NULL", true, true},
+		{"tenant_id % 5 = 2", true, false},
+		{"status LIKE 'del%'", true, false},
+		{"status NOT LIKE '_eleted'", false, false},
+		{"big = 18446744073709551615", true, false},
+		{"big > 0 XOR id > 0", false, false},
+		{"tenant_id = '42'", true, false},
+		{"-id < 0", true, true},
+		{"TRUE", true, true},
+		// case-insensitive like the default collations
+		{"status = 'DELETED'", true, false},
+		{"status IN ('Deleted')", true, false},
+		{"status > 'Abc'", true, false},
+		{"status LIKE 'DEL%'", true, false},
+		{"status LIKE status", true, false},
+		// ENUM and SET are compared as strings
+		{"state = 'deleted'", true, false},
+		{"state = 2", false, false},
+		{"state IN ('active', \"it's\")", false, false},
+		{"tags = 'a,c'", true, false},
+		{"tags LIKE '%c'", true, false},
+	}
+	for _, cs := range cases {
+		node, err := parseFilterExpr(cs.expr)
+		c.Assert(err, IsNil, Commentf("%s", cs.expr))
+		matched, err := evalFilterExprOnRow(node, filterColumns, row)
+		c.Assert(err, IsNil, Commentf("%s", cs.expr))
+		c.Assert(matched, Equals, cs.matched, Commentf("%s", cs.expr))
+		matched, err = evalFilterExprOnRow(node, filterColumns, nullRow)
+		c.Assert(err, IsNil, Commentf("%s", cs.expr))
+		c.Assert(matched, Equals, cs.null, Commentf("%s", cs.expr))
+	}
+
+	// column not found
+	node, err := parseFilterExpr("name = 'a'")
+	c.Assert(err, IsNil)
+	_, err = evalFilterExprOnRow(node, filterColumns, row)
+	c.Assert(err, ErrorMatches, ".*column name not found.*")
+
+	// literal patterns of LIKE are compiled when parsing
+	node, err = parseFilterExpr("status LIKE 'a%' OR (status NOT LIKE 'b|_' ESCAPE '|' AND status LIKE state)")
+	c.Assert(err, IsNil)
+	c.Assert(node.likes, HasLen, 2)
+	for like, re := range node.likes {
+		c.Assert(re.String(), Equals, map[string]string{"a%": "(?is)^a.*$", "b|_": "(?is)^b_$"}[like.Pattern.(ast.ValueExpr).GetValue().(string)])
+	}
+
+	// not supported
+	for _, expr := range []string{"id = (SELECT 1)", "upper(status) = 'A'", "id & 1", "status REGEXP 'a'", "id IN (SELECT 1)"} {
+		_, err = parseFilterExpr(expr)
+		c.Assert(err, NotNil, Commentf("%s", expr))
+	}
+	_, err = parseFilterExpr("id = ")
+	c.Assert(err, NotNil)
+}
+
+func (t *testExprFilterSuite) TestFilterRows(c *C) {
+	rules := []*config.ExpressionFilter{
+		{
+			Schema:          "db_*",
+			Table:           "tb",
+			InsertValueExpr: "status = 'deleted'",
+			DeleteValueExpr: "id > 10",
+		},
+		{
+			Schema:             "db_*",
+			Table:              "tb",
+			InsertValueExpr:    "id = 1",
+			UpdateOldValueExpr: "status = 'a'",
+			UpdateNewValueExpr: "status = 'b'",
+		},
+		{
+			Schema:             "db_1",
+			Table:              "*",
+			UpdateNewValueExpr: "id = 100",
+		},
+	}
+	_, err := newExprFilter(false, []*config.ExpressionFilter{{Schema: "db", Table: "tb", InsertValueExpr: "id &"}})
+	c.Assert(err, NotNil)
+	f, err := newExprFilter(false, rules)
+	c.Assert(err, IsNil)
+
+	columns := []*column{
+		{idx: 0, name: "id", tp: "int"},
+		{idx: 1, name: "status", tp: "varchar(20)"},
+	}
+	rows := [][]interface{}{
+		{int32(1), "a"},
+		{int32(2), "deleted"},
+		{int32(11), "b"},
+		{int32(100), "b"},
+	}
+
+	// not matched tables
+	result, err := f.filterRows("db", "tb", columns, replication.WRITE_ROWS_EVENTv2, rows)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, rows)
+
+	// insert, rules of two schema patterns matched
+	result, err = f.filterRows("DB_1", "TB", columns, replication.WRITE_ROWS_EVENTv2, rows)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, rows[2:])
+
+	// delete
+	result, err = f.filterRows("db_2", "tb", columns, replication.DELETE_ROWS_EVENTv2, rows)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, rows[:2])
+
+	// update, both old and new values should be matched
+	updateRows := [][]interface{}{
+		{int32(1), "a"}, {int32(1), "b"},
+		{int32(11), "b"}, {int32(100), "b"},
+	}
+	result, err = f.filterRows("db_2", "tb", columns, replication.UPDATE_ROWS_EVENTv2, updateRows)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, updateRows[2:])
+	result, err = f.filterRows("db_2", "tb", columns, replication.UPDATE_ROWS_EVENTv2, updateRows[1:3])
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, updateRows[1:3])
+	result, err = f.filterRows("db_1", "tb2", columns, replication.UPDATE_ROWS_EVENTv2, updateRows)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, updateRows[:2])
+}
+
+func (t *testExprFilterSuite) TestFilterColumns(c *C) {
+	columns := []*column{
+		{idx: 0, name: "ID", tp: "int(11) unsigned", unsigned: true},
+		{idx: 1, name: "e", tp: "ENUM('a','it''s','c\\'d')"},
+		{idx: 2, name: "s", tp: "set('x','y','z')"},
+	}
+	filterColumns := newFilterColumns(columns)
+	c.Assert(filterColumns[0].name, Equals, "id")
+	c.Assert(filterColumns[1].elems, DeepEquals, []string{"a", "it's", "c'd"})
+	c.Assert(filterColumns[1].isSet, IsFalse)
+	c.Assert(filterColumns[2].elems, DeepEquals, []string{"x", "y", "z"})
+	c.Assert(filterColumns[2].isSet, IsTrue)
+
+	c.Assert(filterColumns[0].value(int32(-1)), Equals, uint64(4294967295))
+	c.Assert(filterColumns[1].value(int64(2)), Equals, "it's")
+	c.Assert(filterColumns[1].value(int64(0)), Equals, "")
+	c.Assert(filterColumns[1].value(int64(4)), Equals, "")
+	c.Assert(filterColumns[2].value(int64(0)), Equals, "")
+	c.Assert(filterColumns[2].value(int64(6)), Equals, "y,z")
+	c.Assert(filterColumns[2].value(nil), IsNil)
+}
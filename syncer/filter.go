@@ -80,8 +80,8 @@ var (
 		"^DROP\\s+USER",
 		"^SET\\s+PASSWORD",
 
-		// alter database
-		"^ALTER DATABASE",
+		// alter database, only changing character set or collation supported
+		"^ALTER\\s+(DATABASE|SCHEMA)\\s+.*UPGRADE\\s+DATA\\s+DIRECTORY\\s+NAME",
 	}
 )
 
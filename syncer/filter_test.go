@@ -104,8 +104,9 @@ END`, true},
 		{"revoke reload on *.* from 't2'@'%'", true},
 
 		// alter database
-		{"alter database foo character set = utf8", true},
-		{"alter database foo collate=utf8_bin", true},
+		{"alter database foo character set = utf8", false},
+		{"alter database foo collate=utf8_bin", false},
+		{"ALTER DATABASE `#mysql50#foo` UPGRADE DATA DIRECTORY NAME", true},
 	}
 
 	//filter, err := bf.NewBinlogEvent(nil)
@@ -33,6 +33,7 @@ const (
 	flush
 	skip // used by Syncer.recordSkipSQLsPos to record global pos, but not execute SQL
 	rotate
+	txn // rows of an upstream transaction, used when `preserve-txn` enabled
 )
 
 func (t opType) String() string {
@@ -53,6 +54,8 @@ func (t opType) String() string {
 		return "skip"
 	case rotate:
 		return "rotate"
+	case txn:
+		return "txn"
 	}
 
 	return ""
@@ -75,6 +78,11 @@ type job struct {
 	ddls         []string
 	traceID      string
 	traceGID     string
+	rows         []*job      // row jobs of a txn job, executed in one downstream transaction
+	node         *causalNode // node in causality, nil if causality disabled
+	change       *rowChange  // change of the row, used to compact jobs when `compact-dml` enabled
+	seq          int64       // sequence of the txn job, used to track executed transactions when `exactly-once` enabled
+	eventTime    uint32      // timestamp in the header of the binlog event, in seconds
 }
 
 func (j *job) String() string {
@@ -147,6 +155,30 @@ func newXIDJob(pos, cmdPos mysql.Position, currentGtidSet gtid.Set, traceID stri
 	}
 }
 
+// newTxnJob creates a job for rows of an upstream transaction, the transaction is scheduled as a whole by causality
+func newTxnJob(rows []*job, key string) *job {
+	last := rows[len(rows)-1]
+	return &job{
+		tp:         txn,
+		key:        key,
+		pos:        last.pos,
+		currentPos: last.currentPos,
+		gtidSet:    last.gtidSet,
+		rows:       rows,
+	}
+}
+
+// newCheckpointJob creates a job to save checkpoint along with DMLs, it's executed directly and never dispatched
+func newCheckpointJob(sql string, args []interface{}, pos mysql.Position) *job {
+	return &job{
+		tp:         flush,
+		sql:        sql,
+		args:       args,
+		pos:        pos,
+		currentPos: pos,
+	}
+}
+
 func newFlushJob() *job {
 	return &job{tp: flush}
 }
@@ -0,0 +1,219 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go/ioutil2"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+// localPoint is a checkpoint saved in the local file
+type localPoint struct {
+	Schema     string `json:"schema,omitempty"`
+	Table      string `json:"table,omitempty"`
+	BinlogName string `json:"binlog-name"`
+	BinlogPos  uint32 `json:"binlog-pos"`
+	BinlogGTID string `json:"binlog-gtid,omitempty"`
+	TableInfo  string `json:"table-info,omitempty"`
+}
+
+// localCheckPoints are all checkpoints saved in the local file
+type localCheckPoints struct {
+	Global localPoint   `json:"global"`
+	Tables []localPoint `json:"tables"`
+}
+
+// newLocalPoint returns the current point if current is true, otherwise the flushed point
+func newLocalPoint(schema, table string, b *binlogPoint, current bool) localPoint {
+	b.RLock()
+	defer b.RUnlock()
+	p := localPoint{Schema: schema, Table: table}
+	pos, gs, info := b.flushedPos, b.flushedGTIDSet, b.flushedTableInfo
+	if current {
+		pos, gs, info = b.Position, b.gtidSet, b.tableInfo
+	}
+	p.BinlogName, p.BinlogPos, p.BinlogGTID, p.TableInfo = pos.Name, pos.Pos, gtidSetString(gs), info
+	return p
+}
+
+// flushedOnce returns whether the point has been flushed or loaded
+func (b *binlogPoint) flushedOnce() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.flushedPos.Compare(minCheckpoint) != 0 || b.flushedGTIDSet != nil || len(b.flushedTableInfo) > 0
+}
+
+// LocalCheckPoint implements CheckPoint
+// which saves checkpoints in a local JSON file in `file-sink.dir`,
+// used when jobs are not executed in target database, like the file sink and the SQL sink.
+// checkpoints in memory are the same with RemoteCheckPoint
+type LocalCheckPoint struct {
+	*RemoteCheckPoint
+
+	filename string
+}
+
+// NewLocalCheckPoint creates a new LocalCheckPoint
+func NewLocalCheckPoint(cfg *config.SubTaskConfig, id string) CheckPoint {
+	remote := NewRemoteCheckPoint(cfg, id).(*RemoteCheckPoint)
+	return &LocalCheckPoint{
+		RemoteCheckPoint: remote,
+		filename:         filepath.Join(cfg.FileSink.Dir, fmt.Sprintf("%s.%s.json", remote.table, id)),
+	}
+}
+
+// Init implements CheckPoint.Init
+func (cp *LocalCheckPoint) Init() error {
+	err := os.MkdirAll(filepath.Dir(cp.filename), 0755)
+	return errors.Annotatef(err, "create dir of checkpoint file %s", cp.filename)
+}
+
+// Close implements CheckPoint.Close
+func (cp *LocalCheckPoint) Close() {}
+
+// Clear implements CheckPoint.Clear
+func (cp *LocalCheckPoint) Clear() error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	err := os.Remove(cp.filename)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+
+	cp.globalPoint = newBinlogPoint(minCheckpoint, nil, minCheckpoint, nil)
+
+	cp.points = make(map[string]map[string]*binlogPoint)
+
+	return nil
+}
+
+// DeleteTablePoint implements CheckPoint.DeleteTablePoint
+func (cp *LocalCheckPoint) DeleteTablePoint(sourceSchema, sourceTable string) error {
+	cp.Lock()
+	defer cp.Unlock()
+	mSchema, ok := cp.points[sourceSchema]
+	if !ok {
+		return nil
+	}
+	_, ok = mSchema[sourceTable]
+	if !ok {
+		return nil
+	}
+
+	// other checkpoints are kept as flushed
+	cps := localCheckPoints{Global: newLocalPoint("", "", cp.globalPoint, false)}
+	for schema, mSchema := range cp.points {
+		for table, point := range mSchema {
+			if (schema != sourceSchema || table != sourceTable) && point.flushedOnce() {
+				cps.Tables = append(cps.Tables, newLocalPoint(schema, table, point, false))
+			}
+		}
+	}
+	err := cp.save(&cps)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	delete(mSchema, sourceTable)
+	return nil
+}
+
+// FlushPointsExcept implements CheckPoint.FlushPointsExcept
+func (cp *LocalCheckPoint) FlushPointsExcept(exceptTables [][]string) error {
+	cp.RLock()
+	defer cp.RUnlock()
+
+	excepts := make(map[string]map[string]struct{})
+	for _, schemaTable := range exceptTables {
+		schema, table := schemaTable[0], schemaTable[1]
+		if _, ok := excepts[schema]; !ok {
+			excepts[schema] = make(map[string]struct{})
+		}
+		excepts[schema][table] = struct{}{}
+	}
+
+	// checkpoints of except tables are kept as flushed
+	cps := localCheckPoints{Global: newLocalPoint("", "", cp.globalPoint, true)}
+	points := make([]*binlogPoint, 0, 100)
+	for schema, mSchema := range cp.points {
+		for table, point := range mSchema {
+			if _, ok := excepts[schema][table]; ok {
+				if point.flushedOnce() {
+					cps.Tables = append(cps.Tables, newLocalPoint(schema, table, point, false))
+				}
+				continue
+			}
+			cps.Tables = append(cps.Tables, newLocalPoint(schema, table, point, true))
+			points = append(points, point)
+		}
+	}
+	err := cp.save(&cps)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cp.globalPoint.flush()
+	for _, point := range points {
+		point.flush()
+	}
+
+	cp.globalPointSaveTime = time.Now()
+	return nil
+}
+
+// Load implements CheckPoint.Load
+func (cp *LocalCheckPoint) Load() error {
+	data, err := ioutil.ReadFile(cp.filename)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	var cps localCheckPoints
+	err = json.Unmarshal(data, &cps)
+	if err != nil {
+		return errors.Annotatef(err, "checkpoint file %s", cp.filename)
+	}
+	for _, p := range append(cps.Tables, cps.Global) {
+		isGlobal := len(p.Schema) == 0 && len(p.Table) == 0
+		err = cp.loadPoint(p.Schema, p.Table, mysql.Position{Name: p.BinlogName, Pos: p.BinlogPos}, p.BinlogGTID, p.TableInfo, isGlobal)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	log.Infof("[checkpoint] load %d table checkpoints from file %s", len(cps.Tables), cp.filename)
+	return nil
+}
+
+// save writes checkpoints into the file atomically
+func (cp *LocalCheckPoint) save(cps *localCheckPoints) error {
+	data, err := json.Marshal(cps)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = ioutil2.WriteFileAtomic(cp.filename, data, 0644)
+	return errors.Annotatef(err, "save checkpoints into file %s", cp.filename)
+}
@@ -15,25 +15,36 @@ package syncer
 
 import (
 	"context"
+	"path"
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
 
+	"github.com/pingcap/dm/dm/config"
 	"github.com/pingcap/dm/dm/pb"
 	"github.com/pingcap/dm/dm/unit"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/utils"
 	sm "github.com/pingcap/dm/syncer/safe-mode"
 )
 
 func (s *Syncer) enableSafeModeInitializationPhase(ctx context.Context, safeMode *sm.SafeMode) {
 	safeMode.Reset() // in initialization phase, reset first
-	safeMode.Add(1)  // try to enable
 
 	if s.cfg.SafeMode {
 		safeMode.Add(1) // add 1 but should no corresponding -1
 		log.Info("[syncer] enable safe-mode by config")
 	}
 
+	if s.cfg.ExactlyOnce {
+		// checkpoints are saved along with DMLs, re-syncing from them is exact
+		log.Info("[syncer] exactly-once enabled, not enable safe-mode in initialization phase")
+		return
+	}
+
+	safeMode.Add(1) // try to enable
+
 	go func() {
 		defer func() {
 			err := safeMode.Add(-1) // try to disable after 5 minutes
@@ -49,3 +60,27 @@ func (s *Syncer) enableSafeModeInitializationPhase(ctx context.Context, safeMode
 		}
 	}()
 }
+
+// enableSafeModeForDumpSnapshots enables safe-mode until reaching the latest snapshot of the dump unit.
+// tables may be dumped in different snapshots after the dump resumed, syncer starts from the first one,
+// so binlog events before the latest snapshot may have been dumped already for some tables.
+// returns the position to disable safe-mode, nil if not needed
+func (s *Syncer) enableSafeModeForDumpSnapshots(safeMode *sm.SafeMode, pos mysql.Position) (*mysql.Position, error) {
+	if s.cfg.Mode != config.ModeAll {
+		return nil, nil
+	}
+	metafile := path.Join(s.cfg.Dir, "metadata")
+	if !utils.IsFileExists(metafile) {
+		return nil, nil
+	}
+	latestPos, err := utils.ParseMetaDataLatestPos(metafile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if latestPos == nil || pos.Compare(*latestPos) >= 0 {
+		return nil, nil
+	}
+
+	log.Infof("[syncer] enable safe-mode until reaching the latest snapshot %s of the dump unit", latestPos)
+	return latestPos, errors.Trace(safeMode.Add(1))
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	sm "github.com/pingcap/dm/syncer/safe-mode"
+)
+
+var _ = Suite(&testModeSuite{})
+
+type testModeSuite struct{}
+
+func (t *testModeSuite) TestEnableSafeModeForDumpSnapshots(c *C) {
+	cfg := &config.SubTaskConfig{Mode: config.ModeAll}
+	cfg.Dir = c.MkDir()
+	s := &Syncer{cfg: cfg}
+	safeMode := sm.NewSafeMode()
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 4}
+
+	// no metadata
+	exitPos, err := s.enableSafeModeForDumpSnapshots(safeMode, pos)
+	c.Assert(err, IsNil)
+	c.Assert(exitPos, IsNil)
+
+	// only one snapshot
+	metafile := filepath.Join(cfg.Dir, "metadata")
+	c.Assert(ioutil.WriteFile(metafile, []byte(`Started dump at: 2019-03-01 10:00:00
+SHOW MASTER STATUS:
+	Log: mysql-bin.000001
+	Pos: 4
+
+Finished dump at: 2019-03-01 10:00:01
+`), 0644), IsNil)
+	exitPos, err = s.enableSafeModeForDumpSnapshots(safeMode, pos)
+	c.Assert(err, IsNil)
+	c.Assert(exitPos, IsNil)
+	c.Assert(safeMode.Enable(), IsFalse)
+
+	// resumed in a later snapshot
+	c.Assert(ioutil.WriteFile(metafile, []byte(`Started dump at: 2019-03-01 10:00:00
+SHOW MASTER STATUS:
+	Log: mysql-bin.000001
+	Pos: 4
+
+SHOW MASTER STATUS (latest snapshot):
+	Log: mysql-bin.000002
+	Pos: 154
+
+Finished dump at: 2019-03-01 12:00:01
+`), 0644), IsNil)
+	exitPos, err = s.enableSafeModeForDumpSnapshots(safeMode, pos)
+	c.Assert(err, IsNil)
+	c.Assert(exitPos, DeepEquals, &mysql.Position{Name: "mysql-bin.000002", Pos: 154})
+	c.Assert(safeMode.Enable(), IsTrue)
+
+	// already passed the latest snapshot
+	safeMode.Reset()
+	exitPos, err = s.enableSafeModeForDumpSnapshots(safeMode, mysql.Position{Name: "mysql-bin.000002", Pos: 154})
+	c.Assert(err, IsNil)
+	c.Assert(exitPos, IsNil)
+	c.Assert(safeMode.Enable(), IsFalse)
+
+	// not in all mode
+	cfg.Mode = config.ModeIncrement
+	exitPos, err = s.enableSafeModeForDumpSnapshots(safeMode, pos)
+	c.Assert(err, IsNil)
+	c.Assert(exitPos, IsNil)
+}
@@ -16,6 +16,9 @@ package syncer
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +26,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go/ioutil2"
 
 	"github.com/pingcap/dm/dm/config"
 )
@@ -78,10 +82,11 @@ type OnlineDDLStorage struct {
 
 	cfg *config.SubTaskConfig
 
-	db     *Conn
-	schema string // schema name, set through task config
-	table  string // table name, now it's task name
-	id     string // now it is `server-id` used as MySQL slave
+	db       *Conn
+	schema   string // schema name, set through task config
+	table    string // table name, now it's task name
+	id       string // now it is `server-id` used as MySQL slave
+	filename string // local file to store information instead of target database, only used by the SQL sink
 
 	// map ghost schema => [ghost table => ghost ddl info, ...]
 	ddls map[string]map[string]*GhostDDLInfo
@@ -96,12 +101,20 @@ func NewOnlineDDLStorage(cfg *config.SubTaskConfig) *OnlineDDLStorage {
 		id:     strconv.Itoa(cfg.ServerID),
 		ddls:   make(map[string]map[string]*GhostDDLInfo),
 	}
+	if cfg.Sink != config.SinkMySQL {
+		// no target database for the file sink and the SQL sink
+		s.filename = filepath.Join(cfg.FileSink.Dir, fmt.Sprintf("%s.%s.json", s.table, s.id))
+	}
 
 	return s
 }
 
 // Init initials online handler
 func (s *OnlineDDLStorage) Init() error {
+	if len(s.filename) > 0 {
+		return errors.Trace(s.loadFile())
+	}
+
 	db, err := createDB(s.cfg, s.cfg.To, maxCheckPointTimeout)
 	if err != nil {
 		return errors.Trace(err)
@@ -194,6 +207,9 @@ func (s *OnlineDDLStorage) Save(ghostSchema, ghostTable, realSchema, realTable,
 	// maybe we meed more checks for it
 
 	info.DDLs = append(info.DDLs, ddl)
+	if len(s.filename) > 0 {
+		return errors.Trace(s.saveFile())
+	}
 	ddlsBytes, err := json.Marshal(mSchema[ghostTable])
 	if err != nil {
 		return errors.Trace(err)
@@ -214,6 +230,16 @@ func (s *OnlineDDLStorage) Delete(ghostSchema, ghostTable string) error {
 		return nil
 	}
 
+	if len(s.filename) > 0 {
+		info := mSchema[ghostTable]
+		delete(mSchema, ghostTable)
+		err := s.saveFile()
+		if err != nil {
+			mSchema[ghostTable] = info
+		}
+		return errors.Trace(err)
+	}
+
 	// delete all checkpoints
 	sql := fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE `id` = '%s' and `ghost_schema` = '%s' and `ghost_table` = '%s'", s.schema, s.table, s.id, ghostSchema, ghostTable)
 	err := s.db.executeSQL([]string{sql}, [][]interface{}{nil}, maxRetryCount)
@@ -230,11 +256,18 @@ func (s *OnlineDDLStorage) Clear() error {
 	s.Lock()
 	defer s.Unlock()
 
-	// delete all checkpoints
-	sql := fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE `id` = '%s'", s.schema, s.table, s.id)
-	err := s.db.executeSQL([]string{sql}, [][]interface{}{nil}, maxRetryCount)
-	if err != nil {
-		return errors.Trace(err)
+	if len(s.filename) > 0 {
+		err := os.Remove(s.filename)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+	} else {
+		// delete all checkpoints
+		sql := fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE `id` = '%s'", s.schema, s.table, s.id)
+		err := s.db.executeSQL([]string{sql}, [][]interface{}{nil}, maxRetryCount)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	s.ddls = make(map[string]map[string]*GhostDDLInfo)
@@ -280,6 +313,33 @@ func (s *OnlineDDLStorage) createTable() error {
 	return errors.Trace(err)
 }
 
+// loadFile loads information from the local file
+func (s *OnlineDDLStorage) loadFile() error {
+	s.Lock()
+	defer s.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(s.filename), 0755)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(s.filename)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(json.Unmarshal(data, &s.ddls), "online ddl file %s", s.filename)
+}
+
+// saveFile saves all information into the local file atomically
+func (s *OnlineDDLStorage) saveFile() error {
+	data, err := json.Marshal(s.ddls)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ioutil2.WriteFileAtomic(s.filename, data, 0644))
+}
+
 func escapeSingleQuote(str string) string {
 	return strings.Replace(str, "'", "''", -1)
 }
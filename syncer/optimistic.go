@@ -0,0 +1,171 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+	parserpkg "github.com/pingcap/dm/pkg/parser"
+	"github.com/pingcap/dm/pkg/shardddl"
+)
+
+// checkOptimisticDDL checks whether the DDL is supported in optimistic shard mode.
+// DDLs of shard tables are applied in downstream before other shard tables executing them,
+// so DDLs which make DMLs of other shard tables fail or change their meaning are not supported,
+// like adding a NOT NULL column without default value, or renaming a column
+func checkOptimisticDDL(stmt ast.StmtNode) error {
+	switch v := stmt.(type) {
+	case *ast.CreateDatabaseStmt, *parserpkg.AlterDatabaseStmt, *ast.CreateTableStmt, *ast.DropDatabaseStmt, *ast.DropTableStmt, *ast.TruncateTableStmt, *ast.RenameTableStmt:
+		// handled by sharding groups
+		return nil
+	case *ast.CreateIndexStmt, *ast.DropIndexStmt:
+		return nil
+	case *ast.AlterTableStmt:
+		for _, spec := range v.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns:
+				for _, def := range spec.NewColumns {
+					if isNotNullWithoutDefault(def) {
+						return errors.NotSupportedf("add column %s NOT NULL without default value in optimistic shard mode", def.Name.Name.O)
+					}
+				}
+			case ast.AlterTableChangeColumn:
+				if len(spec.NewColumns) > 0 && spec.OldColumnName.Name.L != spec.NewColumns[0].Name.Name.L {
+					return errors.NotSupportedf("rename column %s in optimistic shard mode", spec.OldColumnName.Name.O)
+				}
+			case ast.AlterTableDropColumn, ast.AlterTableModifyColumn, ast.AlterTableAlterColumn,
+				ast.AlterTableAddConstraint, ast.AlterTableDropIndex, ast.AlterTableRenameIndex, ast.AlterTableOption,
+				ast.AlterTableRenameTable:
+			default:
+				return errors.NotSupportedf("alter table type %d in optimistic shard mode", spec.Tp)
+			}
+		}
+		return nil
+	}
+	return errors.NotSupportedf("DDL %T in optimistic shard mode", stmt)
+}
+
+// syncedOptimistically returns whether the sharding DDL is synced in the optimistic way in optimistic shard mode,
+// schema-level DDLs and DDLs truncating or dropping tables are synced by sharding groups as in pessimistic shard mode
+func syncedOptimistically(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.CreateDatabaseStmt, *parserpkg.AlterDatabaseStmt, *ast.DropDatabaseStmt, *ast.DropTableStmt, *ast.TruncateTableStmt:
+		return false
+	}
+	return true
+}
+
+// isNotNullWithoutDefault returns whether the column is NOT NULL without default value,
+// DMLs not specifying values of the column fail for such column
+func isNotNullWithoutDefault(def *ast.ColumnDef) bool {
+	var notNull, hasDefault bool
+	for _, opt := range def.Options {
+		switch opt.Tp {
+		case ast.ColumnOptionNotNull, ast.ColumnOptionPrimaryKey:
+			notNull = true
+		case ast.ColumnOptionDefaultValue, ast.ColumnOptionAutoIncrement:
+			hasDefault = true
+		}
+	}
+	return notNull && !hasDefault
+}
+
+// trackedTableBeforeDDL checks whether the DDL is supported in optimistic shard mode,
+// and returns the tracked structure of the table before the DDL, nil if the DDL isn't altering a table
+func (s *Syncer) trackedTableBeforeDDL(stmt ast.StmtNode, tableNames [][]*filter.Table) (*shardddl.Table, error) {
+	if err := checkOptimisticDDL(stmt); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch stmt.(type) {
+	case *ast.AlterTableStmt, *ast.CreateIndexStmt, *ast.DropIndexStmt:
+	default:
+		return nil, nil
+	}
+	t, err := s.loadTrackedTable(tableNames[0][0].Schema, tableNames[0][0].Name, tableNames[1][0].Schema, tableNames[1][0].Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return shardTable(t), nil
+}
+
+// handleOptimisticDDL coordinates the sharding DDL with dm-master in optimistic shard mode,
+// returns the DDL exec item from dm-master, whether it's canceled from external when waiting for dm-master.
+// DDLs in the request of the item, generated by dm-master from the changes of the joined schema, are executed instead of the DDLs.
+// nil is returned for DDLs not changing the structure of the table, like creating a non-unique index,
+// which are executed directly without coordination
+func (s *Syncer) handleOptimisticDDL(ddlInfo *shardingDDLInfo, ddls []string, before *shardddl.Table) (*DDLExecItem, bool, error) {
+	var (
+		schema       = ddlInfo.tableNames[0][0].Schema
+		table        = ddlInfo.tableNames[0][0].Name
+		targetSchema = ddlInfo.tableNames[1][0].Schema
+		targetTable  = ddlInfo.tableNames[1][0].Name
+	)
+	source, _ := GenTableID(schema, table)
+	tracked := s.schemaTracker.getTable(schema, table)
+	if before == nil || tracked == nil {
+		return nil, false, errors.NotFoundf("tracked structure of table %s", source)
+	}
+	after := shardTable(tracked)
+	if before.Equal(after) {
+		log.Infof("[syncer] DDLs %v not changing the structure of table %s, execute them directly", ddls, source)
+		return nil, false, nil
+	}
+
+	sources := []string{source}
+	if group := s.sgk.Group(targetSchema, targetTable); group != nil {
+		for id := range group.Sources() {
+			if id != source {
+				sources = append(sources, id)
+			}
+		}
+	}
+	sort.Strings(sources)
+
+	// Don't send new DDLInfo to dm-master until all local sql jobs finished
+	s.jobWg.Wait()
+
+	info := &pb.DDLInfo{
+		Task:        s.cfg.Name,
+		Schema:      targetSchema,
+		Table:       targetTable,
+		DDLs:        ddls,
+		Optimistic:  true,
+		Source:      source,
+		Sources:     sources,
+		TableBefore: before.String(),
+		TableAfter:  after.String(),
+	}
+	s.ddlInfoCh <- info // save DDLInfo, and dm-worker will fetch it
+
+	// block and wait dm-master to compute the joined schema
+	item, ok := <-s.ddlExecInfo.Chan(ddls)
+	if !ok {
+		return nil, true, nil
+	}
+	if err := s.failedByMaster(item); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if item.req.Exec {
+		log.Infof("[syncer] add DDL %v of table %s to job as %v, request is %+v", ddls, source, item.req.DDLs, item.req)
+	} else {
+		log.Infof("[syncer] ignore DDL %v of table %s not changing the joined schema, request is %+v", ddls, source, item.req)
+	}
+	return item, false, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser"
+)
+
+var _ = Suite(&testOptimisticSuite{})
+
+type testOptimisticSuite struct{}
+
+func (t *testOptimisticSuite) TestCheckOptimisticDDL(c *C) {
+	cases := []struct {
+		sql       string
+		supported bool
+	}{
+		{"CREATE TABLE `tb` (`id` INT PRIMARY KEY)", true},
+		{"DROP TABLE `tb`", true},
+		{"ALTER TABLE `tb` ADD COLUMN `c` INT", true},
+		{"ALTER TABLE `tb` ADD COLUMN `c` INT NOT NULL DEFAULT 0", true},
+		{"ALTER TABLE `tb` ADD COLUMN `c` INT NOT NULL", false},
+		{"ALTER TABLE `tb` MODIFY COLUMN `c` BIGINT", true},
+		{"ALTER TABLE `tb` CHANGE COLUMN `c` `c` BIGINT", true},
+		{"ALTER TABLE `tb` CHANGE COLUMN `c` `d` BIGINT", false},
+		{"ALTER TABLE `tb` DROP COLUMN `c`", true},
+		{"ALTER TABLE `tb` ADD UNIQUE KEY `uk` (`c`)", true},
+		{"ALTER TABLE `tb` RENAME TO `tb2`", true},
+		{"ALTER TABLE `tb` DROP PARTITION `p1`", false},
+		{"CREATE INDEX `idx` ON `tb` (`c`)", true},
+		{"RENAME TABLE `tb` TO `tb2`", true},
+	}
+	p := parser.New()
+	for _, cs := range cases {
+		stmt, err := p.ParseOneStmt(cs.sql, "", "")
+		c.Assert(err, IsNil)
+		err = checkOptimisticDDL(stmt)
+		if cs.supported {
+			c.Assert(err, IsNil, Commentf("%s", cs.sql))
+		} else {
+			c.Assert(err, NotNil, Commentf("%s", cs.sql))
+		}
+	}
+}
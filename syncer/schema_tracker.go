@@ -0,0 +1,597 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	tmysql "github.com/pingcap/parser/mysql"
+	"github.com/pingcap/parser/opcode"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+	parserpkg "github.com/pingcap/dm/pkg/parser"
+	"github.com/pingcap/dm/pkg/shardddl"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+const primaryKeyName = "primary" // key name of primary key, lower case like in `getTableIndex`
+
+// schemaTracker tracks structures of upstream tables by replaying upstream DDLs in binlog order
+// on top of the structures loaded before (from checkpoint or downstream),
+// so DMLs can be generated with the structure of the upstream table when the binlog event written,
+// even if the downstream table differs from it, like in sharding DDL syncing.
+//
+// tables are keyed by `source-schema`.`source-table`, and tracked tables are never modified in place,
+// so it's safe to hold a tracked table after DDLs replayed.
+type schemaTracker struct {
+	sync.RWMutex
+	tables map[string]*table
+}
+
+func newSchemaTracker() *schemaTracker {
+	return &schemaTracker{
+		tables: make(map[string]*table),
+	}
+}
+
+// reset forgets all tracked tables, they should be loaded again
+func (st *schemaTracker) reset() {
+	st.Lock()
+	defer st.Unlock()
+	st.tables = make(map[string]*table)
+}
+
+// getTable returns the tracked table, nil if not tracked
+func (st *schemaTracker) getTable(schema, name string) *table {
+	st.RLock()
+	defer st.RUnlock()
+	return st.tables[dbutil.TableName(schema, name)]
+}
+
+// setTable starts to track the table, or replaces the tracked one
+func (st *schemaTracker) setTable(t *table) {
+	st.Lock()
+	defer st.Unlock()
+	st.tables[dbutil.TableName(t.schema, t.name)] = t
+}
+
+// applyDDL replays the upstream DDL on tracked tables, `tableNames` is the upstream tables fetched from the DDL.
+// NOTE: table names in `stmt` may be already renamed to target ones, so always use `tableNames`.
+// tables not tracked yet should be loaded before, otherwise they are left not tracked.
+func (st *schemaTracker) applyDDL(stmt ast.StmtNode, tableNames []*filter.Table) error {
+	st.Lock()
+	defer st.Unlock()
+
+	switch v := stmt.(type) {
+	case *ast.CreateDatabaseStmt, *parserpkg.AlterDatabaseStmt, *ast.TruncateTableStmt:
+		// structure of tables not changed
+	case *ast.DropDatabaseStmt:
+		prefix := dbutil.TableName(tableNames[0].Schema, "")
+		prefix = prefix[:len(prefix)-2] // trim "``"
+		for key := range st.tables {
+			if strings.HasPrefix(key, prefix) {
+				delete(st.tables, key)
+			}
+		}
+	case *ast.CreateTableStmt:
+		key := dbutil.TableName(tableNames[0].Schema, tableNames[0].Name)
+		if v.ReferTable != nil {
+			refer, ok := st.tables[dbutil.TableName(tableNames[1].Schema, tableNames[1].Name)]
+			if !ok {
+				delete(st.tables, key)
+				return errors.NotFoundf("tracked table %s.%s", tableNames[1].Schema, tableNames[1].Name)
+			}
+			t := cloneTable(refer)
+			t.schema, t.name = tableNames[0].Schema, tableNames[0].Name
+			st.tables[key] = t
+			return nil
+		}
+		t := &table{
+			schema:       tableNames[0].Schema,
+			name:         tableNames[0].Name,
+			indexColumns: make(map[string][]*column),
+		}
+		for _, def := range v.Cols {
+			if err := addColumn(t, def, nil); err != nil {
+				return errors.Annotatef(err, "create table %s.%s", t.schema, t.name)
+			}
+		}
+		for _, cons := range v.Constraints {
+			addConstraint(t, cons)
+		}
+		st.tables[key] = t
+	case *ast.DropTableStmt:
+		delete(st.tables, dbutil.TableName(tableNames[0].Schema, tableNames[0].Name))
+	case *ast.RenameTableStmt:
+		st.renameTable(tableNames[0], tableNames[1])
+	case *ast.CreateIndexStmt:
+		return st.alterTable(tableNames[0], func(t *table) error {
+			if v.Unique {
+				addIndex(t, v.IndexName, v.IndexColNames)
+			}
+			return nil
+		})
+	case *ast.DropIndexStmt:
+		return st.alterTable(tableNames[0], func(t *table) error {
+			delete(t.indexColumns, strings.ToLower(v.IndexName))
+			return nil
+		})
+	case *ast.AlterTableStmt:
+		for _, spec := range v.Specs {
+			if spec.Tp == ast.AlterTableRenameTable {
+				st.renameTable(tableNames[0], tableNames[1])
+				continue
+			}
+			err := st.alterTable(tableNames[0], func(t *table) error {
+				return alterTable(t, spec)
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	default:
+		return errors.NotSupportedf("DDL %T", stmt)
+	}
+	return nil
+}
+
+// getTableFromDump returns structure of the source table in dump files of the dump unit,
+// which is the structure at the start position of the syncer. returns nil if the table not dumped
+func (s *Syncer) getTableFromDump(schema, name string) (*table, error) {
+	if s.cfg.Mode != config.ModeAll {
+		return nil, nil
+	}
+	file := filepath.Join(s.cfg.Dir, fmt.Sprintf("%s.%s-schema.sql", schema, name))
+	if !utils.IsFileExists(file) {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stmts, err := parserpkg.Parse(parser.New(), string(data), "", "")
+	if err != nil {
+		return nil, errors.Annotatef(err, "parse dump file %s", file)
+	}
+	for _, stmt := range stmts {
+		if _, ok := stmt.(*ast.CreateTableStmt); !ok {
+			continue
+		}
+		st := newSchemaTracker()
+		err = st.applyDDL(stmt, []*filter.Table{{Schema: schema, Name: name}})
+		if err != nil {
+			return nil, errors.Annotatef(err, "track table from dump file %s", file)
+		}
+		return st.getTable(schema, name), nil
+	}
+	return nil, errors.Errorf("no CREATE TABLE statement in dump file %s", file)
+}
+
+// alterTable applies fn on a copy of the tracked table, then replaces the tracked one
+func (st *schemaTracker) alterTable(tbl *filter.Table, fn func(t *table) error) error {
+	key := dbutil.TableName(tbl.Schema, tbl.Name)
+	t, ok := st.tables[key]
+	if !ok {
+		return errors.NotFoundf("tracked table %s.%s", tbl.Schema, tbl.Name)
+	}
+	t = cloneTable(t)
+	if err := fn(t); err != nil {
+		// the tracked table may be mismatched now, load it again later
+		delete(st.tables, key)
+		return errors.Annotatef(err, "alter table %s.%s", tbl.Schema, tbl.Name)
+	}
+	st.tables[key] = t
+	return nil
+}
+
+func (st *schemaTracker) renameTable(oldTbl, newTbl *filter.Table) {
+	oldKey := dbutil.TableName(oldTbl.Schema, oldTbl.Name)
+	newKey := dbutil.TableName(newTbl.Schema, newTbl.Name)
+	t, ok := st.tables[oldKey]
+	delete(st.tables, oldKey)
+	if !ok {
+		delete(st.tables, newKey)
+		return
+	}
+	t = cloneTable(t)
+	t.schema, t.name = newTbl.Schema, newTbl.Name
+	st.tables[newKey] = t
+}
+
+// alterTable applies an ALTER TABLE spec on the table
+func alterTable(t *table, spec *ast.AlterTableSpec) error {
+	switch spec.Tp {
+	case ast.AlterTableAddColumns:
+		for _, def := range spec.NewColumns {
+			if err := addColumn(t, def, spec.Position); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	case ast.AlterTableDropColumn:
+		dropColumn(t, spec.OldColumnName.Name.O)
+	case ast.AlterTableModifyColumn:
+		return errors.Trace(changeColumn(t, spec.NewColumns[0].Name.Name.O, spec.NewColumns[0], spec.Position))
+	case ast.AlterTableChangeColumn:
+		return errors.Trace(changeColumn(t, spec.OldColumnName.Name.O, spec.NewColumns[0], spec.Position))
+	case ast.AlterTableAddConstraint:
+		addConstraint(t, spec.Constraint)
+	case ast.AlterTableDropPrimaryKey:
+		delete(t.indexColumns, primaryKeyName)
+	case ast.AlterTableDropIndex:
+		delete(t.indexColumns, strings.ToLower(spec.Name))
+	case ast.AlterTableRenameIndex:
+		from, to := strings.ToLower(spec.FromKey.O), strings.ToLower(spec.ToKey.O)
+		if cols, ok := t.indexColumns[from]; ok {
+			delete(t.indexColumns, from)
+			t.indexColumns[to] = cols
+		}
+	case ast.AlterTableAlterColumn:
+		return alterColumnDefault(t, spec.NewColumns[0])
+	default:
+		// other specs (like table options, partitions) not change columns and unique keys
+	}
+	return nil
+}
+
+// newColumn creates a column from the column definition, same as the one got from `SHOW COLUMNS`
+func newColumn(def *ast.ColumnDef) *column {
+	c := &column{
+		name:     def.Name.Name.O,
+		tp:       def.Tp.InfoSchemaStr(),
+		unsigned: tmysql.HasUnsignedFlag(def.Tp.Flag),
+	}
+	for _, opt := range def.Options {
+		switch opt.Tp {
+		case ast.ColumnOptionNotNull, ast.ColumnOptionPrimaryKey:
+			c.NotNull = true
+		case ast.ColumnOptionNull:
+			c.NotNull = false
+		case ast.ColumnOptionDefaultValue:
+			c.defaultValue = columnDefault(opt.Expr)
+		case ast.ColumnOptionAutoIncrement:
+			c.extra = "auto_increment"
+		case ast.ColumnOptionGenerated:
+			if opt.Stored {
+				c.extra = "STORED GENERATED"
+			} else {
+				c.extra = "VIRTUAL GENERATED"
+			}
+		}
+	}
+	return c
+}
+
+// columnDefault returns the default value of the expression, same as `Default` got from `SHOW COLUMNS`
+func columnDefault(expr ast.ExprNode) *string {
+	var value string
+	switch v := expr.(type) {
+	case ast.ValueExpr:
+		if v.GetValue() == nil {
+			return nil
+		}
+		value = literalString(v)
+	case *ast.UnaryOperationExpr:
+		if val, ok := v.V.(ast.ValueExpr); ok && v.Op == opcode.Minus && val.GetValue() != nil {
+			value = "-" + literalString(val)
+			break
+		}
+		value = restoreExpr(expr)
+	case *ast.FuncCallExpr:
+		switch v.FnName.L {
+		case ast.CurrentTimestamp, ast.Now, ast.LocalTime, ast.LocalTimestamp:
+			value = "CURRENT_TIMESTAMP"
+			if len(v.Args) > 0 {
+				value += "(" + restoreExpr(v.Args[0]) + ")"
+			}
+		default:
+			value = restoreExpr(expr)
+		}
+	default:
+		value = restoreExpr(expr)
+	}
+	return &value
+}
+
+// literalString returns the literal value without quotes
+func literalString(v ast.ValueExpr) string {
+	switch val := v.GetValue().(type) {
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// restoreExpr restores the expression to SQL text, empty if failed
+func restoreExpr(expr ast.ExprNode) string {
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		log.Warnf("[schema-tracker] restore expression %+v error %v", expr, err)
+	}
+	return sb.String()
+}
+
+// alterColumnDefault sets or drops the default value of the column, like `ALTER COLUMN c SET DEFAULT 1`
+func alterColumnDefault(t *table, def *ast.ColumnDef) error {
+	c := findTrackedColumn(t.columns, def.Name.Name.O)
+	if c == nil {
+		return errors.NotFoundf("column %s", def.Name.Name.O)
+	}
+	c.defaultValue = nil
+	if len(def.Options) > 0 {
+		c.defaultValue = columnDefault(def.Options[0].Expr)
+	}
+	return nil
+}
+
+// addColumn adds a column to the table in the position, a column with the same name is replaced
+func addColumn(t *table, def *ast.ColumnDef, pos *ast.ColumnPosition) error {
+	c := newColumn(def)
+	if old := findTrackedColumn(t.columns, c.name); old != nil {
+		log.Warnf("[schema-tracker] column %s already exists in table %s.%s, replace it", c.name, t.schema, t.name)
+		dropColumn(t, c.name)
+	}
+	if err := insertColumn(t, c, pos); err != nil {
+		return errors.Trace(err)
+	}
+	addColumnIndexes(t, def)
+	return nil
+}
+
+// changeColumn replaces the column named oldName with the column definition, and moves it to the position
+func changeColumn(t *table, oldName string, def *ast.ColumnDef, pos *ast.ColumnPosition) error {
+	old := findTrackedColumn(t.columns, oldName)
+	if old == nil {
+		// the change may be already applied, like the table loaded from downstream in sharding DDL syncing
+		log.Warnf("[schema-tracker] column %s not exists in table %s.%s, add it as %s", oldName, t.schema, t.name, def.Name.Name.O)
+		return errors.Trace(addColumn(t, def, pos))
+	}
+	c := newColumn(def)
+	if pos == nil || pos.Tp == ast.ColumnPositionNone {
+		t.columns[old.idx] = c
+		resetColumnIdx(t)
+	} else {
+		t.columns = append(t.columns[:old.idx], t.columns[old.idx+1:]...)
+		resetColumnIdx(t)
+		if err := insertColumn(t, c, pos); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for key, cols := range t.indexColumns {
+		for i, col := range cols {
+			if col == old {
+				cols[i] = c
+			}
+		}
+		t.indexColumns[key] = cols
+	}
+	addColumnIndexes(t, def)
+	return nil
+}
+
+// dropColumn drops the column and removes it from unique keys, a key is dropped if all its columns dropped
+func dropColumn(t *table, name string) {
+	c := findTrackedColumn(t.columns, name)
+	if c == nil {
+		log.Warnf("[schema-tracker] column %s not exists in table %s.%s, ignore dropping it", name, t.schema, t.name)
+		return
+	}
+	t.columns = append(t.columns[:c.idx], t.columns[c.idx+1:]...)
+	resetColumnIdx(t)
+
+	for key, cols := range t.indexColumns {
+		remain := make([]*column, 0, len(cols))
+		for _, col := range cols {
+			if col != c {
+				remain = append(remain, col)
+			}
+		}
+		if len(remain) == 0 {
+			delete(t.indexColumns, key)
+		} else {
+			t.indexColumns[key] = remain
+		}
+	}
+}
+
+func insertColumn(t *table, c *column, pos *ast.ColumnPosition) error {
+	idx := len(t.columns)
+	if pos != nil {
+		switch pos.Tp {
+		case ast.ColumnPositionFirst:
+			idx = 0
+		case ast.ColumnPositionAfter:
+			relative := findTrackedColumn(t.columns, pos.RelativeColumn.Name.O)
+			if relative == nil {
+				return errors.NotFoundf("column %s", pos.RelativeColumn.Name.O)
+			}
+			idx = relative.idx + 1
+		}
+	}
+	t.columns = append(t.columns, nil)
+	copy(t.columns[idx+1:], t.columns[idx:])
+	t.columns[idx] = c
+	resetColumnIdx(t)
+	return nil
+}
+
+// findTrackedColumn finds the column by name, case-insensitive like column names in MySQL
+func findTrackedColumn(columns []*column, name string) *column {
+	for _, c := range columns {
+		if strings.EqualFold(c.name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func resetColumnIdx(t *table) {
+	for i, c := range t.columns {
+		c.idx = i
+	}
+}
+
+// addColumnIndexes adds unique keys defined in column options
+func addColumnIndexes(t *table, def *ast.ColumnDef) {
+	c := findTrackedColumn(t.columns, def.Name.Name.O)
+	for _, opt := range def.Options {
+		switch opt.Tp {
+		case ast.ColumnOptionPrimaryKey:
+			t.indexColumns[primaryKeyName] = []*column{c}
+		case ast.ColumnOptionUniqKey:
+			t.indexColumns[uniqueIndexName(t, c.name)] = []*column{c}
+		}
+	}
+}
+
+// addConstraint adds the primary key or unique key, other constraints are ignored
+func addConstraint(t *table, cons *ast.Constraint) {
+	switch cons.Tp {
+	case ast.ConstraintPrimaryKey:
+		addIndex(t, primaryKeyName, cons.Keys)
+		for _, c := range t.indexColumns[primaryKeyName] {
+			c.NotNull = true // columns of primary key are always NOT NULL
+		}
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		addIndex(t, cons.Name, cons.Keys)
+	}
+}
+
+func addIndex(t *table, name string, keys []*ast.IndexColName) {
+	if len(keys) == 0 {
+		return
+	}
+	if name == "" {
+		// like MySQL, unnamed key is named after its first column
+		name = uniqueIndexName(t, keys[0].Column.Name.O)
+	}
+	cols := make([]*column, 0, len(keys))
+	for _, key := range keys {
+		if c := findTrackedColumn(t.columns, key.Column.Name.O); c != nil {
+			cols = append(cols, c)
+		}
+	}
+	t.indexColumns[strings.ToLower(name)] = cols
+}
+
+// uniqueIndexName returns name for a new key, suffix `_2`, `_3`... appended if the name already used
+func uniqueIndexName(t *table, name string) string {
+	newName := name
+	for i := 2; ; i++ {
+		if _, ok := t.indexColumns[strings.ToLower(newName)]; !ok {
+			return newName
+		}
+		newName = name + "_" + strconv.Itoa(i)
+	}
+}
+
+// cloneTable deep copies the table, then the copy can be modified without affecting the original one
+func cloneTable(t *table) *table {
+	clone := &table{
+		schema:       t.schema,
+		name:         t.name,
+		columns:      make([]*column, 0, len(t.columns)),
+		indexColumns: make(map[string][]*column, len(t.indexColumns)),
+	}
+	for _, c := range t.columns {
+		c2 := *c
+		clone.columns = append(clone.columns, &c2)
+	}
+	for key, cols := range t.indexColumns {
+		cols2 := make([]*column, 0, len(cols))
+		for _, c := range cols {
+			cols2 = append(cols2, clone.columns[c.idx])
+		}
+		clone.indexColumns[key] = cols2
+	}
+	return clone
+}
+
+// shardTable converts the tracked table to the structure saved in checkpoint and sent to dm-master
+func shardTable(t *table) *shardddl.Table {
+	info := &shardddl.Table{
+		Columns: make([]*shardddl.Column, 0, len(t.columns)),
+		Indexes: make(map[string][]string, len(t.indexColumns)),
+	}
+	for _, c := range t.columns {
+		info.Columns = append(info.Columns, &shardddl.Column{
+			Name:     c.name,
+			Type:     c.tp,
+			NotNull:  c.NotNull,
+			Unsigned: c.unsigned,
+			Extra:    c.extra,
+			Default:  c.defaultValue,
+		})
+	}
+	for key, cols := range t.indexColumns {
+		names := make([]string, 0, len(cols))
+		for _, c := range cols {
+			names = append(names, c.name)
+		}
+		info.Indexes[key] = names
+	}
+	return info
+}
+
+// marshalTable marshals the tracked table to save in checkpoint, empty if the table is nil
+func marshalTable(t *table) (string, error) {
+	if t == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(shardTable(t))
+	return string(data), errors.Trace(err)
+}
+
+// unmarshalTable unmarshals the tracked table saved in checkpoint
+func unmarshalTable(schema, name, data string) (*table, error) {
+	info, err := shardddl.ParseTable(data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "tracked table %s.%s", schema, name)
+	}
+	t := &table{
+		schema:  schema,
+		name:    name,
+		columns: make([]*column, 0, len(info.Columns)),
+	}
+	for i, c := range info.Columns {
+		t.columns = append(t.columns, &column{
+			idx:      i,
+			name:     c.Name,
+			NotNull:  c.NotNull,
+			unsigned: c.Unsigned,
+			tp:       c.Type,
+			extra:    c.Extra,
+
+			defaultValue: c.Default,
+		})
+	}
+	t.indexColumns = findColumns(t.columns, info.Indexes)
+	return t, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser"
+
+	"github.com/pingcap/dm/dm/config"
+	parserpkg "github.com/pingcap/dm/pkg/parser"
+)
+
+var _ = Suite(&testSchemaTrackerSuite{})
+
+type testSchemaTrackerSuite struct{}
+
+func (t *testSchemaTrackerSuite) applyDDL(c *C, st *schemaTracker, sql string) {
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	tableNames, err := parserpkg.FetchDDLTableNames("test", stmt)
+	c.Assert(err, IsNil)
+	c.Assert(st.applyDDL(stmt, tableNames), IsNil)
+}
+
+func columnNames(t *table) []string {
+	names := make([]string, 0, len(t.columns))
+	for i, col := range t.columns {
+		if col.idx != i {
+			return nil
+		}
+		names = append(names, col.name)
+	}
+	return names
+}
+
+func indexNames(t *table, key string) []string {
+	names := make([]string, 0)
+	for _, col := range t.indexColumns[key] {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+func (t *testSchemaTrackerSuite) TestApplyDDL(c *C) {
+	st := newSchemaTracker()
+
+	t.applyDDL(c, st, "CREATE TABLE t1 (id INT PRIMARY KEY, a INT UNSIGNED, b VARCHAR(10) NOT NULL, c INT AS (a + 1), UNIQUE KEY uk_b (b))")
+	tbl := st.getTable("test", "t1")
+	c.Assert(tbl, NotNil)
+	c.Assert(columnNames(tbl), DeepEquals, []string{"id", "a", "b", "c"})
+	c.Assert(tbl.columns[0].NotNull, IsTrue)
+	c.Assert(tbl.columns[1].unsigned, IsTrue)
+	c.Assert(tbl.columns[1].NotNull, IsFalse)
+	c.Assert(tbl.columns[2].NotNull, IsTrue)
+	c.Assert(tbl.columns[3].isGeneratedColumn(), IsTrue)
+	c.Assert(indexNames(tbl, "primary"), DeepEquals, []string{"id"})
+	c.Assert(indexNames(tbl, "uk_b"), DeepEquals, []string{"b"})
+
+	// tracked table is never modified in place
+	t.applyDDL(c, st, "ALTER TABLE t1 ADD COLUMN d INT FIRST")
+	c.Assert(columnNames(tbl), DeepEquals, []string{"id", "a", "b", "c"})
+	tbl = st.getTable("test", "t1")
+	c.Assert(columnNames(tbl), DeepEquals, []string{"d", "id", "a", "b", "c"})
+
+	t.applyDDL(c, st, "ALTER TABLE t1 ADD COLUMN e INT AFTER a")
+	t.applyDDL(c, st, "ALTER TABLE t1 DROP COLUMN c")
+	t.applyDDL(c, st, "ALTER TABLE t1 CHANGE COLUMN b b2 VARCHAR(20) AFTER d")
+	tbl = st.getTable("test", "t1")
+	c.Assert(columnNames(tbl), DeepEquals, []string{"d", "b2", "id", "a", "e"})
+	c.Assert(indexNames(tbl, "uk_b"), DeepEquals, []string{"b2"})
+	c.Assert(tbl.columns[1].NotNull, IsFalse)
+
+	t.applyDDL(c, st, "ALTER TABLE t1 ADD UNIQUE (a, e)")
+	t.applyDDL(c, st, "CREATE UNIQUE INDEX uk_d ON t1 (d)")
+	t.applyDDL(c, st, "ALTER TABLE t1 DROP PRIMARY KEY")
+	tbl = st.getTable("test", "t1")
+	c.Assert(tbl.indexColumns, HasLen, 3)
+	c.Assert(indexNames(tbl, "a"), DeepEquals, []string{"a", "e"})
+	c.Assert(indexNames(tbl, "uk_d"), DeepEquals, []string{"d"})
+
+	// key is dropped after all its columns dropped
+	t.applyDDL(c, st, "ALTER TABLE t1 DROP COLUMN d")
+	t.applyDDL(c, st, "DROP INDEX uk_b ON t1")
+	tbl = st.getTable("test", "t1")
+	c.Assert(tbl.indexColumns, HasLen, 1)
+	c.Assert(indexNames(tbl, "a"), DeepEquals, []string{"a", "e"})
+
+	// rename and create like
+	t.applyDDL(c, st, "RENAME TABLE t1 TO t2")
+	c.Assert(st.getTable("test", "t1"), IsNil)
+	t.applyDDL(c, st, "CREATE TABLE t3 LIKE t2")
+	tbl = st.getTable("test", "t3")
+	c.Assert(tbl, NotNil)
+	c.Assert(tbl.name, Equals, "t3")
+	c.Assert(columnNames(tbl), DeepEquals, []string{"b2", "id", "a", "e"})
+
+	// not tracked table
+	stmt, err := parser.New().ParseOneStmt("ALTER TABLE t4 ADD COLUMN a INT", "", "")
+	c.Assert(err, IsNil)
+	tableNames, err := parserpkg.FetchDDLTableNames("test", stmt)
+	c.Assert(err, IsNil)
+	c.Assert(st.applyDDL(stmt, tableNames), NotNil)
+
+	t.applyDDL(c, st, "DROP TABLE t2")
+	c.Assert(st.getTable("test", "t2"), IsNil)
+	t.applyDDL(c, st, "DROP DATABASE test")
+	c.Assert(st.getTable("test", "t3"), IsNil)
+}
+
+func (t *testSchemaTrackerSuite) TestColumnDefault(c *C) {
+	defaultValues := func(tbl *table) []interface{} {
+		values := make([]interface{}, 0, len(tbl.columns))
+		for _, col := range tbl.columns {
+			if col.defaultValue == nil {
+				values = append(values, nil)
+			} else {
+				values = append(values, *col.defaultValue)
+			}
+		}
+		return values
+	}
+
+	st := newSchemaTracker()
+	t.applyDDL(c, st, "CREATE TABLE t1 (id INT PRIMARY KEY, a INT DEFAULT -1, b VARCHAR(10) NOT NULL DEFAULT '', c DECIMAL(5,2) DEFAULT '1.5', d INT DEFAULT NULL, e TIMESTAMP(3) DEFAULT NOW(3))")
+	tbl := st.getTable("test", "t1")
+	c.Assert(defaultValues(tbl), DeepEquals, []interface{}{nil, "-1", "", "1.5", nil, "CURRENT_TIMESTAMP(3)"})
+
+	t.applyDDL(c, st, "ALTER TABLE t1 ADD COLUMN f DATETIME DEFAULT CURRENT_TIMESTAMP, MODIFY COLUMN b VARCHAR(10) NOT NULL DEFAULT 'x'")
+	t.applyDDL(c, st, "ALTER TABLE t1 ALTER COLUMN a DROP DEFAULT")
+	t.applyDDL(c, st, "ALTER TABLE t1 ALTER COLUMN d SET DEFAULT 10")
+	tbl = st.getTable("test", "t1")
+	c.Assert(defaultValues(tbl), DeepEquals, []interface{}{nil, nil, "x", "1.5", "10", "CURRENT_TIMESTAMP(3)", "CURRENT_TIMESTAMP"})
+	c.Assert(shardTable(tbl).Columns[2].String(), Equals, "varchar(10) NOT NULL DEFAULT 'x'")
+
+	stmt, err := parser.New().ParseOneStmt("ALTER TABLE t1 ALTER COLUMN g SET DEFAULT 1", "", "")
+	c.Assert(err, IsNil)
+	tableNames, err := parserpkg.FetchDDLTableNames("test", stmt)
+	c.Assert(err, IsNil)
+	c.Assert(st.applyDDL(stmt, tableNames), NotNil)
+}
+
+func (t *testSchemaTrackerSuite) TestMarshalTable(c *C) {
+	st := newSchemaTracker()
+	t.applyDDL(c, st, "CREATE TABLE t1 (id BIGINT UNSIGNED NOT NULL, a MEDIUMINT DEFAULT 0, b JSON AS (a) STORED, PRIMARY KEY (id), UNIQUE (a))")
+	tbl := st.getTable("test", "t1")
+
+	info, err := marshalTable(tbl)
+	c.Assert(err, IsNil)
+	tbl2, err := unmarshalTable("test", "t1", info)
+	c.Assert(err, IsNil)
+	c.Assert(tbl2, DeepEquals, tbl)
+
+	info, err = marshalTable(nil)
+	c.Assert(err, IsNil)
+	c.Assert(info, Equals, "")
+
+	_, err = unmarshalTable("test", "t1", "invalid")
+	c.Assert(err, NotNil)
+}
+
+func (t *testSchemaTrackerSuite) TestGetTableFromDump(c *C) {
+	cfg := &config.SubTaskConfig{Mode: config.ModeAll}
+	cfg.Dir = c.MkDir()
+	s := &Syncer{cfg: cfg}
+
+	// not dumped
+	tbl, err := s.getTableFromDump("db", "tb")
+	c.Assert(err, IsNil)
+	c.Assert(tbl, IsNil)
+
+	data := "/*!40101 SET NAMES binary*/;\nCREATE TABLE `tb` (\n  `id` int(11) NOT NULL,\n  `name` varchar(20) DEFAULT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"
+	c.Assert(ioutil.WriteFile(filepath.Join(cfg.Dir, "db.tb-schema.sql"), []byte(data), 0644), IsNil)
+	tbl, err = s.getTableFromDump("db", "tb")
+	c.Assert(err, IsNil)
+	c.Assert(tbl.schema, Equals, "db")
+	c.Assert(tbl.name, Equals, "tb")
+	c.Assert(columnNames(tbl), DeepEquals, []string{"id", "name"})
+	c.Assert(indexNames(tbl, primaryKeyName), DeepEquals, []string{"id"})
+
+	// no dump unit in the task
+	cfg.Mode = config.ModeIncrement
+	tbl, err = s.getTableFromDump("db", "tb")
+	c.Assert(err, IsNil)
+	c.Assert(tbl, IsNil)
+
+	// invalid dump file
+	cfg.Mode = config.ModeAll
+	c.Assert(ioutil.WriteFile(filepath.Join(cfg.Dir, "db.tb-schema.sql"), []byte("/*!40101 SET NAMES binary*/;"), 0644), IsNil)
+	_, err = s.getTableFromDump("db", "tb")
+	c.Assert(err, NotNil)
+}
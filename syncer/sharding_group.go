@@ -21,10 +21,21 @@ package syncer
  *   when the first staring of the task, same DDL for the whole sharding group should not in partial executed
  *      the startup point can not be in the middle of the first-pos and last-pos of a sharding group's DDL
  *   do not support to modify router-rules online (when unresolved)
- *   do not support to rename table or database in a sharding group, another solution for it
- *   do not support to sync using GTID mode (GTID is supported by relay unit now)
+ *   do not support to rename database in a sharding group, another solution for it
+ *   renaming a table moves it to the sharding group of its new target table with its checkpoint,
+ *      the sharding group is created if the new target table is not merged from other tables yet,
+ *      and the DDL is not executed in downstream, so the new target table should be created in downstream manually
+ *   re-syncing for a sharding group always uses binlog position, even in GTID mode,
+ *      so the upstream should not be switched when there are unresolved sharding DDLs
  *   do not support same <schema-name, table-name> pair for upstream and downstream when merging sharding group
- *   ignore all drop schema/table and truncate table ddls
+ *   drop schema/table and truncate table ddls are handled according to shard-truncate-drop-policy
+ *      ignore (default): ignored, and tables dropped leave their sharding groups
+ *      coordinate: synced like other sharding DDLs, and tables dropped keep in their sharding groups
+ *      error: stop the syncing with an error
+ *   schema-level DDLs (like ALTER DATABASE) are synced by the sharding group of the target schema,
+ *      which contains all source tables routed to the target schema,
+ *      and a schema-level DDL of an upstream schema is synced for all its tables in the group.
+ *      DMLs are not blocked by schema-level DDLs, so no re-syncing needed after synced
  *
  * checkpoint mechanism (ref: checkpoint.go):
  *   save checkpoint for every upstream table, and also global checkpoint
@@ -69,6 +80,7 @@ package syncer
  *    NO guarantee for this even though every table records checkpoint dependently
  *    because execution of binlog and update of checkpoint are in different goroutines concurrently
  *    so when re-starting the process or recovering from errors, safe-mode must be enabled
+ *    (except `exactly-once` enabled for non-sharding tasks, which saves checkpoints along with DMLs, see exactly_once.go)
  *
  */
 
@@ -95,10 +107,12 @@ type ShardingGroup struct {
 	remain       int
 	sources      map[string]bool     // source table ID -> whether source table's DDL synced
 	sourceDDLs   map[string][]string // source table ID -> ddl text; detect multiple ddl for on table in a sharding ddl
-	IsSchemaOnly bool                // whether is a schema (database) only DDL TODO: zxc add schema-level syncing support later
+	IsSchemaOnly bool                // whether is a schema (database) only DDL
 	firstPos     *mysql.Position     // first DDL's binlog pos, used to restrain the global checkpoint when un-resolved
 	firstEndPos  *mysql.Position     // first DDL's binlog End_log_pos, used to re-direct binlog streamer after synced
 	ddls         []string            // DDL which current in syncing
+	// source table ID -> binlog position of its last event, reported to dm-master to find idle source tables
+	lastPos map[string]mysql.Position
 }
 
 // NewShardingGroup creates a new ShardingGroup
@@ -107,6 +121,7 @@ func NewShardingGroup(sources []string, isSchemaOnly bool) *ShardingGroup {
 		remain:       len(sources),
 		sources:      make(map[string]bool, len(sources)),
 		sourceDDLs:   make(map[string][]string),
+		lastPos:      make(map[string]mysql.Position),
 		IsSchemaOnly: isSchemaOnly,
 		firstPos:     nil,
 		firstEndPos:  nil,
@@ -175,11 +190,30 @@ func (sg *ShardingGroup) Leave(sources []string) error {
 		}
 		delete(sg.sources, source)
 		delete(sg.sourceDDLs, source)
+		delete(sg.lastPos, source)
 	}
 
 	return nil
 }
 
+// LeaveUnsynced removes un-synced sources from the sharding group even if it's in syncing,
+// returns whether the sharding group is synced by removing them.
+// used when dm-master finds them idle, like their upstream tables decommissioned
+func (sg *ShardingGroup) LeaveUnsynced(sources []string) bool {
+	sg.Lock()
+	defer sg.Unlock()
+
+	for _, source := range sources {
+		if synced, ok := sg.sources[source]; ok && !synced {
+			sg.remain--
+			delete(sg.sources, source)
+			delete(sg.sourceDDLs, source)
+			delete(sg.lastPos, source)
+		}
+	}
+	return sg.firstPos != nil && sg.remain <= 0
+}
+
 // Reset resets all sources to un-synced state
 // when the previous sharding DDL synced and resolved, we need reset it
 func (sg *ShardingGroup) Reset() {
@@ -196,22 +230,32 @@ func (sg *ShardingGroup) Reset() {
 }
 
 // TrySync tries to sync the sharding group
-// if source not in sharding group before, it will be added
+// if source not in sharding group before, it will be added.
+// for schema-level DDL, source is the upstream schema ID, and all source tables in the schema are synced
 func (sg *ShardingGroup) TrySync(source string, pos, endPos mysql.Position, ddls []string) (bool, int, error) {
 	sg.Lock()
 	defer sg.Unlock()
 
-	synced, ok := sg.sources[source]
-	if !ok {
-		// new source added, sg.remain unchanged
-		sg.sources[source] = true
-		sg.sourceDDLs[source] = ddls
-	} else if !synced {
-		sg.remain--
-		sg.sources[source] = true
-		sg.sourceDDLs[source] = ddls
-	} else if !utils.CompareShardingDDLs(sg.sourceDDLs[source], ddls) {
-		return sg.remain <= 0, sg.remain, errors.NotSupportedf("execute multiple ddls: previous ddl %s and current ddl %s for source table %s", sg.sourceDDLs[source], ddl, source)
+	sources := []string{source}
+	if sg.IsSchemaOnly {
+		if schema, table := UnpackTableID(source); len(table) == 0 {
+			sources = sg.sourcesInSchema(schema)
+		}
+	}
+
+	for _, source := range sources {
+		synced, ok := sg.sources[source]
+		if !ok {
+			// new source added, sg.remain unchanged
+			sg.sources[source] = true
+			sg.sourceDDLs[source] = ddls
+		} else if !synced {
+			sg.remain--
+			sg.sources[source] = true
+			sg.sourceDDLs[source] = ddls
+		} else if !utils.CompareShardingDDLs(sg.sourceDDLs[source], ddls) {
+			return sg.remain <= 0, sg.remain, errors.NotSupportedf("execute multiple ddls: previous ddl %s and current ddl %s for source table %s", sg.sourceDDLs[source], ddls, source)
+		}
 	}
 
 	if sg.firstPos == nil {
@@ -222,6 +266,24 @@ func (sg *ShardingGroup) TrySync(source string, pos, endPos mysql.Position, ddls
 	return sg.remain <= 0, sg.remain, nil
 }
 
+// sourcesInSchema returns source tables in the upstream schema
+func (sg *ShardingGroup) sourcesInSchema(schema string) []string {
+	sources := make([]string, 0, len(sg.sources))
+	for source := range sg.sources {
+		if s, _ := UnpackTableID(source); s == schema {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// HasSchema returns whether the group contains source tables in the upstream schema
+func (sg *ShardingGroup) HasSchema(schema string) bool {
+	sg.RLock()
+	defer sg.RUnlock()
+	return len(sg.sourcesInSchema(schema)) > 0
+}
+
 // InSyncing checks whether the source is in syncing
 func (sg *ShardingGroup) InSyncing(source string) bool {
 	sg.RLock()
@@ -260,6 +322,45 @@ func (sg *ShardingGroup) UnresolvedGroupInfo() *pb.ShardingGroup {
 	return group
 }
 
+// ProgressInfo returns pb.ShardingGroup with binlog positions of the last events of un-synced sources
+func (sg *ShardingGroup) ProgressInfo() *pb.ShardingGroup {
+	sg.RLock()
+	defer sg.RUnlock()
+
+	group := &pb.ShardingGroup{
+		DDLs:     sg.ddls,
+		Synced:   make([]string, 0, len(sg.sources)-sg.remain),
+		Unsynced: make([]string, 0, sg.remain),
+		Progress: make([]*pb.ShardingSource, 0, sg.remain),
+	}
+	if sg.firstPos != nil {
+		group.FirstPos = sg.firstPos.String()
+	}
+	for source, synced := range sg.sources {
+		if synced {
+			group.Synced = append(group.Synced, source)
+			continue
+		}
+		group.Unsynced = append(group.Unsynced, source)
+		progress := &pb.ShardingSource{Source: source}
+		if pos, ok := sg.lastPos[source]; ok {
+			progress.LastBinlog = pos.String()
+		}
+		group.Progress = append(group.Progress, progress)
+	}
+	return group
+}
+
+// UpdateProgress records the binlog position of the last event of the source
+func (sg *ShardingGroup) UpdateProgress(source string, pos mysql.Position) {
+	sg.Lock()
+	defer sg.Unlock()
+
+	if _, ok := sg.sources[source]; ok {
+		sg.lastPos[source] = pos
+	}
+}
+
 // Sources returns all sources (and whether synced)
 func (sg *ShardingGroup) Sources() map[string]bool {
 	sg.RLock()
@@ -348,9 +449,12 @@ func GenTableID(schema, table string) (ID string, isSchemaOnly bool) {
 	return fmt.Sprintf("`%s`.`%s`", schema, table), false
 }
 
-// UnpackTableID unpacks table ID to <schema, table> pair
+// UnpackTableID unpacks table ID to <schema, table> pair, table is empty for schema ID
 func UnpackTableID(id string) (string, string) {
 	parts := strings.Split(id, "`.`")
+	if len(parts) == 1 {
+		return strings.Trim(parts[0], "`"), ""
+	}
 	schema := strings.TrimLeft(parts[0], "`")
 	table := strings.TrimRight(parts[1], "`")
 	return schema, table
@@ -437,6 +541,7 @@ func (k *ShardingGroupKeeper) LeaveGroup(targetSchema, targetTable string, sourc
 }
 
 // TrySync tries to sync the sharding group
+// for schema-level DDL, targetTable is empty and source is the upstream schema ID
 // returns
 //   isSharding: whether the source table is in a sharding group
 //   group: the sharding group
@@ -444,10 +549,6 @@ func (k *ShardingGroupKeeper) LeaveGroup(targetSchema, targetTable string, sourc
 //   remain: remain un-synced source table's count
 func (k *ShardingGroupKeeper) TrySync(targetSchema, targetTable, source string, pos, endPos mysql.Position, ddls []string) (needShardingHandle bool, group *ShardingGroup, synced bool, remain int, err error) {
 	tableID, schemaOnly := GenTableID(targetSchema, targetTable)
-	if schemaOnly {
-		// NOTE: now we don't support syncing for schema only sharding DDL
-		return false, nil, true, 0, nil
-	}
 
 	k.Lock()
 	defer k.Unlock()
@@ -456,6 +557,12 @@ func (k *ShardingGroupKeeper) TrySync(targetSchema, targetTable, source string,
 	if !ok {
 		return false, group, true, 0, nil
 	}
+	if schemaOnly {
+		if schema, _ := UnpackTableID(source); !group.HasSchema(schema) {
+			// no tables of the upstream schema in the group, like creating a new database
+			return false, nil, true, 0, nil
+		}
+	}
 	synced, remain, err = group.TrySync(source, pos, endPos, ddls)
 	return true, group, synced, remain, errors.Trace(err)
 }
@@ -472,6 +579,16 @@ func (k *ShardingGroupKeeper) InSyncing(targetSchema, targetTable, source string
 	return group.InSyncing(source)
 }
 
+// UpdateProgress records the binlog position of the last event of the source table in the sharding group
+func (k *ShardingGroupKeeper) UpdateProgress(targetSchema, targetTable, source string, pos mysql.Position) {
+	tableID, _ := GenTableID(targetSchema, targetTable)
+	k.RLock()
+	defer k.RUnlock()
+	if group, ok := k.groups[tableID]; ok {
+		group.UpdateProgress(source, pos)
+	}
+}
+
 // UnresolvedTables returns all source tables which with DDLs are un-resolved
 // NOTE: this func only ensure the returned tables are current un-resolved
 // if passing the returned tables to other func (like checkpoint),
@@ -552,6 +669,19 @@ func (k *ShardingGroupKeeper) UnresolvedGroups() []*pb.ShardingGroup {
 	return groups
 }
 
+// ShardingProgress returns all sharding groups with binlog progress of their un-synced sources
+func (k *ShardingGroupKeeper) ShardingProgress() []*pb.ShardingGroup {
+	k.RLock()
+	defer k.RUnlock()
+	groups := make([]*pb.ShardingGroup, 0, len(k.groups))
+	for target, group := range k.groups {
+		gi := group.ProgressInfo()
+		gi.Target = target // set target
+		groups = append(groups, gi)
+	}
+	return groups
+}
+
 // ShardingReSync represents re-sync info for a sharding DDL group
 type ShardingReSync struct {
 	currPos      mysql.Position // current DDL's binlog pos, initialize to first DDL's pos
@@ -0,0 +1,186 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+)
+
+var _ = Suite(&testShardingGroupSuite{})
+
+type testShardingGroupSuite struct{}
+
+func (t *testShardingGroupSuite) TestUnpackTableID(c *C) {
+	schema, table := UnpackTableID("`db`.`tb`")
+	c.Assert(schema, Equals, "db")
+	c.Assert(table, Equals, "tb")
+
+	schema, table = UnpackTableID("`db`")
+	c.Assert(schema, Equals, "db")
+	c.Assert(table, Equals, "")
+}
+
+func (t *testShardingGroupSuite) TestSchemaLevelSync(c *C) {
+	var (
+		k    = NewShardingGroupKeeper()
+		pos1 = mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+		pos2 = mysql.Position{Name: "mysql-bin.000001", Pos: 200}
+		ddls = []string{"ALTER DATABASE `db` CHARACTER SET = utf8mb4"}
+	)
+	_, _, _, _, err := k.AddGroup("db", "tb", []string{"`db1`.`tb1`", "`db1`.`tb2`", "`db2`.`tb1`"}, false)
+	c.Assert(err, IsNil)
+
+	// no tables of the upstream schema in the group
+	needShardingHandle, group, synced, _, err := k.TrySync("db", "", "`db3`", pos1, pos2, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(needShardingHandle, IsFalse)
+	c.Assert(group, IsNil)
+	c.Assert(synced, IsTrue)
+
+	// all tables of the upstream schema synced
+	needShardingHandle, group, synced, remain, err := k.TrySync("db", "", "`db1`", pos1, pos2, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(needShardingHandle, IsTrue)
+	c.Assert(group.IsSchemaOnly, IsTrue)
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 1)
+	c.Assert(*group.FirstPosUnresolved(), Equals, pos1)
+	c.Assert(k.UnresolvedTables(), HasLen, 3)
+
+	// DMLs of tables are not blocked by schema-level DDL
+	c.Assert(k.InSyncing("db", "tb", "`db1`.`tb1`"), IsFalse)
+
+	_, group, synced, remain, err = k.TrySync("db", "", "`db2`", pos2, pos2, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsTrue)
+	c.Assert(remain, Equals, 0)
+
+	group.Reset()
+	c.Assert(group.IsUnresolved(), IsFalse)
+	c.Assert(k.UnresolvedTables(), HasLen, 0)
+}
+
+func (t *testShardingGroupSuite) TestLeaveUnsynced(c *C) {
+	var (
+		k    = NewShardingGroupKeeper()
+		tb1  = "`db`.`tb1`"
+		tb2  = "`db`.`tb2`"
+		tb3  = "`db`.`tb3`"
+		pos1 = mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+		pos2 = mysql.Position{Name: "mysql-bin.000001", Pos: 200}
+		ddls = []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c1` INT"}
+	)
+	_, _, _, _, err := k.AddGroup("db", "tb", []string{tb1, tb2, tb3}, false)
+	c.Assert(err, IsNil)
+	group := k.Group("db", "tb")
+
+	// progress of sources not synced, even if the group is not in syncing
+	k.UpdateProgress("db", "tb", tb1, pos1)
+	k.UpdateProgress("db", "tb", "`db`.`tb4`", pos1) // not in the group
+	var tableProgress *pb.ShardingGroup
+	for _, gi := range k.ShardingProgress() {
+		if gi.Target == "`db`.`tb`" {
+			tableProgress = gi
+		}
+	}
+	c.Assert(tableProgress, NotNil)
+	c.Assert(tableProgress.FirstPos, Equals, "")
+	c.Assert(tableProgress.Progress, HasLen, 3)
+
+	_, _, synced, remain, err := k.TrySync("db", "tb", tb1, pos1, pos2, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 2)
+	k.UpdateProgress("db", "tb", tb2, pos2)
+
+	gi := group.ProgressInfo()
+	c.Assert(gi.FirstPos, Equals, pos1.String())
+	c.Assert(gi.DDLs, DeepEquals, ddls)
+	c.Assert(gi.Synced, DeepEquals, []string{tb1})
+	c.Assert(gi.Unsynced, HasLen, 2)
+	lastBinlogs := make(map[string]string)
+	for _, p := range gi.Progress {
+		lastBinlogs[p.Source] = p.LastBinlog
+	}
+	c.Assert(lastBinlogs, DeepEquals, map[string]string{tb2: pos2.String(), tb3: ""})
+
+	// synced sources and sources not in the group are not removed
+	c.Assert(group.LeaveUnsynced([]string{tb1, tb3, "`db`.`tb4`"}), IsFalse)
+	c.Assert(group.Sources(), DeepEquals, map[string]bool{tb1: true, tb2: false})
+	c.Assert(group.LeaveUnsynced([]string{tb3}), IsFalse)
+	// synced after all un-synced sources left
+	c.Assert(group.LeaveUnsynced([]string{tb2}), IsTrue)
+	c.Assert(group.IsUnresolved(), IsTrue)
+	c.Assert(group.UnresolvedGroupInfo().DDLs, DeepEquals, ddls)
+
+	group.Reset()
+	c.Assert(group.Sources(), DeepEquals, map[string]bool{tb1: false})
+	// a left source joins again when it reaches the DDL
+	_, _, synced, remain, err = k.TrySync("db", "tb", tb2, pos1, pos2, ddls)
+	c.Assert(err, IsNil)
+	c.Assert(synced, IsFalse)
+	c.Assert(remain, Equals, 1)
+}
+
+func (t *testShardingGroupSuite) TestRenameTableInSharding(c *C) {
+	cfg := &config.SubTaskConfig{Name: "test", MetaSchema: "dm_meta", IsSharding: true}
+	cfg.FileSink.Dir = c.MkDir()
+	s := &Syncer{
+		cfg:           cfg,
+		sgk:           NewShardingGroupKeeper(),
+		checkpoint:    NewLocalCheckPoint(cfg, "101"),
+		schemaTracker: newSchemaTracker(),
+		tables:        map[string]*table{"`db1`.`tb2`": {schema: "db", name: "tb"}},
+		genColsCache:  NewGenColCache(),
+	}
+	_, _, _, _, err := s.sgk.AddGroup("db", "tb", []string{"`db1`.`tb1`", "`db1`.`tb2`"}, false)
+	c.Assert(err, IsNil)
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+	renamed := func(oldTable, newTable, oldTarget, newTarget string) error {
+		return s.renameTableInSharding([][]*filter.Table{
+			{{Schema: "db1", Name: oldTable}, {Schema: "db1", Name: newTable}},
+			{{Schema: "db", Name: oldTarget}, {Schema: "db", Name: newTarget}},
+		}, pos, nil)
+	}
+
+	// routed to the same target table
+	c.Assert(renamed("tb2", "tb3", "tb", "tb"), IsNil)
+	sources := s.sgk.Group("db", "tb").Sources()
+	c.Assert(sources, HasLen, 2)
+	c.Assert(sources, HasKey, "`db1`.`tb3`")
+	c.Assert(s.sgk.Group("db", "").Sources(), Not(HasKey), "`db1`.`tb2`")
+	c.Assert(s.checkpoint.(*LocalCheckPoint).points["db1"], HasKey, "tb3")
+	c.Assert(s.tables, Not(HasKey), "`db1`.`tb2`")
+
+	// routed to a new target table without sharding group, the group is created
+	c.Assert(renamed("tb3", "bak", "tb", "bak"), IsNil)
+	c.Assert(s.sgk.Group("db", "tb").Sources(), DeepEquals, map[string]bool{"`db1`.`tb1`": false})
+	c.Assert(s.sgk.Group("db", "bak").Sources(), DeepEquals, map[string]bool{"`db1`.`bak`": false})
+	c.Assert(s.checkpoint.(*LocalCheckPoint).points["db1"], HasKey, "bak")
+
+	// routed back to the existing sharding group
+	c.Assert(renamed("bak", "tb3", "bak", "tb"), IsNil)
+	c.Assert(s.sgk.Group("db", "tb").Sources(), HasLen, 2)
+	c.Assert(s.sgk.Group("db", "bak").Sources(), HasLen, 0)
+
+	// the sharding group is un-resolved
+	_, _, _, _, err = s.sgk.TrySync("db", "tb", "`db1`.`tb1`", pos, pos, []string{"ALTER TABLE `db`.`tb` ADD COLUMN `c` INT"})
+	c.Assert(err, IsNil)
+	c.Assert(renamed("tb3", "tb4", "tb", "tb"), NotNil)
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+// leaveShardingReq is a request to remove idle sources from a sharding group
+type leaveShardingReq struct {
+	target  string   // target table ID of the sharding group
+	sources []string // source table IDs
+}
+
+// LeaveShardingGroup removes un-synced sources from the sharding group of the target table,
+// dm-master uses it to drop idle sources (like decommissioned upstream tables) which would block the sharding DDL forever.
+// the request is handled in the main loop of the syncer at transaction boundaries,
+// and the sharding DDL is synced if all other sources have executed it.
+func (s *Syncer) LeaveShardingGroup(ctx context.Context, target string, sources []string) error {
+	if !s.cfg.IsSharding {
+		return errors.NotSupportedf("leave sharding group for task %s not in sharding mode", s.cfg.Name)
+	}
+	schema, table := UnpackTableID(target)
+	if s.sgk.Group(schema, table) == nil {
+		return errors.NotFoundf("sharding group for target table %s", target)
+	}
+
+	newCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	log.Infof("[syncer] sources %v are leaving sharding group %s", sources, target)
+
+	select {
+	case s.leaveShardingCh <- &leaveShardingReq{target: target, sources: sources}:
+		return nil
+	case <-newCtx.Done():
+		return newCtx.Err()
+	}
+}
+
+// ShardingProgress returns sharding groups with binlog progress of their un-synced sources, nil if not in sharding mode
+func (s *Syncer) ShardingProgress() []*pb.ShardingGroup {
+	if !s.cfg.IsSharding {
+		return nil
+	}
+	return s.sgk.ShardingProgress()
+}
+
+func (s *Syncer) tryLeaveSharding() *leaveShardingReq {
+	select {
+	case req := <-s.leaveShardingCh:
+		return req
+	default:
+		return nil
+	}
+}
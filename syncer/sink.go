@@ -0,0 +1,376 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/log"
+)
+
+// sink is where jobs are executed, DML jobs are executed in batches, and DDL jobs are executed one by one.
+// checkpoints are flushed after all jobs before them executed by sinks, so jobs may be executed again after restarted
+type sink interface {
+	// executeJobs executes DML jobs in a batch
+	executeJobs(jobs []*job, maxRetry int) *ExecErrorContext
+	// executeDDLs executes DDLs of the DDL job
+	executeDDLs(j *job) error
+	// close closes the sink
+	close()
+}
+
+// createSinks creates sinks for `worker-count` DML queues and the DDL queue,
+// dbs and ddlDB are only used by the MySQL sink
+func createSinks(cfg *config.SubTaskConfig, dbs []*Conn, ddlDB *Conn) ([]sink, error) {
+	sinks := make([]sink, 0, cfg.WorkerCount+1)
+	var (
+		shared sink // all queues write into the same files
+		err    error
+	)
+	switch cfg.Sink {
+	case config.SinkFile:
+		shared, err = newFileSink(cfg)
+	case config.SinkSQL:
+		shared, err = newSQLSink(cfg)
+	default:
+		for _, db := range dbs {
+			sinks = append(sinks, &dbSink{db: db})
+		}
+		sinks = append(sinks, &dbSink{db: ddlDB})
+		return sinks, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i := 0; i <= cfg.WorkerCount; i++ {
+		sinks = append(sinks, shared)
+	}
+	return sinks, nil
+}
+
+// dbSink executes jobs as SQLs in MySQL / TiDB
+type dbSink struct {
+	db *Conn
+}
+
+func (d *dbSink) executeJobs(jobs []*job, maxRetry int) *ExecErrorContext {
+	return d.db.executeSQLJob(jobs, maxRetry)
+}
+
+func (d *dbSink) executeDDLs(j *job) error {
+	args := make([][]interface{}, len(j.ddls))
+	return d.db.executeSQL(j.ddls, args, 1)
+}
+
+// connections are closed by syncer
+func (d *dbSink) close() {}
+
+// change event operations, the same with Debezium
+const (
+	eventOpCreate = "c"
+	eventOpUpdate = "u"
+	eventOpDelete = "d"
+	eventOpDDL    = "ddl"
+	eventOpSQL    = "sql" // SQL of jobs without row changes, like ones replaced by sql-operator
+)
+
+// changeEvent is a Debezium-style change event written by the file sink
+type changeEvent struct {
+	Before   map[string]interface{} `json:"before"`
+	After    map[string]interface{} `json:"after"`
+	Op       string                 `json:"op"`
+	Database string                 `json:"database,omitempty"` // target schema
+	Table    string                 `json:"table,omitempty"`    // target table
+	DDL      string                 `json:"ddl,omitempty"`
+	SQL      string                 `json:"sql,omitempty"`
+	Args     []interface{}          `json:"args,omitempty"`
+	Source   eventSource            `json:"source"`
+	TsMs     int64                  `json:"ts_ms"` // timestamp of the binlog event, in milliseconds but with precision of seconds
+}
+
+// eventSource is the upstream source of the change event
+type eventSource struct {
+	SourceID string `json:"source_id"`
+	Task     string `json:"task"`
+	DB       string `json:"db,omitempty"`
+	Table    string `json:"table,omitempty"`
+	File     string `json:"file"`
+	Pos      uint32 `json:"pos"`
+	GTID     string `json:"gtid,omitempty"`
+}
+
+// rotatingFile writes data into files in `dir` named `<prefix><sequence><ext>`, and rotates them by size or time.
+// data are synced to disk before returning, and files written before are never appended
+type rotatingFile struct {
+	sync.Mutex
+
+	dir      string
+	prefix   string
+	ext      string
+	maxSize  int64
+	interval time.Duration
+
+	seq      int // sequence of the current file
+	file     *os.File
+	size     int64
+	openTime time.Time
+}
+
+func newRotatingFile(cfg *config.FileSinkConfig, prefix, ext string) (*rotatingFile, error) {
+	f := &rotatingFile{
+		dir:      cfg.Dir,
+		prefix:   prefix,
+		ext:      ext,
+		maxSize:  cfg.MaxSize * 1024 * 1024,
+		interval: cfg.Interval(),
+	}
+	err := os.MkdirAll(f.dir, 0755)
+	if err != nil {
+		return nil, errors.Annotatef(err, "create dir %s", f.dir)
+	}
+
+	// continue after files written before
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		seq, err2 := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+		if err2 != nil {
+			continue
+		}
+		if seq > f.seq {
+			f.seq = seq
+		}
+	}
+	if f.seq > 0 {
+		err = truncatePartialLine(f.filename(f.seq))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) filename(seq int) string {
+	return filepath.Join(f.dir, fmt.Sprintf("%s%06d%s", f.prefix, seq, f.ext))
+}
+
+// truncatePartialLine removes the last line not completely written, like written when the process crashed
+func truncatePartialLine(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+	size := bytes.LastIndexByte(data, '\n') + 1
+	log.Warnf("[syncer] truncate partial line of file %s from size %d to %d", filename, len(data), size)
+	return errors.Trace(os.Truncate(filename, int64(size)))
+}
+
+// rotate closes the current file, and the next file is opened when writing
+func (f *rotatingFile) rotate() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return errors.Annotatef(err, "close file %s", f.filename(f.seq))
+}
+
+func (f *rotatingFile) write(data []byte) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.file != nil && f.interval > 0 && time.Since(f.openTime) >= f.interval {
+		if err := f.rotate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if f.file == nil {
+		f.seq++
+		filename := f.filename(f.seq)
+		file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return errors.Annotatef(err, "open file %s", filename)
+		}
+		f.file, f.size, f.openTime = file, 0, time.Now()
+		log.Infof("[syncer] open sink file %s", filename)
+	}
+
+	_, err := f.file.Write(data)
+	if err == nil {
+		err = f.file.Sync()
+	}
+	if err != nil {
+		// remove data partially written, they are written again after resumed
+		if err2 := f.file.Truncate(f.size); err2 != nil {
+			log.Errorf("[syncer] truncate file %s to size %d error %v", f.filename(f.seq), f.size, err2)
+		}
+		return errors.Annotatef(err, "write file %s", f.filename(f.seq))
+	}
+	f.size += int64(len(data))
+	if f.size >= f.maxSize {
+		return errors.Trace(f.rotate())
+	}
+	return nil
+}
+
+func (f *rotatingFile) close() {
+	f.Lock()
+	defer f.Unlock()
+	if err := f.rotate(); err != nil {
+		log.Errorf("[syncer] close sink file error %v", errors.ErrorStack(err))
+	}
+}
+
+// sinkFilePrefix returns the prefix of files written by sinks, `<task>.<source-id>.`
+func sinkFilePrefix(cfg *config.SubTaskConfig) string {
+	return fmt.Sprintf("%s.%s.", cfg.Name, cfg.SourceID)
+}
+
+// fileSink writes row changes and DDLs as newline-delimited JSON change events into files in `dir`,
+// named `<task>.<source-id>.<sequence>.json`, and rotated by size or time.
+// a batch of jobs is written and synced to disk before returning, so events are written at least once
+type fileSink struct {
+	cfg *config.SubTaskConfig
+	out *rotatingFile
+}
+
+func newFileSink(cfg *config.SubTaskConfig) (*fileSink, error) {
+	out, err := newRotatingFile(&cfg.FileSink, sinkFilePrefix(cfg), ".json")
+	if err != nil {
+		return nil, errors.Annotate(err, "file sink")
+	}
+	return &fileSink{cfg: cfg, out: out}, nil
+}
+
+func (f *fileSink) executeJobs(jobs []*job, maxRetry int) *ExecErrorContext {
+	if len(jobs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, j := range jobs {
+		if err := f.encodeEvent(&buf, jobEvent(j), j); err != nil {
+			return &ExecErrorContext{err: errors.Trace(err), pos: j.currentPos, jobs: fmt.Sprintf("%v", j)}
+		}
+	}
+	if err := f.out.write(buf.Bytes()); err != nil {
+		return &ExecErrorContext{err: errors.Trace(err), pos: jobs[len(jobs)-1].currentPos, jobs: fmt.Sprintf("%v", jobs)}
+	}
+	return nil
+}
+
+func (f *fileSink) executeDDLs(j *job) error {
+	var buf bytes.Buffer
+	for _, ddl := range j.ddls {
+		ev := &changeEvent{Op: eventOpDDL, DDL: ddl}
+		if err := f.encodeEvent(&buf, ev, j); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(f.out.write(buf.Bytes()))
+}
+
+func (f *fileSink) encodeEvent(buf *bytes.Buffer, ev *changeEvent, j *job) error {
+	ev.Database, ev.Table = j.targetSchema, j.targetTable
+	ev.Source = eventSource{
+		SourceID: f.cfg.SourceID,
+		Task:     f.cfg.Name,
+		DB:       j.sourceSchema,
+		Table:    j.sourceTable,
+		File:     j.currentPos.Name,
+		Pos:      j.currentPos.Pos,
+	}
+	if j.gtidSet != nil {
+		ev.Source.GTID = j.gtidSet.String()
+	}
+	ev.TsMs = int64(j.eventTime) * 1000 // the time the change happened in the upstream
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return errors.Annotatef(err, "encode change event of job %s", j)
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
+
+func (f *fileSink) close() {
+	f.out.close()
+}
+
+// jobEvent returns the change event of the DML job
+func jobEvent(j *job) *changeEvent {
+	c := j.change
+	if c == nil {
+		return &changeEvent{Op: eventOpSQL, SQL: j.sql, Args: j.args}
+	}
+	ev := &changeEvent{
+		Before: rowImage(c.originalColumns, c.before),
+		After:  rowImage(c.originalColumns, c.after),
+	}
+	switch {
+	case c.before == nil:
+		ev.Op = eventOpCreate
+	case c.after == nil:
+		ev.Op = eventOpDelete
+	default:
+		ev.Op = eventOpUpdate
+	}
+	return ev
+}
+
+// rowImage returns values of the row keyed by column names, nil if the row is nil
+func rowImage(columns []*column, row []interface{}) map[string]interface{} {
+	if row == nil {
+		return nil
+	}
+	image := make(map[string]interface{}, len(columns))
+	for i, c := range columns {
+		if i >= len(row) {
+			break
+		}
+		value := castUnsigned(row[i], c.unsigned, c.tp)
+		if b, ok := value.([]byte); ok && !isBinaryType(c.tp) {
+			// text values are written as strings, and binary values are written as base64 encoded strings
+			value = string(b)
+		}
+		image[c.name] = value
+	}
+	return image
+}
+
+func isBinaryType(tp string) bool {
+	tp = strings.ToLower(tp)
+	return strings.Contains(tp, "blob") || strings.Contains(tp, "binary")
+}
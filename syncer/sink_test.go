@@ -0,0 +1,147 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testSinkSuite{})
+
+type testSinkSuite struct{}
+
+func readEvents(c *C, filename string) []map[string]interface{} {
+	f, err := os.Open(filename)
+	c.Assert(err, IsNil)
+	defer f.Close()
+
+	var events []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ev := make(map[string]interface{})
+		c.Assert(json.Unmarshal(scanner.Bytes(), &ev), IsNil)
+		events = append(events, ev)
+	}
+	c.Assert(scanner.Err(), IsNil)
+	return events
+}
+
+func (t *testSinkSuite) TestFileSink(c *C) {
+	dir := c.MkDir()
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source"}
+	cfg.WorkerCount = 2
+	cfg.Sink = config.SinkFile
+	cfg.FileSink.Dir = dir
+
+	sinks, err := createSinks(cfg, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(sinks, HasLen, 3)
+	fs := sinks[0].(*fileSink)
+	c.Assert(sinks[2], Equals, sinks[0])
+	fs.out.maxSize = 1024
+
+	columns := []*column{
+		{idx: 0, name: "id", tp: "bigint(20) unsigned", unsigned: true},
+		{idx: 1, name: "name", tp: "varchar(20)"},
+		{idx: 2, name: "data", tp: "blob"},
+	}
+	param := &genDMLParam{schema: "target_db", table: "target_tb", originalColumns: columns, rowImages: true}
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+	newRowJob := func(tp opType, before, after []interface{}) *job {
+		j := newJob(tp, "db", "tb", "target_db", "target_tb", "", nil, "", pos, pos, nil, "")
+		j.change = newRowChange(tp, param, nil, after, before, after)
+		j.eventTime = 1572000000
+		return j
+	}
+	jobs := []*job{
+		newRowJob(insert, nil, []interface{}{int64(-1), []byte("a"), []byte("b")}),
+		newRowJob(update, []interface{}{int64(1), []byte("a"), nil}, []interface{}{int64(1), []byte("b"), nil}),
+		newRowJob(del, []interface{}{int64(1), []byte("b"), nil}, nil),
+		newJob(insert, "db", "tb", "target_db", "target_tb", "INSERT INTO t VALUES (?)", []interface{}{1}, "", pos, pos, nil, ""),
+	}
+	c.Assert(fs.executeJobs(jobs, 1), IsNil)
+	ddlJob := newDDLJob(nil, []string{"CREATE DATABASE db", "CREATE TABLE db.tb (id INT)"}, pos, pos, nil, nil, "")
+	c.Assert(fs.executeDDLs(ddlJob), IsNil)
+
+	events := readEvents(c, filepath.Join(dir, "task.source.000001.json"))
+	c.Assert(events, HasLen, 6)
+	c.Assert(events[0]["op"], Equals, eventOpCreate)
+	c.Assert(events[0]["before"], IsNil)
+	c.Assert(events[0]["after"], DeepEquals, map[string]interface{}{"id": "18446744073709551615", "name": "a", "data": "Yg=="})
+	c.Assert(events[0]["database"], Equals, "target_db")
+	c.Assert(events[0]["table"], Equals, "target_tb")
+	c.Assert(events[0]["source"], DeepEquals, map[string]interface{}{
+		"source_id": "source", "task": "task", "db": "db", "table": "tb", "file": "mysql-bin.000001", "pos": float64(1234),
+	})
+	c.Assert(events[0]["ts_ms"], Equals, float64(1572000000000)) // from the binlog event, not the processing time
+	c.Assert(events[1]["op"], Equals, eventOpUpdate)
+	c.Assert(events[1]["before"], DeepEquals, map[string]interface{}{"id": "1", "name": "a", "data": nil})
+	c.Assert(events[1]["after"], DeepEquals, map[string]interface{}{"id": "1", "name": "b", "data": nil})
+	c.Assert(events[2]["op"], Equals, eventOpDelete)
+	c.Assert(events[2]["after"], IsNil)
+	c.Assert(events[3]["op"], Equals, eventOpSQL)
+	c.Assert(events[3]["sql"], Equals, "INSERT INTO t VALUES (?)")
+	c.Assert(events[4]["op"], Equals, eventOpDDL)
+	c.Assert(events[4]["ddl"], Equals, "CREATE DATABASE db")
+	c.Assert(events[5]["ddl"], Equals, "CREATE TABLE db.tb (id INT)")
+
+	// rotated by size
+	c.Assert(fs.out.file, IsNil)
+	fs.out.maxSize = 1024 * 1024
+	c.Assert(fs.executeJobs(jobs[:1], 1), IsNil)
+	c.Assert(fs.out.seq, Equals, 2)
+	fs.close()
+
+	// continue after the last file, and the partial line is truncated
+	filename := filepath.Join(dir, "task.source.000002.json")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(filename, append(data, `{"op":`...), 0644), IsNil)
+	fs, err = newFileSink(cfg)
+	c.Assert(err, IsNil)
+	c.Assert(fs.out.seq, Equals, 2)
+	data2, err := ioutil.ReadFile(filename)
+	c.Assert(err, IsNil)
+	c.Assert(data2, DeepEquals, data)
+	c.Assert(fs.executeJobs(jobs[:1], 1), IsNil)
+	fs.close()
+	c.Assert(readEvents(c, filepath.Join(dir, "task.source.000003.json")), HasLen, 1)
+}
+
+func (t *testSinkSuite) TestFileSinkWithoutTarget(c *C) {
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source", ServerID: 101}
+	cfg.Sink = config.SinkFile
+	cfg.FileSink.Dir = c.MkDir()
+
+	// checkpoints and online DDL info are saved in local files, rather than the target database
+	syncer := NewSyncer(cfg)
+	_, ok := syncer.checkpoint.(*LocalCheckPoint)
+	c.Assert(ok, IsTrue)
+	c.Assert(NewOnlineDDLStorage(cfg).filename, Equals, filepath.Join(cfg.FileSink.Dir, "task_onlineddl.101.json"))
+
+	cfg.Sink = config.SinkMySQL
+	syncer = NewSyncer(cfg)
+	_, ok = syncer.checkpoint.(*RemoteCheckPoint)
+	c.Assert(ok, IsTrue)
+	c.Assert(NewOnlineDDLStorage(cfg).filename, Equals, "")
+}
@@ -0,0 +1,186 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+// sqlSink writes jobs as SQLs into files in `dir` instead of executing them, used to check what will be executed (dry-run).
+// files are named `<task>.<source-id>.<sequence>.sql` and rotated by size or time,
+// each batch of DML jobs is written in a transaction like executed by the MySQL sink,
+// and each SQL is written after a comment with the binlog position of the job
+type sqlSink struct {
+	out *rotatingFile
+}
+
+func newSQLSink(cfg *config.SubTaskConfig) (*sqlSink, error) {
+	out, err := newRotatingFile(&cfg.FileSink, sinkFilePrefix(cfg), ".sql")
+	if err != nil {
+		return nil, errors.Annotate(err, "sql sink")
+	}
+	return &sqlSink{out: out}, nil
+}
+
+func (q *sqlSink) executeJobs(jobs []*job, maxRetry int) *ExecErrorContext {
+	if len(jobs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	buf.WriteString("BEGIN;\n")
+	for _, j := range jobs {
+		sql, err := interpolateSQL(j.sql, j.args)
+		if err != nil {
+			return &ExecErrorContext{err: errors.Trace(err), pos: j.currentPos, jobs: fmt.Sprintf("%v", j)}
+		}
+		writeSQL(&buf, j, sql)
+	}
+	buf.WriteString("COMMIT;\n")
+	if err := q.out.write(buf.Bytes()); err != nil {
+		return &ExecErrorContext{err: errors.Trace(err), pos: jobs[len(jobs)-1].currentPos, jobs: fmt.Sprintf("%v", jobs)}
+	}
+	return nil
+}
+
+func (q *sqlSink) executeDDLs(j *job) error {
+	var buf bytes.Buffer
+	for _, ddl := range j.ddls {
+		writeSQL(&buf, j, ddl)
+	}
+	return errors.Trace(q.out.write(buf.Bytes()))
+}
+
+func (q *sqlSink) close() {
+	q.out.close()
+}
+
+// writeSQL writes the SQL of the job after a comment with its binlog position
+func writeSQL(buf *bytes.Buffer, j *job, sql string) {
+	fmt.Fprintf(buf, "-- pos: %s", j.currentPos)
+	if j.gtidSet != nil {
+		fmt.Fprintf(buf, ", gtid: %s", j.gtidSet)
+	}
+	if len(j.sourceTable) > 0 {
+		fmt.Fprintf(buf, ", source: `%s`.`%s`", j.sourceSchema, j.sourceTable)
+	}
+	buf.WriteString("\n")
+	buf.WriteString(strings.TrimRight(strings.TrimSpace(sql), ";"))
+	buf.WriteString(";\n")
+}
+
+// interpolateSQL replaces `?` placeholders (not in quotes) in the SQL with the literals of args
+func interpolateSQL(sql string, args []interface{}) (string, error) {
+	if len(args) == 0 {
+		return sql, nil
+	}
+
+	var (
+		buf   bytes.Buffer
+		quote byte // the quote character if in quotes
+		idx   int
+	)
+	for i := 0; i < len(sql); i++ {
+		ch := sql[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote != '`' && i+1 < len(sql) {
+				buf.WriteByte(ch)
+				i++
+				ch = sql[i]
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '?':
+			if idx >= len(args) {
+				return "", errors.Errorf("SQL %s has more placeholders than %d args", sql, len(args))
+			}
+			buf.WriteString(sqlLiteral(args[idx]))
+			idx++
+			continue
+		}
+		buf.WriteByte(ch)
+	}
+	if idx != len(args) {
+		return "", errors.Errorf("SQL %s has %d placeholders, but got %d args", sql, idx, len(args))
+	}
+	return buf.String(), nil
+}
+
+// sqlLiteral returns the SQL literal of the value
+func sqlLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return quoteSQLString(v)
+	case []byte:
+		if !utf8.Valid(v) {
+			return "X'" + hex.EncodeToString(v) + "'"
+		}
+		return quoteSQLString(string(v))
+	default:
+		return quoteSQLString(fmt.Sprintf("%v", v))
+	}
+}
+
+// quoteSQLString quotes the string in single quotes, and escapes special characters like MySQL
+func quoteSQLString(str string) string {
+	var buf bytes.Buffer
+	buf.Grow(len(str) + 2)
+	buf.WriteByte('\'')
+	for i := 0; i < len(str); i++ {
+		switch ch := str[i]; ch {
+		case 0:
+			buf.WriteString(`\0`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\x1a':
+			buf.WriteString(`\Z`)
+		case '\'':
+			buf.WriteString(`\'`)
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	buf.WriteByte('\'')
+	return buf.String()
+}
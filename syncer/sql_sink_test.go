@@ -0,0 +1,128 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/dm/config"
+)
+
+var _ = Suite(&testSQLSinkSuite{})
+
+type testSQLSinkSuite struct{}
+
+func (t *testSQLSinkSuite) TestInterpolateSQL(c *C) {
+	cases := []struct {
+		sql      string
+		args     []interface{}
+		expected string
+	}{
+		{"SELECT 1", nil, "SELECT 1"},
+		{
+			"REPLACE INTO `db`.`t?` (`a`,`b`,`c`,`d`,`e`) VALUES (?,?,?,?,?);",
+			[]interface{}{int64(-1), uint64(18446744073709551615), 1.5, nil, "it's\n\"a\"\\"},
+			"REPLACE INTO `db`.`t?` (`a`,`b`,`c`,`d`,`e`) VALUES (-1,18446744073709551615,1.5,NULL,'it\\'s\\n\\\"a\\\"\\\\');",
+		},
+		{
+			"UPDATE `db`.`t` SET `a` = ? WHERE `b` = '?\\'?' AND `c` = ? LIMIT 1;",
+			[]interface{}{[]byte("abc"), []byte{0xff, 0x00}},
+			"UPDATE `db`.`t` SET `a` = 'abc' WHERE `b` = '?\\'?' AND `c` = X'ff00' LIMIT 1;",
+		},
+	}
+	for _, cs := range cases {
+		sql, err := interpolateSQL(cs.sql, cs.args)
+		c.Assert(err, IsNil)
+		c.Assert(sql, Equals, cs.expected)
+	}
+
+	_, err := interpolateSQL("DELETE FROM t WHERE a = ?", []interface{}{1, 2})
+	c.Assert(err, NotNil)
+	_, err = interpolateSQL("DELETE FROM t WHERE a = ? AND b = ?", []interface{}{1})
+	c.Assert(err, NotNil)
+}
+
+func (t *testSQLSinkSuite) TestSQLSink(c *C) {
+	dir := c.MkDir()
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source", WorkerCount: 1}
+	cfg.Sink = config.SinkSQL
+	cfg.FileSink.Dir = dir
+	cfg.FileSink.MaxSize = 1
+
+	sinks, err := createSinks(cfg, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(sinks, HasLen, 2)
+	q := sinks[0].(*sqlSink)
+
+	pos := mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+	jobs := []*job{
+		newJob(insert, "db", "tb", "target_db", "target_tb", "REPLACE INTO `target_db`.`target_tb` (`id`,`name`) VALUES (?,?);", []interface{}{1, "a"}, "", pos, pos, nil, ""),
+		newJob(del, "db", "tb", "target_db", "target_tb", "DELETE FROM `target_db`.`target_tb` WHERE `id` = ? LIMIT 1;", []interface{}{2}, "", pos, pos, nil, ""),
+	}
+	c.Assert(q.executeJobs(jobs, 1), IsNil)
+	pos.Pos = 2345
+	c.Assert(q.executeDDLs(newDDLJob(nil, []string{"ALTER TABLE `target_db`.`target_tb` ADD COLUMN `c` INT"}, pos, pos, nil, nil, "")), IsNil)
+	q.close()
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "task.source.000001.sql"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "BEGIN;\n"+
+		"-- pos: (mysql-bin.000001, 1234), source: `db`.`tb`\n"+
+		"REPLACE INTO `target_db`.`target_tb` (`id`,`name`) VALUES (1,'a');\n"+
+		"-- pos: (mysql-bin.000001, 1234), source: `db`.`tb`\n"+
+		"DELETE FROM `target_db`.`target_tb` WHERE `id` = 2 LIMIT 1;\n"+
+		"COMMIT;\n"+
+		"-- pos: (mysql-bin.000001, 2345)\n"+
+		"ALTER TABLE `target_db`.`target_tb` ADD COLUMN `c` INT;\n")
+}
+
+func (t *testSQLSinkSuite) TestLocalCheckPoint(c *C) {
+	cfg := &config.SubTaskConfig{Name: "task"}
+	cfg.FileSink.Dir = c.MkDir()
+	cp := NewLocalCheckPoint(cfg, "source")
+	c.Assert(cp.Init(), IsNil)
+	c.Assert(cp.Load(), IsNil)
+	c.Assert(cp.GlobalPoint(), Equals, minCheckpoint)
+
+	pos1 := mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+	pos2 := mysql.Position{Name: "mysql-bin.000001", Pos: 2345}
+	cp.SaveTablePoint("db", "tb1", pos1, nil)
+	cp.SaveTableInfo("db", "tb1", `{"columns":[]}`)
+	cp.SaveTablePoint("db", "tb2", pos1, nil)
+	cp.SaveGlobalPoint(pos1, nil)
+	c.Assert(cp.FlushPointsExcept([][]string{{"db", "tb2"}}), IsNil)
+	c.Assert(cp.FlushedGlobalPoint(), Equals, pos1)
+
+	cp.SaveTablePoint("db", "tb1", pos2, nil)
+	cp.SaveGlobalPoint(pos2, nil)
+	c.Assert(cp.DeleteTablePoint("db", "tb2"), IsNil)
+
+	// only flushed checkpoints are loaded
+	cp2 := NewLocalCheckPoint(cfg, "source")
+	c.Assert(cp2.Load(), IsNil)
+	c.Assert(cp2.GlobalPoint(), Equals, pos1)
+	c.Assert(cp2.IsNewerTablePoint("db", "tb1", pos1, nil), IsFalse)
+	c.Assert(cp2.IsNewerTablePoint("db", "tb1", pos2, nil), IsTrue)
+	c.Assert(cp2.TableInfo("db", "tb1"), Equals, `{"columns":[]}`)
+	c.Assert(cp2.IsNewerTablePoint("db", "tb2", pos1, nil), IsTrue)
+
+	c.Assert(cp.Clear(), IsNil)
+	cp3 := NewLocalCheckPoint(cfg, "source")
+	c.Assert(cp3.Load(), IsNil)
+	c.Assert(cp3.GlobalPoint(), Equals, minCheckpoint)
+}
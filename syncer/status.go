@@ -53,6 +53,9 @@ func (s *Syncer) Status() interface{} {
 	if masterGTIDSet != nil { // masterGTIDSet maybe a nil interface
 		st.MasterBinlogGtid = masterGTIDSet.String()
 	}
+	if syncerGTIDSet := s.checkpoint.FlushedGlobalPointGTID(); syncerGTIDSet != nil {
+		st.SyncerBinlogGtid = syncerGTIDSet.String()
+	}
 
 	// If a syncer unit is waiting for relay log catch up, it has not executed
 	// LoadMeta and will return a parsed binlog name error. As we can find mysql
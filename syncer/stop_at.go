@@ -0,0 +1,126 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/pkg/gtid"
+)
+
+// stopPoint is the point where the syncer stops, parsed from `stop-at` in config.
+// it is checked at transaction boundaries, so transactions are never split
+type stopPoint struct {
+	pos *mysql.Position // stop when the position is reached
+	gs  gtid.Set        // stop when the GTID set is executed
+	ts  uint32          // stop before the first transaction committed at or after the timestamp
+}
+
+// newStopPoint parses stop-at, returns nil if not specified
+func newStopPoint(cfg *config.SubTaskConfig, loc *time.Location) (*stopPoint, error) {
+	stopAt := cfg.StopAt
+	if stopAt == nil {
+		return nil, nil
+	}
+	p := &stopPoint{}
+	switch {
+	case len(stopAt.BinLogName) > 0:
+		p.pos = &mysql.Position{Name: stopAt.BinLogName, Pos: stopAt.BinLogPos}
+	case len(stopAt.BinLogGTID) > 0:
+		if !cfg.EnableGTID {
+			return nil, errors.NotSupportedf("stop-at binlog-gtid without enable-gtid")
+		}
+		gs, err := gtid.ParserGTID(cfg.Flavor, stopAt.BinLogGTID)
+		if err != nil {
+			return nil, errors.Annotate(err, "stop-at")
+		}
+		p.gs = gs
+	case len(stopAt.BinLogTime) > 0:
+		t, err := stopAt.ParseBinLogTime(loc)
+		if err != nil {
+			return nil, errors.Annotate(err, "stop-at")
+		}
+		p.ts = uint32(t.Unix())
+	default:
+		return nil, errors.NotValidf("empty stop-at")
+	}
+	return p, nil
+}
+
+// reached returns whether the stop point is reached at a transaction boundary,
+// lastPos and gs are the position and the GTID set executed until the boundary,
+// ts is the timestamp of the next transaction, 0 if no next transaction received yet
+func (p *stopPoint) reached(lastPos mysql.Position, gs gtid.Set, ts uint32) bool {
+	switch {
+	case p.pos != nil:
+		return lastPos.Compare(*p.pos) >= 0
+	case p.gs != nil:
+		return gs != nil && gs.Contain(p.gs)
+	default:
+		return ts > 0 && ts >= p.ts
+	}
+}
+
+func (p *stopPoint) String() string {
+	switch {
+	case p.pos != nil:
+		return fmt.Sprintf("position %s", p.pos)
+	case p.gs != nil:
+		return fmt.Sprintf("GTID set %s", p.gs)
+	default:
+		return fmt.Sprintf("timestamp %d", p.ts)
+	}
+}
+
+// txnBoundary tracks whether events are received at a transaction boundary
+type txnBoundary struct {
+	inTxn bool
+}
+
+// isTxnStart returns whether the event starts a new transaction (or a DDL)
+func (b *txnBoundary) isTxnStart(e *replication.BinlogEvent) bool {
+	if b.inTxn {
+		return false
+	}
+	switch e.Event.(type) {
+	case *replication.GTIDEvent, *replication.MariadbGTIDEvent, *replication.QueryEvent:
+		return true
+	}
+	return false
+}
+
+// update updates the state after the event received
+func (b *txnBoundary) update(e *replication.BinlogEvent) {
+	switch ev := e.Event.(type) {
+	case *replication.GTIDEvent, *replication.MariadbGTIDEvent:
+		b.inTxn = true
+	case *replication.QueryEvent:
+		// DDLs and `COMMIT` end the transaction
+		b.inTxn = strings.EqualFold(strings.TrimSpace(string(ev.Query)), "BEGIN")
+	case *replication.XIDEvent:
+		b.inTxn = false
+	case *replication.RotateEvent:
+		if e.Header.Timestamp == 0 {
+			// fake rotate event when (re)started, always at a boundary
+			b.inTxn = false
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+
+	"github.com/pingcap/dm/dm/config"
+	"github.com/pingcap/dm/dm/pb"
+	"github.com/pingcap/dm/pkg/binlog/event"
+	"github.com/pingcap/dm/pkg/gtid"
+	"github.com/pingcap/dm/pkg/utils"
+)
+
+var _ = Suite(&testStopAtSuite{})
+
+type testStopAtSuite struct{}
+
+func (t *testStopAtSuite) TestStopPoint(c *C) {
+	cfg := &config.SubTaskConfig{Flavor: mysql.MySQLFlavor}
+	p, err := newStopPoint(cfg, time.UTC)
+	c.Assert(err, IsNil)
+	c.Assert(p, IsNil)
+
+	// position
+	cfg.StopAt = &config.StopAt{BinLogName: "mysql-bin.000002", BinLogPos: 1000}
+	p, err = newStopPoint(cfg, time.UTC)
+	c.Assert(err, IsNil)
+	c.Assert(p.reached(mysql.Position{Name: "mysql-bin.000001", Pos: 2000}, nil, 0), IsFalse)
+	c.Assert(p.reached(mysql.Position{Name: "mysql-bin.000002", Pos: 999}, nil, 0), IsFalse)
+	c.Assert(p.reached(mysql.Position{Name: "mysql-bin.000002", Pos: 1000}, nil, 0), IsTrue)
+
+	// GTID set
+	cfg.StopAt = &config.StopAt{BinLogGTID: "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"}
+	_, err = newStopPoint(cfg, time.UTC)
+	c.Assert(err, NotNil)
+	cfg.EnableGTID = true
+	p, err = newStopPoint(cfg, time.UTC)
+	c.Assert(err, IsNil)
+	gs, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-13")
+	c.Assert(err, IsNil)
+	c.Assert(p.reached(mysql.Position{}, nil, 0), IsFalse)
+	c.Assert(p.reached(mysql.Position{}, gs, 0), IsFalse)
+	c.Assert(gs.Update("3ccc475b-2343-11e7-be21-6c0b84d59f30:14"), IsNil)
+	c.Assert(p.reached(mysql.Position{}, gs, 0), IsTrue)
+
+	// time
+	cfg.StopAt = &config.StopAt{BinLogTime: "2019-06-01 12:00:00"}
+	p, err = newStopPoint(cfg, time.FixedZone("UTC+8", 8*3600))
+	c.Assert(err, IsNil)
+	ts := uint32(time.Date(2019, 6, 1, 4, 0, 0, 0, time.UTC).Unix())
+	c.Assert(p.reached(mysql.Position{}, nil, 0), IsFalse)
+	c.Assert(p.reached(mysql.Position{}, nil, ts-1), IsFalse)
+	c.Assert(p.reached(mysql.Position{}, nil, ts), IsTrue)
+}
+
+func (t *testStopAtSuite) TestTxnBoundary(c *C) {
+	newEvent := func(ev replication.Event) *replication.BinlogEvent {
+		return &replication.BinlogEvent{Header: &replication.EventHeader{Timestamp: 1}, Event: ev}
+	}
+	cases := []struct {
+		e     *replication.BinlogEvent
+		start bool
+	}{
+		// transaction with GTID
+		{newEvent(&replication.GTIDEvent{}), true},
+		{newEvent(&replication.QueryEvent{Query: []byte("BEGIN")}), false},
+		{newEvent(&replication.RowsEvent{}), false},
+		{newEvent(&replication.XIDEvent{}), false},
+		// DDL with GTID
+		{newEvent(&replication.GTIDEvent{}), true},
+		{newEvent(&replication.QueryEvent{Query: []byte("CREATE TABLE t (id INT)")}), false},
+		// transaction without GTID
+		{newEvent(&replication.QueryEvent{Query: []byte("BEGIN")}), true},
+		{newEvent(&replication.RowsEvent{}), false},
+		{newEvent(&replication.QueryEvent{Query: []byte("COMMIT")}), false},
+		// DDL without GTID
+		{newEvent(&replication.QueryEvent{Query: []byte("DROP TABLE t")}), true},
+		{newEvent(&replication.QueryEvent{Query: []byte("BEGIN")}), true},
+	}
+	var b txnBoundary
+	for i, cs := range cases {
+		c.Assert(b.isTxnStart(cs.e), Equals, cs.start, Commentf("case %d", i))
+		b.update(cs.e)
+	}
+	c.Assert(b.inTxn, IsTrue)
+	b.update(&replication.BinlogEvent{Header: &replication.EventHeader{}, Event: &replication.RotateEvent{}})
+	c.Assert(b.inTxn, IsFalse)
+}
+
+func (t *testStopAtSuite) TestProcessFinishedAtStopPoint(c *C) {
+	dir, err := ioutil.TempDir("", "test_stop_at")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	// relay log with only the file header, no more events will be received
+	relayDir := filepath.Join(dir, "relay")
+	uuid := "c6ae5afe-c7a3-11e8-a19d-0242ac130006.000001"
+	c.Assert(os.MkdirAll(filepath.Join(relayDir, uuid), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(relayDir, utils.UUIDIndexFilename), []byte(uuid+"\n"), 0644), IsNil)
+	gs, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	c.Assert(err, IsNil)
+	_, data, err := event.GenCommonFileHeader(mysql.MySQLFlavor, 1, gs)
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(relayDir, uuid, "mysql-bin.000001"), data, 0644), IsNil)
+
+	cfg := &config.SubTaskConfig{
+		Name:             "test-stop-at",
+		Mode:             config.ModeIncrement,
+		Flavor:           mysql.MySQLFlavor,
+		BinlogType:       "local",
+		RelayDir:         relayDir,
+		EnableANSIQuotes: true, // not query the upstream for SQL mode
+		Meta:             &config.Meta{BinLogName: "mysql-bin.000001", BinLogPos: 4},
+		StopAt:           &config.StopAt{BinLogName: "mysql-bin.000001", BinLogPos: 4},
+	}
+	cfg.WorkerCount = 1
+	cfg.Batch = 1
+	cfg.Sink = config.SinkSQL // not write into the downstream
+	cfg.FileSink = config.FileSinkConfig{Dir: filepath.Join(dir, "sink"), MaxSize: 1}
+
+	syncer := NewSyncer(cfg)
+	syncer.fromDB = &Conn{cfg: cfg}
+	syncer.sinks, err = createSinks(cfg, nil, nil)
+	c.Assert(err, IsNil)
+	defer syncer.closeSinks()
+	syncer.stopPoint, err = newStopPoint(cfg, syncer.timezone)
+	c.Assert(err, IsNil)
+	c.Assert(syncer.checkpoint.Init(), IsNil)
+	c.Assert(syncer.checkpoint.Load(), IsNil)
+
+	origEventTimeout := eventTimeout
+	eventTimeout = 100 * time.Millisecond
+	defer func() {
+		eventTimeout = origEventTimeout
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr := make(chan pb.ProcessResult, 1)
+	go syncer.Process(ctx, pr)
+
+	// Process returns without error and not canceled, the sub task becomes Finished
+	select {
+	case result := <-pr:
+		c.Assert(result.Errors, HasLen, 0)
+		c.Assert(result.IsCanceled, IsFalse)
+	case <-time.After(10 * time.Second):
+		c.Fatal("syncer not finished after reached the stop point")
+	}
+	c.Assert(syncer.checkpoint.GlobalPoint(), DeepEquals, mysql.Position{Name: "mysql-bin.000001", Pos: 4})
+}
@@ -40,8 +40,10 @@ import (
 	fr "github.com/pingcap/dm/pkg/func-rollback"
 	"github.com/pingcap/dm/pkg/gtid"
 	"github.com/pingcap/dm/pkg/log"
+	"github.com/pingcap/dm/pkg/shardddl"
 	"github.com/pingcap/dm/pkg/streamer"
 	"github.com/pingcap/dm/pkg/tracing"
+	"github.com/pingcap/dm/pkg/transform"
 	"github.com/pingcap/dm/pkg/utils"
 	sm "github.com/pingcap/dm/syncer/safe-mode"
 	"github.com/pingcap/dm/syncer/sql-operator"
@@ -87,6 +89,7 @@ type Syncer struct {
 	ddlInfoCh       chan *pb.DDLInfo               // DDL info pending to sync, only support sync one DDL lock one time, refine if needed
 	ddlExecInfo     *DDLExecInfo                   // DDL execute (ignore) info
 	injectEventCh   chan *replication.BinlogEvent  // extra binlog event chan, used to inject binlog event into the main for loop
+	leaveShardingCh chan *leaveShardingReq         // requests to remove idle sources from sharding groups, handled in the main for loop
 
 	// TODO: extract to interface?
 	syncer      *replication.BinlogSyncer
@@ -96,24 +99,34 @@ type Syncer struct {
 	wg    sync.WaitGroup
 	jobWg sync.WaitGroup
 
-	tables       map[string]*table   // table cache: `target-schema`.`target-table` -> table
-	cacheColumns map[string][]string // table columns cache: `target-schema`.`target-table` -> column names list
-	genColsCache *GenColCache
+	tables            map[string]*table            // table cache: `source-schema`.`source-table` -> table with target schema and name
+	cacheColumns      map[string][]string          // table columns cache: `source-schema`.`source-table` -> column names list
+	transformedTables map[string]*transformedTable // transformed table cache: `source-schema`.`source-table` -> downstream table
+	genColsCache      *GenColCache
+	schemaTracker     *schemaTracker // tracks structures of upstream tables
 
 	fromDB *Conn
 	toDBs  []*Conn
 	ddlDB  *Conn
+	sinks  []sink // sinks of DML queues and the DDL queue
 
 	jobs               []chan *job
 	jobsClosed         sync2.AtomicBool
 	jobsChanLock       sync.Mutex
 	queueBucketMapping []string
 
-	c *causality
+	c         *causality
+	txnBuffer *txnBuffer // rows of the upstream transaction in syncing, only used when `preserve-txn` or `exactly-once` enabled
+
+	appliedPoints *appliedPoints // executed transactions, only used when `exactly-once` enabled
+
+	stopPoint *stopPoint // where the syncer stops and finishes, nil if `stop-at` not specified
 
 	tableRouter   *router.Table
 	binlogFilter  *bf.BinlogEvent
+	exprFilter    *exprFilter
 	columnMapping *cm.Mapping
+	transformer   *transform.Transformer
 	bwList        *filter.Filter
 
 	closed sync2.AtomicBool
@@ -171,12 +184,21 @@ func NewSyncer(cfg *config.SubTaskConfig) *Syncer {
 	syncer.count.Set(0)
 	syncer.tables = make(map[string]*table)
 	syncer.cacheColumns = make(map[string][]string)
+	syncer.transformedTables = make(map[string]*transformedTable)
 	syncer.genColsCache = NewGenColCache()
-	syncer.c = newCausality()
+	syncer.schemaTracker = newSchemaTracker()
+	syncer.c = newCausality(cfg.WorkerCount)
+	syncer.txnBuffer = newTxnBuffer()
+	syncer.appliedPoints = newAppliedPoints()
 	syncer.tableRouter, _ = router.NewTableRouter(cfg.CaseSensitive, []*router.TableRule{})
 	syncer.done = make(chan struct{})
 	syncer.bwList = filter.New(cfg.CaseSensitive, cfg.BWList)
-	syncer.checkpoint = NewRemoteCheckPoint(cfg, syncer.checkpointID())
+	if cfg.Sink != config.SinkMySQL {
+		// nothing is written into target database for the file sink and the SQL sink (dry-run)
+		syncer.checkpoint = NewLocalCheckPoint(cfg, syncer.checkpointID())
+	} else {
+		syncer.checkpoint = NewRemoteCheckPoint(cfg, syncer.checkpointID())
+	}
 	syncer.injectEventCh = make(chan *replication.BinlogEvent)
 	syncer.tracer = tracing.GetTracer()
 	syncer.setTimezone()
@@ -207,6 +229,7 @@ func NewSyncer(cfg *config.SubTaskConfig) *Syncer {
 		syncer.sgk = NewShardingGroupKeeper()
 		syncer.ddlInfoCh = make(chan *pb.DDLInfo, 1)
 		syncer.ddlExecInfo = NewDDLExecInfo()
+		syncer.leaveShardingCh = make(chan *leaveShardingReq, 10)
 	}
 
 	return syncer
@@ -255,11 +278,27 @@ func (s *Syncer) Init() (err error) {
 	}
 	rollbackHolder.Add(fr.FuncRollback{"close-DBs", s.closeDBs})
 
+	s.sinks, err = createSinks(s.cfg, s.toDBs, s.ddlDB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rollbackHolder.Add(fr.FuncRollback{"close-sinks", s.closeSinks})
+
 	s.binlogFilter, err = bf.NewBinlogEvent(s.cfg.CaseSensitive, s.cfg.FilterRules)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	s.exprFilter, err = newExprFilter(s.cfg.CaseSensitive, s.cfg.ExprFilter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.stopPoint, err = newStopPoint(s.cfg, s.timezone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	if len(s.cfg.ColumnMappingRules) > 0 {
 		s.columnMapping, err = cm.NewMapping(s.cfg.CaseSensitive, s.cfg.ColumnMappingRules)
 		if err != nil {
@@ -267,6 +306,13 @@ func (s *Syncer) Init() (err error) {
 		}
 	}
 
+	if len(s.cfg.ColumnTransformRules) > 0 {
+		s.transformer, err = transform.NewTransformer(s.cfg.CaseSensitive, s.cfg.ColumnTransformRules)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if s.cfg.OnlineDDLScheme != "" {
 		fn, ok := OnlineDDLSchemes[s.cfg.OnlineDDLScheme]
 		if !ok {
@@ -316,6 +362,13 @@ func (s *Syncer) Init() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+
+	// locate binlog-time in meta before it is used, like in setInitActiveRelayLog and LoadMeta
+	err = s.locateBinlogTime()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	if s.cfg.EnableHeartbeat {
 		s.heartbeat, err = GetHeartbeat(&HeartbeatConfig{
 			serverID:  s.cfg.ServerID,
@@ -421,8 +474,11 @@ func (s *Syncer) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	s.done = make(chan struct{})
 	// create new job chans
 	s.newJobChans(s.cfg.WorkerCount + 1)
+	s.c = newCausality(s.cfg.WorkerCount)
 	// clear tables info
 	s.clearAllTables()
+	// tracked tables may be ahead of checkpoint, track them again from checkpoint
+	s.schemaTracker.reset()
 
 	s.runFatalChan = make(chan *pb.ProcessError, s.cfg.WorkerCount+1)
 	s.execErrorDetected.Set(false)
@@ -466,11 +522,9 @@ func (s *Syncer) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	}()
 
 	err := s.Run(newCtx)
-	if err != nil {
-		// returned error rather than sent to runFatalChan
-		// cancel goroutines created in s.Run
-		cancel()
-	}
+	// returned error rather than sent to runFatalChan, or returned without error after reached stop-at,
+	// cancel goroutines created in s.Run and background jobs
+	cancel()
 	s.closeJobChans()     // Run returned, all jobs sent, we can close s.jobs
 	s.wg.Wait()           // wait for sync goroutine to return
 	close(s.runFatalChan) // Run returned, all potential fatal sent to s.runFatalChan
@@ -511,18 +565,20 @@ func (s *Syncer) getMasterStatus() (mysql.Position, gtid.Set, error) {
 	return utils.GetMasterStatus(s.fromDB.db, s.cfg.Flavor)
 }
 
-// clearTables is used for clear table cache of given table. this function must
+// clearTables is used for clear table cache of given source table. this function must
 // be called when DDL is applied to this table.
 func (s *Syncer) clearTables(schema, table string) {
 	key := dbutil.TableName(schema, table)
 	delete(s.tables, key)
 	delete(s.cacheColumns, key)
+	delete(s.transformedTables, key)
 	s.genColsCache.clearTable(schema, table)
 }
 
 func (s *Syncer) clearAllTables() {
 	s.tables = make(map[string]*table)
 	s.cacheColumns = make(map[string][]string)
+	s.transformedTables = make(map[string]*transformedTable)
 	s.genColsCache.reset()
 }
 
@@ -549,19 +605,22 @@ func (s *Syncer) getTableFromDB(db *Conn, schema string, name string) (*table, e
 	return table, nil
 }
 
-func (s *Syncer) getTable(schema string, table string) (*table, []string, error) {
-	key := dbutil.TableName(schema, table)
+// getTable returns structure of the source table tracked by schema tracker,
+// with schema and name of the target table which DMLs are generated for
+func (s *Syncer) getTable(originSchema, originTable, schema, table string) (*table, []string, error) {
+	key := dbutil.TableName(originSchema, originTable)
 
 	value, ok := s.tables[key]
 	if ok {
 		return value, s.cacheColumns[key], nil
 	}
 
-	db := s.toDBs[len(s.toDBs)-1]
-	t, err := s.getTableFromDB(db, schema, table)
+	tracked, err := s.loadTrackedTable(originSchema, originTable, schema, table)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	t := cloneTable(tracked)
+	t.schema, t.name = schema, table
 
 	// compute cache column list for column mapping
 	columns := make([]string, 0, len(t.columns))
@@ -574,6 +633,99 @@ func (s *Syncer) getTable(schema string, table string) (*table, []string, error)
 	return t, columns, nil
 }
 
+// loadTrackedTable returns the tracked structure of the source table,
+// if not tracked yet, starts to track it from structure saved in checkpoint,
+// or from the target table in downstream if no structure saved (like the table is just loaded by loader),
+// or from the dump files of the dump unit if the target table is transformed or not exists,
+// or from the upstream table if not dumped
+func (s *Syncer) loadTrackedTable(originSchema, originTable, schema, table string) (*table, error) {
+	t := s.schemaTracker.getTable(originSchema, originTable)
+	if t != nil {
+		return t, nil
+	}
+
+	var err error
+	if info := s.checkpoint.TableInfo(originSchema, originTable); len(info) > 0 {
+		t, err = unmarshalTable(originSchema, originTable, info)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		log.Infof("[syncer] track table %s.%s from checkpoint", originSchema, originTable)
+	} else if s.transformer.Matched(originSchema, originTable) || s.cfg.Sink != config.SinkMySQL {
+		// columns of the downstream table are transformed, or no downstream table for other sinks,
+		// so track it from the structure dumped at the start position, or the upstream table if not dumped
+		t, err = s.getTableFromDump(originSchema, originTable)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if t != nil {
+			log.Infof("[syncer] track table %s.%s from dump files", originSchema, originTable)
+		} else {
+			t, err = s.getTableFromDB(s.fromDB, originSchema, originTable)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			log.Warnf("[syncer] track table %s.%s from upstream table, which may be changed after the binlog position", originSchema, originTable)
+		}
+	} else {
+		db := s.toDBs[len(s.toDBs)-1]
+		t, err = s.getTableFromDB(db, schema, table)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		t.schema, t.name = originSchema, originTable
+		log.Infof("[syncer] track table %s.%s from downstream table %s.%s", originSchema, originTable, schema, table)
+	}
+
+	s.schemaTracker.setTable(t)
+	return t, nil
+}
+
+// trackDDL replays the upstream DDL in schema tracker, and clears caches of affected tables
+// tableNames is [[source tables], [target tables]] returned by handleDDL
+func (s *Syncer) trackDDL(stmt ast.StmtNode, tableNames [][]*filter.Table) error {
+	// tables need to be tracked before the DDL applied
+	var base []int
+	switch v := stmt.(type) {
+	case *ast.CreateTableStmt:
+		if v.ReferTable != nil {
+			base = append(base, 1)
+		}
+	case *ast.AlterTableStmt, *ast.RenameTableStmt, *ast.CreateIndexStmt, *ast.DropIndexStmt:
+		base = append(base, 0)
+	}
+	for _, i := range base {
+		_, err := s.loadTrackedTable(tableNames[0][i].Schema, tableNames[0][i].Name, tableNames[1][i].Schema, tableNames[1][i].Name)
+		if err != nil {
+			return errors.Annotatef(err, "track table %s.%s", tableNames[0][i].Schema, tableNames[0][i].Name)
+		}
+	}
+
+	err := s.schemaTracker.applyDDL(stmt, tableNames[0])
+	if err != nil {
+		return errors.Annotatef(err, "track DDL on %s", tableNames[0][0])
+	}
+
+	if _, ok := stmt.(*ast.DropDatabaseStmt); ok {
+		s.clearAllTables()
+	} else {
+		for _, tbl := range tableNames[0] {
+			s.clearTables(tbl.Schema, tbl.Name)
+		}
+	}
+	return nil
+}
+
+// saveTrackedTable saves the tracked structure of the source table along with its checkpoint
+func (s *Syncer) saveTrackedTable(schema, table string) error {
+	info, err := marshalTable(s.schemaTracker.getTable(schema, table))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.checkpoint.SaveTableInfo(schema, table, info)
+	return nil
+}
+
 func (s *Syncer) addCount(isFinished bool, queueBucket string, tp opType, n int64) {
 	m := addedJobsTotal
 	if isFinished {
@@ -621,7 +773,14 @@ func (s *Syncer) addJob(job *job) error {
 	)
 	switch job.tp {
 	case xid:
-		s.saveGlobalPoint(job.pos)
+		// rows of the transaction should be dispatched before saving the global checkpoint
+		if err := s.commitTxn(); err != nil {
+			return errors.Trace(err)
+		}
+		s.saveGlobalPoint(job.pos, job.gtidSet)
+		if s.cfg.ExactlyOnce {
+			s.appliedPoints.save(job.pos, job.gtidSet)
+		}
 		return nil
 	case flush:
 		addedJobsTotal.WithLabelValues("flush", s.cfg.Name, adminQueueName).Inc()
@@ -634,6 +793,9 @@ func (s *Syncer) addJob(job *job) error {
 		finishedJobsTotal.WithLabelValues("flush", s.cfg.Name, adminQueueName).Inc()
 		return errors.Trace(s.flushCheckPoints())
 	case ddl:
+		if err := s.commitTxn(); err != nil {
+			return errors.Trace(err)
+		}
 		s.jobWg.Wait()
 		addedJobsTotal.WithLabelValues("ddl", s.cfg.Name, adminQueueName).Inc()
 		s.jobWg.Add(1)
@@ -644,37 +806,65 @@ func (s *Syncer) addJob(job *job) error {
 		}
 	case insert, update, del:
 		s.jobWg.Add(1)
-		queueBucket = int(utils.GenHashKey(job.key)) % s.cfg.WorkerCount
+		queueBucket = s.queueOfJob(job)
 		s.addCount(false, s.queueBucketMapping[queueBucket], job.tp, 1)
 		s.jobs[queueBucket] <- job
+	case txn:
+		if s.cfg.ExactlyOnce {
+			job.seq = s.appliedPoints.dispatch()
+		}
+		s.jobWg.Add(1)
+		queueBucket = s.queueOfJob(job)
+		for _, row := range job.rows {
+			s.addCount(false, s.queueBucketMapping[queueBucket], row.tp, 1)
+		}
+		s.jobs[queueBucket] <- job
 	}
 
 	if s.tracer.Enable() {
-		_, err := s.tracer.CollectSyncerJobEvent(job.traceID, job.traceGID, int32(job.tp), job.pos, job.currentPos, s.queueBucketMapping[queueBucket], job.sql, job.ddls, job.args, execDDLReq, pb.SyncerJobState_queued)
-		if err != nil {
-			log.Errorf("[syncer] trace error: %s", err)
+		traceJobs := job.rows
+		if job.tp != txn {
+			traceJobs = append(traceJobs, job)
+		}
+		for _, j := range traceJobs {
+			_, err := s.tracer.CollectSyncerJobEvent(j.traceID, j.traceGID, int32(j.tp), j.pos, j.currentPos, s.queueBucketMapping[queueBucket], j.sql, j.ddls, j.args, execDDLReq, pb.SyncerJobState_queued)
+			if err != nil {
+				log.Errorf("[syncer] trace error: %s", err)
+			}
 		}
 	}
 
 	wait := s.checkWait(job)
 	if wait {
 		s.jobWg.Wait()
-		s.c.reset()
 	}
 
 	switch job.tp {
 	case ddl:
 		// only save checkpoint for DDL and XID (see above)
-		s.saveGlobalPoint(job.pos)
+		s.saveGlobalPoint(job.pos, job.gtidSet)
+		if s.cfg.ExactlyOnce {
+			// all jobs before the DDL have been executed, global checkpoints saved with DMLs should not be older than it
+			s.appliedPoints.save(job.pos, job.gtidSet)
+		}
 		if len(job.sourceSchema) > 0 {
-			s.checkpoint.SaveTablePoint(job.sourceSchema, job.sourceTable, job.pos)
+			s.checkpoint.SaveTablePoint(job.sourceSchema, job.sourceTable, job.pos, job.gtidSet)
+			if err := s.saveTrackedTable(job.sourceSchema, job.sourceTable); err != nil {
+				return errors.Trace(err)
+			}
 		}
 		// reset sharding group after checkpoint saved
 		s.resetShardingGroup(job.targetSchema, job.targetTable)
 	case insert, update, del:
 		// save job's current pos for DML events
 		if len(job.sourceSchema) > 0 {
-			s.checkpoint.SaveTablePoint(job.sourceSchema, job.sourceTable, job.currentPos)
+			s.checkpoint.SaveTablePoint(job.sourceSchema, job.sourceTable, job.currentPos, job.gtidSet)
+		}
+	case txn:
+		for _, row := range job.rows {
+			if len(row.sourceSchema) > 0 {
+				s.checkpoint.SaveTablePoint(row.sourceSchema, row.sourceTable, row.currentPos, row.gtidSet)
+			}
 		}
 	}
 
@@ -685,11 +875,24 @@ func (s *Syncer) addJob(job *job) error {
 	return nil
 }
 
-func (s *Syncer) saveGlobalPoint(globalPoint mysql.Position) {
+// queueOfJob returns the queue to dispatch a DML job
+func (s *Syncer) queueOfJob(job *job) int {
+	if job.node != nil {
+		return job.node.queue
+	}
+	return int(utils.GenHashKey(job.key)) % s.cfg.WorkerCount
+}
+
+func (s *Syncer) saveGlobalPoint(globalPoint mysql.Position, gs gtid.Set) {
 	if s.cfg.IsSharding {
-		globalPoint = s.sgk.AdjustGlobalPoint(globalPoint)
+		adjusted := s.sgk.AdjustGlobalPoint(globalPoint)
+		if adjusted.Compare(globalPoint) != 0 {
+			// GTID set of the adjusted position is unknown, keep the previous one which is older
+			gs = nil
+		}
+		globalPoint = adjusted
 	}
-	s.checkpoint.SaveGlobalPoint(globalPoint)
+	s.checkpoint.SaveGlobalPoint(globalPoint, gs)
 }
 
 func (s *Syncer) resetShardingGroup(schema, table string) {
@@ -738,21 +941,29 @@ func (s *Syncer) flushCheckPoints() error {
 	return nil
 }
 
-func (s *Syncer) sync(ctx context.Context, queueBucket string, db *Conn, jobChan chan *job) {
+func (s *Syncer) sync(ctx context.Context, queueBucket string, sk sink, jobChan chan *job) {
 	defer s.wg.Done()
 
 	idx := 0
 	count := s.cfg.Batch
 	jobs := make([]*job, 0, count)
+	nodes := make([]*causalNode, 0, count)
+	seqs := make([]int64, 0, count)
 	tpCnt := make(map[opType]int64)
 
 	clearF := func() {
+		// jobs depending on executed jobs can be executed now
+		for _, node := range nodes {
+			s.c.finish(node)
+		}
 		for i := 0; i < idx; i++ {
 			s.jobWg.Done()
 		}
 
 		idx = 0
 		jobs = jobs[0:0]
+		nodes = nodes[0:0]
+		seqs = seqs[0:0]
 		for tpName, v := range tpCnt {
 			s.addCount(true, queueBucket, tpName, v)
 			tpCnt[tpName] = 0
@@ -767,13 +978,29 @@ func (s *Syncer) sync(ctx context.Context, queueBucket string, db *Conn, jobChan
 
 	executeSQLs := func() error {
 		if len(jobs) == 0 {
+			// transactions without any SQL to execute
+			s.appliedPoints.finish(seqs)
 			return nil
 		}
-		errCtx := db.executeSQLJob(jobs, s.cfg.MaxRetry)
+		execJobs := jobs
+		if s.cfg.CompactDML {
+			execJobs = compactJobs(jobs)
+		}
+		if s.cfg.ExactlyOnce {
+			if s.execErrorDetected.Get() {
+				// checkpoints saved after the error may skip jobs failed in other queues
+				log.Warnf("[syncer] error detected when executing SQL job, skip executing %d jobs in %s", len(jobs), queueBucket)
+				return nil
+			}
+			execJobs = append(execJobs[:len(execJobs):len(execJobs)], s.genCheckpointJobs(jobs, seqs)...)
+		}
+		errCtx := sk.executeJobs(execJobs, s.cfg.MaxRetry)
 		var err error
 		if errCtx != nil {
 			err = errCtx.err
 			s.appendExecErrors(errCtx)
+		} else if s.cfg.ExactlyOnce {
+			s.appliedPoints.finish(seqs)
 		}
 		if s.tracer.Enable() {
 			syncerJobState := s.tracer.FinishedSyncerJobState(err)
@@ -794,6 +1021,23 @@ func (s *Syncer) sync(ctx context.Context, queueBucket string, db *Conn, jobChan
 			if !ok {
 				return
 			}
+
+			if sqlJob.node != nil && len(sqlJob.node.deps) > 0 {
+				// execute jobs received before waiting, because they may be depended by jobs in other queues
+				err = executeSQLs()
+				if err != nil {
+					fatalF(err, pb.ErrorType_ExecSQL)
+				} else {
+					clearF()
+				}
+				conflictDetectedTotal.WithLabelValues(s.cfg.Name, queueBucket).Inc()
+				startTime := time.Now()
+				sqlJob.node.wait()
+				conflictWaitHistogram.WithLabelValues(s.cfg.Name, queueBucket).Observe(time.Since(startTime).Seconds())
+			}
+			if sqlJob.node != nil {
+				nodes = append(nodes, sqlJob.node)
+			}
 			idx++
 
 			if sqlJob.tp == ddl {
@@ -806,8 +1050,7 @@ func (s *Syncer) sync(ctx context.Context, queueBucket string, db *Conn, jobChan
 				if sqlJob.ddlExecItem != nil && sqlJob.ddlExecItem.req != nil && !sqlJob.ddlExecItem.req.Exec {
 					log.Infof("[syncer] ignore sharding DDLs %v", sqlJob.ddls)
 				} else {
-					args := make([][]interface{}, len(sqlJob.ddls))
-					err = db.executeSQL(sqlJob.ddls, args, 1)
+					err = sk.executeDDLs(sqlJob)
 					if err != nil && ignoreDDLError(err) {
 						err = nil
 					}
@@ -848,12 +1091,21 @@ func (s *Syncer) sync(ctx context.Context, queueBucket string, db *Conn, jobChan
 				tpCnt[sqlJob.tp] += int64(len(sqlJob.ddls))
 				clearF()
 
+			} else if sqlJob.tp == txn {
+				// rows of a transaction are never split into different downstream transactions
+				jobs = append(jobs, sqlJob.rows...)
+				if sqlJob.seq > 0 {
+					seqs = append(seqs, sqlJob.seq)
+				}
+				for _, row := range sqlJob.rows {
+					tpCnt[row.tp]++
+				}
 			} else if sqlJob.tp != flush && len(sqlJob.sql) > 0 {
 				jobs = append(jobs, sqlJob)
 				tpCnt[sqlJob.tp]++
 			}
 
-			if idx >= count || sqlJob.tp == flush {
+			if idx >= count || len(jobs) >= count || sqlJob.tp == flush {
 				err = executeSQLs()
 				if err != nil {
 					fatalF(err, pb.ErrorType_ExecSQL)
@@ -899,6 +1151,12 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		}
 	}
 
+	// discard rows of the uncommitted transaction in last running, they will be re-synced
+	s.txnBuffer.reset()
+	// all jobs in last running finished, start tracking from the global checkpoint
+	s.appliedPoints.reset()
+	s.appliedPoints.save(s.checkpoint.GlobalPoint(), s.checkpoint.GlobalPointGTID())
+
 	// currentPos is the pos for current received event (End_log_pos in `show binlog events` for mysql)
 	// lastPos is the pos for last received (ROTATE / QUERY / XID) event (End_log_pos in `show binlog events` for mysql)
 	// we use currentPos to replace and skip binlog event of specfied position and update table checkpoint in sharding ddl
@@ -907,13 +1165,21 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		currentPos = s.checkpoint.GlobalPoint() // also init to global checkpoint
 		lastPos    = s.checkpoint.GlobalPoint()
 	)
-	log.Infof("replicate binlog from latest checkpoint %+v", lastPos)
+	// currentGTIDSet is the GTID set executed until current received event, nil if GTID not enabled or unknown yet.
+	// it is updated by GTID events, and initialized by Previous_GTIDs event if no GTID set in global checkpoint
+	var currentGTIDSet gtid.Set
+	if s.cfg.EnableGTID {
+		currentGTIDSet = s.checkpoint.GlobalPointGTID()
+	}
+	log.Infof("replicate binlog from latest checkpoint %+v", s.checkpoint)
 
+	// after resumed by GTID, binlog name may change if switched to another upstream
+	resumedByGTID := s.resumeByGTID(currentGTIDSet)
 	var globalStreamer streamer.Streamer
 	if s.binlogType == RemoteBinlog {
-		globalStreamer, err = s.getBinlogStreamer(s.syncer, lastPos)
+		globalStreamer, err = s.getBinlogStreamer(s.syncer, lastPos, currentGTIDSet)
 	} else if s.binlogType == LocalBinlog {
-		globalStreamer, err = s.getBinlogStreamer(s.localReader, lastPos)
+		globalStreamer, err = s.getBinlogStreamer(s.localReader, lastPos, currentGTIDSet)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -926,7 +1192,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		s.queueBucketMapping = append(s.queueBucketMapping, name)
 		go func(i int, n string) {
 			ctx2, cancel := context.WithCancel(ctx)
-			s.sync(ctx2, n, s.toDBs[i], s.jobs[i])
+			s.sync(ctx2, n, s.sinks[i], s.jobs[i])
 			cancel()
 		}(i, name)
 	}
@@ -935,7 +1201,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	s.wg.Add(1)
 	go func() {
 		ctx2, cancel := context.WithCancel(ctx)
-		s.sync(ctx2, adminQueueName, s.ddlDB, s.jobs[s.cfg.WorkerCount])
+		s.sync(ctx2, adminQueueName, s.sinks[s.cfg.WorkerCount], s.jobs[s.cfg.WorkerCount])
 		cancel()
 	}()
 
@@ -970,6 +1236,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	// it's eventual consistency.
 	safeMode := sm.NewSafeMode()
 	s.enableSafeModeInitializationPhase(ctx, safeMode)
+	safeModeExitPos, err := s.enableSafeModeForDumpSnapshots(safeMode, lastPos)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// syncing progress with sharding DDL group
 	// 1. use the global streamer to sync regular binlog events
@@ -993,6 +1263,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		traceSource         = fmt.Sprintf("%s.syncer.%s", s.cfg.SourceID, s.cfg.Name)
 		traceEvent          *pb.SyncerBinlogEvent
 		traceID             string
+		boundary            txnBoundary // transaction boundaries of the global streamer, to check stop-at and leave sharding groups
 	)
 
 	closeShardingSyncer := func() {
@@ -1008,6 +1279,66 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		shardingReSync = nil
 		lastPos = savedGlobalLastPos // restore global last pos
 	}
+	// GTID set for jobs, nil in sharding re-syncing because currentGTIDSet is ahead of the re-synced events
+	jobGTIDSet := func() gtid.Set {
+		if shardingReSync != nil {
+			return nil
+		}
+		return currentGTIDSet
+	}
+	// leaveShardingGroup removes idle sources from the sharding group,
+	// if other sources have executed the sharding DDL, syncs the DDL like receiving it from the last source
+	leaveShardingGroup := func(req *leaveShardingReq) (canceled bool, err error) {
+		targetSchema, targetTable := UnpackTableID(req.target)
+		group := s.sgk.Group(targetSchema, targetTable)
+		if group == nil {
+			log.Warnf("[syncer] sharding group %s not found, ignore leaving sources %v", req.target, req.sources)
+			return false, nil
+		}
+		synced := group.LeaveUnsynced(req.sources)
+		log.Infof("[syncer] sources %v left sharding group %s, synced: %v", req.sources, req.target, synced)
+		if !synced {
+			return false, nil
+		}
+
+		err = safeMode.DescForTable(targetSchema, targetTable) // try disable safe-mode after sharding group synced
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !group.IsSchemaOnly {
+			firstEndPos := group.FirstEndPosUnresolved()
+			if firstEndPos == nil {
+				return false, errors.Errorf("no valid End_log_pos of the first DDL exists for sharding group %s", req.target)
+			}
+			shardingReSyncCh <- &ShardingReSync{
+				currPos:      *firstEndPos,
+				latestPos:    currentPos,
+				targetSchema: targetSchema,
+				targetTable:  targetTable,
+			}
+		}
+
+		s.jobWg.Wait()
+		ddls := group.UnresolvedGroupInfo().DDLs
+		s.ddlInfoCh <- &pb.DDLInfo{
+			Task:   s.cfg.Name,
+			Schema: targetSchema,
+			Table:  targetTable,
+			DDLs:   ddls,
+		}
+		ddlExecItem, ok := <-s.ddlExecInfo.Chan(ddls)
+		if !ok {
+			return true, nil
+		}
+		if err = s.failedByMaster(ddlExecItem); err != nil {
+			return false, errors.Trace(err)
+		}
+		log.Infof("[syncer] add DDL %v to job after sources left sharding group %s, request is %+v", ddls, req.target, ddlExecItem.req)
+
+		job := newDDLJob(nil, ddls, lastPos, currentPos, jobGTIDSet(), ddlExecItem, traceID)
+		job.targetSchema, job.targetTable = targetSchema, targetTable // reset the sharding group after executed
+		return false, errors.Trace(s.addJob(job))
+	}
 	defer func() {
 		closeShardingSyncer()
 	}()
@@ -1017,6 +1348,14 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		s.currentPosMu.currentPos = currentPos
 		s.currentPosMu.Unlock()
 
+		if safeModeExitPos != nil && shardingReSync == nil && lastPos.Compare(*safeModeExitPos) >= 0 {
+			log.Infof("[syncer] reached the latest snapshot %s of the dump unit, try to disable safe-mode", safeModeExitPos)
+			safeModeExitPos = nil
+			if err = safeMode.Add(-1); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
 		// if there are sharding groups need to re-sync previous ignored DMLs, we use another special streamer
 		if shardingStreamer == nil && len(shardingReSyncCh) > 0 {
 			// some sharding groups need to re-syncing
@@ -1026,13 +1365,13 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 			if s.binlogType == RemoteBinlog {
 				shardingSyncer = replication.NewBinlogSyncer(s.shardingSyncCfg)
-				shardingStreamer, err = s.getBinlogStreamer(shardingSyncer, shardingReSync.currPos)
+				shardingStreamer, err = s.getBinlogStreamer(shardingSyncer, shardingReSync.currPos, nil)
 			} else if s.binlogType == LocalBinlog {
 				shardingReader = streamer.NewBinlogReader(&streamer.BinlogReaderConfig{
 					RelayDir: s.cfg.RelayDir,
 					Timezone: s.timezone,
 				})
-				shardingStreamer, err = s.getBinlogStreamer(shardingReader, shardingReSync.currPos)
+				shardingStreamer, err = s.getBinlogStreamer(shardingReader, shardingReSync.currPos, nil)
 			}
 			log.Debugf("[syncer] start using a  special streamer to re-sync DMLs for sharding group %+v", shardingReSync)
 		}
@@ -1042,6 +1381,21 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			err error
 		)
 
+		// sources only leave sharding groups in global streaming and at transaction boundaries, like receiving DDLs
+		if shardingReSync == nil && !boundary.inTxn && s.cfg.IsSharding {
+			if req := s.tryLeaveSharding(); req != nil {
+				canceled, err2 := leaveShardingGroup(req)
+				if err2 != nil {
+					return errors.Trace(err2)
+				}
+				if canceled {
+					log.Info("[syncer] cancel to add DDL to job because of canceled from external")
+					return nil
+				}
+				continue
+			}
+		}
+
 		// we only inject sqls  in global streaming to avoid DDL position confusion
 		if shardingReSync == nil {
 			e = s.tryInject(latestOp, currentPos)
@@ -1065,6 +1419,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		} else if err == context.DeadlineExceeded {
 			log.Info("deadline exceeded.")
 			eventTimeoutCounter += eventTimeout
+			if shardingReSync == nil && !boundary.inTxn && s.reachStopPoint(lastPos, currentGTIDSet, 0) {
+				return errors.Trace(s.stopAt(lastPos))
+			}
 			if eventTimeoutCounter < maxEventTimeout {
 				err = s.flushJobs()
 				if err != nil {
@@ -1080,6 +1437,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					shardingStreamer, err = s.reopenWithRetry(s.shardingSyncCfg)
 				} else {
 					globalStreamer, err = s.reopenWithRetry(s.syncCfg)
+					resumedByGTID = s.resumeByGTID(s.checkpoint.GlobalPointGTID())
 				}
 				if err != nil {
 					return errors.Trace(err)
@@ -1097,6 +1455,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					shardingStreamer, err = s.reSyncBinlog(s.shardingSyncCfg)
 				} else {
 					globalStreamer, err = s.reSyncBinlog(s.syncCfg)
+					resumedByGTID = s.resumeByGTID(s.checkpoint.GlobalPointGTID())
 				}
 				if err != nil {
 					return errors.Trace(err)
@@ -1119,15 +1478,24 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 		s.binlogSizeCount.Add(int64(e.Header.EventSize))
 
 		log.Debugf("[syncer] receive binlog event with header %+v", e.Header)
+		if shardingReSync == nil {
+			if s.stopPoint != nil && boundary.isTxnStart(e) && s.reachStopPoint(lastPos, currentGTIDSet, e.Header.Timestamp) {
+				return errors.Trace(s.stopAt(lastPos))
+			}
+			boundary.update(e)
+		}
 		switch ev := e.Event.(type) {
 		case *replication.RotateEvent:
 			currentPos = mysql.Position{
 				Name: string(ev.NextLogName),
 				Pos:  uint32(ev.Position),
 			}
-			if currentPos.Name > lastPos.Name {
+			if currentPos.Name > lastPos.Name || (resumedByGTID && shardingReSync == nil) {
 				lastPos = currentPos
 			}
+			if shardingReSync == nil {
+				resumedByGTID = false
+			}
 
 			if shardingReSync != nil {
 				if currentPos.Compare(shardingReSync.currPos) == 1 {
@@ -1174,7 +1542,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				}
 			}
 
-			if !s.checkpoint.IsNewerTablePoint(string(ev.Table.Schema), string(ev.Table.Table), currentPos) {
+			if !s.checkpoint.IsNewerTablePoint(string(ev.Table.Schema), string(ev.Table.Table), currentPos, jobGTIDSet()) {
 				log.Debugf("[syncer] ignore obsolete row event in %s that is old than checkpoint of table %s.%s", currentPos, string(ev.Table.Schema), string(ev.Table.Table))
 				continue
 			}
@@ -1192,7 +1560,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			if ignore {
 				binlogSkippedEventsTotal.WithLabelValues("rows", s.cfg.Name).Inc()
 				// for RowsEvent, we should record lastPos rather than currentPos
-				if err = s.recordSkipSQLsPos(lastPos, nil); err != nil {
+				if err = s.recordSkipSQLsPos(lastPos, jobGTIDSet()); err != nil {
 					return errors.Trace(err)
 				}
 
@@ -1201,6 +1569,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 			if s.cfg.IsSharding {
 				source, _ := GenTableID(string(ev.Table.Schema), string(ev.Table.Table))
+				if shardingReSync == nil {
+					// dm-master finds idle sources blocking the sharding DDL by their progress
+					s.sgk.UpdateProgress(schemaName, tableName, source, currentPos)
+				}
 				if s.sgk.InSyncing(schemaName, tableName, source) {
 					// current source is in sharding DDL syncing, ignore DML
 					log.Debugf("[syncer] source %s is in sharding DDL syncing, ignore Rows event %v", source, currentPos)
@@ -1208,15 +1580,33 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				}
 			}
 
-			table, columns, err := s.getTable(schemaName, tableName)
+			table, columns, err := s.getTable(originSchema, originTable, schemaName, tableName)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			rows, err := s.mappingDML(originSchema, originTable, columns, ev.Rows)
+			evRows, err := s.exprFilter.filterRows(originSchema, originTable, table.columns, e.Header.EventType, ev.Rows)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			prunedColumns, prunedRows, err := pruneGeneratedColumnDML(table.columns, rows, schemaName, tableName, s.genColsCache)
+			if len(evRows) == 0 {
+				// all rows are skipped by expression filter
+				binlogSkippedEventsTotal.WithLabelValues("rows", s.cfg.Name).Inc()
+				if err = s.recordSkipSQLsPos(lastPos, jobGTIDSet()); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			rows, err := s.mappingDML(originSchema, originTable, columns, evRows)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// the table and rows are replaced by the downstream ones if columns transformed
+			table, rows, err = s.transformDML(originSchema, originTable, table, rows)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// use source table as the cache key, source tables in a sharding group may differ in structure
+			prunedColumns, prunedRows, err := pruneGeneratedColumnDML(table.columns, rows, originSchema, originTable, s.genColsCache)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -1226,6 +1616,7 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				sqls    []string
 				keys    [][]string
 				args    [][]interface{}
+				changes []*rowChange
 			)
 
 			// for RowsEvent, one event may have multi SQLs and multi keys, (eg. INSERT INTO t1 VALUES (11, 12), (21, 22) )
@@ -1243,11 +1634,13 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				columns:              prunedColumns,
 				originalColumns:      table.columns,
 				originalIndexColumns: table.indexColumns,
+				compact:              s.cfg.CompactDML,
+				rowImages:            s.cfg.Sink == config.SinkFile,
 			}
 			switch e.Header.EventType {
 			case replication.WRITE_ROWS_EVENTv0, replication.WRITE_ROWS_EVENTv1, replication.WRITE_ROWS_EVENTv2:
 				if !applied {
-					sqls, keys, args, err = genInsertSQLs(param)
+					sqls, keys, args, changes, err = genInsertSQLs(param)
 					if err != nil {
 						return errors.Errorf("gen insert sqls failed: %v, schema: %s, table: %s", errors.Trace(err), table.schema, table.name)
 					}
@@ -1272,15 +1665,20 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					if keys != nil {
 						key = keys[i]
 					}
-					err = s.commitJob(insert, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, true, lastPos, currentPos, nil, traceID)
+					var change *rowChange
+					if changes != nil {
+						change = changes[i]
+					}
+					err = s.commitJob(insert, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, change, true, lastPos, currentPos, jobGTIDSet(), e.Header.Timestamp, traceID)
 					if err != nil {
 						return errors.Trace(err)
 					}
 				}
 			case replication.UPDATE_ROWS_EVENTv0, replication.UPDATE_ROWS_EVENTv1, replication.UPDATE_ROWS_EVENTv2:
 				if !applied {
-					param.safeMode = safeMode.Enable()
-					sqls, keys, args, err = genUpdateSQLs(param)
+					// row changes are written as is by the file sink, no need to replace them
+					param.safeMode = safeMode.Enable() && s.cfg.Sink != config.SinkFile
+					sqls, keys, args, changes, err = genUpdateSQLs(param)
 					if err != nil {
 						return errors.Errorf("gen update sqls failed: %v, schema: %s, table: %s", err, table.schema, table.name)
 					}
@@ -1305,15 +1703,19 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					if keys != nil {
 						key = keys[i]
 					}
+					var change *rowChange
+					if changes != nil {
+						change = changes[i]
+					}
 
-					err = s.commitJob(update, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, true, lastPos, currentPos, nil, traceID)
+					err = s.commitJob(update, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, change, true, lastPos, currentPos, jobGTIDSet(), e.Header.Timestamp, traceID)
 					if err != nil {
 						return errors.Trace(err)
 					}
 				}
 			case replication.DELETE_ROWS_EVENTv0, replication.DELETE_ROWS_EVENTv1, replication.DELETE_ROWS_EVENTv2:
 				if !applied {
-					sqls, keys, args, err = genDeleteSQLs(param)
+					sqls, keys, args, changes, err = genDeleteSQLs(param)
 					if err != nil {
 						return errors.Errorf("gen delete sqls failed: %v, schema: %s, table: %s", err, table.schema, table.name)
 					}
@@ -1338,8 +1740,12 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					if keys != nil {
 						key = keys[i]
 					}
+					var change *rowChange
+					if changes != nil {
+						change = changes[i]
+					}
 
-					err = s.commitJob(del, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, true, lastPos, currentPos, nil, traceID)
+					err = s.commitJob(del, string(ev.Table.Schema), string(ev.Table.Table), table.schema, table.name, sqls[i], arg, key, change, true, lastPos, currentPos, jobGTIDSet(), e.Header.Timestamp, traceID)
 					if err != nil {
 						return errors.Trace(err)
 					}
@@ -1362,13 +1768,19 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				binlogSkippedEventsTotal.WithLabelValues("query", s.cfg.Name).Inc()
 				log.Warnf("[skip query-sql]%s [schema]:%s", sql, ev.Schema)
 				lastPos = currentPos // before record skip pos, update lastPos
-				if err = s.recordSkipSQLsPos(lastPos, nil); err != nil {
+				if err = s.recordSkipSQLsPos(lastPos, jobGTIDSet()); err != nil {
 					return errors.Trace(err)
 				}
 				continue
 			}
 			if !parseResult.isDDL {
 				// skipped sql maybe not a DDL (like `BEGIN`)
+				if strings.EqualFold(sql, "COMMIT") {
+					// transaction of non-transactional tables ends with `COMMIT` instead of XID event
+					if err = s.commitTxn(); err != nil {
+						return errors.Trace(err)
+					}
+				}
 				continue
 			}
 
@@ -1425,7 +1837,8 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			var (
 				ddlInfo        *shardingDDLInfo
 				needHandleDDLs []string
-				targetTbls     = make(map[string]*filter.Table)
+				sourceTbls     = make(map[string]*filter.Table)
+				tableBefore    *shardddl.Table // structure of the table before DDLs, in optimistic shard mode
 			)
 			for _, sql := range sqls {
 				sqlDDL, tableNames, stmt, err := s.handleDDL(parser2, string(ev.Schema), sql)
@@ -1440,33 +1853,60 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 				// for DDL, we wait it to be executed, so we can check if event is newer in this syncer's main process goroutine
 				// ignore obsolete DDL here can avoid to try-sync again for already synced DDLs
-				if !s.checkpoint.IsNewerTablePoint(tableNames[0][0].Schema, tableNames[0][0].Name, currentPos) {
+				if !s.checkpoint.IsNewerTablePoint(tableNames[0][0].Schema, tableNames[0][0].Name, currentPos, jobGTIDSet()) {
 					log.Infof("[syncer] ignore obsolete DDL %s in pos %v", sql, currentPos)
 					continue
 				}
 
+				if s.cfg.IsSharding && s.cfg.ShardMode == config.ShardOptimistic && ddlInfo == nil {
+					tableBefore, err = s.trackedTableBeforeDDL(stmt, tableNames)
+					if err != nil {
+						return errors.Trace(err)
+					}
+				}
+
+				// the structure of upstream table changed no matter whether the DDL executed in downstream
+				err = s.trackDDL(stmt, tableNames)
+				if err != nil {
+					return errors.Trace(err)
+				}
+
+				if s.transformer.Matched(tableNames[0][0].Schema, tableNames[0][0].Name) {
+					var skip bool
+					sqlDDL, skip, err = s.transformDDL(parser2, sql, tableNames)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					if skip {
+						log.Infof("[syncer] skip DDL %s only changing columns dropped by column transform rules in pos %v", sql, currentPos)
+						continue
+					}
+				}
+
 				if s.cfg.IsSharding {
-					switch stmt.(type) {
-					case *ast.DropDatabaseStmt:
-						err := s.dropSchemaInSharding(tableNames[0][0].Schema)
+					switch v := stmt.(type) {
+					case *ast.DropDatabaseStmt, *ast.DropTableStmt, *ast.TruncateTableStmt:
+						ignored, err := s.handleTruncateDropInSharding(stmt, sql, tableNames)
 						if err != nil {
 							return errors.Trace(err)
 						}
-						continue
-					case *ast.DropTableStmt:
-						sourceID, _ := GenTableID(tableNames[0][0].Schema, tableNames[0][0].Name)
-						err = s.sgk.LeaveGroup(tableNames[1][0].Schema, tableNames[1][0].Name, []string{sourceID})
-						if err != nil {
-							return errors.Trace(err)
+						if ignored {
+							continue
 						}
-						err = s.checkpoint.DeleteTablePoint(tableNames[0][0].Schema, tableNames[0][0].Name)
+					case *ast.RenameTableStmt:
+						err = s.renameTableInSharding(tableNames, currentPos, jobGTIDSet())
 						if err != nil {
 							return errors.Trace(err)
 						}
 						continue
-					case *ast.TruncateTableStmt:
-						log.Infof("[syncer] ignore truncate table statement %s in sharding group", sqlDDL)
-						continue
+					case *ast.AlterTableStmt:
+						if v.Specs[0].Tp == ast.AlterTableRenameTable {
+							err = s.renameTableInSharding(tableNames, currentPos, jobGTIDSet())
+							if err != nil {
+								return errors.Trace(err)
+							}
+							continue
+						}
 					}
 
 					// in sharding mode, we only support to do one ddl in one event
@@ -1484,13 +1924,13 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				}
 
 				needHandleDDLs = append(needHandleDDLs, sqlDDL)
-				targetTbls[tableNames[1][0].String()] = tableNames[1][0]
+				sourceTbls[tableNames[0][0].String()] = tableNames[0][0]
 			}
 
 			log.Infof("need handled ddls %v in position %v", needHandleDDLs, currentPos)
 			if len(needHandleDDLs) == 0 {
 				log.Infof("skip query %s in position %v", string(ev.Query), currentPos)
-				if err = s.recordSkipSQLsPos(lastPos, nil); err != nil {
+				if err = s.recordSkipSQLsPos(lastPos, jobGTIDSet()); err != nil {
 					return errors.Trace(err)
 				}
 				continue
@@ -1515,17 +1955,21 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					needHandleDDLs = appliedSQLs // maybe nil
 					log.Infof("[convert] execute need handled ddls converted to %v in position %s by sql operator", needHandleDDLs, currentPos)
 				}
-				job := newDDLJob(nil, needHandleDDLs, lastPos, currentPos, nil, nil, traceID)
+				job := newDDLJob(nil, needHandleDDLs, lastPos, currentPos, jobGTIDSet(), nil, traceID)
+				job.eventTime = e.Header.Timestamp
 				err = s.addJob(job)
 				if err != nil {
 					return errors.Trace(err)
 				}
 				log.Infof("[end] execute need handled ddls %v in position %v", needHandleDDLs, currentPos)
 
-				for _, tbl := range targetTbls {
-					s.clearTables(tbl.Schema, tbl.Name)
-					// save checkpoint of each table
-					s.checkpoint.SaveTablePoint(tbl.Schema, tbl.Name, currentPos)
+				for _, tbl := range sourceTbls {
+					// save checkpoint of each table, with its tracked structure
+					s.checkpoint.SaveTablePoint(tbl.Schema, tbl.Name, currentPos, jobGTIDSet())
+					err = s.saveTrackedTable(tbl.Schema, tbl.Name)
+					if err != nil {
+						return errors.Trace(err)
+					}
 				}
 
 				for _, table := range onlineDDLTableNames {
@@ -1557,8 +2001,6 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			source, _ = GenTableID(ddlInfo.tableNames[0][0].Schema, ddlInfo.tableNames[0][0].Name)
 
 			switch ddlInfo.stmt.(type) {
-			case *ast.CreateDatabaseStmt:
-				// for CREATE DATABASE, we do nothing. when CREATE TABLE under this DATABASE, sharding groups will be added
 			case *ast.CreateTableStmt:
 				// for CREATE TABLE, we add it to group
 				needShardingHandle, group, synced, remain, err = s.sgk.AddGroup(ddlInfo.tableNames[1][0].Schema, ddlInfo.tableNames[1][0].Name, []string{source}, true)
@@ -1567,6 +2009,25 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				}
 				log.Infof("[syncer] add table %s to shard group (%v)", source, needShardingHandle)
 			default:
+				// schema-level DDLs (CREATE / ALTER DATABASE) are synced by the sharding group of the target schema in both shard modes,
+				// and executed directly if no tables of the upstream schema in the sharding group, like creating a new database
+				if s.cfg.ShardMode == config.ShardOptimistic && syncedOptimistically(ddlInfo.stmt) {
+					// DMLs are never blocked in optimistic shard mode, so no sharding re-sync needed
+					var canceled bool
+					ddlExecItem, canceled, err = s.handleOptimisticDDL(ddlInfo, needHandleDDLs, tableBefore)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					if canceled {
+						log.Info("[syncer] cancel to add DDL to job because of canceled from external")
+						return nil
+					}
+					if ddlExecItem != nil && ddlExecItem.req.Exec && len(ddlExecItem.req.DDLs) > 0 {
+						// DDLs changing the joined schema are executed, rather than the DDLs of the shard table
+						needHandleDDLs = ddlExecItem.req.DDLs
+					}
+					break
+				}
 				needShardingHandle, group, synced, remain, err = s.sgk.TrySync(ddlInfo.tableNames[1][0].Schema, ddlInfo.tableNames[1][0].Name, source, startPos, currentPos, needHandleDDLs)
 				if err != nil {
 					return errors.Trace(err)
@@ -1584,7 +2045,11 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				// save checkpoint in memory, don't worry, if error occurred, we can rollback it
 				// for non-last sharding DDL's table, this checkpoint will be used to skip binlog event when re-syncing
 				// NOTE: when last sharding DDL executed, all this checkpoints will be flushed in the same txn
-				s.checkpoint.SaveTablePoint(ddlInfo.tableNames[0][0].Schema, ddlInfo.tableNames[0][0].Name, currentPos)
+				s.checkpoint.SaveTablePoint(ddlInfo.tableNames[0][0].Schema, ddlInfo.tableNames[0][0].Name, currentPos, jobGTIDSet())
+				err = s.saveTrackedTable(ddlInfo.tableNames[0][0].Schema, ddlInfo.tableNames[0][0].Name)
+				if err != nil {
+					return errors.Trace(err)
+				}
 				if !synced {
 					log.Infof("[syncer] source %s is in sharding DDL syncing, ignore DDL %v", source, startPos)
 					continue
@@ -1595,19 +2060,22 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				if err != nil {
 					return errors.Trace(err)
 				}
-				// maybe multi-groups' sharding DDL synced in this for-loop (one query-event, multi tables)
-				if cap(shardingReSyncCh) < len(sqls) {
-					shardingReSyncCh = make(chan *ShardingReSync, len(sqls))
-				}
-				firstEndPos := group.FirstEndPosUnresolved()
-				if firstEndPos == nil {
-					return errors.Errorf("no valid End_log_pos of the first DDL exists for sharding group with source %s", source)
-				}
-				shardingReSyncCh <- &ShardingReSync{
-					currPos:      *firstEndPos,
-					latestPos:    currentPos,
-					targetSchema: ddlInfo.tableNames[1][0].Schema,
-					targetTable:  ddlInfo.tableNames[1][0].Name,
+				// DMLs are not ignored for schema-level DDLs, so no sharding re-sync needed
+				if !group.IsSchemaOnly {
+					// maybe multi-groups' sharding DDL synced in this for-loop (one query-event, multi tables)
+					if cap(shardingReSyncCh) < len(sqls) {
+						shardingReSyncCh = make(chan *ShardingReSync, len(sqls))
+					}
+					firstEndPos := group.FirstEndPosUnresolved()
+					if firstEndPos == nil {
+						return errors.Errorf("no valid End_log_pos of the first DDL exists for sharding group with source %s", source)
+					}
+					shardingReSyncCh <- &ShardingReSync{
+						currPos:      *firstEndPos,
+						latestPos:    currentPos,
+						targetSchema: ddlInfo.tableNames[1][0].Schema,
+						targetTable:  ddlInfo.tableNames[1][0].Name,
+					}
 				}
 
 				// Don't send new DDLInfo to dm-master until all local sql jobs finished
@@ -1632,6 +2100,9 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 					log.Info("[syncer] cancel to add DDL to job because of canceled from external")
 					return nil
 				}
+				if err = s.failedByMaster(ddlExecItem); err != nil {
+					return errors.Trace(err)
+				}
 				if ddlExecItem.req.Exec {
 					log.Infof("[syncer] add DDL %v to job, request is %+v", ddlInfo1.DDLs, ddlExecItem.req)
 				} else {
@@ -1649,7 +2120,8 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				needHandleDDLs = appliedSQLs // maybe nil
 				log.Infof("[convert] execute need handled ddls converted to %v in position %s by sql operator", needHandleDDLs, currentPos)
 			}
-			job := newDDLJob(ddlInfo, needHandleDDLs, lastPos, currentPos, nil, ddlExecItem, traceID)
+			job := newDDLJob(ddlInfo, needHandleDDLs, lastPos, currentPos, jobGTIDSet(), ddlExecItem, traceID)
+			job.eventTime = e.Header.Timestamp
 			err = s.addJob(job)
 			if err != nil {
 				return errors.Trace(err)
@@ -1660,8 +2132,6 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 			}
 
 			log.Infof("[ddl][end]%v", needHandleDDLs)
-
-			s.clearTables(ddlInfo.tableNames[1][0].Schema, ddlInfo.tableNames[1][0].Name)
 		case *replication.XIDEvent:
 			if shardingReSync != nil {
 				shardingReSync.currPos.Pos = e.Header.LogPos
@@ -1675,50 +2145,70 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 
 			latestOp = xid
 			currentPos.Pos = e.Header.LogPos
-			log.Debugf("[XID event][last_pos]%v [current_pos]%v [gtid set]%v", lastPos, currentPos, ev.GSet)
+			log.Debugf("[XID event][last_pos]%v [current_pos]%v [gtid set]%v", lastPos, currentPos, currentGTIDSet)
 			lastPos.Pos = e.Header.LogPos // update lastPos
 
-			job := newXIDJob(currentPos, currentPos, nil, traceID)
+			job := newXIDJob(currentPos, currentPos, jobGTIDSet(), traceID)
 			err = s.addJob(job)
 			if err != nil {
 				return errors.Trace(err)
 			}
+		case *replication.GTIDEvent:
+			if currentGTIDSet != nil && ev.GNO > 0 { // GNO is 0 for anonymous GTID event
+				err = currentGTIDSet.Update(fmt.Sprintf("%s:%d", formatSID(ev.SID), ev.GNO))
+				if err != nil {
+					return errors.Annotatef(err, "update GTID set %s", currentGTIDSet)
+				}
+			}
+		case *replication.MariadbGTIDEvent:
+			if currentGTIDSet != nil {
+				err = currentGTIDSet.Update(ev.GTID.String())
+				if err != nil {
+					return errors.Annotatef(err, "update GTID set %s", currentGTIDSet)
+				}
+			}
+		case *replication.GenericEvent:
+			if e.Header.EventType == replication.PREVIOUS_GTIDS_EVENT && s.cfg.EnableGTID && currentGTIDSet == nil && shardingReSync == nil {
+				// GTID set executed before this binlog file, used as base if no GTID set in global checkpoint
+				currentGTIDSet, err = parsePreviousGTIDs(s.cfg.Flavor, ev.Data)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				log.Infof("[syncer] initialize GTID set %s from Previous_GTIDs event in %v", currentGTIDSet, currentPos)
+			}
 		}
 	}
 }
 
-func (s *Syncer) commitJob(tp opType, sourceSchema, sourceTable, targetSchema, targetTable, sql string, args []interface{}, keys []string, retry bool, pos, cmdPos mysql.Position, gs gtid.Set, traceID string) error {
-	key, err := s.resolveCasuality(keys)
-	if err != nil {
-		return errors.Errorf("resolve karam error %v", err)
+func (s *Syncer) commitJob(tp opType, sourceSchema, sourceTable, targetSchema, targetTable, sql string, args []interface{}, keys []string, change *rowChange, retry bool, pos, cmdPos mysql.Position, gs gtid.Set, eventTime uint32, traceID string) error {
+	var key string
+	if len(keys) > 0 {
+		key = keys[0]
 	}
 	job := newJob(tp, sourceSchema, sourceTable, targetSchema, targetTable, sql, args, key, pos, cmdPos, gs, traceID)
-	err = s.addJob(job)
+	job.change = change
+	job.eventTime = eventTime
+	if s.cfg.ExactlyOnce {
+		// jobs changing the same table are executed in order, then the table's checkpoint can be saved along with them
+		keys = append(keys[:len(keys):len(keys)], dbutil.TableName(sourceSchema, sourceTable))
+	}
+	if s.cfg.PreserveTxn || s.cfg.ExactlyOnce {
+		// dispatched after the transaction committed
+		s.txnBuffer.add(job, keys)
+		return nil
+	}
+
+	job.node = s.resolveCasuality(keys)
+	err := s.addJob(job)
 	return errors.Trace(err)
 }
 
-func (s *Syncer) resolveCasuality(keys []string) (string, error) {
+// resolveCasuality adds the job with keys into causality, returns nil if causality disabled
+func (s *Syncer) resolveCasuality(keys []string) *causalNode {
 	if s.cfg.DisableCausality {
-		if len(keys) > 0 {
-			return keys[0], nil
-		}
-		return "", nil
-	}
-	if s.c.detectConflict(keys) {
-		log.Debug("[causality] meet causality key, will generate a flush job and wait all sqls executed")
-		if err := s.flushJobs(); err != nil {
-			return "", errors.Trace(err)
-		}
-		s.c.reset()
-	}
-	if err := s.c.add(keys); err != nil {
-		return "", errors.Trace(err)
-	}
-	var key string
-	if len(keys) > 0 {
-		key = keys[0]
+		return nil
 	}
-	return s.c.get(key), nil
+	return s.c.add(keys)
 }
 
 func (s *Syncer) genRouter() error {
@@ -1807,13 +2297,20 @@ func (s *Syncer) printStatus(ctx context.Context) {
 }
 
 // NOTE: refactor with remote and local streamer later
-func (s *Syncer) getBinlogStreamer(syncerOrReader interface{}, pos mysql.Position) (streamer.Streamer, error) {
+// gs is the GTID set to resume from, nil to resume from pos
+func (s *Syncer) getBinlogStreamer(syncerOrReader interface{}, pos mysql.Position, gs gtid.Set) (streamer.Streamer, error) {
 	if s.binlogType == RemoteBinlog {
-		return s.getRemoteBinlogStreamer(syncerOrReader, pos)
+		return s.getRemoteBinlogStreamer(syncerOrReader, pos, gs)
 	}
 	return s.getLocalBinlogStreamer(syncerOrReader, pos)
 }
 
+// resumeByGTID returns whether to resume syncing by GTID set rather than binlog position.
+// only for syncing from the upstream directly, relay unit handles GTID when switching upstream for local relay log
+func (s *Syncer) resumeByGTID(gs gtid.Set) bool {
+	return s.cfg.EnableGTID && s.binlogType == RemoteBinlog && gs != nil && len(gs.String()) > 0
+}
+
 func (s *Syncer) getLocalBinlogStreamer(syncerOrReader interface{}, pos mysql.Position) (streamer.Streamer, error) {
 	reader, ok := syncerOrReader.(*streamer.BinlogReader)
 	if !ok {
@@ -1822,7 +2319,7 @@ func (s *Syncer) getLocalBinlogStreamer(syncerOrReader interface{}, pos mysql.Po
 	return reader.StartSync(pos)
 }
 
-func (s *Syncer) getRemoteBinlogStreamer(syncerOrReader interface{}, pos mysql.Position) (streamer.Streamer, error) {
+func (s *Syncer) getRemoteBinlogStreamer(syncerOrReader interface{}, pos mysql.Position, gs gtid.Set) (streamer.Streamer, error) {
 	syncer, ok := syncerOrReader.(*replication.BinlogSyncer)
 	if !ok {
 		return nil, errors.NotValidf("replication.BinlogSyncer %v", syncerOrReader)
@@ -1831,9 +2328,8 @@ func (s *Syncer) getRemoteBinlogStreamer(syncerOrReader interface{}, pos mysql.P
 		lastSlaveConnectionID := syncer.LastConnectionID()
 		log.Infof("[syncer] last slave connection id %d", lastSlaveConnectionID)
 	}()
-	if s.cfg.EnableGTID {
-		// NOTE: our (per-table based) checkpoint does not support GTID yet
-		return nil, errors.New("[syncer] now support GTID mode yet")
+	if s.resumeByGTID(gs) {
+		return s.startSyncByGTID(syncer, gs)
 	}
 
 	return s.startSyncByPosition(syncer, pos)
@@ -1846,6 +2342,11 @@ func (s *Syncer) createDBs() error {
 		return errors.Trace(err)
 	}
 
+	if s.cfg.Sink != config.SinkMySQL {
+		// jobs are written into files instead of executed in target database
+		return nil
+	}
+
 	s.toDBs = make([]*Conn, 0, s.cfg.WorkerCount)
 	s.toDBs, err = createDBs(s.cfg, s.cfg.To, s.cfg.WorkerCount, maxDMLConnectionTimeout)
 	if err != nil {
@@ -1870,6 +2371,13 @@ func (s *Syncer) closeDBs() {
 	closeDBs(s.ddlDB)
 }
 
+// closeSinks closes sinks of all queues
+func (s *Syncer) closeSinks() {
+	for _, sk := range s.sinks {
+		sk.close()
+	}
+}
+
 // record skip ddl/dml sqls' position
 // make newJob's sql argument empty to distinguish normal sql and skips sql
 func (s *Syncer) recordSkipSQLsPos(pos mysql.Position, gtidSet gtid.Set) error {
@@ -1885,6 +2393,29 @@ func (s *Syncer) flushJobs() error {
 	return errors.Trace(err)
 }
 
+// reachStopPoint returns whether the stop point is reached at the transaction boundary.
+// for sharding tasks, it is not reached until all sharding DDLs synced,
+// otherwise DMLs ignored in the sharding group would be lost after finished
+func (s *Syncer) reachStopPoint(lastPos mysql.Position, gs gtid.Set, ts uint32) bool {
+	if s.stopPoint == nil || !s.stopPoint.reached(lastPos, gs, ts) {
+		return false
+	}
+	if s.cfg.IsSharding {
+		if tables := s.sgk.UnresolvedTables(); len(tables) > 0 {
+			log.Debugf("[syncer] reached stop-at %s at %v, but wait for sharding DDL of tables %v to be synced", s.stopPoint, lastPos, tables)
+			return false
+		}
+	}
+	return true
+}
+
+// stopAt flushes all jobs and checkpoints when the stop point reached,
+// then Run returns without error and the sub task finishes
+func (s *Syncer) stopAt(lastPos mysql.Position) error {
+	log.Infof("[syncer] reached stop-at %s at %v, flush all jobs and checkpoints before finished", s.stopPoint, lastPos)
+	return errors.Trace(s.flushJobs())
+}
+
 func (s *Syncer) reSyncBinlog(cfg replication.BinlogSyncerConfig) (streamer.Streamer, error) {
 	err := s.retrySyncGTIDs()
 	if err != nil {
@@ -1921,7 +2452,7 @@ func (s *Syncer) reopen(cfg replication.BinlogSyncerConfig) (streamer.Streamer,
 
 	// TODO: refactor to support relay
 	s.syncer = replication.NewBinlogSyncer(cfg)
-	return s.getBinlogStreamer(s.syncer, s.checkpoint.GlobalPoint())
+	return s.getBinlogStreamer(s.syncer, s.checkpoint.GlobalPoint(), s.checkpoint.GlobalPointGTID())
 }
 
 func (s *Syncer) startSyncByPosition(syncer *replication.BinlogSyncer, pos mysql.Position) (streamer.Streamer, error) {
@@ -1929,6 +2460,12 @@ func (s *Syncer) startSyncByPosition(syncer *replication.BinlogSyncer, pos mysql
 	return streamer, errors.Trace(err)
 }
 
+func (s *Syncer) startSyncByGTID(syncer *replication.BinlogSyncer, gs gtid.Set) (streamer.Streamer, error) {
+	log.Infof("[syncer] start sync by GTID set %s", gs)
+	streamer, err := syncer.StartSyncGTID(gs.Origin())
+	return streamer, errors.Trace(err)
+}
+
 func (s *Syncer) renameShardingSchema(schema, table string) (string, string) {
 	if schema == "" {
 		return schema, table
@@ -1971,6 +2508,8 @@ func (s *Syncer) Close() {
 
 	s.closeDBs()
 
+	s.closeSinks()
+
 	s.checkpoint.Close()
 
 	s.closeOnlineDDL()
@@ -2070,7 +2609,9 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		oldBwList        *filter.Filter
 		oldTableRouter   *router.Table
 		oldBinlogFilter  *bf.BinlogEvent
+		oldExprFilter    *exprFilter
 		oldColumnMapping *cm.Mapping
+		oldTransformer   *transform.Transformer
 	)
 
 	defer func() {
@@ -2086,9 +2627,15 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		if oldBinlogFilter != nil {
 			s.binlogFilter = oldBinlogFilter
 		}
+		if oldExprFilter != nil {
+			s.exprFilter = oldExprFilter
+		}
 		if oldColumnMapping != nil {
 			s.columnMapping = oldColumnMapping
 		}
+		if oldTransformer != nil {
+			s.transformer = oldTransformer
+		}
 	}()
 
 	// update black-white-list
@@ -2109,6 +2656,13 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		return errors.Trace(err)
 	}
 
+	// update expression filter
+	oldExprFilter = s.exprFilter
+	s.exprFilter, err = newExprFilter(cfg.CaseSensitive, cfg.ExprFilter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// update column-mappings
 	oldColumnMapping = s.columnMapping
 	s.columnMapping, err = cm.NewMapping(cfg.CaseSensitive, cfg.ColumnMappingRules)
@@ -2116,6 +2670,15 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 		return errors.Trace(err)
 	}
 
+	// update column-transforms
+	oldTransformer = s.transformer
+	s.transformer, err = transform.NewTransformer(cfg.CaseSensitive, cfg.ColumnTransformRules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.transformedTables = make(map[string]*transformedTable)
+	s.genColsCache.reset()
+
 	if s.cfg.IsSharding {
 		// re-init sharding group
 		s.initShardingGroups()
@@ -2125,12 +2688,21 @@ func (s *Syncer) Update(cfg *config.SubTaskConfig) error {
 	s.cfg.BWList = cfg.BWList
 	s.cfg.RouteRules = cfg.RouteRules
 	s.cfg.FilterRules = cfg.FilterRules
+	s.cfg.ExprFilter = cfg.ExprFilter
 	s.cfg.ColumnMappingRules = cfg.ColumnMappingRules
+	s.cfg.ColumnTransformRules = cfg.ColumnTransformRules
 	s.cfg.Timezone = cfg.Timezone
 
 	// update timezone
 	s.setTimezone()
 
+	// update stop-at, parse the time in the updated timezone
+	s.stopPoint, err = newStopPoint(cfg, s.timezone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.cfg.StopAt = cfg.StopAt
+
 	return nil
 }
 
@@ -2157,8 +2729,34 @@ func (s *Syncer) needResync() bool {
 // assume that reset master before switching to new master, and only the new master would write
 // it's a weak function to try best to fix gtid set while switching master/slave
 func (s *Syncer) retrySyncGTIDs() error {
-	// NOTE: our (per-table based) checkpoint does not support GTID yet, implement it if needed
-	log.Warn("[syncer] our (per-table based) checkpoint does not support GTID yet")
+	// TODO: now we don't implement quering GTID from MariaDB, implement it later
+	if s.cfg.Flavor != mysql.MySQLFlavor {
+		return nil
+	}
+	oldGTIDSet := s.checkpoint.GlobalPointGTID()
+	if oldGTIDSet == nil {
+		log.Warn("[syncer] no GTID set in global checkpoint, can not retry sync with GTID")
+		return nil
+	}
+	log.Infof("[syncer] start retry sync with old GTID %s", oldGTIDSet)
+
+	_, newGTIDSet, err := s.getMasterStatus()
+	if err != nil {
+		return errors.Annotatef(err, "get master status")
+	}
+	log.Infof("[syncer] new master GTID set %v", newGTIDSet)
+
+	masterUUID, err := utils.GetServerUUID(s.fromDB.db, s.cfg.Flavor)
+	if err != nil {
+		return errors.Annotatef(err, "get master UUID")
+	}
+	log.Infof("[syncer] master UUID %s", masterUUID)
+
+	err = oldGTIDSet.Replace(newGTIDSet, []interface{}{masterUUID})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.checkpoint.SaveGlobalPoint(s.checkpoint.GlobalPoint(), oldGTIDSet)
 	return nil
 }
 
@@ -2190,6 +2788,17 @@ func (s *Syncer) ExecuteDDL(ctx context.Context, execReq *pb.ExecDDLRequest) (<-
 	return item.resp, nil
 }
 
+// failedByMaster returns the error if dm-master requests the sharding DDL to fail, like when the DDL lock timeout,
+// the request is responded at once, so the sub task can be paused with the error
+func (s *Syncer) failedByMaster(item *DDLExecItem) error {
+	if len(item.req.Error) == 0 {
+		return nil
+	}
+	item.resp <- nil
+	s.ddlExecInfo.ClearBlockingDDL()
+	return errors.Errorf("sharding DDL lock %s failed by dm-master: %s", item.req.LockID, item.req.Error)
+}
+
 // UpdateFromConfig updates config for `From`
 func (s *Syncer) UpdateFromConfig(cfg *config.SubTaskConfig) error {
 	s.Lock()
@@ -0,0 +1,136 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	parserpkg "github.com/pingcap/dm/pkg/parser"
+	"github.com/pingcap/dm/pkg/transform"
+)
+
+// transformedTable is the downstream table of an upstream table transformed by column transform rules
+type transformedTable struct {
+	table     *table           // nil if no rule matched
+	transform *transform.Table // nil if no rule matched
+}
+
+// transformDML transforms columns and rows of the upstream table to the downstream table by column transform rules,
+// returns the table and rows as is if no rule matched
+func (s *Syncer) transformDML(originSchema, originTable string, t *table, rows [][]interface{}) (*table, [][]interface{}, error) {
+	if s.transformer == nil {
+		return t, rows, nil
+	}
+
+	key := dbutil.TableName(originSchema, originTable)
+	tt, ok := s.transformedTables[key]
+	if !ok {
+		columns := make([]string, 0, len(t.columns))
+		types := make([]string, 0, len(t.columns))
+		for _, c := range t.columns {
+			columns = append(columns, c.name)
+			types = append(types, c.tp)
+		}
+		trans, err := s.transformer.Table(originSchema, originTable, columns, types)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		tt = &transformedTable{transform: trans}
+		if trans != nil {
+			tt.table = transformTable(t, trans)
+		}
+		s.transformedTables[key] = tt
+	}
+	if tt.transform == nil {
+		return t, rows, nil
+	}
+
+	transformed := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		// unsigned integers are in binlog as signed ones, other values are normalized by column types in TransformRow
+		input := make([]interface{}, len(row))
+		copy(input, row)
+		for i, idx := range tt.transform.Indexes {
+			if tt.transform.Masked(i) && idx < len(t.columns) {
+				input[idx] = castUnsigned(row[idx], t.columns[idx].unsigned, t.columns[idx].tp)
+			}
+		}
+		value, err := tt.transform.TransformRow(input)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "transform row of %s", key)
+		}
+		transformed = append(transformed, value)
+	}
+	return tt.table, transformed, nil
+}
+
+// transformTable returns the downstream table with transformed columns,
+// indexes containing dropped columns or columns masked not injectively are removed,
+// because they can't identify rows in WHERE clauses, causality and compaction any more,
+// and masked columns are treated as strings
+func transformTable(t *table, tt *transform.Table) *table {
+	clone := &table{
+		schema:       t.schema,
+		name:         t.name,
+		columns:      make([]*column, 0, len(tt.Columns)),
+		indexColumns: make(map[string][]*column, len(t.indexColumns)),
+	}
+	sourceIdx := make(map[int]*column, len(tt.Columns)) // upstream column index -> downstream column
+	for i, idx := range tt.Indexes {
+		c := *t.columns[idx]
+		c.idx = i
+		c.name = tt.Columns[i]
+		if tt.Masked(i) {
+			c.unsigned = false
+			c.tp = "varchar"
+		}
+		clone.columns = append(clone.columns, &c)
+		sourceIdx[idx] = &c
+	}
+
+	for key, cols := range t.indexColumns {
+		cols2 := make([]*column, 0, len(cols))
+		for _, c := range cols {
+			c2, ok := sourceIdx[c.idx]
+			if !ok || !tt.Injective(c2.idx) {
+				break
+			}
+			cols2 = append(cols2, c2)
+		}
+		if len(cols2) == len(cols) {
+			clone.indexColumns[key] = cols2
+		}
+	}
+	return clone
+}
+
+// transformDDL transforms columns in the upstream DDL executed in downstream by column transform rules,
+// tableNames is [[source tables], [target tables]] returned by handleDDL.
+// returns true if the DDL should be skipped because it only changes dropped columns
+func (s *Syncer) transformDDL(p *parser.Parser, sql string, tableNames [][]*filter.Table) (string, bool, error) {
+	// parse the upstream DDL again, because the parsed one is tracked by schema tracker
+	stmt, err := parserpkg.ParseOneStmt(p, sql, "", "")
+	if err != nil {
+		return "", false, errors.Annotatef(err, "ddl %s", sql)
+	}
+	skip, err := s.transformer.TransformDDL(tableNames[0][0].Schema, tableNames[0][0].Name, stmt)
+	if err != nil || skip {
+		return "", skip, errors.Trace(err)
+	}
+	ddl, err := parserpkg.RenameDDLTable(stmt, tableNames[1])
+	return ddl, false, errors.Trace(err)
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/dm/pkg/transform"
+)
+
+var _ = Suite(&testTransformSuite{})
+
+type testTransformSuite struct{}
+
+func (t *testTransformSuite) TestTransformDML(c *C) {
+	transformer, err := transform.NewTransformer(false, []*transform.Rule{{
+		SchemaPattern: "db",
+		TablePattern:  "tb",
+		DropColumns:   []string{"ssn"},
+		RenameColumns: map[string]string{"addr": "address"},
+		MaskColumns:   []*transform.MaskRule{{Column: "big", Function: transform.MaskKeepFirst, Length: 2}},
+	}})
+	c.Assert(err, IsNil)
+	s := &Syncer{
+		transformer:       transformer,
+		transformedTables: make(map[string]*transformedTable),
+	}
+
+	tb := &table{
+		schema: "target_db",
+		name:   "target_tb",
+		columns: []*column{
+			{idx: 0, name: "id", tp: "int"},
+			{idx: 1, name: "ssn", tp: "varchar(20)"},
+			{idx: 2, name: "addr", tp: "text"},
+			{idx: 3, name: "big", tp: "bigint unsigned", unsigned: true},
+		},
+	}
+	tb.indexColumns = map[string][]*column{
+		"PRIMARY": {tb.columns[0]},
+		"uk_ssn":  {tb.columns[1], tb.columns[0]},
+		"idx":     {tb.columns[2], tb.columns[0]},
+	}
+	rows := [][]interface{}{
+		{int32(1), "123-45-6789", "road", int64(-1)},
+		{int32(2), nil, nil, nil},
+	}
+
+	t2, rows2, err := s.transformDML("db", "tb", tb, rows)
+	c.Assert(err, IsNil)
+	c.Assert(t2.schema, Equals, "target_db")
+	c.Assert(t2.name, Equals, "target_tb")
+	c.Assert(t2.columns, DeepEquals, []*column{
+		{idx: 0, name: "id", tp: "int"},
+		{idx: 1, name: "address", tp: "text"},
+		{idx: 2, name: "big", tp: "varchar"},
+	})
+	c.Assert(t2.indexColumns, HasLen, 2)
+	c.Assert(t2.indexColumns["PRIMARY"], DeepEquals, []*column{t2.columns[0]})
+	c.Assert(t2.indexColumns["idx"], DeepEquals, []*column{t2.columns[1], t2.columns[0]})
+	c.Assert(rows2, DeepEquals, [][]interface{}{
+		{int32(1), "road", "18******************"},
+		{int32(2), nil, nil},
+	})
+	c.Assert(s.transformedTables, HasLen, 1)
+
+	// not matched
+	t3, rows3, err := s.transformDML("db", "tb2", tb, rows)
+	c.Assert(err, IsNil)
+	c.Assert(t3, Equals, tb)
+	c.Assert(rows3, DeepEquals, rows)
+
+	// DDL
+	tableNames := [][]*filter.Table{{{Schema: "db", Name: "tb"}}, {{Schema: "target_db", Name: "target_tb"}}}
+	ddl, skip, err := s.transformDDL(parser.New(), "ALTER TABLE `db`.`tb` ADD COLUMN `ssn2` INT AFTER `ssn`", tableNames)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsFalse)
+	c.Assert(ddl, Equals, "ALTER TABLE `target_db`.`target_tb` ADD COLUMN `ssn2` INT")
+	_, skip, err = s.transformDDL(parser.New(), "ALTER TABLE `db`.`tb` DROP COLUMN `ssn`", tableNames)
+	c.Assert(err, IsNil)
+	c.Assert(skip, IsTrue)
+}
+
+func (t *testTransformSuite) TestTransformMaskedKey(c *C) {
+	transformer, err := transform.NewTransformer(false, []*transform.Rule{{
+		SchemaPattern: "db",
+		TablePattern:  "tb",
+		MaskColumns: []*transform.MaskRule{
+			{Column: "id", Function: transform.MaskKeepFirst, Length: 1},
+			{Column: "email", Function: transform.MaskHash, Value: "salt"},
+		},
+	}})
+	c.Assert(err, IsNil)
+	s := &Syncer{
+		transformer:       transformer,
+		transformedTables: make(map[string]*transformedTable),
+	}
+
+	tb := &table{
+		schema: "db",
+		name:   "tb",
+		columns: []*column{
+			{idx: 0, name: "id", tp: "int"},
+			{idx: 1, name: "email", tp: "varchar(64)"},
+			{idx: 2, name: "name", tp: "varchar(64)"},
+		},
+	}
+	tb.indexColumns = map[string][]*column{
+		"PRIMARY":  {tb.columns[0]},
+		"uk_email": {tb.columns[1]},
+		"uk_name":  {tb.columns[2], tb.columns[0]},
+	}
+	rows := [][]interface{}{{int32(12), "a@b.c", "n"}, {int32(13), "d@e.f", "n"}}
+
+	// rows 12 and 13 are both masked to "1*", the primary key and keys containing it can't identify rows,
+	// but the key of the column masked by hash still can
+	t2, rows2, err := s.transformDML("db", "tb", tb, rows)
+	c.Assert(err, IsNil)
+	c.Assert(rows2[0][0], Equals, rows2[1][0])
+	c.Assert(t2.indexColumns, HasLen, 1)
+	c.Assert(t2.indexColumns["uk_email"], DeepEquals, []*column{t2.columns[1]})
+
+	// all keys contain columns masked not injectively, all columns are used to identify rows
+	s.transformedTables = make(map[string]*transformedTable)
+	delete(tb.indexColumns, "uk_email")
+	t3, _, err := s.transformDML("db", "tb", tb, rows)
+	c.Assert(err, IsNil)
+	c.Assert(t3.indexColumns, HasLen, 0)
+}
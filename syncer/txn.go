@@ -0,0 +1,66 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/errors"
+)
+
+// txnBuffer buffers row jobs of the upstream transaction in syncing when `preserve-txn` or `exactly-once` enabled.
+// rows are dispatched together after the transaction committed (XID event), see `Syncer.commitTxn`.
+// NOTE: all rows of a transaction are kept in memory, so huge transactions need more memory
+type txnBuffer struct {
+	rows []*job
+	keys []string // causality keys of all rows
+}
+
+func newTxnBuffer() *txnBuffer {
+	return &txnBuffer{}
+}
+
+func (b *txnBuffer) add(row *job, keys []string) {
+	b.rows = append(b.rows, row)
+	b.keys = append(b.keys, keys...)
+}
+
+// pop returns rows and keys buffered, and resets the buffer
+func (b *txnBuffer) pop() ([]*job, []string) {
+	rows, keys := b.rows, b.keys
+	b.reset()
+	return rows, keys
+}
+
+// reset discards rows buffered, rows of an uncommitted transaction will be re-synced from the checkpoint
+func (b *txnBuffer) reset() {
+	b.rows = nil
+	b.keys = nil
+}
+
+// commitTxn dispatches rows of the committed upstream transaction as one txn job.
+// the transaction is scheduled by causality with keys of all its rows, and executed in one queue atomically,
+// so transactions without conflicting keys can still be executed concurrently in different queues
+func (s *Syncer) commitTxn() error {
+	rows, keys := s.txnBuffer.pop()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var key string
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	job := newTxnJob(rows, key)
+	job.node = s.resolveCasuality(keys)
+	return errors.Trace(s.addJob(job))
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/siddontang/go-mysql/mysql"
+)
+
+var _ = Suite(&testTxnSuite{})
+
+type testTxnSuite struct{}
+
+func (t *testTxnSuite) TestTxnBuffer(c *C) {
+	b := newTxnBuffer()
+	rows, keys := b.pop()
+	c.Assert(rows, HasLen, 0)
+	c.Assert(keys, HasLen, 0)
+
+	pos1 := mysql.Position{Name: "mysql-bin.000001", Pos: 100}
+	pos2 := mysql.Position{Name: "mysql-bin.000001", Pos: 200}
+	row1 := newJob(insert, "db", "tb", "db", "tb", "INSERT", nil, "", pos1, pos1, nil, "")
+	row2 := newJob(update, "db", "tb2", "db", "tb2", "UPDATE", nil, "", pos2, pos2, nil, "")
+	b.add(row1, []string{"1.id.db.tb"})
+	b.add(row2, []string{"2.id.db.tb2", "3.id.db.tb2"})
+
+	rows, keys = b.pop()
+	c.Assert(rows, DeepEquals, []*job{row1, row2})
+	c.Assert(keys, DeepEquals, []string{"1.id.db.tb", "2.id.db.tb2", "3.id.db.tb2"})
+	rows, _ = b.pop()
+	c.Assert(rows, HasLen, 0)
+
+	b.add(row1, nil)
+	b.reset()
+	rows, _ = b.pop()
+	c.Assert(rows, HasLen, 0)
+
+	j := newTxnJob([]*job{row1, row2}, "1.id.db.tb")
+	c.Assert(j.tp, Equals, txn)
+	c.Assert(j.key, Equals, "1.id.db.tb")
+	c.Assert(j.currentPos, Equals, pos2)
+	c.Assert(j.rows, HasLen, 2)
+}
@@ -14,10 +14,14 @@
 package syncer
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/ast"
+	"github.com/siddontang/go-mysql/mysql"
+
+	"github.com/pingcap/dm/pkg/gtid"
 )
 
 func toBinlogType(bt string) BinlogType {
@@ -71,3 +75,23 @@ func tableNameResultSet(rs ast.ResultSetNode) (schema, table string, err error)
 	}
 	return tn.Schema.O, tn.Name.O, nil
 }
+
+// formatSID formats server UUID in GTID event as `xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`
+func formatSID(sid []byte) string {
+	if len(sid) != 16 {
+		return fmt.Sprintf("%x", sid)
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sid[0:4], sid[4:6], sid[6:8], sid[8:10], sid[10:16])
+}
+
+// parsePreviousGTIDs parses GTID set from the body of MySQL's Previous_GTIDs event
+func parsePreviousGTIDs(flavor string, data []byte) (gtid.Set, error) {
+	if flavor != mysql.MySQLFlavor {
+		return nil, errors.NotSupportedf("Previous_GTIDs event for flavor %s", flavor)
+	}
+	gs, err := mysql.DecodeMysqlGTIDSet(data)
+	if err != nil {
+		return nil, errors.Annotate(err, "decode Previous_GTIDs event")
+	}
+	return gtid.ParserGTID(flavor, gs.String())
+}
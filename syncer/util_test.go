@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
 	_ "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/siddontang/go-mysql/mysql"
 )
 
 var _ = Suite(&testUtilSuite{})
@@ -86,3 +87,22 @@ func (t *testUtilSuite) TestTableNameForDML(c *C) {
 		c.Assert(table, Equals, cs.table)
 	}
 }
+
+func (t *testUtilSuite) TestGTIDHelpers(c *C) {
+	gs, err := mysql.ParseMysqlGTIDSet("53ea0ed1-9bf8-11e6-8bea-64006a897c73:1-14,53ea0ed1-9bf8-11e6-8bea-64006a897c72:1-3")
+	c.Assert(err, IsNil)
+	mgs := gs.(*mysql.MysqlGTIDSet)
+
+	for sid, set := range mgs.Sets {
+		c.Assert(formatSID(set.SID.Bytes()), Equals, sid)
+	}
+
+	parsed, err := parsePreviousGTIDs(mysql.MySQLFlavor, mgs.Encode())
+	c.Assert(err, IsNil)
+	c.Assert(parsed.Origin().Equal(gs), IsTrue)
+
+	_, err = parsePreviousGTIDs(mysql.MariaDBFlavor, mgs.Encode())
+	c.Assert(err, NotNil)
+	_, err = parsePreviousGTIDs(mysql.MySQLFlavor, []byte{1})
+	c.Assert(err, NotNil)
+}